@@ -0,0 +1,99 @@
+// Command keystore manages the encrypted key files the server signs with. The password is read
+// from KEYSTORE_PASSWORD.
+//
+//	keystore import -dir keys -id treasury [-curve secp256k1] [-cipher aes-128-ctr] < treasury.hex
+//	keystore list -dir keys
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/keystore"
+)
+
+const usage = "usage: keystore import|list [flags]"
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Getenv("KEYSTORE_PASSWORD")); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer, password string) error {
+	if len(args) == 0 {
+		return fmt.Errorf(usage)
+	}
+	if password == "" {
+		return fmt.Errorf("KEYSTORE_PASSWORD is not set")
+	}
+
+	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	dir := flags.String("dir", "keys", "keystore directory")
+	switch args[0] {
+	case "import":
+		id := flags.String("id", "", "key ID, the name of the key file")
+		curve := flags.String("curve", ports.CurveSecp256k1, "secp256k1 (EVM, Tron, Bitcoin) or ed25519 (Solana)")
+		cipherName := flags.String("cipher", keystore.CipherAESCTR, "aes-128-ctr (standard v3, read by geth) or aes-256-gcm (read only by this tool and the server)")
+		light := flags.Bool("light", false, "use light scrypt parameters, faster to decrypt and easier to brute-force")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		return importKey(stdin, stdout, *dir, keystore.Key{ID: *id, Curve: *curve}, password, *cipherName, *light)
+	case "list":
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		return listKeys(stdout, *dir, password)
+	default:
+		return fmt.Errorf(usage)
+	}
+}
+
+// importKey encrypts the hex private key read from stdin into the keystore
+func importKey(stdin io.Reader, stdout io.Writer, dir string, key keystore.Key, password, cipherName string, light bool) error {
+	encoded, err := io.ReadAll(stdin)
+	if err != nil {
+		return fmt.Errorf("failed to read private key: %w", err)
+	}
+	defer clear(encoded)
+	key.PrivateKey, err = hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(encoded)), "0x"))
+	if err != nil {
+		return fmt.Errorf("failed to decode private key: %w", err)
+	}
+	defer clear(key.PrivateKey)
+
+	params := keystore.StandardScrypt
+	if light {
+		params = keystore.LightScrypt
+	}
+	if err := keystore.WriteKey(dir, key, password, params, cipherName); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "key %s written to %s\n", key.ID, filepath.Join(dir, key.ID+".json"))
+	return nil
+}
+
+// listKeys prints the ID, curve and public key of every key in the keystore
+func listKeys(stdout io.Writer, dir, password string) error {
+	manager, err := keystore.Load(dir, password)
+	if err != nil {
+		return err
+	}
+	keys, err := manager.ListKeys(context.Background())
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		fmt.Fprintf(stdout, "%s\t%s\t%x\n", key.ID, key.Curve, key.PublicKey)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	key := strings.Repeat("46", 32)
+
+	var out bytes.Buffer
+	require.NoError(t, run([]string{"import", "-dir", dir, "-id", "treasury", "-light"}, strings.NewReader("0x"+key+"\n"), &out, "secret"))
+	assert.Contains(t, out.String(), "key treasury written to")
+	require.NoError(t, run([]string{"import", "-dir", dir, "-id", "fee-payer", "-curve", "ed25519", "-cipher", "aes-256-gcm", "-light"}, strings.NewReader(key), &out, "secret"))
+
+	out.Reset()
+	require.NoError(t, run([]string{"list", "-dir", dir}, nil, &out, "secret"))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], "fee-payer\ted25519\t"))
+	// The compressed public key of the 0x46... key
+	assert.Equal(t, "treasury\tsecp256k1\t024bc2a31265153f07e70e0bab08724e6b85e217f8cd628ceb62974247bb493382", lines[1])
+
+	for name, args := range map[string][]string{
+		"no command":      nil,
+		"unknown command": {"export"},
+		"existing key":    {"import", "-dir", dir, "-id", "treasury", "-light"},
+		"bad flag":        {"list", "-verbose"},
+		"unknown cipher":  {"import", "-dir", dir, "-id", "other", "-cipher", "aes-128-cbc", "-light"},
+	} {
+		require.Error(t, run(args, strings.NewReader(key), &out, "secret"), name)
+	}
+	require.Error(t, run([]string{"import", "-dir", dir, "-id", "other", "-light"}, strings.NewReader("zz"), &out, "secret"))
+	require.Error(t, run([]string{"list", "-dir", dir}, nil, &out, "wrong"))
+	require.ErrorContains(t, run([]string{"list", "-dir", dir}, nil, &out, ""), "KEYSTORE_PASSWORD")
+}
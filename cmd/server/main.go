@@ -9,10 +9,14 @@ import (
 func main() {
 	app := fx.New(
 		modules.LoggerModule,
+		modules.ConfigModule,
+		modules.DatabaseModule,
+		modules.KeyStoreModule,
 		modules.EventBusModule,
 		modules.RegistryModule,
 		modules.AdaptersModule,
 		modules.UseCasesModule,
+		modules.HealthModule,
 		modules.APIModule,
 	)
 
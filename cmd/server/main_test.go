@@ -17,10 +17,14 @@ func TestMain_AppInitialization(t *testing.T) {
 	// Create app with a timeout to prevent hanging
 	app := fx.New(
 		modules.LoggerModule,
+		modules.ConfigModule,
+		modules.DatabaseModule,
+		modules.KeyStoreModule,
 		modules.EventBusModule,
 		modules.RegistryModule,
 		modules.AdaptersModule,
 		modules.UseCasesModule,
+		modules.HealthModule,
 		modules.APIModule,
 		fx.NopLogger, // Suppress fx logs during tests
 	)
@@ -23,9 +23,450 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/chains": {
+            "post": {
+                "description": "Cria o adapter de uma rede descrita como uma entrada do config.yaml (type, network, rpc_url, endpoints, chain_id, ...) e o registra sem reiniciar o servidor",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Registra uma blockchain",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "parameters": [
+                    {
+                        "description": "Network entry: type, network and the keys of a config.yaml network entry",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Chain registrada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Requisição inválida",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Token inválido",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/chains/{chain}": {
+            "get": {
+                "description": "Retorna tipo, token nativo e suas casas decimais, flag de testnet, altura do bloco atual, conectividade e estado de uma chain registrada",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Consulta os detalhes de uma blockchain",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "ethereum",
+                        "description": "Chain ID",
+                        "name": "chain",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Detalhes da chain",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Token inválido",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Chain não encontrada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove uma chain do registro; requisições em andamento terminam com o adapter anterior",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Remove uma blockchain",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "ethereum",
+                        "description": "Chain ID",
+                        "name": "chain",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Chain removida",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Token inválido",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Chain não encontrada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/chains/{chain}/disable": {
+            "post": {
+                "description": "Desativa a rede de uma chain, que passa a recusar requisições sem ser removida do registro",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Desabilita uma blockchain",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "ethereum",
+                        "description": "Chain ID",
+                        "name": "chain",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Detalhes da chain",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Token inválido",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Chain não encontrada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/chains/{chain}/enable": {
+            "post": {
+                "description": "Ativa a rede de uma chain desabilitada, que volta a atender requisições",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Habilita uma blockchain",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "ethereum",
+                        "description": "Chain ID",
+                        "name": "chain",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Detalhes da chain",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Token inválido",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Chain não encontrada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
         "/chains": {
             "get": {
-                "description": "Retorna uma lista de IDs de todas as blockchains registradas no sistema",
+                "description": "Retorna os IDs das blockchains registradas no sistema e, em metadata, o tipo, a rede, o token nativo e suas casas decimais de cada uma",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chains"
+                ],
+                "summary": "Lista todas as blockchains suportadas",
+                "responses": {
+                    "200": {
+                        "description": "Lista de chains",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/{chain}": {
+            "get": {
+                "description": "Retorna os metadados de uma chain (rede, token nativo e suas casas decimais, explorer) e dados ao vivo do nó: último bloco, peers e preço do gás; uma chain desabilitada é descrita sem os dados ao vivo",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chains"
+                ],
+                "summary": "Consulta as informações de uma blockchain",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "ethereum",
+                        "description": "Chain ID",
+                        "name": "chain",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Informações da chain",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Chain não encontrada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/{chain}/balance/{address}": {
+            "get": {
+                "description": "Retorna o saldo de um endereço em uma blockchain específica",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Balance"
+                ],
+                "summary": "Consulta saldo de uma carteira",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "ethereum",
+                        "description": "Chain ID",
+                        "name": "chain",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb",
+                        "description": "Wallet Address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token Contract Address",
+                        "name": "token_address",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Saldo da carteira",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Requisição inválida",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/{chain}/psbt/export": {
+            "post": {
+                "description": "Cria e guarda uma transação e a retorna como PSBT (BIP-174) em base64 para assinatura offline",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PSBT"
+                ],
+                "summary": "Exporta uma transação não assinada como PSBT",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "bitcoin-mainnet",
+                        "description": "Chain ID",
+                        "name": "chain",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Transaction data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.ExportPSBTRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "PSBT exportado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Requisição inválida",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/{chain}/psbt/import": {
+            "post": {
+                "description": "Combina PSBTs assinados, finaliza a transação e opcionalmente a transmite",
                 "consumes": [
                     "application/json"
                 ],
@@ -33,12 +474,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Chains"
+                    "PSBT"
+                ],
+                "summary": "Importa PSBTs assinados",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "bitcoin-mainnet",
+                        "description": "Chain ID",
+                        "name": "chain",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Signed PSBTs",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.ImportPSBTRequest"
+                        }
+                    }
                 ],
-                "summary": "Lista todas as blockchains suportadas",
                 "responses": {
                     "200": {
-                        "description": "Lista de chains",
+                        "description": "PSBT importado",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Requisição inválida",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -47,9 +521,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/{chain}/balance/{address}": {
-            "get": {
-                "description": "Retorna o saldo de um endereço em uma blockchain específica",
+        "/{chain}/transaction/create": {
+            "post": {
+                "description": "Cria e prepara uma transação para ser assinada e transmitida",
                 "consumes": [
                     "application/json"
                 ],
@@ -57,9 +531,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Balance"
+                    "Transactions"
                 ],
-                "summary": "Consulta saldo de uma carteira",
+                "summary": "Cria uma nova transação",
                 "parameters": [
                     {
                         "type": "string",
@@ -70,23 +544,18 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "example": "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb",
-                        "description": "Wallet Address",
-                        "name": "address",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Token Contract Address",
-                        "name": "token_address",
-                        "in": "query"
+                        "description": "Transaction data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.CreateTransactionRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Saldo da carteira",
+                    "201": {
+                        "description": "Transação criada",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -109,9 +578,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/{chain}/transaction/create": {
+        "/{chain}/transaction/estimate-fee": {
             "post": {
-                "description": "Cria e prepara uma transação para ser assinada e transmitida",
+                "description": "Monta a transação descrita no corpo, igual ao de /transaction/create, sem guardá-la, e retorna a taxa estimada: gas_price nas chains legadas e max_fee_per_gas/max_priority_fee nas redes EIP-1559",
                 "consumes": [
                     "application/json"
                 ],
@@ -121,7 +590,7 @@ const docTemplate = `{
                 "tags": [
                     "Transactions"
                 ],
-                "summary": "Cria uma nova transação",
+                "summary": "Estima a taxa de uma transação",
                 "parameters": [
                     {
                         "type": "string",
@@ -142,8 +611,8 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Transação criada",
+                    "200": {
+                        "description": "Taxa estimada",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -156,6 +625,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "404": {
+                        "description": "Chain não encontrada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Erro interno",
                         "schema": {
@@ -168,7 +644,7 @@ const docTemplate = `{
         },
         "/{chain}/transaction/send": {
             "post": {
-                "description": "Envia uma transação assinada para a blockchain",
+                "description": "Envia para a blockchain uma transação criada e assinada pela API (transaction_id) ou assinada fora dela (signed_data, a transação serializada no formato da chain, em hex ou base64 na Solana)",
                 "consumes": [
                     "application/json"
                 ],
@@ -207,7 +683,21 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Requisição inválida",
+                        "description": "Requisição ou transação inválida",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Chain ou transação não encontrada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Transação não assinada ou já transmitida",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -278,6 +768,149 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "/{chain}/transaction/{hash}/bump": {
+            "post": {
+                "description": "Substitui a transação por outra com taxa maior (RBF, BIP-125) ou gasta sua saída de troco com uma transação filha de taxa alta (CPFP) e transmite o resultado",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Acelera uma transação não confirmada",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "bitcoin-mainnet",
+                        "description": "Chain ID",
+                        "name": "chain",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transaction hash",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fee bump data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.BumpFeeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transação acelerada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Requisição inválida",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/{chain}/transaction/{id}/sign": {
+            "post": {
+                "description": "Assina uma transação criada por /transaction/create com uma chave do key store, referenciada por key_id; a chave privada nunca trafega na requisição",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transactions"
+                ],
+                "summary": "Assina uma transação criada",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "ethereum",
+                        "description": "Chain ID",
+                        "name": "chain",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Signing key",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_api.SignTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transação assinada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Requisição inválida ou chave desconhecida",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Chain ou transação não encontrada",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Transação já transmitida",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
@@ -292,6 +925,20 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_api.BumpFeeRequest": {
+            "type": "object",
+            "properties": {
+                "fee_rate": {
+                    "type": "string"
+                },
+                "key_id": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                }
+            }
+        },
         "internal_api.CreateTransactionRequest": {
             "type": "object",
             "properties": {
@@ -307,6 +954,29 @@ const docTemplate = `{
                 "gas_limit": {
                     "type": "integer"
                 },
+                "options": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "token_address": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_api.ExportPSBTRequest": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
                 "to": {
                     "type": "string"
                 },
@@ -314,6 +984,36 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "internal_api.ImportPSBTRequest": {
+            "type": "object",
+            "properties": {
+                "broadcast": {
+                    "type": "boolean"
+                },
+                "psbts": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_api.SignTransactionRequest": {
+            "type": "object",
+            "properties": {
+                "key_id": {
+                    "type": "string"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "AdminToken": {
+            "description": "Token Bearer dos endpoints de administração (admin.token), no formato \"Bearer <token>\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
@@ -0,0 +1,108 @@
+package base58
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+)
+
+const alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+const checksumLength = 4
+
+var (
+	bigRadix   = big.NewInt(58)
+	decodeMap  [256]int8
+	zeroDigit  = alphabet[0]
+	errNoInput = fmt.Errorf("base58 input cannot be empty")
+)
+
+func init() {
+	for i := range decodeMap {
+		decodeMap[i] = -1
+	}
+	for i := 0; i < len(alphabet); i++ {
+		decodeMap[alphabet[i]] = int8(i)
+	}
+}
+
+// Encode encodes bytes as a base58 string, preserving leading zero bytes
+func Encode(input []byte) string {
+	x := new(big.Int).SetBytes(input)
+	mod := new(big.Int)
+
+	out := make([]byte, 0, len(input)*138/100+1)
+	for x.Sign() > 0 {
+		x.DivMod(x, bigRadix, mod)
+		out = append(out, alphabet[mod.Int64()])
+	}
+	for _, b := range input {
+		if b != 0 {
+			break
+		}
+		out = append(out, zeroDigit)
+	}
+
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// Decode decodes a base58 string
+func Decode(input string) ([]byte, error) {
+	if input == "" {
+		return nil, errNoInput
+	}
+
+	x := new(big.Int)
+	for i := 0; i < len(input); i++ {
+		digit := decodeMap[input[i]]
+		if digit < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", input[i])
+		}
+		x.Mul(x, bigRadix)
+		x.Add(x, big.NewInt(int64(digit)))
+	}
+
+	zeros := 0
+	for zeros < len(input) && input[zeros] == zeroDigit {
+		zeros++
+	}
+
+	decoded := x.Bytes()
+	out := make([]byte, zeros+len(decoded))
+	copy(out[zeros:], decoded)
+	return out, nil
+}
+
+// CheckEncode appends a double-SHA256 checksum to the payload and base58-encodes it
+func CheckEncode(payload []byte) string {
+	data := make([]byte, 0, len(payload)+checksumLength)
+	data = append(data, payload...)
+	data = append(data, checksum(payload)...)
+	return Encode(data)
+}
+
+// CheckDecode decodes a base58check string and verifies its checksum
+func CheckDecode(input string) ([]byte, error) {
+	data, err := Decode(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < checksumLength {
+		return nil, fmt.Errorf("base58check input too short")
+	}
+	payload := data[:len(data)-checksumLength]
+	if !bytes.Equal(checksum(payload), data[len(data)-checksumLength:]) {
+		return nil, fmt.Errorf("invalid base58check checksum")
+	}
+	return payload, nil
+}
+
+func checksum(payload []byte) []byte {
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	return second[:checksumLength]
+}
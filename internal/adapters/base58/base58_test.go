@@ -0,0 +1,63 @@
+package base58
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		hex     string
+		encoded string
+	}{
+		{"", ""},
+		{"61", "2g"},
+		{"626262", "a3gV"},
+		{"636363", "aPEr"},
+		{"73696d706c792061206c6f6e6720737472696e67", "2cFupjhnEsSn59qHXstmK2ffpLv2"},
+		{"00eb15231dfceb60925886b67d065299925915aeb172c06647", "1NS17iag9jJgTHD1VXjvLCEnZuQ3rJDE9L"},
+		{"00000000000000000000", "1111111111"},
+	}
+
+	for _, tc := range cases {
+		raw, err := hex.DecodeString(tc.hex)
+		require.NoError(t, err)
+		assert.Equal(t, tc.encoded, Encode(raw))
+		if tc.encoded == "" {
+			continue
+		}
+		decoded, err := Decode(tc.encoded)
+		require.NoError(t, err)
+		assert.Equal(t, raw, decoded)
+	}
+
+	_, err := Decode("")
+	require.Error(t, err)
+	_, err = Decode("0OIl")
+	require.Error(t, err)
+}
+
+func TestCheckEncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	// Tron address of the USDT contract
+	payload, err := hex.DecodeString("41a614f803b6fd780986a42c78ec9c7f77e6ded13c")
+	require.NoError(t, err)
+	assert.Equal(t, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", CheckEncode(payload))
+
+	decoded, err := CheckDecode("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	require.NoError(t, err)
+	assert.Equal(t, payload, decoded)
+
+	_, err = CheckDecode("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6u")
+	require.Error(t, err)
+	_, err = CheckDecode("2g")
+	require.Error(t, err)
+	_, err = CheckDecode("0")
+	require.Error(t, err)
+}
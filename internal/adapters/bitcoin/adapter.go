@@ -5,15 +5,107 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/rpcpool"
 )
 
-// Adapter implements the ChainAdapter interface for Bitcoin
+const (
+	defaultPollInterval = 30 * time.Second
+	// nativeDecimals converts satoshis to coins on every supported UTXO chain
+	nativeDecimals = 8
+	// defaultConfirmationTarget is the number of blocks fee rates are estimated for
+	defaultConfirmationTarget = 6
+	// averageTxSize is used when a transaction has no selected inputs to size it by
+	averageTxSize = 250
+)
+
+// RPC backends selectable per network
+const (
+	// BackendEsplora reads the chain through an Esplora REST API
+	BackendEsplora = "esplora"
+	// BackendBitcoind reads the chain through the JSON-RPC interface of bitcoind
+	BackendBitcoind = "bitcoind"
+)
+
+// NetworkConfig describes a Bitcoin network entry (bitcoin.networks in config.yaml)
+type NetworkConfig struct {
+	// Chain is the UTXO chain of the network, ChainBitcoin (default), ChainLitecoin, ChainDogecoin or ChainBitcoinCash
+	Chain  string `yaml:"chain"`
+	Name   string `yaml:"name"`
+	RPCURL string `yaml:"rpc_url"`
+	// ExplorerURL is the block explorer of the network
+	ExplorerURL string `yaml:"explorer_url"`
+	// Backend is the API behind rpc_url, BackendEsplora (default) or BackendBitcoind
+	Backend     string `yaml:"backend"`
+	RPCUser     string `yaml:"rpc_user"`
+	RPCPassword string `yaml:"rpc_password"`
+	// RPCWallet is the bitcoind wallet listing unspent outputs; without one the UTXO set is scanned
+	RPCWallet string `yaml:"rpc_wallet"`
+	// CoinSelection is the default coin selection strategy; empty selects branch-and-bound
+	CoinSelection string `yaml:"coin_selection"`
+	// MinConfirmations is the default number of confirmations a UTXO needs to be spent
+	MinConfirmations int64 `yaml:"min_confirmations"`
+	// Endpoints are further backends of the same kind balanced with rpc_url and failed over to
+	Endpoints []rpcpool.Endpoint `yaml:"endpoints"`
+	// Pool tunes endpoint selection and health tracking when endpoints are set
+	Pool rpcpool.Options `yaml:"pool"`
+}
+
+// Validate checks the network configuration
+func (c NetworkConfig) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("network name cannot be empty")
+	}
+	if c.RPCURL == "" {
+		return fmt.Errorf("rpc_url cannot be empty for network %s", c.Name)
+	}
+	if _, err := LookupChainParams(c.chain(), c.Name); err != nil {
+		return err
+	}
+	if c.Backend != "" && c.Backend != BackendEsplora && c.Backend != BackendBitcoind {
+		return fmt.Errorf("unknown backend %q for network %s", c.Backend, c.Name)
+	}
+	if _, err := NewCoinSelector(c.CoinSelection); err != nil {
+		return fmt.Errorf("invalid coin_selection for network %s: %w", c.Name, err)
+	}
+	if c.MinConfirmations < 0 {
+		return fmt.Errorf("min_confirmations cannot be negative for network %s", c.Name)
+	}
+	if err := rpcpool.Validate(c.Endpoints, c.Pool); err != nil {
+		return fmt.Errorf("invalid endpoints for network %s: %w", c.Name, err)
+	}
+	return nil
+}
+
+// ChainID returns the identifier the network is registered under, such as bitcoin-mainnet
+func (c NetworkConfig) ChainID() string {
+	return chainID(c.chain(), c.Name)
+}
+
+// chain returns the configured chain, defaulting to Bitcoin
+func (c NetworkConfig) chain() string {
+	if c.Chain == "" {
+		return ChainBitcoin
+	}
+	return c.Chain
+}
+
+// Adapter implements the ChainAdapter interface for Bitcoin and the UTXO chains derived from it
 type Adapter struct {
-	rpcClient RPCClient
-	network   string
+	rpcClient        RPCClient
+	params           *ChainParams
+	rpcURL           string
+	explorerURL      string
+	pollInterval     time.Duration
+	selector         CoinSelector
+	minConfirmations int64
+	pool             *rpcpool.Pool
 }
 
 // RPCClient defines the interface for Bitcoin RPC operations
@@ -70,17 +162,89 @@ type TxOutput struct {
 	Address      string
 }
 
-// NewAdapter creates a new Bitcoin adapter
+// NewAdapter creates a new Bitcoin adapter; networks without chain parameters accept no addresses
 func NewAdapter(rpcClient RPCClient, network string) *Adapter {
+	params, err := LookupChainParams(ChainBitcoin, network)
+	if err != nil {
+		params = &ChainParams{Chain: ChainBitcoin, Network: network, Ticker: "BTC", SupportsRBF: true, MinFeeRate: 1}
+	}
+	return NewChainAdapter(rpcClient, params)
+}
+
+// NewChainAdapter creates a new adapter for a network of a UTXO chain
+func NewChainAdapter(rpcClient RPCClient, params *ChainParams) *Adapter {
 	return &Adapter{
-		rpcClient: rpcClient,
-		network:   network,
+		rpcClient:    rpcClient,
+		params:       params,
+		pollInterval: defaultPollInterval,
+		selector:     BranchAndBoundSelector{Fallback: KnapsackSelector{}},
+	}
+}
+
+// NewAdapterWithConfig creates a new adapter for a configured network
+func NewAdapterWithConfig(rpcClient RPCClient, config NetworkConfig) (*Adapter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	params, err := LookupChainParams(config.chain(), config.Name)
+	if err != nil {
+		return nil, err
+	}
+	adapter := NewChainAdapter(rpcClient, params)
+	adapter.rpcURL = config.RPCURL
+	adapter.explorerURL = config.ExplorerURL
+	adapter.selector, _ = NewCoinSelector(config.CoinSelection)
+	adapter.minConfirmations = config.MinConfirmations
+	return adapter, nil
+}
+
+// NewRPCClient creates the client of the backend a network is configured with
+func NewRPCClient(config NetworkConfig) (RPCClient, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
 	}
+	return newRPCClient(config, config.RPCURL, nil), nil
 }
 
-// GetChainID returns the chain identifier
+// newRPCClient creates a client of the configured backend at url
+func newRPCClient(config NetworkConfig, url string, httpClient *http.Client) RPCClient {
+	if config.Backend == BackendBitcoind {
+		return NewBitcoindClient(url, config.RPCUser, config.RPCPassword, config.RPCWallet, httpClient)
+	}
+	return NewEsploraClient(url, httpClient)
+}
+
+// NewAdapterFromConfig creates a new adapter backed by the configured RPC backend
+func NewAdapterFromConfig(config NetworkConfig) (*Adapter, error) {
+	rpcClient, err := NewRPCClient(config)
+	if err != nil {
+		return nil, err
+	}
+	if len(config.Endpoints) == 0 {
+		return NewAdapterWithConfig(rpcClient, config)
+	}
+	endpoints := append([]rpcpool.Endpoint{{URL: config.RPCURL}}, config.Endpoints...)
+	pool, err := rpcpool.New(endpoints, config.Pool, func(ctx context.Context, endpoint string, client *http.Client) (uint64, error) {
+		height, err := newRPCClient(config, endpoint, client).GetBlockCount(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return uint64(max(height, 0)), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	adapter, err := NewAdapterWithConfig(newRPCClient(config, pool.URL(), pool.Client()), config)
+	if err != nil {
+		return nil, err
+	}
+	adapter.pool = pool
+	return adapter, nil
+}
+
+// GetChainID returns the chain identifier, such as bitcoin-mainnet or litecoin-testnet
 func (a *Adapter) GetChainID() string {
-	return fmt.Sprintf("bitcoin-%s", a.network)
+	return a.params.ChainID()
 }
 
 // GetChainType returns the chain type
@@ -88,6 +252,17 @@ func (a *Adapter) GetChainType() entities.ChainType {
 	return entities.ChainTypeBitcoin
 }
 
+// DescribeChain returns the chain's type, network and native currency; every network but
+// mainnet is a testnet
+func (a *Adapter) DescribeChain() (*entities.Chain, error) {
+	chain, err := entities.NewChain(a.GetChainID(), entities.ChainTypeBitcoin, a.params.Network, a.params.Network != "mainnet", a.params.Ticker)
+	if err != nil {
+		return nil, err
+	}
+	chain.SetDecimals(nativeDecimals)
+	return chain, nil
+}
+
 // IsConnected checks if the adapter is connected to the Bitcoin network
 func (a *Adapter) IsConnected(ctx context.Context) bool {
 	_, err := a.rpcClient.GetBlockCount(ctx)
@@ -106,8 +281,16 @@ func (a *Adapter) GetBlockNumber(ctx context.Context) (uint64, error) {
 	return uint64(height), nil
 }
 
+// ParseAddress decodes an address, rejecting addresses of other networks than the adapter's
+func (a *Adapter) ParseAddress(address string) (*Address, error) {
+	return parseAddress(address, a.params.Chain, a.params.Network)
+}
+
 // GetNativeBalance returns the Bitcoin balance for an address
 func (a *Adapter) GetNativeBalance(ctx context.Context, address *valueobjects.Address) (*big.Int, error) {
+	if _, err := a.ParseAddress(address.String()); err != nil {
+		return nil, err
+	}
 	balance, err := a.rpcClient.GetBalance(ctx, address.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get balance: %w", err)
@@ -115,20 +298,64 @@ func (a *Adapter) GetNativeBalance(ctx context.Context, address *valueobjects.Ad
 	return balance, nil
 }
 
-// CreateTransaction creates a new Bitcoin transaction using UTXO selection
+// CreateTransaction creates a new Bitcoin transaction, selecting the sender's UTXOs with the
+// configured CoinSelector. The OptionCoinSelection and OptionMinConfirmations options override
+// the network defaults per request. Without a gas price, the fee rate is the node's estimate,
+// at least the chain's minimum relay fee rate. On chains supporting BIP-125, inputs signal
+// replace-by-fee unless OptionReplaceable is "false"
 func (a *Adapter) CreateTransaction(ctx context.Context, params entities.TransactionParams) (*entities.Transaction, error) {
+	selector, minConfirmations, err := a.selectionOptions(params.Options)
+	if err != nil {
+		return nil, err
+	}
+	replaceable := a.params.SupportsRBF
+	if value, ok := params.Options[OptionReplaceable]; ok {
+		if replaceable, err = strconv.ParseBool(value); err != nil {
+			return nil, fmt.Errorf("invalid %s option: %q", OptionReplaceable, value)
+		}
+		if replaceable && !a.params.SupportsRBF {
+			return nil, fmt.Errorf("replace-by-fee is not supported on %s", a.GetChainID())
+		}
+	}
+	if params.Value == nil || !params.Value.IsInt64() {
+		return nil, fmt.Errorf("value must be a satoshi amount")
+	}
+	from, err := a.ParseAddress(params.From.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid from address: %w", err)
+	}
+	to, err := a.ParseAddress(params.To.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid to address: %w", err)
+	}
+	fromScript, toScript := from.ScriptPubKey(), to.ScriptPubKey()
+	if dust := a.params.dustThreshold(toScript); params.Value.Int64() < dust {
+		return nil, fmt.Errorf("value %s is below the dust threshold of %d satoshis", params.Value, dust)
+	}
+
 	// Get UTXOs for the sender address
 	utxos, err := a.rpcClient.ListUnspent(ctx, params.From.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to list unspent: %w", err)
 	}
 
-	// Select UTXOs using coin selection algorithm
 	feePerByte := params.GasPrice
 	if feePerByte == nil {
-		feePerByte = big.NewInt(1000) // Default fee
+		if feePerByte, err = a.feeRate(ctx); err != nil {
+			return nil, err
+		}
 	}
-	selectedUTXOs, changeAmount, err := a.selectCoins(utxos, params.Value, feePerByte)
+	if !feePerByte.IsInt64() || feePerByte.Sign() < 0 {
+		return nil, fmt.Errorf("invalid fee rate: %s", feePerByte)
+	}
+	selection, err := selector.Select(utxos, SelectionRequest{
+		Target:           params.Value.Int64(),
+		FeeRate:          feePerByte.Int64(),
+		RecipientScript:  toScript,
+		ChangeScript:     fromScript,
+		DustLimit:        a.params.dustThreshold(fromScript),
+		MinConfirmations: minConfirmations,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("coin selection failed: %w", err)
 	}
@@ -148,79 +375,62 @@ func (a *Adapter) CreateTransaction(ctx context.Context, params entities.Transac
 	}
 
 	// Store UTXO information in metadata for signing
-	tx.SetMetadata("utxos", selectedUTXOs)
-	tx.SetMetadata("change_amount", changeAmount.String())
+	tx.SetMetadata(MetadataUTXOs, selection.UTXOs)
+	tx.SetMetadata(MetadataChangeAmount, big.NewInt(selection.Change).String())
+	tx.SetMetadata(MetadataFee, big.NewInt(selection.Fee).String())
+	tx.SetMetadata(MetadataReplaceable, replaceable)
 
 	return tx, nil
 }
 
-// selectCoins implements a simple coin selection algorithm
-func (a *Adapter) selectCoins(utxos []UTXO, amount, feePerByte *big.Int) ([]UTXO, *big.Int, error) {
-	var selected []UTXO
-	total := big.NewInt(0)
-	required := new(big.Int).Set(amount)
-
-	// Estimate fee (simplified - assume 250 bytes per input, 34 bytes per output)
-	estimatedSize := int64(10 + len(utxos)*250 + 2*34)
-	fee := new(big.Int).Mul(feePerByte, big.NewInt(estimatedSize))
-	required.Add(required, fee)
-
-	// Simple greedy selection
-	for _, utxo := range utxos {
-		if total.Cmp(required) >= 0 {
-			break
+// selectionOptions resolves the coin selector and minimum confirmations of a request
+func (a *Adapter) selectionOptions(options map[string]string) (CoinSelector, int64, error) {
+	selector := a.selector
+	if selector == nil {
+		selector = BranchAndBoundSelector{Fallback: KnapsackSelector{}}
+	}
+	if strategy, ok := options[OptionCoinSelection]; ok {
+		var err error
+		if selector, err = NewCoinSelector(strategy); err != nil {
+			return nil, 0, err
 		}
-		selected = append(selected, utxo)
-		total.Add(total, big.NewInt(utxo.Amount))
 	}
 
-	if total.Cmp(required) < 0 {
-		return nil, nil, fmt.Errorf("insufficient funds: need %s, have %s", required.String(), total.String())
+	minConfirmations := a.minConfirmations
+	if value, ok := options[OptionMinConfirmations]; ok {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 0 {
+			return nil, 0, fmt.Errorf("invalid %s option: %q", OptionMinConfirmations, value)
+		}
+		minConfirmations = parsed
 	}
-
-	change := new(big.Int).Sub(total, required)
-	return selected, change, nil
+	return selector, minConfirmations, nil
 }
 
-// SignTransaction signs a Bitcoin transaction
-func (a *Adapter) SignTransaction(ctx context.Context, tx *entities.Transaction, privateKey []byte) error {
-	// In production, this would use proper Bitcoin signing with ECDSA
-	// For now, we create a simplified signature
-	_ = privateKey // privateKey would be used in production
-	signature := a.createSignature(tx)
-
-	sig, err := valueobjects.NewSignature(hex.EncodeToString(signature))
+// SignTransaction signs every input with BIP-143 (P2WPKH and fork ID chains) or legacy (P2PKH) signature hashes
+func (a *Adapter) SignTransaction(ctx context.Context, tx *entities.Transaction, keys ports.KeyManager, keyID string) error {
+	msg, utxos, err := unsignedTx(tx, a.params)
 	if err != nil {
-		return fmt.Errorf("failed to create signature: %w", err)
+		return fmt.Errorf("failed to build transaction: %w", err)
 	}
-
-	if err := tx.SetSignature(sig); err != nil {
-		return fmt.Errorf("failed to set signature: %w", err)
+	key, err := newSigningKey(ctx, keys, keyID)
+	if err != nil {
+		return err
+	}
+	if err := signInputs(ctx, msg, utxos, tx.From().Value(), key, a.params); err != nil {
+		return fmt.Errorf("failed to sign transaction: %w", err)
 	}
 
-	return nil
-} // createSignature creates a transaction signature (simplified)
-func (a *Adapter) createSignature(tx *entities.Transaction) []byte {
-	// In production, use proper Bitcoin SIGHASH and ECDSA signing
-	// This is a simplified version for demonstration
-	data := fmt.Sprintf("%s:%s:%s:%s",
-		tx.From().String(),
-		tx.To().String(),
-		tx.Value().String(),
-		tx.ID(),
-	)
-	return []byte(hex.EncodeToString([]byte(data)))
+	return applySignedTx(tx, msg)
 }
 
 // BroadcastTransaction broadcasts a signed Bitcoin transaction
 func (a *Adapter) BroadcastTransaction(ctx context.Context, tx *entities.Transaction) (*valueobjects.Hash, error) {
-	if tx.Signature() == nil {
-		return nil, fmt.Errorf("transaction not signed")
+	rawTx, err := rawTransaction(tx)
+	if err != nil {
+		return nil, err
 	}
 
-	// Build raw transaction
-	rawTx := a.buildRawTransaction(tx)
-
 	txHash, err := a.rpcClient.SendRawTransaction(ctx, rawTx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to broadcast transaction: %w", err)
@@ -234,16 +444,18 @@ func (a *Adapter) BroadcastTransaction(ctx context.Context, tx *entities.Transac
 	return hash, nil
 }
 
-// buildRawTransaction builds a raw transaction hex string
-func (a *Adapter) buildRawTransaction(tx *entities.Transaction) string {
-	// In production, properly encode transaction according to Bitcoin protocol
-	// This is simplified for demonstration
-	return hex.EncodeToString([]byte(tx.ID()))
+// rawTransaction returns the serialized transaction stored by SignTransaction
+func rawTransaction(tx *entities.Transaction) (string, error) {
+	rawTx, ok := tx.Metadata()[MetadataRawTransaction].(string)
+	if tx.Signature() == nil || !ok || rawTx == "" {
+		return "", fmt.Errorf("transaction not signed")
+	}
+	return rawTx, nil
 }
 
 // GetTransactionStatus returns the status of a transaction
 func (a *Adapter) GetTransactionStatus(ctx context.Context, hash *valueobjects.Hash) (entities.TxStatus, error) {
-	btcTx, err := a.rpcClient.GetRawTransaction(ctx, hash.String())
+	btcTx, err := a.rpcClient.GetRawTransaction(ctx, hash.HexWithoutPrefix())
 	if err != nil {
 		return entities.TxStatusPending, fmt.Errorf("failed to get transaction: %w", err)
 	}
@@ -260,13 +472,233 @@ func (a *Adapter) GetTransactionStatus(ctx context.Context, hash *valueobjects.H
 // EstimateFee estimates the transaction fee
 func (a *Adapter) EstimateFee(ctx context.Context, tx *entities.Transaction) (*entities.Fee, error) {
 	// Estimate fee per byte for 6 block confirmation
-	feePerByte, err := a.rpcClient.EstimateFee(ctx, 6)
+	feePerByte, err := a.rpcClient.EstimateFee(ctx, defaultConfirmationTarget)
 	if err != nil {
 		return nil, fmt.Errorf("failed to estimate fee: %w", err)
 	}
 
-	// Estimate transaction size (simplified)
-	estimatedSize := uint64(250) // Average transaction size
+	estimatedSize, err := a.EstimateGas(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.NewFee(estimatedSize, feePerByte, a.params.Ticker)
+}
+
+// GetBalance returns the Bitcoin balance for a given address
+func (a *Adapter) GetBalance(ctx context.Context, chainID string, address *valueobjects.Address) (*big.Int, error) {
+	return a.GetNativeBalance(ctx, address)
+}
+
+// GetTokenBalance is not supported, since Bitcoin has no token contracts
+func (a *Adapter) GetTokenBalance(ctx context.Context, chainID string, address, tokenAddress *valueobjects.Address) (*big.Int, error) {
+	return nil, fmt.Errorf("token balances are not supported on %s", a.GetChainID())
+}
+
+// BuildTransaction creates a transaction funded by the sender's UTXOs
+func (a *Adapter) BuildTransaction(ctx context.Context, params entities.TransactionParams) (*entities.Transaction, error) {
+	tx, err := a.CreateTransaction(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.SetNonce(ctx, tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// EstimateGas estimates the virtual size of the signed transaction, which is what Bitcoin fees are paid for
+func (a *Adapter) EstimateGas(ctx context.Context, tx *entities.Transaction) (uint64, error) {
+	utxos, err := metadataUTXOs(tx)
+	if err != nil {
+		return averageTxSize, nil
+	}
+	msg, _, err := unsignedTx(tx, a.params)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build transaction: %w", err)
+	}
+
+	inputs := make([]int, len(utxos))
+	for i, utxo := range utxos {
+		script, err := prevOutScript(utxo, tx.From().Value())
+		if err != nil {
+			return 0, err
+		}
+		inputs[i] = inputWeight(script)
+	}
+	outputs := make([][]byte, len(msg.outputs))
+	for i, out := range msg.outputs {
+		outputs[i] = out.pkScript
+	}
+	weight := estimateWeight(inputs, outputs)
+	return uint64((weight + witnessScaleFactor - 1) / witnessScaleFactor), nil
+}
+
+// SetNonce sets a zero nonce, since UTXO transactions are identified by the outputs they spend
+func (a *Adapter) SetNonce(ctx context.Context, tx *entities.Transaction) error {
+	if tx.Nonce() != nil {
+		return nil
+	}
+	return tx.SetNonce(valueobjects.NewNonce(0))
+}
+
+// VerifySignature verifies the signature of every input against the spent outputs
+func (a *Adapter) VerifySignature(ctx context.Context, tx *entities.Transaction) (bool, error) {
+	rawTx, err := rawTransaction(tx)
+	if err != nil {
+		return false, err
+	}
+	data, err := hex.DecodeString(rawTx)
+	if err != nil {
+		return false, fmt.Errorf("invalid raw transaction hex: %w", err)
+	}
+	msg, err := deserializeTx(data)
+	if err != nil {
+		return false, fmt.Errorf("invalid raw transaction: %w", err)
+	}
+	utxos, err := metadataUTXOs(tx)
+	if err != nil {
+		return false, err
+	}
+	return verifyInputs(msg, utxos, tx.From().Value(), a.params)
+}
+
+// GetTransactionReceipt returns the transaction with its confirmation data
+func (a *Adapter) GetTransactionReceipt(ctx context.Context, hash *valueobjects.Hash) (*entities.Transaction, error) {
+	btcTx, err := a.rpcClient.GetRawTransaction(ctx, hash.HexWithoutPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	if len(btcTx.Inputs) == 0 || len(btcTx.Outputs) == 0 {
+		return nil, fmt.Errorf("transaction %s has no inputs or outputs", btcTx.TxID)
+	}
+
+	// Inputs only reference previous outputs, so the sender is read from the spent output
+	input := btcTx.Inputs[0]
+	prevTx, err := a.rpcClient.GetRawTransaction(ctx, input.TxID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get previous transaction: %w", err)
+	}
+	if int(input.Vout) >= len(prevTx.Outputs) {
+		return nil, fmt.Errorf("previous output %s:%d not found", input.TxID, input.Vout)
+	}
+
+	from, err := valueobjects.NewAddress(prevTx.Outputs[input.Vout].Address, a.GetChainID())
+	if err != nil {
+		return nil, fmt.Errorf("invalid from address: %w", err)
+	}
+	output := btcTx.Outputs[0]
+	to, err := valueobjects.NewAddress(output.Address, a.GetChainID())
+	if err != nil {
+		return nil, fmt.Errorf("invalid to address: %w", err)
+	}
+
+	tx, err := entities.NewTransaction(entities.TransactionParams{
+		ChainID: a.GetChainID(),
+		From:    from,
+		To:      to,
+		Value:   big.NewInt(output.Value),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+	if err := tx.SetHash(hash); err != nil {
+		return nil, err
+	}
+	tx.SetMetadata("outputs", btcTx.Outputs)
+
+	if btcTx.Confirmations <= 0 {
+		tx.UpdateStatus(entities.TxStatusPending)
+		return tx, nil
+	}
+
+	height, err := a.GetBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	confirmations := uint64(btcTx.Confirmations)
+	tx.UpdateStatus(entities.TxStatusConfirmed)
+	tx.SetConfirmations(confirmations)
+	if height+1 >= confirmations {
+		tx.SetBlockNumber(height + 1 - confirmations)
+	}
+	tx.SetMetadata("block_hash", btcTx.BlockHash)
+	return tx, nil
+}
+
+// WaitForConfirmation polls the node until the transaction reaches the given confirmations
+func (a *Adapter) WaitForConfirmation(ctx context.Context, hash *valueobjects.Hash, confirmations uint64) error {
+	if confirmations == 0 {
+		confirmations = 1
+	}
+
+	ticker := time.NewTicker(a.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		btcTx, err := a.rpcClient.GetRawTransaction(ctx, hash.HexWithoutPrefix())
+		if err != nil {
+			return fmt.Errorf("failed to get transaction: %w", err)
+		}
+		if btcTx.Confirmations > 0 && uint64(btcTx.Confirmations) >= confirmations {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetGasPrice returns the estimated fee rate per byte
+func (a *Adapter) GetGasPrice(ctx context.Context) (*big.Int, error) {
+	feeRate, err := a.rpcClient.EstimateFee(ctx, defaultConfirmationTarget)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate fee: %w", err)
+	}
+	return feeRate, nil
+}
+
+// feeRate returns the node's fee rate estimate, raised to the minimum relay fee rate of the chain
+func (a *Adapter) feeRate(ctx context.Context) (*big.Int, error) {
+	rate, err := a.rpcClient.EstimateFee(ctx, defaultConfirmationTarget)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate fee rate, set a gas price: %w", err)
+	}
+	if minimum := big.NewInt(a.params.MinFeeRate); rate.Cmp(minimum) < 0 {
+		return minimum, nil
+	}
+	return rate, nil
+}
+
+// GetMaxPriorityFee returns zero, since Bitcoin has no priority fees
+func (a *Adapter) GetMaxPriorityFee(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(0), nil
+}
+
+// GetNetworkInfo returns the network information
+func (a *Adapter) GetNetworkInfo(ctx context.Context) (*entities.Network, error) {
+	network, err := entities.NewNetwork(a.GetChainID(), a.params.Network, a.rpcURL)
+	if err != nil {
+		return nil, err
+	}
+	if a.explorerURL != "" {
+		network.SetExplorerURL(a.explorerURL)
+	}
+	if a.pool != nil {
+		network.SetEndpoints(a.pool.Health())
+	}
+	return network, nil
+}
+
+// GetPeers returns zero, since the RPC client does not expose peer connections
+func (a *Adapter) GetPeers(ctx context.Context) (int, error) {
+	return 0, nil
+}
 
-	return entities.NewFee(estimatedSize, feePerByte, "BTC")
+// GetLatestBlock returns the latest block height
+func (a *Adapter) GetLatestBlock(ctx context.Context) (uint64, error) {
+	return a.GetBlockNumber(ctx)
 }
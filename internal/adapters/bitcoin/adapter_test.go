@@ -2,12 +2,18 @@ package bitcoin
 
 import (
 	"context"
-	"encoding/hex"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/rpcpool"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -65,7 +71,7 @@ func TestNewAdapter(t *testing.T) {
 	adapter := NewAdapter(mockRPC, "mainnet")
 
 	assert.NotNil(t, adapter)
-	assert.Equal(t, "mainnet", adapter.network)
+	assert.Equal(t, "mainnet", adapter.params.Network)
 	assert.Equal(t, mockRPC, adapter.rpcClient)
 }
 
@@ -85,6 +91,22 @@ func TestGetChainType(t *testing.T) {
 	assert.Equal(t, entities.ChainTypeBitcoin, chainType)
 }
 
+func TestDescribeChain(t *testing.T) {
+	chain, err := NewAdapter(new(MockRPCClient), "mainnet").DescribeChain()
+	require.NoError(t, err)
+	assert.Equal(t, "bitcoin-mainnet", chain.Name())
+	assert.Equal(t, "mainnet", chain.NetworkID())
+	assert.Equal(t, "BTC", chain.NativeToken())
+	assert.False(t, chain.IsTestnet())
+
+	params, err := LookupChainParams(ChainLitecoin, "testnet")
+	require.NoError(t, err)
+	chain, err = NewChainAdapter(new(MockRPCClient), params).DescribeChain()
+	require.NoError(t, err)
+	assert.Equal(t, "LTC", chain.NativeToken())
+	assert.True(t, chain.IsTestnet())
+}
+
 func TestIsConnected(t *testing.T) {
 	t.Run("connected successfully", func(t *testing.T) {
 		mockRPC := new(MockRPCClient)
@@ -285,6 +307,7 @@ func TestCreateTransaction(t *testing.T) {
 		}
 
 		mockRPC.On("ListUnspent", mock.Anything, fromAddr.String()).Return(utxos, nil)
+		mockRPC.On("EstimateFee", mock.Anything, defaultConfirmationTarget).Return(big.NewInt(12), nil).Once()
 
 		params := entities.TransactionParams{
 			ChainID: "bitcoin-mainnet",
@@ -294,217 +317,132 @@ func TestCreateTransaction(t *testing.T) {
 			// No GasPrice specified
 		}
 
+		// The node's estimate is used, raised to the 1 sat/vB minimum relay fee rate
 		tx, err := adapter.CreateTransaction(context.Background(), params)
 		require.NoError(t, err)
-		assert.NotNil(t, tx)
-		mockRPC.AssertExpectations(t)
-	})
-}
-
-func TestSelectCoins(t *testing.T) {
-	adapter := &Adapter{}
-
-	t.Run("sufficient funds", func(t *testing.T) {
-		utxos := []UTXO{
-			{Amount: 100000000}, // 1 BTC
-			{Amount: 50000000},  // 0.5 BTC
-			{Amount: 25000000},  // 0.25 BTC
-		}
+		assert.Equal(t, big.NewInt(12), tx.GasPrice())
 
-		selected, change, err := adapter.selectCoins(utxos, big.NewInt(60000000), big.NewInt(1000))
+		mockRPC.On("EstimateFee", mock.Anything, defaultConfirmationTarget).Return(big.NewInt(0), nil).Once()
+		tx, err = adapter.CreateTransaction(context.Background(), params)
 		require.NoError(t, err)
-		assert.NotEmpty(t, selected)
-		assert.NotNil(t, change)
-		assert.True(t, change.Cmp(big.NewInt(0)) >= 0)
-	})
-
-	t.Run("insufficient funds", func(t *testing.T) {
-		utxos := []UTXO{
-			{Amount: 10000000}, // 0.1 BTC
-		}
+		assert.Equal(t, big.NewInt(1), tx.GasPrice())
 
-		_, _, err := adapter.selectCoins(utxos, big.NewInt(500000000), big.NewInt(1000))
-		require.Error(t, err)
-		assert.Contains(t, err.Error(), "insufficient funds")
-	})
-
-	t.Run("empty UTXOs", func(t *testing.T) {
-		utxos := []UTXO{}
-
-		_, _, err := adapter.selectCoins(utxos, big.NewInt(10000000), big.NewInt(1000))
+		mockRPC.On("EstimateFee", mock.Anything, defaultConfirmationTarget).Return(nil, assert.AnError).Once()
+		_, err = adapter.CreateTransaction(context.Background(), params)
 		require.Error(t, err)
-		assert.Contains(t, err.Error(), "insufficient funds")
-	})
-}
-
-func TestSignTransaction(t *testing.T) {
-	t.Run("success", func(t *testing.T) {
-		mockRPC := new(MockRPCClient)
-		adapter := NewAdapter(mockRPC, "mainnet")
-
-		fromAddr, err := valueobjects.NewAddress("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "bitcoin-mainnet")
-		require.NoError(t, err)
-		toAddr, err := valueobjects.NewAddress("1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", "bitcoin-mainnet")
-		require.NoError(t, err)
-
-		params := entities.TransactionParams{
-			ChainID: "bitcoin-mainnet",
-			From:    fromAddr,
-			To:      toAddr,
-			Value:   big.NewInt(50000000),
-		}
-
-		tx, err := entities.NewTransaction(params)
-		require.NoError(t, err)
-
-		privateKey := []byte("test-private-key")
-		err = adapter.SignTransaction(context.Background(), tx, privateKey)
-		require.NoError(t, err)
-		assert.NotNil(t, tx.Signature())
+		assert.Contains(t, err.Error(), "failed to estimate fee rate, set a gas price")
+		mockRPC.AssertExpectations(t)
 	})
 }
 
-func TestCreateSignature(t *testing.T) {
-	mockRPC := new(MockRPCClient)
-	adapter := NewAdapter(mockRPC, "mainnet")
-
+func TestCreateTransactionOptions(t *testing.T) {
 	fromAddr, err := valueobjects.NewAddress("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "bitcoin-mainnet")
 	require.NoError(t, err)
 	toAddr, err := valueobjects.NewAddress("1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", "bitcoin-mainnet")
 	require.NoError(t, err)
 
-	params := entities.TransactionParams{
-		ChainID: "bitcoin-mainnet",
-		From:    fromAddr,
-		To:      toAddr,
-		Value:   big.NewInt(50000000),
+	utxos := []UTXO{
+		{TxID: strings.Repeat("01", 32), Amount: 30000, Confirmations: 0},
+		{TxID: strings.Repeat("02", 32), Amount: 50000, Confirmations: 3},
+		{TxID: strings.Repeat("03", 32), Amount: 400000, Confirmations: 10},
 	}
-
-	tx, err := entities.NewTransaction(params)
-	require.NoError(t, err)
-
-	signature := adapter.createSignature(tx)
-
-	assert.NotEmpty(t, signature)
-}
-
-func TestBroadcastTransaction(t *testing.T) {
-	t.Run("success", func(t *testing.T) {
+	build := func(t *testing.T, value int64, options map[string]string) (*entities.Transaction, error) {
 		mockRPC := new(MockRPCClient)
-		adapter := NewAdapter(mockRPC, "mainnet")
+		mockRPC.On("ListUnspent", mock.Anything, fromAddr.String()).Return(utxos, nil).Maybe()
+		return NewAdapter(mockRPC, "mainnet").CreateTransaction(context.Background(), entities.TransactionParams{
+			From:     fromAddr,
+			To:       toAddr,
+			Value:    big.NewInt(value),
+			GasPrice: big.NewInt(10),
+			Options:  options,
+		})
+	}
 
-		fromAddr, err := valueobjects.NewAddress("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "bitcoin-mainnet")
-		require.NoError(t, err)
-		toAddr, err := valueobjects.NewAddress("1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", "bitcoin-mainnet")
+	t.Run("largest first", func(t *testing.T) {
+		tx, err := build(t, 20000, map[string]string{OptionCoinSelection: CoinSelectionLargestFirst})
 		require.NoError(t, err)
+		assert.Equal(t, utxos[2:], tx.Metadata()[MetadataUTXOs])
+		assert.Equal(t, "2260", tx.Metadata()[MetadataFee])
+		assert.Equal(t, "377740", tx.Metadata()[MetadataChangeAmount])
+	})
 
-		params := entities.TransactionParams{
-			ChainID: "bitcoin-mainnet",
-			From:    fromAddr,
-			To:      toAddr,
-			Value:   big.NewInt(50000000),
-		}
-
-		tx, err := entities.NewTransaction(params)
+	t.Run("smallest first", func(t *testing.T) {
+		tx, err := build(t, 20000, map[string]string{OptionCoinSelection: CoinSelectionSmallestFirst})
 		require.NoError(t, err)
+		assert.Equal(t, utxos[:1], tx.Metadata()[MetadataUTXOs])
+	})
 
-		// Sign the transaction first
-		privateKey := []byte("test-private-key")
-		err = adapter.SignTransaction(context.Background(), tx, privateKey)
+	t.Run("min confirmations", func(t *testing.T) {
+		tx, err := build(t, 20000, map[string]string{
+			OptionCoinSelection:    CoinSelectionSmallestFirst,
+			OptionMinConfirmations: "1",
+		})
 		require.NoError(t, err)
+		assert.Equal(t, utxos[1:2], tx.Metadata()[MetadataUTXOs])
 
-		expectedHash := "abc123def456"
-		mockRPC.On("SendRawTransaction", mock.Anything, mock.AnythingOfType("string")).Return(expectedHash, nil)
+		_, err = build(t, 20000, map[string]string{OptionMinConfirmations: "20"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "insufficient funds")
+	})
 
-		hash, err := adapter.BroadcastTransaction(context.Background(), tx)
-		require.NoError(t, err)
-		assert.NotNil(t, hash)
-		assert.Contains(t, hash.String(), expectedHash)
-		mockRPC.AssertExpectations(t)
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := build(t, 20000, map[string]string{OptionCoinSelection: "random"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown coin selection strategy")
+
+		_, err = build(t, 20000, map[string]string{OptionMinConfirmations: "-1"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid min_confirmations option")
 	})
 
-	t.Run("unsigned transaction", func(t *testing.T) {
+	t.Run("invalid addresses", func(t *testing.T) {
 		mockRPC := new(MockRPCClient)
 		adapter := NewAdapter(mockRPC, "mainnet")
-
-		fromAddr, err := valueobjects.NewAddress("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "bitcoin-mainnet")
+		testnetAddr, err := valueobjects.NewAddress("tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7", "bitcoin-mainnet")
 		require.NoError(t, err)
-		toAddr, err := valueobjects.NewAddress("1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", "bitcoin-mainnet")
+		typoAddr, err := valueobjects.NewAddress("1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN3", "bitcoin-mainnet")
 		require.NoError(t, err)
 
-		params := entities.TransactionParams{
-			ChainID: "bitcoin-mainnet",
-			From:    fromAddr,
-			To:      toAddr,
-			Value:   big.NewInt(50000000),
-		}
+		_, err = adapter.CreateTransaction(context.Background(), entities.TransactionParams{From: fromAddr, To: testnetAddr, Value: big.NewInt(20000)})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid to address")
+		assert.Contains(t, err.Error(), "is not a bitcoin-mainnet address")
 
-		tx, err := entities.NewTransaction(params)
-		require.NoError(t, err)
+		_, err = adapter.CreateTransaction(context.Background(), entities.TransactionParams{From: typoAddr, To: toAddr, Value: big.NewInt(20000)})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid from address")
 
-		_, err = adapter.BroadcastTransaction(context.Background(), tx)
+		_, err = adapter.GetNativeBalance(context.Background(), typoAddr)
 		require.Error(t, err)
-		assert.Contains(t, err.Error(), "transaction not signed")
+		mockRPC.AssertNotCalled(t, "ListUnspent", mock.Anything, mock.Anything)
+		mockRPC.AssertNotCalled(t, "GetBalance", mock.Anything, mock.Anything)
 	})
 
-	t.Run("broadcast error", func(t *testing.T) {
-		mockRPC := new(MockRPCClient)
-		adapter := NewAdapter(mockRPC, "mainnet")
-
-		fromAddr, err := valueobjects.NewAddress("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "bitcoin-mainnet")
+	t.Run("replaceable", func(t *testing.T) {
+		tx, err := build(t, 20000, nil)
 		require.NoError(t, err)
-		toAddr, err := valueobjects.NewAddress("1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", "bitcoin-mainnet")
+		assert.Equal(t, true, tx.Metadata()[MetadataReplaceable])
+		msg, _, err := unsignedTx(tx, &mainnetParams)
 		require.NoError(t, err)
+		assert.Equal(t, uint32(sequenceRBF), msg.inputs[0].sequence)
 
-		params := entities.TransactionParams{
-			ChainID: "bitcoin-mainnet",
-			From:    fromAddr,
-			To:      toAddr,
-			Value:   big.NewInt(50000000),
-		}
-
-		tx, err := entities.NewTransaction(params)
+		tx, err = build(t, 20000, map[string]string{OptionReplaceable: "false"})
 		require.NoError(t, err)
-
-		// Sign the transaction
-		privateKey := []byte("test-private-key")
-		err = adapter.SignTransaction(context.Background(), tx, privateKey)
+		assert.Equal(t, false, tx.Metadata()[MetadataReplaceable])
+		msg, _, err = unsignedTx(tx, &mainnetParams)
 		require.NoError(t, err)
+		assert.Equal(t, uint32(sequenceFinal), msg.inputs[0].sequence)
 
-		mockRPC.On("SendRawTransaction", mock.Anything, mock.AnythingOfType("string")).Return("", assert.AnError)
-
-		_, err = adapter.BroadcastTransaction(context.Background(), tx)
+		_, err = build(t, 20000, map[string]string{OptionReplaceable: "maybe"})
 		require.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to broadcast transaction")
-		mockRPC.AssertExpectations(t)
+		assert.Contains(t, err.Error(), "invalid replaceable option")
 	})
-}
-
-func TestBuildRawTransaction(t *testing.T) {
-	mockRPC := new(MockRPCClient)
-	adapter := NewAdapter(mockRPC, "mainnet")
-
-	fromAddr, err := valueobjects.NewAddress("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "bitcoin-mainnet")
-	require.NoError(t, err)
-	toAddr, err := valueobjects.NewAddress("1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", "bitcoin-mainnet")
-	require.NoError(t, err)
-
-	params := entities.TransactionParams{
-		ChainID: "bitcoin-mainnet",
-		From:    fromAddr,
-		To:      toAddr,
-		Value:   big.NewInt(50000000),
-	}
-
-	tx, err := entities.NewTransaction(params)
-	require.NoError(t, err)
-
-	rawTx := adapter.buildRawTransaction(tx)
-	assert.NotEmpty(t, rawTx)
 
-	// Verify it's a valid hex string
-	_, err = hex.DecodeString(rawTx)
-	assert.NoError(t, err)
+	t.Run("dust value", func(t *testing.T) {
+		_, err := build(t, 545, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "below the dust threshold of 546")
+	})
 }
 
 func TestGetTransactionStatus(t *testing.T) {
@@ -520,7 +458,7 @@ func TestGetTransactionStatus(t *testing.T) {
 			Confirmations: 6,
 		}
 
-		mockRPC.On("GetRawTransaction", mock.Anything, hash.String()).Return(btcTx, nil)
+		mockRPC.On("GetRawTransaction", mock.Anything, hash.HexWithoutPrefix()).Return(btcTx, nil)
 
 		status, err := adapter.GetTransactionStatus(context.Background(), hash)
 		require.NoError(t, err)
@@ -540,7 +478,7 @@ func TestGetTransactionStatus(t *testing.T) {
 			Confirmations: 0,
 		}
 
-		mockRPC.On("GetRawTransaction", mock.Anything, hash.String()).Return(btcTx, nil)
+		mockRPC.On("GetRawTransaction", mock.Anything, hash.HexWithoutPrefix()).Return(btcTx, nil)
 
 		status, err := adapter.GetTransactionStatus(context.Background(), hash)
 		require.NoError(t, err)
@@ -555,7 +493,7 @@ func TestGetTransactionStatus(t *testing.T) {
 		hash, err := valueobjects.NewHash("abc123def456")
 		require.NoError(t, err)
 
-		mockRPC.On("GetRawTransaction", mock.Anything, hash.String()).Return((*Transaction)(nil), assert.AnError)
+		mockRPC.On("GetRawTransaction", mock.Anything, hash.HexWithoutPrefix()).Return((*Transaction)(nil), assert.AnError)
 
 		status, err := adapter.GetTransactionStatus(context.Background(), hash)
 		require.Error(t, err)
@@ -624,3 +562,355 @@ func TestEstimateFee(t *testing.T) {
 		mockRPC.AssertExpectations(t)
 	})
 }
+
+var (
+	_ ports.ChainAdapter = (*Adapter)(nil)
+	_ ports.PSBTProvider = (*Adapter)(nil)
+)
+
+func testAddresses(t *testing.T) (*valueobjects.Address, *valueobjects.Address) {
+	t.Helper()
+	fromAddr, err := valueobjects.NewAddress("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "bitcoin-mainnet")
+	require.NoError(t, err)
+	toAddr, err := valueobjects.NewAddress("1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", "bitcoin-mainnet")
+	require.NoError(t, err)
+	return fromAddr, toAddr
+}
+
+func TestNewAdapterWithConfig(t *testing.T) {
+	mockRPC := new(MockRPCClient)
+
+	adapter, err := NewAdapterWithConfig(mockRPC, NetworkConfig{Name: "mainnet", RPCURL: "https://blockstream.info/api"})
+	require.NoError(t, err)
+	assert.Equal(t, "bitcoin-mainnet", adapter.GetChainID())
+
+	network, err := adapter.GetNetworkInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "bitcoin-mainnet", network.ChainID())
+	assert.Equal(t, "mainnet", network.Name())
+	assert.Equal(t, "https://blockstream.info/api", network.RPCURL())
+
+	_, err = NewAdapterWithConfig(mockRPC, NetworkConfig{RPCURL: "https://blockstream.info/api"})
+	require.Error(t, err)
+	_, err = NewAdapterWithConfig(mockRPC, NetworkConfig{Name: "mainnet"})
+	require.Error(t, err)
+
+	adapter, err = NewAdapterWithConfig(mockRPC, NetworkConfig{
+		Name:             "mainnet",
+		RPCURL:           "https://blockstream.info/api",
+		CoinSelection:    CoinSelectionLargestFirst,
+		MinConfirmations: 6,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, LargestFirstSelector{}, adapter.selector)
+	assert.Equal(t, int64(6), adapter.minConfirmations)
+
+	_, err = NewAdapterWithConfig(mockRPC, NetworkConfig{Name: "mainnet", RPCURL: "https://blockstream.info/api", CoinSelection: "random"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid coin_selection")
+	_, err = NewAdapterWithConfig(mockRPC, NetworkConfig{Name: "mainnet", RPCURL: "https://blockstream.info/api", MinConfirmations: -1})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "min_confirmations")
+	_, err = NewAdapterWithConfig(mockRPC, NetworkConfig{Name: "signet", RPCURL: "https://blockstream.info/api"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown bitcoin network")
+}
+
+func TestNewAdapterFromConfig(t *testing.T) {
+	rpcClient, err := NewRPCClient(NetworkConfig{Name: "mainnet", RPCURL: "https://blockstream.info/api"})
+	require.NoError(t, err)
+	assert.IsType(t, &EsploraClient{}, rpcClient)
+
+	rpcClient, err = NewRPCClient(NetworkConfig{
+		Name:        "regtest",
+		RPCURL:      "http://127.0.0.1:18443",
+		Backend:     BackendBitcoind,
+		RPCUser:     "rpcuser",
+		RPCPassword: "rpcpassword",
+		RPCWallet:   "watch",
+	})
+	require.NoError(t, err)
+	require.IsType(t, &BitcoindClient{}, rpcClient)
+	assert.Equal(t, "watch", rpcClient.(*BitcoindClient).wallet)
+
+	adapter, err := NewAdapterFromConfig(NetworkConfig{Name: "testnet", RPCURL: "https://blockstream.info/testnet/api", Backend: BackendEsplora})
+	require.NoError(t, err)
+	assert.Equal(t, "bitcoin-testnet", adapter.GetChainID())
+	assert.IsType(t, &EsploraClient{}, adapter.rpcClient)
+
+	_, err = NewAdapterFromConfig(NetworkConfig{Name: "mainnet", RPCURL: "https://blockstream.info/api", Backend: "electrum"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown backend "electrum"`)
+
+	_, err = NewAdapterFromConfig(NetworkConfig{Name: "mainnet", RPCURL: "https://blockstream.info/api", Endpoints: []rpcpool.Endpoint{{URL: "https://mempool.space/api", Weight: -1}}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid endpoints for network mainnet")
+}
+
+func TestNewAdapterFromConfig_Endpoints(t *testing.T) {
+	var staleHits atomic.Int64
+	stale := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		staleHits.Add(1)
+		_, _ = w.Write([]byte("800000"))
+	}))
+	t.Cleanup(stale.Close)
+	synced := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/mempool/api/blocks/tip/height", r.URL.Path)
+		_, _ = w.Write([]byte("800010"))
+	}))
+	t.Cleanup(synced.Close)
+
+	adapter, err := NewAdapterFromConfig(NetworkConfig{
+		Name:      "mainnet",
+		RPCURL:    stale.URL + "/api",
+		Endpoints: []rpcpool.Endpoint{{URL: synced.URL + "/mempool/api"}},
+		Pool:      rpcpool.Options{MaxBlockLag: 3},
+	})
+	require.NoError(t, err)
+	adapter.pool.CheckHealth(context.Background())
+	staleHits.Store(0)
+
+	// Requests skip the endpoint trailing the chain tip
+	for range 3 {
+		height, err := adapter.GetBlockNumber(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, uint64(800010), height)
+	}
+	assert.Zero(t, staleHits.Load())
+
+	network, err := adapter.GetNetworkInfo(context.Background())
+	require.NoError(t, err)
+	require.Len(t, network.Endpoints(), 2)
+	assert.True(t, network.Endpoints()[0].Stale)
+	assert.Equal(t, uint64(800000), network.Endpoints()[0].BlockHeight)
+	assert.True(t, network.Endpoints()[1].Healthy)
+}
+
+func TestBuildTransaction(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		adapter := NewAdapter(mockRPC, "mainnet")
+		fromAddr, toAddr := testAddresses(t)
+
+		utxos := []UTXO{{TxID: strings.Repeat("ab", 32), Vout: 0, Amount: 200000000, Confirmations: 10}}
+		mockRPC.On("ListUnspent", mock.Anything, fromAddr.String()).Return(utxos, nil)
+		mockRPC.On("EstimateFee", mock.Anything, defaultConfirmationTarget).Return(big.NewInt(10), nil)
+
+		tx, err := adapter.BuildTransaction(context.Background(), entities.TransactionParams{
+			From:  fromAddr,
+			To:    toAddr,
+			Value: big.NewInt(50000000),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "bitcoin-mainnet", tx.ChainID())
+		assert.Equal(t, uint64(0), tx.Nonce().Value())
+		assert.Equal(t, utxos, tx.Metadata()["utxos"])
+
+		size, err := adapter.EstimateGas(context.Background(), tx)
+		require.NoError(t, err)
+		// One P2PKH input paying a P2PKH recipient and P2PKH change
+		assert.Equal(t, uint64(10+148+2*34), size)
+		mockRPC.AssertExpectations(t)
+	})
+
+	t.Run("coin selection error", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		adapter := NewAdapter(mockRPC, "mainnet")
+		fromAddr, toAddr := testAddresses(t)
+
+		mockRPC.On("ListUnspent", mock.Anything, fromAddr.String()).Return([]UTXO{}, nil)
+		mockRPC.On("EstimateFee", mock.Anything, defaultConfirmationTarget).Return(big.NewInt(10), nil)
+
+		_, err := adapter.BuildTransaction(context.Background(), entities.TransactionParams{
+			From:  fromAddr,
+			To:    toAddr,
+			Value: big.NewInt(50000000),
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "insufficient funds")
+	})
+}
+
+func TestEstimateGasWithoutInputs(t *testing.T) {
+	adapter := NewAdapter(new(MockRPCClient), "mainnet")
+	fromAddr, toAddr := testAddresses(t)
+
+	tx, err := entities.NewTransaction(entities.TransactionParams{ChainID: "bitcoin-mainnet", From: fromAddr, To: toAddr})
+	require.NoError(t, err)
+
+	size, err := adapter.EstimateGas(context.Background(), tx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(250), size)
+
+	require.NoError(t, tx.SetNonce(valueobjects.NewNonce(7)))
+	require.NoError(t, adapter.SetNonce(context.Background(), tx))
+	assert.Equal(t, uint64(7), tx.Nonce().Value())
+}
+
+func TestBalanceProvider(t *testing.T) {
+	mockRPC := new(MockRPCClient)
+	adapter := NewAdapter(mockRPC, "mainnet")
+	fromAddr, toAddr := testAddresses(t)
+
+	mockRPC.On("GetBalance", mock.Anything, fromAddr.String()).Return(big.NewInt(150000), nil)
+
+	balance, err := adapter.GetBalance(context.Background(), "bitcoin-mainnet", fromAddr)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(150000), balance)
+
+	_, err = adapter.GetTokenBalance(context.Background(), "bitcoin-mainnet", fromAddr, toAddr)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not supported")
+	mockRPC.AssertExpectations(t)
+}
+
+func TestGetTransactionReceipt(t *testing.T) {
+	hash, err := valueobjects.NewHash("abc123def456")
+	require.NoError(t, err)
+	fromAddr, toAddr := testAddresses(t)
+
+	prevTx := &Transaction{
+		TxID:    "prev",
+		Outputs: []TxOutput{{Value: 200000000, N: 0, Address: fromAddr.String()}},
+	}
+	btcTx := &Transaction{
+		TxID:          "abc123def456",
+		Inputs:        []TxInput{{TxID: "prev", Vout: 0}},
+		Outputs:       []TxOutput{{Value: 50000000, N: 0, Address: toAddr.String()}, {Value: 149990000, N: 1, Address: fromAddr.String()}},
+		BlockHash:     "000000000000000000024bead8df69990852c202db0e0097c1a12ea637d7e96d",
+		Confirmations: 3,
+	}
+
+	t.Run("confirmed", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		adapter := NewAdapter(mockRPC, "mainnet")
+		mockRPC.On("GetRawTransaction", mock.Anything, hash.HexWithoutPrefix()).Return(btcTx, nil)
+		mockRPC.On("GetRawTransaction", mock.Anything, "prev").Return(prevTx, nil)
+		mockRPC.On("GetBlockCount", mock.Anything).Return(int64(800002), nil)
+
+		tx, err := adapter.GetTransactionReceipt(context.Background(), hash)
+		require.NoError(t, err)
+		assert.Equal(t, fromAddr.String(), tx.From().String())
+		assert.Equal(t, toAddr.String(), tx.To().String())
+		assert.Equal(t, big.NewInt(50000000), tx.Value())
+		assert.Equal(t, entities.TxStatusConfirmed, tx.Status())
+		assert.Equal(t, uint64(3), tx.Confirmations())
+		assert.Equal(t, uint64(800000), tx.BlockNumber())
+		assert.Equal(t, hash, tx.Hash())
+		assert.Equal(t, btcTx.BlockHash, tx.Metadata()["block_hash"])
+		mockRPC.AssertExpectations(t)
+	})
+
+	t.Run("pending", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		adapter := NewAdapter(mockRPC, "mainnet")
+		pending := *btcTx
+		pending.Confirmations = 0
+		mockRPC.On("GetRawTransaction", mock.Anything, hash.HexWithoutPrefix()).Return(&pending, nil)
+		mockRPC.On("GetRawTransaction", mock.Anything, "prev").Return(prevTx, nil)
+
+		tx, err := adapter.GetTransactionReceipt(context.Background(), hash)
+		require.NoError(t, err)
+		assert.Equal(t, entities.TxStatusPending, tx.Status())
+		assert.Equal(t, uint64(0), tx.BlockNumber())
+		mockRPC.AssertExpectations(t)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		adapter := NewAdapter(mockRPC, "mainnet")
+		mockRPC.On("GetRawTransaction", mock.Anything, hash.HexWithoutPrefix()).Return((*Transaction)(nil), assert.AnError).Once()
+		_, err := adapter.GetTransactionReceipt(context.Background(), hash)
+		require.Error(t, err)
+
+		mockRPC.On("GetRawTransaction", mock.Anything, hash.HexWithoutPrefix()).Return(&Transaction{TxID: "abc"}, nil).Once()
+		_, err = adapter.GetTransactionReceipt(context.Background(), hash)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no inputs or outputs")
+
+		mockRPC.On("GetRawTransaction", mock.Anything, hash.HexWithoutPrefix()).Return(btcTx, nil)
+		mockRPC.On("GetRawTransaction", mock.Anything, "prev").Return(&Transaction{TxID: "prev"}, nil).Once()
+		_, err = adapter.GetTransactionReceipt(context.Background(), hash)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "previous output")
+
+		mockRPC.On("GetRawTransaction", mock.Anything, "prev").Return((*Transaction)(nil), assert.AnError).Once()
+		_, err = adapter.GetTransactionReceipt(context.Background(), hash)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get previous transaction")
+
+		mockRPC.On("GetRawTransaction", mock.Anything, "prev").Return(prevTx, nil)
+		mockRPC.On("GetBlockCount", mock.Anything).Return(int64(0), assert.AnError)
+		_, err = adapter.GetTransactionReceipt(context.Background(), hash)
+		require.Error(t, err)
+	})
+}
+
+func TestWaitForConfirmation(t *testing.T) {
+	hash, err := valueobjects.NewHash("abc123def456")
+	require.NoError(t, err)
+
+	t.Run("confirmed after polling", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		adapter := NewAdapter(mockRPC, "mainnet")
+		adapter.pollInterval = time.Millisecond
+
+		mockRPC.On("GetRawTransaction", mock.Anything, hash.HexWithoutPrefix()).Return(&Transaction{Confirmations: 0}, nil).Once()
+		mockRPC.On("GetRawTransaction", mock.Anything, hash.HexWithoutPrefix()).Return(&Transaction{Confirmations: 2}, nil)
+
+		require.NoError(t, adapter.WaitForConfirmation(context.Background(), hash, 2))
+		mockRPC.AssertExpectations(t)
+	})
+
+	t.Run("context timeout", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		adapter := NewAdapter(mockRPC, "mainnet")
+		adapter.pollInterval = time.Millisecond
+
+		mockRPC.On("GetRawTransaction", mock.Anything, hash.HexWithoutPrefix()).Return(&Transaction{Confirmations: 1}, nil)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, adapter.WaitForConfirmation(ctx, hash, 6), context.DeadlineExceeded)
+	})
+
+	t.Run("rpc error", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		adapter := NewAdapter(mockRPC, "mainnet")
+
+		mockRPC.On("GetRawTransaction", mock.Anything, hash.HexWithoutPrefix()).Return((*Transaction)(nil), assert.AnError)
+		require.Error(t, adapter.WaitForConfirmation(context.Background(), hash, 0))
+	})
+}
+
+func TestFeeAndNetworkInfo(t *testing.T) {
+	mockRPC := new(MockRPCClient)
+	adapter := NewAdapter(mockRPC, "testnet")
+
+	mockRPC.On("EstimateFee", mock.Anything, 6).Return(big.NewInt(12), nil).Once()
+	feeRate, err := adapter.GetGasPrice(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(12), feeRate)
+
+	mockRPC.On("EstimateFee", mock.Anything, 6).Return((*big.Int)(nil), assert.AnError).Once()
+	_, err = adapter.GetGasPrice(context.Background())
+	require.Error(t, err)
+
+	tip, err := adapter.GetMaxPriorityFee(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), tip.Int64())
+
+	peers, err := adapter.GetPeers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, peers)
+
+	mockRPC.On("GetBlockCount", mock.Anything).Return(int64(2500000), nil)
+	latest, err := adapter.GetLatestBlock(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2500000), latest)
+
+	// Adapters created without a config have no RPC URL to report
+	_, err = adapter.GetNetworkInfo(context.Background())
+	require.Error(t, err)
+	mockRPC.AssertExpectations(t)
+}
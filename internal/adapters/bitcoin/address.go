@@ -0,0 +1,215 @@
+package bitcoin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/base58"
+)
+
+// AddressType identifies the output template an address pays to
+type AddressType string
+
+// Address types recognized by ParseAddress
+const (
+	AddressTypeP2PKH          AddressType = "p2pkh"
+	AddressTypeP2SH           AddressType = "p2sh"
+	AddressTypeP2WPKH         AddressType = "p2wpkh"
+	AddressTypeP2WSH          AddressType = "p2wsh"
+	AddressTypeP2TR           AddressType = "p2tr"
+	AddressTypeWitnessUnknown AddressType = "witness_unknown"
+)
+
+// Address is a decoded address of a Bitcoin-derived chain
+type Address struct {
+	Type    AddressType
+	Chain   string
+	Network string
+	// WitnessVersion is the segwit version of witness addresses
+	WitnessVersion byte
+	// Program is the public key hash, script hash or witness program the address commits to
+	Program []byte
+}
+
+// ParseAddress decodes a base58check (P2PKH, P2SH) or bech32/bech32m (segwit) Bitcoin address,
+// rejecting addresses of other networks
+func ParseAddress(address, network string) (*Address, error) {
+	return parseAddress(address, ChainBitcoin, network)
+}
+
+// ParseChainAddress decodes an address of a network of any supported UTXO chain
+func ParseChainAddress(address, chain, network string) (*Address, error) {
+	return parseAddress(address, chain, network)
+}
+
+// parseAddress decodes an address of a network of a UTXO chain
+func parseAddress(address, chain, network string) (*Address, error) {
+	params, err := LookupChainParams(chain, network)
+	if err != nil {
+		return nil, err
+	}
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return nil, fmt.Errorf("address cannot be empty")
+	}
+
+	// Base58 addresses of these networks never start with an HRP and its separator
+	if lower := strings.ToLower(address); isSegWitPrefix(lower) {
+		if params.Bech32HRP == "" || !strings.HasPrefix(lower, params.Bech32HRP+"1") {
+			return nil, fmt.Errorf("address %q is not a %s address", address, params.ChainID())
+		}
+		version, program, err := decodeSegWitAddress(address, params.Bech32HRP)
+		if err != nil {
+			return nil, err
+		}
+		return &Address{
+			Type:           segWitAddressType(version, program),
+			Chain:          chain,
+			Network:        network,
+			WitnessVersion: version,
+			Program:        program,
+		}, nil
+	}
+
+	payload, err := base58.CheckDecode(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %q: %w", address, err)
+	}
+	if len(payload) != 1+hash160Length {
+		return nil, fmt.Errorf("invalid address %q: unexpected length %d", address, len(payload))
+	}
+	var addressType AddressType
+	switch payload[0] {
+	case params.PubKeyHashVersion:
+		addressType = AddressTypeP2PKH
+	case params.ScriptHashVersion:
+		addressType = AddressTypeP2SH
+	default:
+		if _, known := base58Network(payload[0]); known {
+			return nil, fmt.Errorf("address %q is not a %s address", address, params.ChainID())
+		}
+		return nil, fmt.Errorf("invalid address %q: unknown version byte 0x%02x", address, payload[0])
+	}
+	return &Address{Type: addressType, Chain: chain, Network: network, Program: payload[1:]}, nil
+}
+
+// ScriptPubKey returns the output script paying to the address
+func (a *Address) ScriptPubKey() []byte {
+	switch a.Type {
+	case AddressTypeP2PKH:
+		return payToPubKeyHashScript(a.Program)
+	case AddressTypeP2SH:
+		return payToScriptHashScript(a.Program)
+	default:
+		return payToWitnessScript(a.WitnessVersion, a.Program)
+	}
+}
+
+// String returns the canonical encoding of the address, lowercase for segwit addresses
+func (a *Address) String() string {
+	params := chainParams[chainID(a.Chain, a.Network)]
+	switch a.Type {
+	case AddressTypeP2PKH:
+		return base58.CheckEncode(append([]byte{params.PubKeyHashVersion}, a.Program...))
+	case AddressTypeP2SH:
+		return base58.CheckEncode(append([]byte{params.ScriptHashVersion}, a.Program...))
+	default:
+		return encodeSegWitAddress(params.Bech32HRP, a.WitnessVersion, a.Program)
+	}
+}
+
+// scriptAddress returns the address of a network paying to a standard output script
+func scriptAddress(script []byte, params *ChainParams) (*Address, error) {
+	switch {
+	case isPayToPubKeyHash(script):
+		return &Address{Type: AddressTypeP2PKH, Chain: params.Chain, Network: params.Network, Program: script[3:23]}, nil
+	case isPayToScriptHash(script):
+		return &Address{Type: AddressTypeP2SH, Chain: params.Chain, Network: params.Network, Program: script[2:22]}, nil
+	case isWitnessProgram(script) && params.Bech32HRP == "":
+		return nil, fmt.Errorf("script %x has no address on %s", script, params.ChainID())
+	case isWitnessProgram(script):
+		version := script[0]
+		if version != opFalse {
+			version -= 0x50
+		}
+		program := script[2:]
+		if version == 0 && len(program) != hash160Length && len(program) != sha256Length {
+			return nil, fmt.Errorf("invalid version 0 witness program length %d", len(program))
+		}
+		return &Address{
+			Type:           segWitAddressType(version, program),
+			Chain:          params.Chain,
+			Network:        params.Network,
+			WitnessVersion: version,
+			Program:        program,
+		}, nil
+	default:
+		return nil, fmt.Errorf("script %x has no address", script)
+	}
+}
+
+// segWitAddressType classifies a witness program
+func segWitAddressType(version byte, program []byte) AddressType {
+	switch {
+	case version == 0 && len(program) == hash160Length:
+		return AddressTypeP2WPKH
+	case version == 0:
+		return AddressTypeP2WSH
+	case version == 1 && len(program) == sha256Length:
+		return AddressTypeP2TR
+	default:
+		return AddressTypeWitnessUnknown
+	}
+}
+
+// isSegWitPrefix reports whether a lowercase address starts with the HRP of a known network
+func isSegWitPrefix(lower string) bool {
+	_, ok := segWitNetwork(lower)
+	return ok
+}
+
+// segWitNetwork returns the network whose HRP a lowercase address starts with, preferring the
+// longest HRP so that bcrt1 addresses are not taken for bc1 ones
+func segWitNetwork(lower string) (*ChainParams, bool) {
+	var found *ChainParams
+	for i := range knownChainParams {
+		params := &knownChainParams[i]
+		if params.Bech32HRP == "" || !strings.HasPrefix(lower, params.Bech32HRP+"1") {
+			continue
+		}
+		if found == nil || len(params.Bech32HRP) > len(found.Bech32HRP) {
+			found = params
+		}
+	}
+	return found, found != nil
+}
+
+// base58Network returns the first known network using a base58 version byte
+func base58Network(version byte) (*ChainParams, bool) {
+	for i := range knownChainParams {
+		params := &knownChainParams[i]
+		if version == params.PubKeyHashVersion || version == params.ScriptHashVersion {
+			return params, true
+		}
+	}
+	return nil, false
+}
+
+// decodeAddress parses an address of any known network. Base58 version bytes are shared between
+// chains, but always for the same address type, so the script the address pays to is unambiguous
+func decodeAddress(address string) (*Address, error) {
+	params, ok := segWitNetwork(strings.ToLower(strings.TrimSpace(address)))
+	if !ok {
+		payload, err := base58.CheckDecode(strings.TrimSpace(address))
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %q: %w", address, err)
+		}
+		if len(payload) == 0 {
+			return nil, fmt.Errorf("invalid address %q: empty payload", address)
+		}
+		if params, ok = base58Network(payload[0]); !ok {
+			return nil, fmt.Errorf("invalid address %q: unknown version byte 0x%02x", address, payload[0])
+		}
+	}
+	return parseAddress(address, params.Chain, params.Network)
+}
@@ -0,0 +1,148 @@
+package bitcoin
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		address        string
+		network        string
+		addressType    AddressType
+		witnessVersion byte
+		scriptPubKey   string
+	}{
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", "mainnet", AddressTypeP2PKH, 0, "76a91477bff20c60e522dfaa3350c39b030a5d004e839a88ac"},
+		{"3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", "mainnet", AddressTypeP2SH, 0, "a914b472a266d0bd89c13706a4132ccfb16f7c3b9fcb87"},
+		// BIP-173 and BIP-350 test vectors
+		{"BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", "mainnet", AddressTypeP2WPKH, 0, "0014751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7", "testnet", AddressTypeP2WSH, 0, "00201863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262"},
+		{"tb1qqqqqp399et2xygdj5xreqhjjvcmzhxw4aywxecjdzew6hylgvsesrxh6hy", "testnet", AddressTypeP2WSH, 0, "0020000000c4a5cad46221b2a187905e5266362b99d5e91c6ce24d165dab93e86433"},
+		{"tb1pqqqqp399et2xygdj5xreqhjjvcmzhxw4aywxecjdzew6hylgvsesf3hn0c", "testnet", AddressTypeP2TR, 1, "5120000000c4a5cad46221b2a187905e5266362b99d5e91c6ce24d165dab93e86433"},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", "mainnet", AddressTypeP2TR, 1, "512079be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"},
+		{"bc1pw508d6qejxtdg4y5r3zarvary0c5xw7kw508d6qejxtdg4y5r3zarvary0c5xw7kt5nd6y", "mainnet", AddressTypeWitnessUnknown, 1, "5128751e76e8199196d454941c45d1b3a323f1433bd6751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"BC1SW50QGDZ25J", "mainnet", AddressTypeWitnessUnknown, 16, "6002751e"},
+		{"bc1zw508d6qejxtdg4y5r3zarvaryvaxxpcs", "mainnet", AddressTypeWitnessUnknown, 2, "5210751e76e8199196d454941c45d1b3a323"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			address, err := ParseAddress(tt.address, tt.network)
+			require.NoError(t, err)
+			assert.Equal(t, tt.addressType, address.Type)
+			assert.Equal(t, tt.network, address.Network)
+			assert.Equal(t, tt.witnessVersion, address.WitnessVersion)
+			assert.Equal(t, tt.scriptPubKey, hex.EncodeToString(address.ScriptPubKey()))
+
+			// String is canonical: round trips and lowercases bech32
+			reparsed, err := ParseAddress(address.String(), tt.network)
+			require.NoError(t, err)
+			assert.Equal(t, address, reparsed)
+			if address.Type != AddressTypeP2PKH && address.Type != AddressTypeP2SH {
+				assert.Equal(t, address.String(), strings.ToLower(tt.address))
+			}
+		})
+	}
+}
+
+func TestParseAddressInvalid(t *testing.T) {
+	for _, invalid := range []string{
+		"",
+		"   ",
+		"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN3",
+		"0x742d35cc6634c0532925a3b844bc9e7595f0beb0",
+		"7SeEnXWPaCCALbVrTnszCVGfRU8cGfx",
+		"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t5",
+		"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kV8f3t4",
+		"bc1gmk9yu",
+		// BIP-350 invalid vectors
+		"tc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq5zuyut",
+		"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqh2y7hd",
+		"BC1S0XLXVLHEMJA6C4DQV22UAPCTQUPFHLXM9H8Z3K2E72Q4K9HCZ7VQ54WELL",
+		"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kemeawh",
+		"bc1p38j9r5y49hruaue7wxjce0updqjuyyx0kh56v8s25huc6995vvpql3jow4",
+		"BC130XLXVLHEMJA6C4DQV22UAPCTQUPFHLXM9H8Z3K2E72Q4K9HCZ7VQ7ZWS8R",
+		"bc1pw5dgrnzv",
+		"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7v8n0nx0muaewav253zgeav",
+		"BC1QR508D6QEJXTDG4Y5R3ZARVARYV98GJ9P",
+		"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7v07qwwzcrf",
+	} {
+		_, err := ParseAddress(invalid, "mainnet")
+		assert.Error(t, err, invalid)
+	}
+
+	for _, invalid := range []string{
+		"tb1z0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqglt7rf",
+		"tb1q0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq24jc47",
+		"tb1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq47Zagq",
+		"tb1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vpggkg4j",
+	} {
+		_, err := ParseAddress(invalid, "testnet")
+		assert.Error(t, err, invalid)
+	}
+
+	_, err := ParseAddress("1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", "signet")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown bitcoin network")
+}
+
+func TestParseAddressChecksumVariant(t *testing.T) {
+	program := make([]byte, 32)
+	data, err := convertBits(program, 8, 5, true)
+	require.NoError(t, err)
+
+	// Version 0 must use bech32 and later versions bech32m
+	_, err = ParseAddress(bech32Encode("bc", append([]byte{0}, data...), bech32mConst), "mainnet")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "wrong checksum variant")
+	_, err = ParseAddress(bech32Encode("bc", append([]byte{1}, data...), bech32Const), "mainnet")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "wrong checksum variant")
+
+	address, err := ParseAddress(bech32Encode("bc", append([]byte{1}, data...), bech32mConst), "mainnet")
+	require.NoError(t, err)
+	assert.Equal(t, AddressTypeP2TR, address.Type)
+}
+
+func TestParseAddressNetwork(t *testing.T) {
+	hash := make([]byte, 20)
+	addresses := map[string]map[AddressType]string{}
+	for _, network := range []string{"mainnet", "testnet", "regtest"} {
+		addresses[network] = map[AddressType]string{}
+		for _, addressType := range []AddressType{AddressTypeP2PKH, AddressTypeP2SH, AddressTypeP2WPKH} {
+			addresses[network][addressType] = (&Address{Type: addressType, Chain: ChainBitcoin, Network: network, Program: hash}).String()
+		}
+	}
+	assert.Regexp(t, "^bc1q", addresses["mainnet"][AddressTypeP2WPKH])
+	assert.Regexp(t, "^bcrt1q", addresses["regtest"][AddressTypeP2WPKH])
+	assert.Regexp(t, "^tb1q", addresses["testnet"][AddressTypeP2WPKH])
+	assert.Regexp(t, "^[mn]", addresses["testnet"][AddressTypeP2PKH])
+	assert.Regexp(t, "^2", addresses["testnet"][AddressTypeP2SH])
+
+	for network, byType := range addresses {
+		for addressType, encoded := range byType {
+			decoded, err := ParseAddress(encoded, network)
+			require.NoError(t, err, encoded)
+			assert.Equal(t, addressType, decoded.Type)
+
+			_, err = ParseAddress(encoded, otherNetwork(network))
+			require.Error(t, err, encoded)
+			assert.Contains(t, err.Error(), "is not a bitcoin-"+otherNetwork(network)+" address")
+		}
+	}
+
+	// Base58 testnet addresses are shared with regtest
+	_, err := ParseAddress(addresses["testnet"][AddressTypeP2PKH], "regtest")
+	require.NoError(t, err)
+}
+
+func otherNetwork(network string) string {
+	if network == "mainnet" {
+		return "testnet"
+	}
+	return "mainnet"
+}
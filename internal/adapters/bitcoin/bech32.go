@@ -0,0 +1,165 @@
+package bitcoin
+
+import (
+	"fmt"
+	"strings"
+)
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// Checksum constants of BIP-173 bech32, used by version 0 witness programs, and BIP-350
+// bech32m, used by version 1 and later
+const (
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+)
+
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HRPExpand(hrp string) []byte {
+	expanded := make([]byte, 0, 2*len(hrp)+1)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]>>5)
+	}
+	expanded = append(expanded, 0)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]&31)
+	}
+	return expanded
+}
+
+// bech32Decode splits a bech32 or bech32m string into its HRP and 5-bit data, verifying the
+// checksum and returning the checksum constant it matched
+func bech32Decode(s string) (string, []byte, uint32, error) {
+	if len(s) > 90 {
+		return "", nil, 0, fmt.Errorf("bech32 string too long")
+	}
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", nil, 0, fmt.Errorf("bech32 string has mixed case")
+	}
+	s = strings.ToLower(s)
+
+	sep := strings.LastIndexByte(s, '1')
+	if sep < 1 || sep+7 > len(s) {
+		return "", nil, 0, fmt.Errorf("invalid bech32 separator position")
+	}
+	hrp := s[:sep]
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", nil, 0, fmt.Errorf("invalid bech32 prefix character %q", hrp[i])
+		}
+	}
+	data := make([]byte, 0, len(s)-sep-1)
+	for _, c := range s[sep+1:] {
+		idx := strings.IndexRune(bech32Charset, c)
+		if idx < 0 {
+			return "", nil, 0, fmt.Errorf("invalid bech32 character %q", c)
+		}
+		data = append(data, byte(idx))
+	}
+
+	constant := bech32Polymod(append(bech32HRPExpand(hrp), data...))
+	if constant != bech32Const && constant != bech32mConst {
+		return "", nil, 0, fmt.Errorf("invalid bech32 checksum")
+	}
+	return hrp, data[:len(data)-6], constant, nil
+}
+
+// bech32Encode encodes 5-bit data under hrp with the checksum constant of bech32 or bech32m
+func bech32Encode(hrp string, data []byte, constant uint32) string {
+	values := append(bech32HRPExpand(hrp), data...)
+	polymod := bech32Polymod(append(values, 0, 0, 0, 0, 0, 0)) ^ constant
+
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, v := range data {
+		sb.WriteByte(bech32Charset[v])
+	}
+	for i := 0; i < 6; i++ {
+		sb.WriteByte(bech32Charset[(polymod>>uint(5*(5-i)))&31])
+	}
+	return sb.String()
+}
+
+// convertBits regroups a byte slice from one bit width to another
+func convertBits(data []byte, from, to uint, pad bool) ([]byte, error) {
+	acc, bits := uint32(0), uint(0)
+	maxv := uint32(1)<<to - 1
+	var out []byte
+	for _, b := range data {
+		if uint32(b)>>from != 0 {
+			return nil, fmt.Errorf("invalid data range")
+		}
+		acc = acc<<from | uint32(b)
+		bits += from
+		for bits >= to {
+			bits -= to
+			out = append(out, byte(acc>>bits&maxv))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			out = append(out, byte(acc<<(to-bits)&maxv))
+		}
+	} else if bits >= from || acc<<(to-bits)&maxv != 0 {
+		return nil, fmt.Errorf("invalid padding")
+	}
+	return out, nil
+}
+
+// decodeSegWitAddress decodes a segwit address with the given HRP into its witness version and
+// program, enforcing bech32 for version 0 and bech32m for later versions
+func decodeSegWitAddress(address, hrp string) (byte, []byte, error) {
+	decodedHRP, data, constant, err := bech32Decode(address)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid address %q: %w", address, err)
+	}
+	if decodedHRP != hrp {
+		return 0, nil, fmt.Errorf("invalid address %q: expected prefix %s", address, hrp)
+	}
+	if len(data) == 0 {
+		return 0, nil, fmt.Errorf("invalid address %q: empty data", address)
+	}
+	version := data[0]
+	if version > 16 {
+		return 0, nil, fmt.Errorf("invalid address %q: invalid witness version %d", address, version)
+	}
+	if (version == 0) != (constant == bech32Const) {
+		return 0, nil, fmt.Errorf("invalid address %q: wrong checksum variant for witness version %d", address, version)
+	}
+	program, err := convertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid address %q: %w", address, err)
+	}
+	if len(program) < 2 || len(program) > 40 {
+		return 0, nil, fmt.Errorf("invalid address %q: invalid program length %d", address, len(program))
+	}
+	if version == 0 && len(program) != hash160Length && len(program) != sha256Length {
+		return 0, nil, fmt.Errorf("invalid address %q: invalid program length %d", address, len(program))
+	}
+	return version, program, nil
+}
+
+// encodeSegWitAddress encodes a witness program as a bech32 (version 0) or bech32m address
+func encodeSegWitAddress(hrp string, version byte, program []byte) string {
+	data, _ := convertBits(program, 8, 5, true)
+	constant := uint32(bech32mConst)
+	if version == 0 {
+		constant = bech32Const
+	}
+	return bech32Encode(hrp, append([]byte{version}, data...), constant)
+}
@@ -0,0 +1,277 @@
+package bitcoin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// satoshisPerBitcoin converts the BTC amounts of bitcoind to satoshis
+const satoshisPerBitcoin = 100000000
+
+// BitcoindClient implements RPCClient over the JSON-RPC interface of bitcoind
+type BitcoindClient struct {
+	url        string
+	user       string
+	password   string
+	wallet     string
+	httpClient *http.Client
+	id         atomic.Uint64
+}
+
+// bitcoindError is the error object of a JSON-RPC response
+type bitcoindError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewBitcoindClient creates a new bitcoind JSON-RPC client. When wallet is set, unspent outputs are
+// listed from that wallet, which must watch the addresses; otherwise they are found by scanning the
+// UTXO set, which sees confirmed outputs only
+func NewBitcoindClient(url, user, password, wallet string, httpClient *http.Client) *BitcoindClient {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultHTTPTimeout}
+	}
+	return &BitcoindClient{
+		url:        strings.TrimRight(url, "/"),
+		user:       user,
+		password:   password,
+		wallet:     wallet,
+		httpClient: httpClient,
+	}
+}
+
+// GetBlockCount returns the height of the chain tip
+func (c *BitcoindClient) GetBlockCount(ctx context.Context) (int64, error) {
+	var height int64
+	if err := c.call(ctx, "getblockcount", nil, &height); err != nil {
+		return 0, err
+	}
+	return height, nil
+}
+
+// GetBalance returns the balance of an address in satoshis
+func (c *BitcoindClient) GetBalance(ctx context.Context, address string) (*big.Int, error) {
+	utxos, err := c.ListUnspent(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	balance := new(big.Int)
+	for _, utxo := range utxos {
+		balance.Add(balance, big.NewInt(utxo.Amount))
+	}
+	return balance, nil
+}
+
+// ListUnspent returns the unspent outputs of an address
+func (c *BitcoindClient) ListUnspent(ctx context.Context, address string) ([]UTXO, error) {
+	if c.wallet != "" {
+		return c.listWalletUnspent(ctx, address)
+	}
+
+	var scan struct {
+		Success  bool  `json:"success"`
+		Height   int64 `json:"height"`
+		Unspents []struct {
+			TxID         string      `json:"txid"`
+			Vout         uint32      `json:"vout"`
+			ScriptPubKey string      `json:"scriptPubKey"`
+			Amount       json.Number `json:"amount"`
+			Height       int64       `json:"height"`
+		} `json:"unspents"`
+	}
+	if err := c.call(ctx, "scantxoutset", []interface{}{"start", []string{"addr(" + address + ")"}}, &scan); err != nil {
+		return nil, err
+	}
+	if !scan.Success {
+		return nil, fmt.Errorf("scantxoutset for %s did not complete", address)
+	}
+
+	utxos := make([]UTXO, len(scan.Unspents))
+	for i, out := range scan.Unspents {
+		amount, err := satoshis(out.Amount)
+		if err != nil {
+			return nil, err
+		}
+		utxos[i] = UTXO{
+			TxID:          out.TxID,
+			Vout:          out.Vout,
+			Address:       address,
+			ScriptPubKey:  out.ScriptPubKey,
+			Amount:        amount,
+			Confirmations: scan.Height - out.Height + 1,
+		}
+	}
+	return utxos, nil
+}
+
+// listWalletUnspent lists the unspent outputs of a wallet address, including unconfirmed ones
+func (c *BitcoindClient) listWalletUnspent(ctx context.Context, address string) ([]UTXO, error) {
+	var outputs []struct {
+		TxID          string      `json:"txid"`
+		Vout          uint32      `json:"vout"`
+		Address       string      `json:"address"`
+		ScriptPubKey  string      `json:"scriptPubKey"`
+		Amount        json.Number `json:"amount"`
+		Confirmations int64       `json:"confirmations"`
+	}
+	if err := c.call(ctx, "listunspent", []interface{}{0, 9999999, []string{address}}, &outputs); err != nil {
+		return nil, err
+	}
+
+	utxos := make([]UTXO, len(outputs))
+	for i, out := range outputs {
+		amount, err := satoshis(out.Amount)
+		if err != nil {
+			return nil, err
+		}
+		utxos[i] = UTXO{
+			TxID:          out.TxID,
+			Vout:          out.Vout,
+			Address:       out.Address,
+			ScriptPubKey:  out.ScriptPubKey,
+			Amount:        amount,
+			Confirmations: out.Confirmations,
+		}
+	}
+	return utxos, nil
+}
+
+// GetRawTransaction returns a transaction with its confirmation status; transactions that are
+// neither in the mempool nor in the wallet require bitcoind to run with -txindex
+func (c *BitcoindClient) GetRawTransaction(ctx context.Context, txHash string) (*Transaction, error) {
+	var verbose struct {
+		Hex           string `json:"hex"`
+		BlockHash     string `json:"blockhash"`
+		Confirmations int64  `json:"confirmations"`
+		Time          int64  `json:"time"`
+		BlockTime     int64  `json:"blocktime"`
+	}
+	if err := c.call(ctx, "getrawtransaction", []interface{}{txHash, true}, &verbose); err != nil {
+		return nil, err
+	}
+	tx, err := DecodeRawTransaction(verbose.Hex)
+	if err != nil {
+		return nil, err
+	}
+	tx.BlockHash = verbose.BlockHash
+	tx.Confirmations = verbose.Confirmations
+	tx.Time = verbose.Time
+	tx.BlockTime = verbose.BlockTime
+	return tx, nil
+}
+
+// SendRawTransaction broadcasts a serialized transaction and returns its ID
+func (c *BitcoindClient) SendRawTransaction(ctx context.Context, rawTx string) (string, error) {
+	var txid string
+	if err := c.call(ctx, "sendrawtransaction", []interface{}{rawTx}, &txid); err != nil {
+		return "", err
+	}
+	return txid, nil
+}
+
+// EstimateFee returns the fee rate, in satoshis per vbyte, to confirm within blocks
+func (c *BitcoindClient) EstimateFee(ctx context.Context, blocks int) (*big.Int, error) {
+	var estimate struct {
+		FeeRate json.Number `json:"feerate"`
+		Errors  []string    `json:"errors"`
+	}
+	if err := c.call(ctx, "estimatesmartfee", []interface{}{blocks}, &estimate); err != nil {
+		return nil, err
+	}
+	if estimate.FeeRate == "" {
+		return nil, fmt.Errorf("no fee estimate for %d blocks: %s", blocks, strings.Join(estimate.Errors, "; "))
+	}
+	// feerate is in BTC per kvB
+	perKVB, err := satoshis(estimate.FeeRate)
+	if err != nil {
+		return nil, err
+	}
+	rate := (perKVB + 999) / 1000
+	if rate < 1 {
+		rate = 1
+	}
+	return big.NewInt(rate), nil
+}
+
+// call sends a JSON-RPC request and decodes its result into result
+func (c *BitcoindClient) call(ctx context.Context, method string, params []interface{}, result interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "1.0",
+		"id":      c.id.Add(1),
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := c.url
+	if c.wallet != "" {
+		url += "/wallet/" + c.wallet
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.user != "" || c.password != "" {
+		req.SetBasicAuth(c.user, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// bitcoind answers RPC errors with a non-200 status and a JSON body describing them
+	var response struct {
+		Result json.RawMessage `json:"result"`
+		Error  *bitcoindError  `json:"error"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("%s returned status %d: %s", method, resp.StatusCode, strings.TrimSpace(string(data)))
+		}
+		return fmt.Errorf("failed to decode %s response: %w", method, err)
+	}
+	if response.Error != nil {
+		return fmt.Errorf("%s failed: %s (code %d)", method, response.Error.Message, response.Error.Code)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %d", method, resp.StatusCode)
+	}
+
+	if err := json.Unmarshal(response.Result, result); err != nil {
+		return fmt.Errorf("failed to decode %s result: %w", method, err)
+	}
+	return nil
+}
+
+// satoshis converts a decimal BTC amount to satoshis without floating point rounding
+func satoshis(amount json.Number) (int64, error) {
+	value, ok := new(big.Rat).SetString(amount.String())
+	if !ok {
+		return 0, fmt.Errorf("invalid amount %q", amount)
+	}
+	value.Mul(value, new(big.Rat).SetInt64(satoshisPerBitcoin))
+	if !value.IsInt() || !value.Num().IsInt64() {
+		return 0, fmt.Errorf("invalid amount %q", amount)
+	}
+	return value.Num().Int64(), nil
+}
@@ -0,0 +1,172 @@
+package bitcoin
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBitcoind answers JSON-RPC requests with canned results, or errors for methods it does not know
+type fakeBitcoind struct {
+	t       *testing.T
+	results map[string]string
+	paths   []string
+	params  map[string][]interface{}
+}
+
+func newFakeBitcoind(t *testing.T, results map[string]string) (*fakeBitcoind, string) {
+	t.Helper()
+	node := &fakeBitcoind{t: t, results: results, params: map[string][]interface{}{}}
+	srv := httptest.NewServer(http.HandlerFunc(node.serve))
+	t.Cleanup(srv.Close)
+	return node, srv.URL
+}
+
+func (n *fakeBitcoind) serve(w http.ResponseWriter, r *http.Request) {
+	user, password, ok := r.BasicAuth()
+	if !ok || user != "rpcuser" || password != "rpcpassword" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	var req struct {
+		JSONRPC string        `json:"jsonrpc"`
+		ID      uint64        `json:"id"`
+		Method  string        `json:"method"`
+		Params  []interface{} `json:"params"`
+	}
+	require.NoError(n.t, json.NewDecoder(r.Body).Decode(&req))
+	assert.Equal(n.t, "1.0", req.JSONRPC)
+	n.paths = append(n.paths, r.URL.Path)
+	n.params[req.Method] = req.Params
+
+	result, ok := n.results[req.Method]
+	if !ok {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"result":null,"error":{"code":-5,"message":"No such mempool or blockchain transaction"},"id":1}`))
+		return
+	}
+	_, _ = w.Write([]byte(`{"result":` + result + `,"error":null,"id":1}`))
+}
+
+func TestBitcoindClient(t *testing.T) {
+	ctx := context.Background()
+	signed, err := DecodeRawTransaction(bip143SignedTx)
+	require.NoError(t, err)
+	address := "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"
+	script := "76a91477bff20c60e522dfaa3350c39b030a5d004e839a88ac"
+
+	node, url := newFakeBitcoind(t, map[string]string{
+		"getblockcount": "800100",
+		"scantxoutset": `{"success":true,"txouts":2,"height":800100,"unspents":[` +
+			`{"txid":"` + testPrevTxID + `","vout":1,"scriptPubKey":"` + script + `","desc":"addr(` + address + `)","amount":0.00100000,"height":800000},` +
+			`{"txid":"` + genesisCoinbaseID + `","vout":0,"scriptPubKey":"` + script + `","desc":"addr(` + address + `)","amount":21000000.00000001,"height":800100}],"total_amount":21000000.00100001}`,
+		"getrawtransaction":  `{"hex":"` + bip143SignedTx + `","txid":"` + signed.TxID + `","blockhash":"00000000000000000001","confirmations":10,"time":1700000000,"blocktime":1700000000}`,
+		"sendrawtransaction": `"` + signed.TxID + `"`,
+		"estimatesmartfee":   `{"feerate":0.00021234,"blocks":6}`,
+	})
+	client := NewBitcoindClient(url+"/", "rpcuser", "rpcpassword", "", nil)
+
+	height, err := client.GetBlockCount(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(800100), height)
+
+	utxos, err := client.ListUnspent(ctx, address)
+	require.NoError(t, err)
+	assert.Equal(t, []UTXO{
+		{TxID: testPrevTxID, Vout: 1, Address: address, ScriptPubKey: script, Amount: 100000, Confirmations: 101},
+		{TxID: genesisCoinbaseID, Vout: 0, Address: address, ScriptPubKey: script, Amount: 2100000000000001, Confirmations: 1},
+	}, utxos)
+	assert.Equal(t, []interface{}{"start", []interface{}{"addr(" + address + ")"}}, node.params["scantxoutset"])
+
+	balance, err := client.GetBalance(ctx, address)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(2100000000100001), balance)
+
+	tx, err := client.GetRawTransaction(ctx, signed.TxID)
+	require.NoError(t, err)
+	assert.Equal(t, signed.TxID, tx.TxID)
+	assert.Equal(t, signed.Inputs, tx.Inputs)
+	assert.Equal(t, int64(10), tx.Confirmations)
+	assert.Equal(t, "00000000000000000001", tx.BlockHash)
+	assert.Equal(t, []interface{}{signed.TxID, true}, node.params["getrawtransaction"])
+
+	txid, err := client.SendRawTransaction(ctx, bip143SignedTx)
+	require.NoError(t, err)
+	assert.Equal(t, signed.TxID, txid)
+
+	// 0.00021234 BTC/kvB is 21.234 sat/vB
+	rate, err := client.EstimateFee(ctx, 6)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(22), rate)
+	assert.Equal(t, []interface{}{float64(6)}, node.params["estimatesmartfee"])
+
+	for _, path := range node.paths {
+		assert.Equal(t, "/", path)
+	}
+}
+
+func TestBitcoindClientWallet(t *testing.T) {
+	node, url := newFakeBitcoind(t, map[string]string{
+		"listunspent": `[{"txid":"` + testPrevTxID + `","vout":0,"address":"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4","scriptPubKey":"0014751e76e8199196d454941c45d1b3a323f1433bd6","amount":0.5,"confirmations":0}]`,
+	})
+	client := NewBitcoindClient(url, "rpcuser", "rpcpassword", "watch", nil)
+
+	utxos, err := client.ListUnspent(context.Background(), "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4")
+	require.NoError(t, err)
+	require.Len(t, utxos, 1)
+	assert.Equal(t, int64(50000000), utxos[0].Amount)
+	assert.Equal(t, int64(0), utxos[0].Confirmations)
+	assert.Equal(t, []string{"/wallet/watch"}, node.paths)
+	assert.Equal(t, []interface{}{float64(0), float64(9999999), []interface{}{"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"}}, node.params["listunspent"])
+}
+
+func TestBitcoindClientErrors(t *testing.T) {
+	ctx := context.Background()
+	_, url := newFakeBitcoind(t, map[string]string{
+		"scantxoutset":     `{"success":false}`,
+		"estimatesmartfee": `{"errors":["Insufficient data or no feerate found"],"blocks":2}`,
+		"getblockcount":    `"tip"`,
+	})
+	client := NewBitcoindClient(url, "rpcuser", "rpcpassword", "", nil)
+
+	_, err := client.GetRawTransaction(ctx, testPrevTxID)
+	assert.ErrorContains(t, err, "getrawtransaction failed: No such mempool or blockchain transaction (code -5)")
+
+	_, err = client.ListUnspent(ctx, "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2")
+	assert.ErrorContains(t, err, "did not complete")
+
+	_, err = client.EstimateFee(ctx, 2)
+	assert.ErrorContains(t, err, "Insufficient data or no feerate found")
+
+	_, err = client.GetBlockCount(ctx)
+	assert.ErrorContains(t, err, "failed to decode getblockcount result")
+
+	_, err = NewBitcoindClient(url, "rpcuser", "wrong", "", nil).GetBlockCount(ctx)
+	assert.ErrorContains(t, err, "returned status 401")
+
+	_, err = NewBitcoindClient("http://127.0.0.1:1", "", "", "", nil).GetBlockCount(ctx)
+	assert.Error(t, err)
+}
+
+func TestSatoshis(t *testing.T) {
+	for amount, expected := range map[string]int64{
+		"1":                 100000000,
+		"0.00000001":        1,
+		"0.1":               10000000,
+		"20999999.97690000": 2099999997690000,
+	} {
+		value, err := satoshis(json.Number(amount))
+		require.NoError(t, err)
+		assert.Equal(t, expected, value, amount)
+	}
+	for _, invalid := range []string{"0.000000001", "abc", "1e20"} {
+		_, err := satoshis(json.Number(invalid))
+		assert.Error(t, err, invalid)
+	}
+}
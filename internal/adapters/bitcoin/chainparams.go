@@ -0,0 +1,93 @@
+package bitcoin
+
+import "fmt"
+
+// UTXO chains served by the adapter, selected with NetworkConfig.Chain
+const (
+	ChainBitcoin     = "bitcoin"
+	ChainLitecoin    = "litecoin"
+	ChainDogecoin    = "dogecoin"
+	ChainBitcoinCash = "bitcoincash"
+)
+
+// sigHashForkID marks signatures of chains that replay-protect with a fork ID (BIP-143 digests for every input)
+const sigHashForkID = 0x40
+
+// ChainParams holds the parameters that tell apart the networks of Bitcoin-derived UTXO chains
+type ChainParams struct {
+	Chain   string
+	Network string
+	// Ticker is the currency fees are reported in
+	Ticker            string
+	PubKeyHashVersion byte
+	ScriptHashVersion byte
+	// Bech32HRP is the human-readable part of segwit addresses, empty on chains without segwit
+	Bech32HRP string
+	// SigHashForkID signs every input with BIP-143 digests carrying ForkID in the sighash type
+	SigHashForkID bool
+	ForkID        uint32
+	// DustLimit is the smallest output relayed regardless of its script, in satoshis
+	DustLimit int64
+	// SupportsRBF tells whether nodes of the chain replace transactions signalling BIP-125
+	SupportsRBF bool
+	// MinFeeRate is the minimum relay fee rate, in satoshis per vbyte; transactions without a gas
+	// price pay the node's fee estimate, raised to it
+	MinFeeRate int64
+}
+
+// knownChainParams lists the supported networks in the order addresses of unknown networks are tried;
+// testnet and regtest share base58 version bytes but not bech32 HRPs
+var knownChainParams = []ChainParams{
+	{Chain: ChainBitcoin, Network: "mainnet", Ticker: "BTC", PubKeyHashVersion: 0x00, ScriptHashVersion: 0x05, Bech32HRP: "bc", SupportsRBF: true, MinFeeRate: 1},
+	{Chain: ChainBitcoin, Network: "testnet", Ticker: "BTC", PubKeyHashVersion: 0x6f, ScriptHashVersion: 0xc4, Bech32HRP: "tb", SupportsRBF: true, MinFeeRate: 1},
+	{Chain: ChainBitcoin, Network: "regtest", Ticker: "BTC", PubKeyHashVersion: 0x6f, ScriptHashVersion: 0xc4, Bech32HRP: "bcrt", SupportsRBF: true, MinFeeRate: 1},
+	{Chain: ChainLitecoin, Network: "mainnet", Ticker: "LTC", PubKeyHashVersion: 0x30, ScriptHashVersion: 0x32, Bech32HRP: "ltc", SupportsRBF: true, MinFeeRate: 10},
+	{Chain: ChainLitecoin, Network: "testnet", Ticker: "LTC", PubKeyHashVersion: 0x6f, ScriptHashVersion: 0x3a, Bech32HRP: "tltc", SupportsRBF: true, MinFeeRate: 10},
+	{Chain: ChainLitecoin, Network: "regtest", Ticker: "LTC", PubKeyHashVersion: 0x6f, ScriptHashVersion: 0x3a, Bech32HRP: "rltc", SupportsRBF: true, MinFeeRate: 10},
+	{Chain: ChainDogecoin, Network: "mainnet", Ticker: "DOGE", PubKeyHashVersion: 0x1e, ScriptHashVersion: 0x16, DustLimit: 1000000, MinFeeRate: 1000},
+	{Chain: ChainDogecoin, Network: "testnet", Ticker: "DOGE", PubKeyHashVersion: 0x71, ScriptHashVersion: 0xc4, DustLimit: 1000000, MinFeeRate: 1000},
+	{Chain: ChainDogecoin, Network: "regtest", Ticker: "DOGE", PubKeyHashVersion: 0x6f, ScriptHashVersion: 0xc4, DustLimit: 1000000, MinFeeRate: 1000},
+	{Chain: ChainBitcoinCash, Network: "mainnet", Ticker: "BCH", PubKeyHashVersion: 0x00, ScriptHashVersion: 0x05, SigHashForkID: true, DustLimit: 546, MinFeeRate: 1},
+	{Chain: ChainBitcoinCash, Network: "testnet", Ticker: "BCH", PubKeyHashVersion: 0x6f, ScriptHashVersion: 0xc4, SigHashForkID: true, DustLimit: 546, MinFeeRate: 1},
+	{Chain: ChainBitcoinCash, Network: "regtest", Ticker: "BCH", PubKeyHashVersion: 0x6f, ScriptHashVersion: 0xc4, SigHashForkID: true, DustLimit: 546, MinFeeRate: 1},
+}
+
+// chainParams indexes knownChainParams by chain ID
+var chainParams = func() map[string]ChainParams {
+	params := make(map[string]ChainParams, len(knownChainParams))
+	for _, p := range knownChainParams {
+		params[p.ChainID()] = p
+	}
+	return params
+}()
+
+// LookupChainParams returns the parameters of a network of a UTXO chain
+func LookupChainParams(chain, network string) (*ChainParams, error) {
+	params, ok := chainParams[chainID(chain, network)]
+	if !ok {
+		return nil, fmt.Errorf("unknown %s network %q", chain, network)
+	}
+	return &params, nil
+}
+
+// ChainID returns the identifier the network is registered under, such as bitcoin-mainnet
+func (p *ChainParams) ChainID() string {
+	return chainID(p.Chain, p.Network)
+}
+
+func chainID(chain, network string) string {
+	return fmt.Sprintf("%s-%s", chain, network)
+}
+
+// sigHashType returns the SIGHASH_ALL type signatures of the chain commit to
+func (p *ChainParams) sigHashType() uint32 {
+	if p.SigHashForkID {
+		return p.ForkID<<8 | sigHashForkID | sigHashAll
+	}
+	return sigHashAll
+}
+
+// dustThreshold returns the smallest output to script the chain relays
+func (p *ChainParams) dustThreshold(script []byte) int64 {
+	return max(dustThreshold(script), p.DustLimit)
+}
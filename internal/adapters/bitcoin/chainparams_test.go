@@ -0,0 +1,235 @@
+package bitcoin
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var mainnetParams = chainParams["bitcoin-mainnet"]
+
+// keyOneHash is the public key hash of the compressed public key of private key 1
+const keyOneHash = "751e76e8199196d454941c45d1b3a323f1433bd6"
+
+func TestLookupChainParams(t *testing.T) {
+	for _, chain := range []string{ChainBitcoin, ChainLitecoin, ChainDogecoin, ChainBitcoinCash} {
+		for _, network := range []string{"mainnet", "testnet", "regtest"} {
+			params, err := LookupChainParams(chain, network)
+			require.NoError(t, err)
+			assert.Equal(t, chain+"-"+network, params.ChainID())
+			assert.NotEmpty(t, params.Ticker)
+			assert.Positive(t, params.MinFeeRate)
+		}
+	}
+
+	_, err := LookupChainParams(ChainDogecoin, "signet")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown dogecoin network "signet"`)
+
+	// Lookups return copies, so callers cannot change the registered parameters
+	params, err := LookupChainParams(ChainBitcoin, "mainnet")
+	require.NoError(t, err)
+	params.Ticker = "XBT"
+	assert.Equal(t, "BTC", chainParams["bitcoin-mainnet"].Ticker)
+}
+
+func TestChainAddresses(t *testing.T) {
+	tests := []struct {
+		chain       string
+		network     string
+		address     string
+		addressType AddressType
+	}{
+		{ChainBitcoin, "mainnet", "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", AddressTypeP2PKH},
+		{ChainLitecoin, "mainnet", "LVuDpNCSSj6pQ7t9Pv6d6sUkLKoqDEVUnJ", AddressTypeP2PKH},
+		{ChainLitecoin, "mainnet", "MJaRnao1s62a2zAKSkmG582KbLKianqb7v", AddressTypeP2SH},
+		{ChainLitecoin, "mainnet", "ltc1qw508d6qejxtdg4y5r3zarvary0c5xw7kgmn4n9", AddressTypeP2WPKH},
+		{ChainLitecoin, "testnet", "QXHFfTBKYXjaaTH1e7Rox8CcdNPGHVhM59", AddressTypeP2SH},
+		{ChainDogecoin, "mainnet", "DFpN6QqFfUm3gKNaxN6tNcab1FArL9cZLE", AddressTypeP2PKH},
+		{ChainDogecoin, "mainnet", "A37YDYSwz3438rFtm1SLVcQHyD7JeueC9H", AddressTypeP2SH},
+		{ChainDogecoin, "testnet", "nesRpRaAbTDmZHwmzBkLd2AtF7Z9L9z5S2", AddressTypeP2PKH},
+		{ChainBitcoinCash, "mainnet", "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", AddressTypeP2PKH},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			address, err := parseAddress(tt.address, tt.chain, tt.network)
+			require.NoError(t, err)
+			assert.Equal(t, tt.addressType, address.Type)
+			assert.Equal(t, keyOneHash, hex.EncodeToString(address.Program))
+			assert.Equal(t, tt.address, address.String())
+
+			// Esplora and the signer derive scripts from addresses without knowing their chain
+			decoded, err := decodeAddress(tt.address)
+			require.NoError(t, err)
+			assert.Equal(t, address.ScriptPubKey(), decoded.ScriptPubKey())
+		})
+	}
+
+	// Chains without segwit reject bech32 addresses, and chains reject each other's addresses
+	for chain, address := range map[string]string{
+		ChainDogecoin:    "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4",
+		ChainBitcoinCash: "ltc1qw508d6qejxtdg4y5r3zarvary0c5xw7kgmn4n9",
+		ChainLitecoin:    "DFpN6QqFfUm3gKNaxN6tNcab1FArL9cZLE",
+		ChainBitcoin:     "LVuDpNCSSj6pQ7t9Pv6d6sUkLKoqDEVUnJ",
+	} {
+		_, err := parseAddress(address, chain, "mainnet")
+		require.Error(t, err, address)
+		assert.Contains(t, err.Error(), "is not a "+chain+"-mainnet address")
+	}
+
+	dogecoin := chainParams["dogecoin-mainnet"]
+	_, err := scriptAddress(mustDecodeHex(t, "0014"+keyOneHash), &dogecoin)
+	require.Error(t, err)
+	litecoin := chainParams["litecoin-mainnet"]
+	address, err := scriptAddress(mustDecodeHex(t, "0014"+keyOneHash), &litecoin)
+	require.NoError(t, err)
+	assert.Equal(t, "ltc1qw508d6qejxtdg4y5r3zarvary0c5xw7kgmn4n9", address.String())
+}
+
+func TestChainAdapterFromConfig(t *testing.T) {
+	mockRPC := new(MockRPCClient)
+	mockRPC.On("EstimateFee", mock.Anything, defaultConfirmationTarget).Return(big.NewInt(2), nil)
+
+	adapter, err := NewAdapterWithConfig(mockRPC, NetworkConfig{Chain: ChainLitecoin, Name: "mainnet", RPCURL: "https://litecoinspace.org/api"})
+	require.NoError(t, err)
+	assert.Equal(t, "litecoin-mainnet", adapter.GetChainID())
+	assert.Equal(t, entities.ChainTypeBitcoin, adapter.GetChainType())
+	network, err := adapter.GetNetworkInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "litecoin-mainnet", network.ChainID())
+
+	tx, err := entities.NewTransaction(entities.TransactionParams{ChainID: adapter.GetChainID(), From: mustAddress(t, "LVuDpNCSSj6pQ7t9Pv6d6sUkLKoqDEVUnJ"), To: mustAddress(t, "MJaRnao1s62a2zAKSkmG582KbLKianqb7v")})
+	require.NoError(t, err)
+	fee, err := adapter.EstimateFee(context.Background(), tx)
+	require.NoError(t, err)
+	assert.Equal(t, "LTC", fee.Currency())
+
+	_, err = NewAdapterWithConfig(mockRPC, NetworkConfig{Chain: "namecoin", Name: "mainnet", RPCURL: "http://127.0.0.1:8336"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown namecoin network")
+}
+
+func TestChainDustAndMinFeeRate(t *testing.T) {
+	from := mustAddress(t, "DFpN6QqFfUm3gKNaxN6tNcab1FArL9cZLE")
+	to := mustAddress(t, "A37YDYSwz3438rFtm1SLVcQHyD7JeueC9H")
+	mockRPC := new(MockRPCClient)
+	mockRPC.On("ListUnspent", mock.Anything, from.String()).Return([]UTXO{
+		{TxID: testPrevTxID, Amount: 500000000, Confirmations: 10},
+	}, nil)
+	mockRPC.On("EstimateFee", mock.Anything, defaultConfirmationTarget).Return(big.NewInt(2), nil)
+	dogecoin := chainParams["dogecoin-mainnet"]
+	adapter := NewChainAdapter(mockRPC, &dogecoin)
+
+	// Dogecoin relays no output below 0.01 DOGE, whatever its script
+	_, err := adapter.CreateTransaction(context.Background(), entities.TransactionParams{From: from, To: to, Value: big.NewInt(999999)})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "below the dust threshold of 1000000 satoshis")
+
+	tx, err := adapter.CreateTransaction(context.Background(), entities.TransactionParams{From: from, To: to, Value: big.NewInt(1000000)})
+	require.NoError(t, err)
+	assert.Equal(t, "dogecoin-mainnet", tx.ChainID())
+	// The estimate is raised to the minimum relay fee rate
+	assert.Equal(t, big.NewInt(dogecoin.MinFeeRate), tx.GasPrice())
+	bitcoinCash := chainParams["bitcoincash-mainnet"]
+	assert.Equal(t, int64(546), bitcoinCash.dustThreshold(mustDecodeHex(t, "a914"+keyOneHash+"87")))
+	assert.Equal(t, int64(540), mainnetParams.dustThreshold(mustDecodeHex(t, "a914"+keyOneHash+"87")))
+}
+
+func TestChainReplaceByFee(t *testing.T) {
+	from := mustAddress(t, "DFpN6QqFfUm3gKNaxN6tNcab1FArL9cZLE")
+	to := mustAddress(t, "A37YDYSwz3438rFtm1SLVcQHyD7JeueC9H")
+	mockRPC := new(MockRPCClient)
+	mockRPC.On("ListUnspent", mock.Anything, from.String()).Return([]UTXO{
+		{TxID: testPrevTxID, Amount: 500000000, Confirmations: 10},
+	}, nil)
+	mockRPC.On("EstimateFee", mock.Anything, defaultConfirmationTarget).Return(big.NewInt(1000), nil)
+	dogecoin := chainParams["dogecoin-mainnet"]
+	adapter := NewChainAdapter(mockRPC, &dogecoin)
+
+	// Dogecoin and Bitcoin Cash nodes do not implement BIP-125, so nothing signals it
+	for _, chainID := range []string{"dogecoin-mainnet", "bitcoincash-mainnet"} {
+		assert.False(t, chainParams[chainID].SupportsRBF, chainID)
+	}
+	for _, chainID := range []string{"bitcoin-mainnet", "litecoin-mainnet"} {
+		assert.True(t, chainParams[chainID].SupportsRBF, chainID)
+	}
+
+	tx, err := adapter.CreateTransaction(context.Background(), entities.TransactionParams{From: from, To: to, Value: big.NewInt(1000000)})
+	require.NoError(t, err)
+	assert.Equal(t, false, tx.Metadata()[MetadataReplaceable])
+	msg, _, err := unsignedTx(tx, &dogecoin)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(sequenceFinal), msg.inputs[0].sequence)
+
+	_, err = adapter.CreateTransaction(context.Background(), entities.TransactionParams{From: from, To: to, Value: big.NewInt(1000000), Options: map[string]string{OptionReplaceable: "true"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "replace-by-fee is not supported on dogecoin-mainnet")
+	_, err = adapter.CreateTransaction(context.Background(), entities.TransactionParams{From: from, To: to, Value: big.NewInt(1000000), Options: map[string]string{OptionReplaceable: "false"}})
+	require.NoError(t, err)
+
+	hash, err := valueobjects.NewHash(testPrevTxID)
+	require.NoError(t, err)
+	_, err = adapter.BumpFee(context.Background(), hash, big.NewInt(10), testKeys(t), "test")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "replace-by-fee is not supported on dogecoin-mainnet")
+}
+
+func TestForkIDSigning(t *testing.T) {
+	bitcoinCash := chainParams["bitcoincash-mainnet"]
+	assert.Equal(t, uint32(0x41), bitcoinCash.sigHashType())
+	assert.Equal(t, uint32(0x4f41), (&ChainParams{SigHashForkID: true, ForkID: 0x4f}).sigHashType())
+	assert.Equal(t, uint32(sigHashAll), mainnetParams.sigHashType())
+
+	p2pkh := "76a914" + bip143PubKeyHash + "88ac"
+	adapter := NewChainAdapter(new(MockRPCClient), &bitcoinCash)
+	tx, err := entities.NewTransaction(entities.TransactionParams{
+		ChainID: adapter.GetChainID(),
+		From:    testKeyAddress(t),
+		To:      mustAddress(t, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH"),
+		Value:   big.NewInt(50000000),
+	})
+	require.NoError(t, err)
+	tx.SetMetadata(MetadataUTXOs, []UTXO{{TxID: testPrevTxID, Vout: 1, ScriptPubKey: p2pkh, Amount: 100000000}})
+	tx.SetMetadata(MetadataChangeAmount, "49990000")
+	require.NoError(t, adapter.SignTransaction(context.Background(), tx, testKeys(t), "test"))
+
+	valid, err := adapter.VerifySignature(context.Background(), tx)
+	require.NoError(t, err)
+	assert.True(t, valid)
+	sig := tx.Signature().Bytes()
+	assert.Equal(t, byte(0x41), sig[len(sig)-1])
+
+	// The fork ID digest commits to the spent amount, so the same input signed for Bitcoin differs
+	msg, utxos, err := unsignedTx(tx, &bitcoinCash)
+	require.NoError(t, err)
+	forkDigest, err := inputSigHash(msg, 0, mustDecodeHex(t, p2pkh), utxos[0].Amount, &bitcoinCash)
+	require.NoError(t, err)
+	legacyDigest, err := inputSigHash(msg, 0, mustDecodeHex(t, p2pkh), utxos[0].Amount, &mainnetParams)
+	require.NoError(t, err)
+	assert.NotEqual(t, forkDigest, legacyDigest)
+	otherAmount, err := inputSigHash(msg, 0, mustDecodeHex(t, p2pkh), utxos[0].Amount+1, &bitcoinCash)
+	require.NoError(t, err)
+	assert.NotEqual(t, forkDigest, otherAmount)
+
+	// Bitcoin nodes reject fork ID signatures
+	bitcoinAdapter := NewAdapter(new(MockRPCClient), "mainnet")
+	valid, err = bitcoinAdapter.VerifySignature(context.Background(), tx)
+	require.NoError(t, err)
+	assert.False(t, valid)
+}
+
+func mustAddress(t *testing.T, address string) *valueobjects.Address {
+	t.Helper()
+	decoded, err := decodeAddress(address)
+	require.NoError(t, err)
+	vo, err := valueobjects.NewAddress(address, chainID(decoded.Chain, decoded.Network))
+	require.NoError(t, err)
+	return vo
+}
@@ -0,0 +1,492 @@
+package bitcoin
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Coin selection strategies accepted by NewCoinSelector and NetworkConfig.CoinSelection
+const (
+	CoinSelectionBranchAndBound = "bnb"
+	CoinSelectionLargestFirst   = "largest-first"
+	CoinSelectionSmallestFirst  = "smallest-first"
+	CoinSelectionKnapsack       = "knapsack"
+)
+
+// Transaction options (entities.TransactionParams.Options) read by CreateTransaction
+const (
+	// OptionCoinSelection overrides the coin selection strategy of the network
+	OptionCoinSelection = "coin_selection"
+	// OptionMinConfirmations overrides the minimum confirmations of spent UTXOs
+	OptionMinConfirmations = "min_confirmations"
+	// OptionReplaceable set to "false" opts the transaction out of replace-by-fee signalling
+	OptionReplaceable = "replaceable"
+)
+
+// Transaction weights in weight units (BIP-141). Input weights assume a 72-byte
+// signature and a compressed public key, so estimates never undershoot the signed size
+const (
+	// txOverheadWeight covers the version, lock time and single-byte input and output counts
+	txOverheadWeight = 10 * witnessScaleFactor
+	// witnessHeaderWeight is the segwit marker and flag
+	witnessHeaderWeight = 2
+
+	p2pkhInputWeight      = 148 * witnessScaleFactor
+	p2wpkhInputWeight     = 41*witnessScaleFactor + 108
+	p2shP2wpkhInputWeight = 64*witnessScaleFactor + 108
+	p2trInputWeight       = 41*witnessScaleFactor + 66
+)
+
+// dustRelayFeeRate is the fee rate, in satoshis per vbyte, dust outputs are priced at
+const dustRelayFeeRate = 3
+
+const (
+	// bnbMaxTries bounds the depth-first search of BranchAndBoundSelector
+	bnbMaxTries = 100000
+	// knapsackIterations is the number of random passes of KnapsackSelector
+	knapsackIterations = 1000
+)
+
+// CoinSelector chooses the UTXOs funding a payment
+type CoinSelector interface {
+	// Select returns the inputs funding req, or an error when utxos cannot cover it
+	Select(utxos []UTXO, req SelectionRequest) (*Selection, error)
+}
+
+// SelectionRequest describes the payment a CoinSelector funds
+type SelectionRequest struct {
+	// Target is the amount paid to the recipient, in satoshis
+	Target int64
+	// FeeRate is the fee in satoshis per virtual byte
+	FeeRate         int64
+	RecipientScript []byte
+	// ChangeScript locks the change output and sizes UTXOs that carry no script of their own
+	ChangeScript []byte
+	// DustLimit is the smallest change worth an output; smaller change is left to the fee
+	DustLimit int64
+	// MinConfirmations excludes UTXOs with fewer confirmations
+	MinConfirmations int64
+}
+
+// Selection is the outcome of coin selection: the selected amount always equals Target + Fee + Change
+type Selection struct {
+	UTXOs  []UTXO
+	Fee    int64
+	Change int64
+}
+
+// NewCoinSelector returns the selector of a strategy; an empty strategy selects the default,
+// branch-and-bound falling back to knapsack as Bitcoin Core does
+func NewCoinSelector(strategy string) (CoinSelector, error) {
+	switch strategy {
+	case "", CoinSelectionBranchAndBound:
+		return BranchAndBoundSelector{Fallback: KnapsackSelector{}}, nil
+	case CoinSelectionLargestFirst:
+		return LargestFirstSelector{}, nil
+	case CoinSelectionSmallestFirst:
+		return SmallestFirstSelector{}, nil
+	case CoinSelectionKnapsack:
+		return KnapsackSelector{}, nil
+	default:
+		return nil, fmt.Errorf("unknown coin selection strategy %q", strategy)
+	}
+}
+
+// LargestFirstSelector spends the largest UTXOs first, minimizing the number of inputs
+type LargestFirstSelector struct{}
+
+// Select implements CoinSelector
+func (LargestFirstSelector) Select(utxos []UTXO, req SelectionRequest) (*Selection, error) {
+	coins := req.candidates(utxos)
+	sort.SliceStable(coins, func(i, j int) bool { return coins[i].effective > coins[j].effective })
+	return req.accumulate(coins)
+}
+
+// SmallestFirstSelector spends the smallest UTXOs first, consolidating them while fees are low
+type SmallestFirstSelector struct{}
+
+// Select implements CoinSelector
+func (SmallestFirstSelector) Select(utxos []UTXO, req SelectionRequest) (*Selection, error) {
+	coins := req.candidates(utxos)
+	sort.SliceStable(coins, func(i, j int) bool { return coins[i].effective < coins[j].effective })
+	return req.accumulate(coins)
+}
+
+// BranchAndBoundSelector searches for an input set paying the target without a change output,
+// wasting at most the cost of creating and later spending change. Fallback is used when no
+// such set exists
+type BranchAndBoundSelector struct {
+	Fallback CoinSelector
+}
+
+// Select implements CoinSelector
+func (s BranchAndBoundSelector) Select(utxos []UTXO, req SelectionRequest) (*Selection, error) {
+	coins := req.candidates(utxos)
+	sort.SliceStable(coins, func(i, j int) bool { return coins[i].effective > coins[j].effective })
+
+	// Effective values already pay for the inputs; the target adds everything else
+	target := req.Target + feeForWeight(txOverheadWeight+witnessHeaderWeight+outputWeight(req.RecipientScript), req.FeeRate)
+	costOfChange := feeForWeight(outputWeight(req.ChangeScript), req.FeeRate) +
+		feeForWeight(inputWeight(req.ChangeScript), req.FeeRate)
+
+	if picked := branchAndBound(coins, target, costOfChange); picked != nil {
+		if selection, ok := req.settle(picked, false); ok {
+			return selection, nil
+		}
+	}
+	if s.Fallback != nil {
+		return s.Fallback.Select(utxos, req)
+	}
+	return nil, fmt.Errorf("no changeless input set pays %d satoshis", req.Target)
+}
+
+// branchAndBound returns the coins whose effective value lands in [target, target+costOfChange]
+// with the least excess, exploring inclusion before omission of each coin
+func branchAndBound(coins []coin, target, costOfChange int64) []coin {
+	var remaining int64
+	for _, c := range coins {
+		remaining += c.effective
+	}
+	if remaining < target {
+		return nil
+	}
+
+	selected := make([]bool, len(coins))
+	var best []bool
+	bestExcess := int64(math.MaxInt64)
+	var value int64
+	tries := 0
+
+	var search func(i int)
+	search = func(i int) {
+		if tries >= bnbMaxTries || bestExcess == 0 {
+			return
+		}
+		tries++
+		if value > target+costOfChange {
+			return
+		}
+		if value >= target {
+			if excess := value - target; excess < bestExcess {
+				bestExcess = excess
+				best = append(best[:0], selected...)
+			}
+			return
+		}
+		if i == len(coins) || value+remaining < target {
+			return
+		}
+
+		effective := coins[i].effective
+		remaining -= effective
+		// Including a coin equal to one just omitted would only revisit the same sets
+		if i == 0 || selected[i-1] || coins[i-1].effective != effective {
+			selected[i] = true
+			value += effective
+			search(i + 1)
+			value -= effective
+			selected[i] = false
+		}
+		search(i + 1)
+		remaining += effective
+	}
+	search(0)
+
+	if best == nil {
+		return nil
+	}
+	var picked []coin
+	for i, ok := range best {
+		if ok {
+			picked = append(picked, coins[i])
+		}
+	}
+	return picked
+}
+
+// KnapsackSelector is Bitcoin Core's stochastic approximation of the smallest input set
+// covering the target plus a change above the dust limit
+type KnapsackSelector struct {
+	// Rand drives the random passes; nil uses the global source. A *rand.Rand is not safe
+	// for concurrent use, so set it only on selectors used by a single goroutine
+	Rand *rand.Rand
+}
+
+// Select implements CoinSelector
+func (s KnapsackSelector) Select(utxos []UTXO, req SelectionRequest) (*Selection, error) {
+	coins := req.candidates(utxos)
+	s.shuffle(len(coins), func(i, j int) { coins[i], coins[j] = coins[j], coins[i] })
+
+	target := req.Target + feeForWeight(txOverheadWeight+witnessHeaderWeight+
+		outputWeight(req.RecipientScript)+outputWeight(req.ChangeScript), req.FeeRate)
+	withChange := target + req.DustLimit
+
+	var smaller []coin
+	var smallerTotal int64
+	var lowestLarger *coin
+	for i, c := range coins {
+		switch {
+		case c.effective == target:
+			return s.settle(utxos, req, []coin{c})
+		case c.effective < withChange:
+			smaller = append(smaller, c)
+			smallerTotal += c.effective
+		case lowestLarger == nil || c.effective < lowestLarger.effective:
+			lowestLarger = &coins[i]
+		}
+	}
+
+	switch {
+	case smallerTotal == target:
+		return s.settle(utxos, req, smaller)
+	case smallerTotal < target:
+		if lowestLarger == nil {
+			// Everything may still pay the target without a change output
+			return s.settle(utxos, req, coins)
+		}
+		return s.settle(utxos, req, []coin{*lowestLarger})
+	}
+
+	sort.SliceStable(smaller, func(i, j int) bool { return smaller[i].effective > smaller[j].effective })
+	picked, value := s.approximateBestSubset(smaller, smallerTotal, target)
+	if value != target && smallerTotal >= withChange {
+		picked, value = s.approximateBestSubset(smaller, smallerTotal, withChange)
+	}
+	// A single larger coin wins when the subset misses both the exact target and room for change
+	if lowestLarger != nil && ((value != target && value < withChange) || lowestLarger.effective <= value) {
+		picked = []coin{*lowestLarger}
+	}
+	return s.settle(utxos, req, picked)
+}
+
+// settle prices the knapsack pick; rounding in effective values can leave it a few satoshis
+// short, in which case accumulating the largest coins settles whenever funds suffice
+func (s KnapsackSelector) settle(utxos []UTXO, req SelectionRequest, picked []coin) (*Selection, error) {
+	if selection, ok := req.settle(picked, true); ok {
+		return selection, nil
+	}
+	return LargestFirstSelector{}.Select(utxos, req)
+}
+
+// approximateBestSubset randomly includes coins over many passes, keeping the smallest total
+// reaching target
+func (s KnapsackSelector) approximateBestSubset(coins []coin, total, target int64) ([]coin, int64) {
+	best := make([]bool, len(coins))
+	for i := range best {
+		best[i] = true
+	}
+	bestValue := total
+
+	included := make([]bool, len(coins))
+	for rep := 0; rep < knapsackIterations && bestValue != target; rep++ {
+		for i := range included {
+			included[i] = false
+		}
+		var value int64
+		reached := false
+		// The first pass includes coins at random, the second fills in the ones left out
+		for pass := 0; pass < 2 && !reached; pass++ {
+			for i := range coins {
+				include := !included[i]
+				if pass == 0 {
+					include = s.intn(2) == 0
+				}
+				if !include {
+					continue
+				}
+				value += coins[i].effective
+				included[i] = true
+				if value >= target {
+					reached = true
+					if value < bestValue {
+						bestValue = value
+						copy(best, included)
+					}
+					value -= coins[i].effective
+					included[i] = false
+				}
+			}
+		}
+	}
+
+	var picked []coin
+	for i, ok := range best {
+		if ok {
+			picked = append(picked, coins[i])
+		}
+	}
+	return picked, bestValue
+}
+
+func (s KnapsackSelector) intn(n int) int {
+	if s.Rand != nil {
+		return s.Rand.Intn(n)
+	}
+	return rand.Intn(n)
+}
+
+func (s KnapsackSelector) shuffle(n int, swap func(i, j int)) {
+	if s.Rand != nil {
+		s.Rand.Shuffle(n, swap)
+		return
+	}
+	rand.Shuffle(n, swap)
+}
+
+// coin is a UTXO priced at the request fee rate
+type coin struct {
+	utxo   UTXO
+	weight int
+	// effective is the amount left after paying to spend the UTXO
+	effective int64
+}
+
+// candidates prices the spendable UTXOs, dropping unconfirmed ones and dust that costs more
+// to spend than it is worth
+func (r SelectionRequest) candidates(utxos []UTXO) []coin {
+	coins := make([]coin, 0, len(utxos))
+	for _, utxo := range utxos {
+		if utxo.Confirmations < r.MinConfirmations {
+			continue
+		}
+		weight := inputWeight(utxoScript(utxo, r.ChangeScript))
+		// Charging segwit inputs for the witness header keeps adding a coin from ever lowering the surplus
+		cost := weight
+		if weight != p2pkhInputWeight {
+			cost += witnessHeaderWeight
+		}
+		effective := utxo.Amount - feeForWeight(cost, r.FeeRate)
+		if effective <= 0 {
+			continue
+		}
+		coins = append(coins, coin{utxo: utxo, weight: weight, effective: effective})
+	}
+	return coins
+}
+
+// accumulate adds coins in order until they pay for the target and fees
+func (r SelectionRequest) accumulate(coins []coin) (*Selection, error) {
+	for i := range coins {
+		if selection, ok := r.settle(coins[:i+1], true); ok {
+			return selection, nil
+		}
+	}
+	return nil, r.insufficient(coins)
+}
+
+// settle prices a transaction spending coins. Change is only created when allowChange is set
+// and it reaches the dust limit; otherwise the excess goes to the fee
+func (r SelectionRequest) settle(coins []coin, allowChange bool) (*Selection, bool) {
+	if len(coins) == 0 {
+		return nil, false
+	}
+	selection := &Selection{UTXOs: make([]UTXO, len(coins))}
+	weights := make([]int, len(coins))
+	var total int64
+	for i, c := range coins {
+		selection.UTXOs[i] = c.utxo
+		weights[i] = c.weight
+		total += c.utxo.Amount
+	}
+
+	weight := estimateWeight(weights, [][]byte{r.RecipientScript})
+	if total < r.Target+feeForWeight(weight, r.FeeRate) {
+		return nil, false
+	}
+	if allowChange {
+		fee := feeForWeight(weight+outputWeight(r.ChangeScript), r.FeeRate)
+		if change := total - r.Target - fee; change > 0 && change >= r.DustLimit {
+			selection.Fee, selection.Change = fee, change
+			return selection, true
+		}
+	}
+	selection.Fee = total - r.Target
+	return selection, true
+}
+
+func (r SelectionRequest) insufficient(coins []coin) error {
+	var total int64
+	for _, c := range coins {
+		total += c.utxo.Amount
+	}
+	return fmt.Errorf("insufficient funds: need %d plus fees, have %d spendable", r.Target, total)
+}
+
+// utxoScript returns the script locking a UTXO, or fallback when the UTXO does not say;
+// unparseable scripts return nil and are sized as legacy inputs
+func utxoScript(utxo UTXO, fallback []byte) []byte {
+	switch {
+	case utxo.ScriptPubKey != "":
+		script, err := hex.DecodeString(utxo.ScriptPubKey)
+		if err != nil {
+			return nil
+		}
+		return script
+	case utxo.Address != "":
+		script, err := addressScript(utxo.Address)
+		if err != nil {
+			return nil
+		}
+		return script
+	default:
+		return fallback
+	}
+}
+
+// inputWeight returns the weight of an input spending script. P2SH outputs are assumed
+// to wrap P2WPKH, and unknown scripts are sized as the larger legacy P2PKH input
+func inputWeight(script []byte) int {
+	switch {
+	case isPayToWitnessPubKeyHash(script):
+		return p2wpkhInputWeight
+	case isPayToTaproot(script):
+		return p2trInputWeight
+	case isPayToScriptHash(script):
+		return p2shP2wpkhInputWeight
+	default:
+		return p2pkhInputWeight
+	}
+}
+
+// outputWeight returns the weight of an output paying to script
+func outputWeight(script []byte) int {
+	return (8 + varIntSize(uint64(len(script))) + len(script)) * witnessScaleFactor
+}
+
+// estimateWeight returns the weight of a transaction with the given input weights and outputs
+func estimateWeight(inputs []int, outputs [][]byte) int {
+	weight := txOverheadWeight
+	segwit := false
+	for _, w := range inputs {
+		weight += w
+		if w != p2pkhInputWeight {
+			segwit = true
+		}
+	}
+	for _, script := range outputs {
+		weight += outputWeight(script)
+	}
+	if segwit {
+		weight += witnessHeaderWeight
+	}
+	return weight
+}
+
+// feeForWeight returns the fee of weight at feeRate satoshis per vbyte
+func feeForWeight(weight int, feeRate int64) int64 {
+	vsize := (weight + witnessScaleFactor - 1) / witnessScaleFactor
+	return int64(vsize) * feeRate
+}
+
+// dustThreshold returns the smallest standard output to script: below it the output costs
+// more to create and spend at the dust relay fee than it is worth
+func dustThreshold(script []byte) int64 {
+	spendSize := p2pkhInputWeight / witnessScaleFactor
+	if isWitnessProgram(script) {
+		spendSize = 67
+	}
+	return int64(outputWeight(script)/witnessScaleFactor+spendSize) * dustRelayFeeRate
+}
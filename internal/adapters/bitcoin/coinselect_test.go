@@ -0,0 +1,291 @@
+package bitcoin
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	p2wpkhTestScript = payToWitnessScript(0, make([]byte, 20))
+	p2pkhTestScript  = payToPubKeyHashScript(make([]byte, 20))
+	p2shTestScript   = payToScriptHashScript(make([]byte, 20))
+	p2trTestScript   = payToWitnessScript(1, make([]byte, 32))
+)
+
+func testUTXOs(script []byte, amounts ...int64) []UTXO {
+	utxos := make([]UTXO, len(amounts))
+	for i, amount := range amounts {
+		utxos[i] = UTXO{
+			TxID:          fmt.Sprintf("%064x", i+1),
+			ScriptPubKey:  hex.EncodeToString(script),
+			Amount:        amount,
+			Confirmations: 6,
+		}
+	}
+	return utxos
+}
+
+func testSelectionRequest(target, feeRate int64) SelectionRequest {
+	return SelectionRequest{
+		Target:          target,
+		FeeRate:         feeRate,
+		RecipientScript: p2wpkhTestScript,
+		ChangeScript:    p2wpkhTestScript,
+		DustLimit:       dustThreshold(p2wpkhTestScript),
+	}
+}
+
+func selectedAmounts(selection *Selection) []int64 {
+	amounts := make([]int64, len(selection.UTXOs))
+	for i, utxo := range selection.UTXOs {
+		amounts[i] = utxo.Amount
+	}
+	return amounts
+}
+
+// assertBalanced checks the invariants every selection must hold
+func assertBalanced(t *testing.T, utxos []UTXO, req SelectionRequest, selection *Selection) {
+	t.Helper()
+	var total int64
+	weights := make([]int, len(selection.UTXOs))
+	for i, utxo := range selection.UTXOs {
+		total += utxo.Amount
+		weights[i] = inputWeight(utxoScript(utxo, req.ChangeScript))
+		assert.GreaterOrEqual(t, utxo.Confirmations, req.MinConfirmations)
+	}
+	require.Equal(t, total, req.Target+selection.Fee+selection.Change, "selected amount must equal target + fee + change")
+
+	outputs := [][]byte{req.RecipientScript}
+	if selection.Change > 0 {
+		assert.GreaterOrEqual(t, selection.Change, req.DustLimit)
+		outputs = append(outputs, req.ChangeScript)
+	}
+	assert.GreaterOrEqual(t, selection.Fee, feeForWeight(estimateWeight(weights, outputs), req.FeeRate))
+
+	seen := make(map[string]bool)
+	for _, utxo := range selection.UTXOs {
+		outpoint := fmt.Sprintf("%s:%d", utxo.TxID, utxo.Vout)
+		assert.False(t, seen[outpoint], "UTXO selected twice")
+		seen[outpoint] = true
+	}
+	assert.LessOrEqual(t, len(selection.UTXOs), len(utxos))
+}
+
+func TestNewCoinSelector(t *testing.T) {
+	tests := []struct {
+		strategy string
+		expected CoinSelector
+	}{
+		{"", BranchAndBoundSelector{Fallback: KnapsackSelector{}}},
+		{CoinSelectionBranchAndBound, BranchAndBoundSelector{Fallback: KnapsackSelector{}}},
+		{CoinSelectionLargestFirst, LargestFirstSelector{}},
+		{CoinSelectionSmallestFirst, SmallestFirstSelector{}},
+		{CoinSelectionKnapsack, KnapsackSelector{}},
+	}
+	for _, tt := range tests {
+		selector, err := NewCoinSelector(tt.strategy)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, selector)
+	}
+
+	_, err := NewCoinSelector("random")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown coin selection strategy")
+}
+
+func TestLargestFirstSelector(t *testing.T) {
+	utxos := testUTXOs(p2wpkhTestScript, 10000, 80000, 30000)
+	req := testSelectionRequest(50000, 2)
+
+	selection, err := LargestFirstSelector{}.Select(utxos, req)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{80000}, selectedAmounts(selection))
+	// 1 P2WPKH input and 2 P2WPKH outputs: 141 vbytes
+	assert.Equal(t, int64(282), selection.Fee)
+	assert.Equal(t, int64(80000-50000-282), selection.Change)
+	assertBalanced(t, utxos, req, selection)
+
+	_, err = LargestFirstSelector{}.Select(utxos, testSelectionRequest(120000, 2))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "insufficient funds")
+}
+
+func TestSmallestFirstSelector(t *testing.T) {
+	utxos := testUTXOs(p2wpkhTestScript, 10000, 80000, 30000, 20000)
+	req := testSelectionRequest(50000, 2)
+
+	selection, err := SmallestFirstSelector{}.Select(utxos, req)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{10000, 20000, 30000}, selectedAmounts(selection))
+	assertBalanced(t, utxos, req, selection)
+}
+
+func TestBranchAndBoundSelector(t *testing.T) {
+	t.Run("changeless match", func(t *testing.T) {
+		req := testSelectionRequest(50000, 1)
+		// The base cost of overhead and the recipient output is 42 satoshis and each input costs 69
+		utxos := testUTXOs(p2wpkhTestScript, 100000, 30042+69, 20000+69, 70000, 45)
+
+		selection, err := BranchAndBoundSelector{}.Select(utxos, req)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []int64{30111, 20069}, selectedAmounts(selection))
+		assert.Zero(t, selection.Change)
+		assertBalanced(t, utxos, req, selection)
+	})
+
+	t.Run("no match without fallback", func(t *testing.T) {
+		utxos := testUTXOs(p2wpkhTestScript, 100000, 70000)
+
+		_, err := BranchAndBoundSelector{}.Select(utxos, testSelectionRequest(50000, 1))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no changeless input set")
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		utxos := testUTXOs(p2wpkhTestScript, 100000, 70000)
+		req := testSelectionRequest(50000, 1)
+
+		selection, err := BranchAndBoundSelector{Fallback: LargestFirstSelector{}}.Select(utxos, req)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{100000}, selectedAmounts(selection))
+		assert.Positive(t, selection.Change)
+		assertBalanced(t, utxos, req, selection)
+	})
+
+	t.Run("skips uneconomical inputs", func(t *testing.T) {
+		utxos := testUTXOs(p2wpkhTestScript, 100, 100, 100)
+
+		_, err := BranchAndBoundSelector{Fallback: LargestFirstSelector{}}.Select(utxos, testSelectionRequest(150, 5))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "insufficient funds")
+	})
+}
+
+func TestKnapsackSelector(t *testing.T) {
+	selector := KnapsackSelector{Rand: rand.New(rand.NewSource(1))}
+
+	t.Run("single larger coin", func(t *testing.T) {
+		utxos := testUTXOs(p2wpkhTestScript, 1000, 2000, 500000, 900000)
+		req := testSelectionRequest(100000, 1)
+
+		selection, err := selector.Select(utxos, req)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{500000}, selectedAmounts(selection))
+		assertBalanced(t, utxos, req, selection)
+	})
+
+	t.Run("subset of smaller coins", func(t *testing.T) {
+		utxos := testUTXOs(p2wpkhTestScript, 40000, 30000, 35000, 20000, 1000000)
+		req := testSelectionRequest(60000, 1)
+
+		selection, err := selector.Select(utxos, req)
+		require.NoError(t, err)
+		assert.NotContains(t, selectedAmounts(selection), int64(1000000))
+		assertBalanced(t, utxos, req, selection)
+	})
+
+	t.Run("insufficient funds", func(t *testing.T) {
+		_, err := selector.Select(testUTXOs(p2wpkhTestScript, 1000, 2000), testSelectionRequest(100000, 1))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "insufficient funds")
+	})
+}
+
+func TestSelectionDust(t *testing.T) {
+	utxos := testUTXOs(p2wpkhTestScript, 50400)
+	req := testSelectionRequest(50000, 1)
+	req.DustLimit = 200
+
+	// A change output costs 31 satoshis, leaving 259 of the 400 over the target
+	selection, err := LargestFirstSelector{}.Select(utxos, req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(141), selection.Fee)
+	assert.Equal(t, int64(259), selection.Change)
+	assertBalanced(t, utxos, req, selection)
+
+	// Below the 294 satoshi P2WPKH dust threshold the excess goes to the fee instead
+	req.DustLimit = dustThreshold(p2wpkhTestScript)
+	selection, err = LargestFirstSelector{}.Select(utxos, req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(400), selection.Fee)
+	assert.Zero(t, selection.Change)
+	assertBalanced(t, utxos, req, selection)
+}
+
+func TestSelectionMinConfirmations(t *testing.T) {
+	utxos := testUTXOs(p2wpkhTestScript, 90000, 60000)
+	utxos[0].Confirmations = 0
+	req := testSelectionRequest(50000, 1)
+	req.MinConfirmations = 1
+
+	selection, err := LargestFirstSelector{}.Select(utxos, req)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{60000}, selectedAmounts(selection))
+
+	req.Target = 70000
+	_, err = LargestFirstSelector{}.Select(utxos, req)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "insufficient funds")
+}
+
+func TestInputWeight(t *testing.T) {
+	assert.Equal(t, 592, inputWeight(p2pkhTestScript))
+	assert.Equal(t, 272, inputWeight(p2wpkhTestScript))
+	assert.Equal(t, 364, inputWeight(p2shTestScript))
+	assert.Equal(t, 230, inputWeight(p2trTestScript))
+	assert.Equal(t, 592, inputWeight(nil))
+}
+
+func TestDustThreshold(t *testing.T) {
+	assert.Equal(t, int64(546), dustThreshold(p2pkhTestScript))
+	assert.Equal(t, int64(540), dustThreshold(p2shTestScript))
+	assert.Equal(t, int64(294), dustThreshold(p2wpkhTestScript))
+	assert.Equal(t, int64(330), dustThreshold(p2trTestScript))
+}
+
+func TestCoinSelectionProperties(t *testing.T) {
+	scripts := [][]byte{p2pkhTestScript, p2wpkhTestScript, p2shTestScript, p2trTestScript}
+	rng := rand.New(rand.NewSource(42))
+	selectors := map[string]CoinSelector{
+		CoinSelectionBranchAndBound: BranchAndBoundSelector{Fallback: KnapsackSelector{Rand: rng}},
+		CoinSelectionLargestFirst:   LargestFirstSelector{},
+		CoinSelectionSmallestFirst:  SmallestFirstSelector{},
+		CoinSelectionKnapsack:       KnapsackSelector{Rand: rng},
+	}
+
+	for i := 0; i < 500; i++ {
+		utxos := make([]UTXO, 1+rng.Intn(12))
+		for j := range utxos {
+			utxos[j] = UTXO{
+				TxID:          fmt.Sprintf("%064x", j+1),
+				ScriptPubKey:  hex.EncodeToString(scripts[rng.Intn(len(scripts))]),
+				Amount:        int64(rng.Intn(200000)) + 1,
+				Confirmations: int64(rng.Intn(4)),
+			}
+		}
+		req := SelectionRequest{
+			Target:           int64(rng.Intn(300000)) + 1,
+			FeeRate:          int64(rng.Intn(50)) + 1,
+			RecipientScript:  scripts[rng.Intn(len(scripts))],
+			ChangeScript:     scripts[rng.Intn(len(scripts))],
+			MinConfirmations: int64(rng.Intn(3)),
+		}
+		req.DustLimit = dustThreshold(req.ChangeScript)
+
+		_, reference := LargestFirstSelector{}.Select(utxos, req)
+		for name, selector := range selectors {
+			selection, err := selector.Select(utxos, req)
+			if reference != nil {
+				require.Error(t, err, "case %d: %s selected coins largest-first could not", i, name)
+				continue
+			}
+			require.NoError(t, err, "case %d: %s failed where largest-first succeeded", i, name)
+			assertBalanced(t, utxos, req, selection)
+		}
+	}
+}
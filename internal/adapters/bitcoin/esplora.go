@@ -0,0 +1,217 @@
+package bitcoin
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultHTTPTimeout = 30 * time.Second
+
+// EsploraClient implements RPCClient over the Esplora REST API (Blockstream, mempool.space)
+type EsploraClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// esploraStatus is the confirmation status of an Esplora transaction or output
+type esploraStatus struct {
+	Confirmed   bool   `json:"confirmed"`
+	BlockHeight int64  `json:"block_height"`
+	BlockHash   string `json:"block_hash"`
+	BlockTime   int64  `json:"block_time"`
+}
+
+// esploraStats sums the outputs funding and spent by an address
+type esploraStats struct {
+	FundedTxoSum int64 `json:"funded_txo_sum"`
+	SpentTxoSum  int64 `json:"spent_txo_sum"`
+}
+
+// NewEsploraClient creates a new Esplora client for an API base URL such as https://blockstream.info/api
+func NewEsploraClient(baseURL string, httpClient *http.Client) *EsploraClient {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultHTTPTimeout}
+	}
+	return &EsploraClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: httpClient,
+	}
+}
+
+// GetBlockCount returns the height of the chain tip
+func (c *EsploraClient) GetBlockCount(ctx context.Context) (int64, error) {
+	body, err := c.do(ctx, http.MethodGet, "/blocks/tip/height", "")
+	if err != nil {
+		return 0, err
+	}
+	height, err := strconv.ParseInt(strings.TrimSpace(string(body)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid block height %q: %w", body, err)
+	}
+	return height, nil
+}
+
+// GetBalance returns the confirmed and unconfirmed balance of an address in satoshis
+func (c *EsploraClient) GetBalance(ctx context.Context, address string) (*big.Int, error) {
+	var info struct {
+		ChainStats   esploraStats `json:"chain_stats"`
+		MempoolStats esploraStats `json:"mempool_stats"`
+	}
+	if err := c.getJSON(ctx, "/address/"+address, &info); err != nil {
+		return nil, err
+	}
+	balance := info.ChainStats.FundedTxoSum - info.ChainStats.SpentTxoSum +
+		info.MempoolStats.FundedTxoSum - info.MempoolStats.SpentTxoSum
+	return big.NewInt(balance), nil
+}
+
+// ListUnspent returns the unspent outputs of an address, including unconfirmed ones
+func (c *EsploraClient) ListUnspent(ctx context.Context, address string) ([]UTXO, error) {
+	var outputs []struct {
+		TxID   string        `json:"txid"`
+		Vout   uint32        `json:"vout"`
+		Value  int64         `json:"value"`
+		Status esploraStatus `json:"status"`
+	}
+	if err := c.getJSON(ctx, "/address/"+address+"/utxo", &outputs); err != nil {
+		return nil, err
+	}
+
+	// Esplora returns no scripts for UTXOs; every output of an address pays the same script
+	var script string
+	if decoded, err := decodeAddress(address); err == nil {
+		script = hex.EncodeToString(decoded.ScriptPubKey())
+	}
+	var tip int64
+	utxos := make([]UTXO, len(outputs))
+	for i, out := range outputs {
+		var confirmations int64
+		if out.Status.Confirmed {
+			if tip == 0 {
+				height, err := c.GetBlockCount(ctx)
+				if err != nil {
+					return nil, err
+				}
+				tip = height
+			}
+			confirmations = tip - out.Status.BlockHeight + 1
+		}
+		utxos[i] = UTXO{
+			TxID:          out.TxID,
+			Vout:          out.Vout,
+			Address:       address,
+			ScriptPubKey:  script,
+			Amount:        out.Value,
+			Confirmations: confirmations,
+		}
+	}
+	return utxos, nil
+}
+
+// GetRawTransaction returns a transaction with its confirmation status
+func (c *EsploraClient) GetRawTransaction(ctx context.Context, txHash string) (*Transaction, error) {
+	rawTx, err := c.do(ctx, http.MethodGet, "/tx/"+txHash+"/hex", "")
+	if err != nil {
+		return nil, err
+	}
+	tx, err := DecodeRawTransaction(strings.TrimSpace(string(rawTx)))
+	if err != nil {
+		return nil, err
+	}
+
+	var status esploraStatus
+	if err := c.getJSON(ctx, "/tx/"+txHash+"/status", &status); err != nil {
+		return nil, err
+	}
+	if status.Confirmed {
+		tip, err := c.GetBlockCount(ctx)
+		if err != nil {
+			return nil, err
+		}
+		tx.BlockHash = status.BlockHash
+		tx.Confirmations = tip - status.BlockHeight + 1
+		tx.Time = status.BlockTime
+		tx.BlockTime = status.BlockTime
+	}
+	return tx, nil
+}
+
+// SendRawTransaction broadcasts a serialized transaction and returns its ID
+func (c *EsploraClient) SendRawTransaction(ctx context.Context, rawTx string) (string, error) {
+	body, err := c.do(ctx, http.MethodPost, "/tx", rawTx)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+// EstimateFee returns the fee rate, in satoshis per vbyte, to confirm within blocks. Esplora
+// estimates a fixed set of targets, so the estimate of the nearest target not above blocks is used
+func (c *EsploraClient) EstimateFee(ctx context.Context, blocks int) (*big.Int, error) {
+	var estimates map[string]float64
+	if err := c.getJSON(ctx, "/fee-estimates", &estimates); err != nil {
+		return nil, err
+	}
+	target, rate := 0, 0.0
+	for key, value := range estimates {
+		n, err := strconv.Atoi(key)
+		if err != nil || n > blocks {
+			continue
+		}
+		if n > target {
+			target, rate = n, value
+		}
+	}
+	if target == 0 {
+		return nil, fmt.Errorf("no fee estimate for %d blocks", blocks)
+	}
+	return big.NewInt(int64(math.Max(1, math.Ceil(rate)))), nil
+}
+
+// getJSON sends a GET request and decodes the JSON response into result
+func (c *EsploraClient) getJSON(ctx context.Context, path string, result interface{}) error {
+	body, err := c.do(ctx, http.MethodGet, path, "")
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", path, err)
+	}
+	return nil
+}
+
+// do sends a request with a plain text body and returns the response body
+func (c *EsploraClient) do(ctx context.Context, method, path, body string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewBufferString(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "text/plain")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned status %d: %s", path, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return data, nil
+}
@@ -0,0 +1,118 @@
+package bitcoin
+
+import (
+	"context"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEsploraServer(t *testing.T, routes map[string]string) *EsploraClient {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/api/tx" {
+			body, _ := io.ReadAll(r.Body)
+			tx, err := DecodeRawTransaction(string(body))
+			if err != nil {
+				http.Error(w, "sendrawtransaction RPC error: TX decode failed", http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(tx.TxID))
+			return
+		}
+		response, ok := routes[r.URL.Path]
+		if !ok {
+			http.Error(w, "Transaction not found", http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(srv.Close)
+	return NewEsploraClient(srv.URL+"/api/", nil)
+}
+
+func TestEsploraClient(t *testing.T) {
+	ctx := context.Background()
+	signed, err := DecodeRawTransaction(bip143SignedTx)
+	require.NoError(t, err)
+	address := "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"
+
+	client := newEsploraServer(t, map[string]string{
+		"/api/blocks/tip/height":  "800100",
+		"/api/address/" + address: `{"address":"` + address + `","chain_stats":{"funded_txo_sum":150000,"spent_txo_sum":50000},"mempool_stats":{"funded_txo_sum":20000,"spent_txo_sum":5000}}`,
+		"/api/address/" + address + "/utxo": `[{"txid":"` + testPrevTxID + `","vout":1,"status":{"confirmed":true,"block_height":800000},"value":100000},` +
+			`{"txid":"` + genesisCoinbaseID + `","vout":0,"status":{"confirmed":false},"value":20000}]`,
+		"/api/tx/" + signed.TxID + "/hex":    bip143SignedTx + "\n",
+		"/api/tx/" + signed.TxID + "/status": `{"confirmed":true,"block_height":800091,"block_hash":"00000000000000000001","block_time":1700000000}`,
+		"/api/fee-estimates":                 `{"1":87.882,"2":80.1,"3":69.3,"6":21.2,"144":1.027,"1008":0.5}`,
+	})
+
+	height, err := client.GetBlockCount(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(800100), height)
+
+	balance, err := client.GetBalance(ctx, address)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(115000), balance)
+
+	utxos, err := client.ListUnspent(ctx, address)
+	require.NoError(t, err)
+	assert.Equal(t, []UTXO{
+		{TxID: testPrevTxID, Vout: 1, Address: address, ScriptPubKey: "76a91477bff20c60e522dfaa3350c39b030a5d004e839a88ac", Amount: 100000, Confirmations: 101},
+		{TxID: genesisCoinbaseID, Vout: 0, Address: address, ScriptPubKey: "76a91477bff20c60e522dfaa3350c39b030a5d004e839a88ac", Amount: 20000},
+	}, utxos)
+
+	tx, err := client.GetRawTransaction(ctx, signed.TxID)
+	require.NoError(t, err)
+	assert.Equal(t, signed.TxID, tx.TxID)
+	assert.Equal(t, signed.Outputs, tx.Outputs)
+	assert.Equal(t, int64(10), tx.Confirmations)
+	assert.Equal(t, "00000000000000000001", tx.BlockHash)
+	assert.Equal(t, int64(1700000000), tx.BlockTime)
+
+	txid, err := client.SendRawTransaction(ctx, bip143SignedTx)
+	require.NoError(t, err)
+	assert.Equal(t, signed.TxID, txid)
+
+	for blocks, expected := range map[int]int64{1: 88, 4: 70, 6: 22, 500: 2, 2000: 1} {
+		rate, err := client.EstimateFee(ctx, blocks)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(expected), rate, "%d blocks", blocks)
+	}
+}
+
+func TestEsploraClientErrors(t *testing.T) {
+	ctx := context.Background()
+	client := newEsploraServer(t, map[string]string{
+		"/api/blocks/tip/height":           "not a number",
+		"/api/address/broken":              "{",
+		"/api/tx/" + testPrevTxID + "/hex": "zz",
+		"/api/fee-estimates":               `{}`,
+	})
+
+	_, err := client.GetBlockCount(ctx)
+	assert.ErrorContains(t, err, "invalid block height")
+
+	_, err = client.GetBalance(ctx, "broken")
+	assert.ErrorContains(t, err, "failed to decode")
+
+	_, err = client.ListUnspent(ctx, "unknown")
+	assert.ErrorContains(t, err, "returned status 404: Transaction not found")
+
+	_, err = client.GetRawTransaction(ctx, testPrevTxID)
+	assert.ErrorContains(t, err, "invalid raw transaction hex")
+
+	_, err = client.SendRawTransaction(ctx, "00")
+	assert.ErrorContains(t, err, "TX decode failed")
+
+	_, err = client.EstimateFee(ctx, 6)
+	assert.ErrorContains(t, err, "no fee estimate for 6 blocks")
+
+	_, err = NewEsploraClient("http://127.0.0.1:1", nil).GetBlockCount(ctx)
+	assert.Error(t, err)
+}
@@ -0,0 +1,322 @@
+package bitcoin
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+)
+
+const (
+	// MetadataReplaces holds the ID of the transaction a replace-by-fee bump replaces
+	MetadataReplaces = "replaces"
+	// MetadataParent holds the ID of the unconfirmed transaction a CPFP child spends
+	MetadataParent = "parent"
+)
+
+// incrementalRelayFeeRate is the fee rate, in satoshis per vbyte, a replacement must pay on top
+// of the fees of the transaction it replaces (BIP-125 rule 4)
+const incrementalRelayFeeRate = 1
+
+// BumpFee replaces an unconfirmed transaction signalling BIP-125 with one spending the same inputs
+// at feeRate satoshis per vbyte, paying the extra fee out of the change returned to the key.
+// The returned transaction is signed and ready to broadcast
+func (a *Adapter) BumpFee(ctx context.Context, hash *valueobjects.Hash, feeRate *big.Int, keys ports.KeyManager, keyID string) (*entities.Transaction, error) {
+	if !a.params.SupportsRBF {
+		return nil, fmt.Errorf("replace-by-fee is not supported on %s", a.GetChainID())
+	}
+	rate, err := feeRateValue(feeRate)
+	if err != nil {
+		return nil, err
+	}
+	key, err := newSigningKey(ctx, keys, keyID)
+	if err != nil {
+		return nil, err
+	}
+	original, err := a.unconfirmedTransaction(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if !signalsReplacement(original) {
+		return nil, fmt.Errorf("transaction %s does not signal replace-by-fee (BIP-125)", original.txid())
+	}
+
+	utxos, inputTotal, err := a.spentOutputs(ctx, original)
+	if err != nil {
+		return nil, err
+	}
+	owned := keyOwns(key)
+	for i, utxo := range utxos {
+		script, _ := hex.DecodeString(utxo.ScriptPubKey)
+		if !owned(script) {
+			return nil, fmt.Errorf("input %d of transaction %s is not spendable by the key", i, original.txid())
+		}
+	}
+	oldFee := inputTotal - outputTotal(original)
+	if rate*int64(original.vsize()) <= oldFee {
+		return nil, fmt.Errorf("fee rate %d sat/vB does not exceed the current fee rate of transaction %s", rate, original.txid())
+	}
+
+	// The last output returning funds to the key is the change
+	change := -1
+	for i, out := range original.outputs {
+		if owned(out.pkScript) {
+			change = i
+		}
+	}
+	if change < 0 {
+		return nil, fmt.Errorf("transaction %s has no change output to pay the fee bump from", original.txid())
+	}
+
+	replacement := original.copyTx()
+	for _, in := range replacement.inputs {
+		in.scriptSig, in.witness = nil, nil
+	}
+	fee := replacementFee(replacement, utxos, rate, oldFee)
+	replacement.outputs[change].value -= fee - oldFee
+	if changeOut := replacement.outputs[change]; changeOut.value < a.params.dustThreshold(changeOut.pkScript) {
+		// Dust change is dropped and left to the fee, which must still cover the replacement
+		replacement.outputs = append(replacement.outputs[:change], replacement.outputs[change+1:]...)
+		if len(replacement.outputs) == 0 || inputTotal-outputTotal(replacement) < replacementFee(replacement, utxos, rate, oldFee) {
+			return nil, fmt.Errorf("change of transaction %s cannot pay a fee rate of %d sat/vB", original.txid(), rate)
+		}
+		change = -1
+	}
+
+	if err := signInputs(ctx, replacement, utxos, "", key, a.params); err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	recipient := 0
+	if recipient == change && len(replacement.outputs) > 1 {
+		recipient = 1
+	}
+	var changeAmount int64
+	if change >= 0 && change != recipient {
+		changeAmount = replacement.outputs[change].value
+	}
+	tx, err := a.signedTransaction(replacement, utxos, recipient, rate)
+	if err != nil {
+		return nil, err
+	}
+	tx.SetMetadata(MetadataChangeAmount, big.NewInt(changeAmount).String())
+	tx.SetMetadata(MetadataFee, big.NewInt(inputTotal-outputTotal(replacement)).String())
+	tx.SetMetadata(MetadataReplaces, original.txid())
+	return tx, nil
+}
+
+// CPFP spends the output an unconfirmed transaction pays to the key with a child paying enough fee
+// for the parent and child together to reach feeRate satoshis per vbyte (child-pays-for-parent).
+// The returned transaction is signed and ready to broadcast
+func (a *Adapter) CPFP(ctx context.Context, hash *valueobjects.Hash, feeRate *big.Int, keys ports.KeyManager, keyID string) (*entities.Transaction, error) {
+	rate, err := feeRateValue(feeRate)
+	if err != nil {
+		return nil, err
+	}
+	key, err := newSigningKey(ctx, keys, keyID)
+	if err != nil {
+		return nil, err
+	}
+	parent, err := a.unconfirmedTransaction(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	_, inputTotal, err := a.spentOutputs(ctx, parent)
+	if err != nil {
+		return nil, err
+	}
+	parentFee := inputTotal - outputTotal(parent)
+
+	owned := keyOwns(key)
+	spent := -1
+	for i, out := range parent.outputs {
+		if owned(out.pkScript) {
+			spent = i
+		}
+	}
+	if spent < 0 {
+		return nil, fmt.Errorf("no output of transaction %s is spendable by the key", parent.txid())
+	}
+	out := parent.outputs[spent]
+	utxo := UTXO{
+		TxID:         parent.txid(),
+		Vout:         uint32(spent),
+		ScriptPubKey: hex.EncodeToString(out.pkScript),
+		Amount:       out.value,
+	}
+
+	childWeight := estimateWeight([]int{inputWeight(out.pkScript)}, [][]byte{out.pkScript})
+	childVSize := int64((childWeight + witnessScaleFactor - 1) / witnessScaleFactor)
+	childFee := rate*(int64(parent.vsize())+childVSize) - parentFee
+	if childFee <= rate*childVSize {
+		return nil, fmt.Errorf("transaction %s already pays a fee rate of %d sat/vB", parent.txid(), rate)
+	}
+	if out.value-childFee < a.params.dustThreshold(out.pkScript) {
+		return nil, fmt.Errorf("output %s:%d cannot pay a child fee of %d satoshis", utxo.TxID, spent, childFee)
+	}
+
+	prevHash, err := parseTxID(utxo.TxID)
+	if err != nil {
+		return nil, err
+	}
+	sequence := uint32(sequenceFinal)
+	if a.params.SupportsRBF {
+		sequence = sequenceRBF
+	}
+	child := &msgTx{
+		version: txVersion,
+		inputs:  []*txIn{{prevHash: prevHash, prevIndex: uint32(spent), sequence: sequence}},
+		outputs: []*txOut{{value: out.value - childFee, pkScript: out.pkScript}},
+	}
+	if err := signInputs(ctx, child, []UTXO{utxo}, "", key, a.params); err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	tx, err := a.signedTransaction(child, []UTXO{utxo}, 0, rate)
+	if err != nil {
+		return nil, err
+	}
+	tx.SetMetadata(MetadataChangeAmount, "0")
+	tx.SetMetadata(MetadataFee, big.NewInt(childFee).String())
+	tx.SetMetadata(MetadataParent, parent.txid())
+	return tx, nil
+}
+
+// unconfirmedTransaction fetches a transaction that is still waiting in the mempool
+func (a *Adapter) unconfirmedTransaction(ctx context.Context, hash *valueobjects.Hash) (*msgTx, error) {
+	btcTx, err := a.rpcClient.GetRawTransaction(ctx, hash.HexWithoutPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	if btcTx.Confirmations > 0 {
+		return nil, fmt.Errorf("transaction %s is already confirmed", btcTx.TxID)
+	}
+	msg, err := wireTransaction(btcTx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction %s: %w", btcTx.TxID, err)
+	}
+	return msg, nil
+}
+
+// spentOutputs returns the outputs spent by the inputs of msg and their total value
+func (a *Adapter) spentOutputs(ctx context.Context, msg *msgTx) ([]UTXO, int64, error) {
+	utxos := make([]UTXO, len(msg.inputs))
+	var total int64
+	for i, in := range msg.inputs {
+		txid := hashToString(in.prevHash[:])
+		prev, err := a.previousTransaction(ctx, txid)
+		if err != nil {
+			return nil, 0, err
+		}
+		if int(in.prevIndex) >= len(prev.outputs) {
+			return nil, 0, fmt.Errorf("input %d spends missing output %s:%d", i, txid, in.prevIndex)
+		}
+		out := prev.outputs[in.prevIndex]
+		utxos[i] = UTXO{TxID: txid, Vout: in.prevIndex, ScriptPubKey: hex.EncodeToString(out.pkScript), Amount: out.value}
+		total += out.value
+	}
+	return utxos, total, nil
+}
+
+// signedTransaction records a signed wire transaction, such as a fee bump, as a transaction paying
+// output recipient
+func (a *Adapter) signedTransaction(msg *msgTx, utxos []UTXO, recipient int, rate int64) (*entities.Transaction, error) {
+	fromScript, err := hex.DecodeString(utxos[0].ScriptPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scriptPubKey: %w", err)
+	}
+	fromAddress, err := scriptAddress(fromScript, a.params)
+	if err != nil {
+		return nil, err
+	}
+	out := msg.outputs[recipient]
+	toAddress, err := scriptAddress(out.pkScript, a.params)
+	if err != nil {
+		return nil, err
+	}
+	from, err := valueobjects.NewAddress(fromAddress.String(), a.GetChainID())
+	if err != nil {
+		return nil, err
+	}
+	to, err := valueobjects.NewAddress(toAddress.String(), a.GetChainID())
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := entities.NewTransaction(entities.TransactionParams{
+		ChainID:  a.GetChainID(),
+		From:     from,
+		To:       to,
+		Value:    big.NewInt(out.value),
+		Nonce:    valueobjects.NewNonce(0),
+		GasPrice: big.NewInt(rate),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+	tx.SetMetadata(MetadataUTXOs, utxos)
+	tx.SetMetadata(MetadataReplaceable, a.params.SupportsRBF && signalsReplacement(msg))
+	if err := applySignedTx(tx, msg); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// replacementFee returns the fee a replacement of a transaction paying oldFee must pay at rate:
+// the rate itself, and at least the incremental relay fee on top of oldFee (BIP-125 rules 3 and 4)
+func replacementFee(msg *msgTx, utxos []UTXO, rate, oldFee int64) int64 {
+	inputs := make([]int, len(utxos))
+	for i, utxo := range utxos {
+		script, _ := hex.DecodeString(utxo.ScriptPubKey)
+		inputs[i] = inputWeight(script)
+	}
+	outputs := make([][]byte, len(msg.outputs))
+	for i, out := range msg.outputs {
+		outputs[i] = out.pkScript
+	}
+	weight := estimateWeight(inputs, outputs)
+	fee := feeForWeight(weight, rate)
+	if minimum := oldFee + feeForWeight(weight, incrementalRelayFeeRate); fee < minimum {
+		fee = minimum
+	}
+	return fee
+}
+
+// signalsReplacement reports whether any input opts the transaction in to replacement (BIP-125)
+func signalsReplacement(msg *msgTx) bool {
+	for _, in := range msg.inputs {
+		if in.sequence <= sequenceRBF {
+			return true
+		}
+	}
+	return false
+}
+
+// keyOwns returns a predicate reporting whether a P2WPKH or P2PKH script is locked to key
+func keyOwns(key *signingKey) func(script []byte) bool {
+	pubKeyHash := hash160(key.pubKey)
+	return func(script []byte) bool {
+		return bytes.Equal(scriptPubKeyHash(script), pubKeyHash)
+	}
+}
+
+func outputTotal(msg *msgTx) int64 {
+	var total int64
+	for _, out := range msg.outputs {
+		total += out.value
+	}
+	return total
+}
+
+// feeRateValue validates a fee rate in satoshis per vbyte
+func feeRateValue(feeRate *big.Int) (int64, error) {
+	if feeRate == nil || feeRate.Sign() <= 0 || !feeRate.IsInt64() {
+		return 0, fmt.Errorf("fee rate must be a positive number of satoshis per vbyte")
+	}
+	return feeRate.Int64(), nil
+}
@@ -0,0 +1,177 @@
+package bitcoin
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// unconfirmedTestTransaction signs a transaction spending the first output of funding with the
+// BIP-143 key, paying value away and change back to the key
+func unconfirmedTestTransaction(t *testing.T, funding *Transaction, value, change int64, sequence uint32) *Transaction {
+	t.Helper()
+	keyScript := payToWitnessScript(0, mustDecodeHex(t, bip143PubKeyHash))
+	prevHash, err := parseTxID(funding.TxID)
+	require.NoError(t, err)
+	msg := &msgTx{
+		version: txVersion,
+		inputs:  []*txIn{{prevHash: prevHash, sequence: sequence}},
+		outputs: []*txOut{
+			{value: value, pkScript: p2wpkhTestScript},
+			{value: change, pkScript: keyScript},
+		},
+	}
+	key, err := newSigningKey(context.Background(), testKeys(t), "test")
+	require.NoError(t, err)
+	utxos := []UTXO{{TxID: funding.TxID, ScriptPubKey: hex.EncodeToString(keyScript), Amount: funding.Outputs[0].Value}}
+	require.NoError(t, signInputs(context.Background(), msg, utxos, "", key, &mainnetParams))
+
+	decoded, err := DecodeRawTransaction(hex.EncodeToString(msg.serialize()))
+	require.NoError(t, err)
+	return decoded
+}
+
+func feeBumpTestAdapter(t *testing.T, txs ...*Transaction) *Adapter {
+	t.Helper()
+	mockRPC := new(MockRPCClient)
+	for _, tx := range txs {
+		mockRPC.On("GetRawTransaction", mock.Anything, tx.TxID).Return(tx, nil)
+	}
+	return NewAdapter(mockRPC, "mainnet")
+}
+
+func testTxHash(t *testing.T, tx *Transaction) *valueobjects.Hash {
+	t.Helper()
+	hash, err := valueobjects.NewHash(tx.TxID)
+	require.NoError(t, err)
+	return hash
+}
+
+func TestBumpFee(t *testing.T) {
+	ctx := context.Background()
+	funding := fundingTransaction(t, payToWitnessScript(0, mustDecodeHex(t, bip143PubKeyHash)))
+
+	t.Run("replaces the transaction at a higher fee rate", func(t *testing.T) {
+		// 1 P2WPKH input and 2 P2WPKH outputs: 141 vbytes paying 10000 satoshis
+		original := unconfirmedTestTransaction(t, funding, 50000000, 49990000, sequenceRBF)
+		adapter := feeBumpTestAdapter(t, funding, original)
+
+		tx, err := adapter.BumpFee(ctx, testTxHash(t, original), big.NewInt(100), testKeys(t), "test")
+		require.NoError(t, err)
+		assert.Equal(t, original.TxID, tx.Metadata()[MetadataReplaces])
+		assert.Equal(t, "14100", tx.Metadata()[MetadataFee])
+		assert.Equal(t, "49985900", tx.Metadata()[MetadataChangeAmount])
+		assert.Equal(t, true, tx.Metadata()[MetadataReplaceable])
+		assert.Equal(t, int64(50000000), tx.Value().Int64())
+		keyAddress := &Address{Type: AddressTypeP2WPKH, Chain: ChainBitcoin, Network: "mainnet", Program: mustDecodeHex(t, bip143PubKeyHash)}
+		assert.Equal(t, keyAddress.String(), tx.From().String())
+
+		replacement, err := DecodeRawTransaction(tx.Metadata()[MetadataRawTransaction].(string))
+		require.NoError(t, err)
+		assert.Equal(t, replacement.TxID, tx.Hash().HexWithoutPrefix())
+		assert.NotEqual(t, original.TxID, replacement.TxID)
+		require.Len(t, replacement.Inputs, 1)
+		assert.Equal(t, original.Inputs[0].TxID, replacement.Inputs[0].TxID)
+		assert.Equal(t, uint32(sequenceRBF), replacement.Inputs[0].Sequence)
+		require.Len(t, replacement.Outputs, 2)
+		assert.Equal(t, int64(49985900), replacement.Outputs[1].Value)
+	})
+
+	t.Run("drops dust change", func(t *testing.T) {
+		// 1000 satoshis of change and a fee of 10000
+		original := unconfirmedTestTransaction(t, funding, 99989000, 1000, sequenceRBF)
+		adapter := feeBumpTestAdapter(t, funding, original)
+
+		// Paying 80 sat/vB leaves less than nothing for change, which is given up to the fee
+		tx, err := adapter.BumpFee(ctx, testTxHash(t, original), big.NewInt(80), testKeys(t), "test")
+		require.NoError(t, err)
+		assert.Equal(t, "11000", tx.Metadata()[MetadataFee])
+		assert.Equal(t, "0", tx.Metadata()[MetadataChangeAmount])
+		replacement, err := DecodeRawTransaction(tx.Metadata()[MetadataRawTransaction].(string))
+		require.NoError(t, err)
+		require.Len(t, replacement.Outputs, 1)
+		assert.Equal(t, int64(99989000), replacement.Outputs[0].Value)
+
+		// Without the change output the transaction is 110 vbytes, which 11000 satoshis pay up to 100 sat/vB
+		_, err = adapter.BumpFee(ctx, testTxHash(t, original), big.NewInt(100), testKeys(t), "test")
+		require.NoError(t, err)
+		_, err = adapter.BumpFee(ctx, testTxHash(t, original), big.NewInt(101), testKeys(t), "test")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot pay a fee rate of 101 sat/vB")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		original := unconfirmedTestTransaction(t, funding, 50000000, 49990000, sequenceRBF)
+		final := unconfirmedTestTransaction(t, funding, 50000000, 49980000, sequenceFinal)
+		adapter := feeBumpTestAdapter(t, funding, original)
+
+		_, err := adapter.BumpFee(ctx, testTxHash(t, original), big.NewInt(0), testKeys(t), "test")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fee rate must be a positive number")
+
+		_, err = adapter.BumpFee(ctx, testTxHash(t, original), big.NewInt(50), testKeys(t), "test")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not exceed the current fee rate")
+
+		_, err = adapter.BumpFee(ctx, testTxHash(t, original), big.NewInt(100), testKeys(t), "one")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is not spendable by the key")
+
+		_, err = feeBumpTestAdapter(t, funding, final).BumpFee(ctx, testTxHash(t, final), big.NewInt(100), testKeys(t), "test")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not signal replace-by-fee")
+
+		confirmed := *original
+		confirmed.Confirmations = 1
+		_, err = feeBumpTestAdapter(t, &confirmed).BumpFee(ctx, testTxHash(t, original), big.NewInt(100), testKeys(t), "test")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is already confirmed")
+	})
+}
+
+func TestCPFP(t *testing.T) {
+	ctx := context.Background()
+	funding := fundingTransaction(t, payToWitnessScript(0, mustDecodeHex(t, bip143PubKeyHash)))
+	// A parent of 141 vbytes paying 1410 satoshis, 10 sat/vB
+	parent := unconfirmedTestTransaction(t, funding, 50000000, 49998590, sequenceFinal)
+	adapter := feeBumpTestAdapter(t, funding, parent)
+
+	t.Run("spends the change with a high-fee child", func(t *testing.T) {
+		tx, err := adapter.CPFP(ctx, testTxHash(t, parent), big.NewInt(50), testKeys(t), "test")
+		require.NoError(t, err)
+		assert.Equal(t, parent.TxID, tx.Metadata()[MetadataParent])
+
+		child, err := DecodeRawTransaction(tx.Metadata()[MetadataRawTransaction].(string))
+		require.NoError(t, err)
+		require.Len(t, child.Inputs, 1)
+		assert.Equal(t, parent.TxID, child.Inputs[0].TxID)
+		assert.Equal(t, uint32(1), child.Inputs[0].Vout)
+		require.Len(t, child.Outputs, 1)
+		assert.Equal(t, parent.Outputs[1].ScriptPubKey, child.Outputs[0].ScriptPubKey)
+
+		// The child pays for both transactions at 50 sat/vB
+		childFee := parent.Outputs[1].Value - child.Outputs[0].Value
+		assert.Equal(t, big.NewInt(childFee).String(), tx.Metadata()[MetadataFee])
+		assert.GreaterOrEqual(t, 1410+childFee, int64(50*(parent.VSize+child.VSize)))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := adapter.CPFP(ctx, testTxHash(t, parent), big.NewInt(5), testKeys(t), "test")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already pays a fee rate of 5 sat/vB")
+
+		_, err = adapter.CPFP(ctx, testTxHash(t, parent), big.NewInt(50), testKeys(t), "one")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no output of transaction")
+
+		_, err = adapter.CPFP(ctx, testTxHash(t, parent), nil, testKeys(t), "test")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fee rate must be a positive number")
+	})
+}
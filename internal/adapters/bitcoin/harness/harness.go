@@ -1,7 +1,10 @@
 package harness
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"math/big"
 	"sync"
@@ -18,8 +21,16 @@ type BitcoinHarness struct {
 	transactions map[string]*bitcoin.Transaction
 	mempool      map[string]*bitcoin.Transaction
 	feeRate      *big.Int
+	funded       uint64
 }
 
+const (
+	// maxSequenceRBF is the highest input sequence signalling replaceability (BIP-125)
+	maxSequenceRBF = 0xfffffffd
+	// incrementalRelayFeeRate is the fee rate, in satoshis per vbyte, replacements must add (BIP-125 rule 4)
+	incrementalRelayFeeRate = 1
+)
+
 // NewBitcoinHarness creates a new Bitcoin test harness
 func NewBitcoinHarness() *BitcoinHarness {
 	return &BitcoinHarness{
@@ -85,23 +96,141 @@ func (h *BitcoinHarness) GetRawTransaction(ctx context.Context, txHash string) (
 	return tx, nil
 }
 
-// SendRawTransaction broadcasts a transaction
+// SendRawTransaction decodes a serialized transaction and adds it to the mempool. A transaction
+// spending the same outputs as mempool transactions replaces them under the BIP-125 rules,
+// evicting their descendants too
 func (h *BitcoinHarness) SendRawTransaction(ctx context.Context, rawTx string) (string, error) {
+	tx, err := bitcoin.DecodeRawTransaction(rawTx)
+	if err != nil {
+		return "", err
+	}
+
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
-	// Generate transaction hash
-	txHash := fmt.Sprintf("btc_%s", rawTx[:16])
+	if conflicts := h.conflicts(tx); len(conflicts) > 0 {
+		evicted, err := h.checkReplacement(tx, conflicts)
+		if err != nil {
+			return "", err
+		}
+		for txid := range evicted {
+			delete(h.mempool, txid)
+		}
+	}
+
+	tx.Confirmations = 0
+	h.mempool[tx.TxID] = tx
+	return tx.TxID, nil
+}
+
+// conflicts returns the mempool transactions spending an output tx also spends
+func (h *BitcoinHarness) conflicts(tx *bitcoin.Transaction) []*bitcoin.Transaction {
+	spent := make(map[string]bool, len(tx.Inputs))
+	for _, in := range tx.Inputs {
+		spent[outpoint(in.TxID, in.Vout)] = true
+	}
+	var conflicts []*bitcoin.Transaction
+	for _, pooled := range h.mempool {
+		if pooled.TxID == tx.TxID {
+			continue
+		}
+		for _, in := range pooled.Inputs {
+			if spent[outpoint(in.TxID, in.Vout)] {
+				conflicts = append(conflicts, pooled)
+				break
+			}
+		}
+	}
+	return conflicts
+}
+
+// checkReplacement applies the BIP-125 rules to tx replacing conflicts, returning the
+// transactions it evicts: the conflicts and every mempool descendant of them
+func (h *BitcoinHarness) checkReplacement(tx *bitcoin.Transaction, conflicts []*bitcoin.Transaction) (map[string]bool, error) {
+	fee, err := h.fee(tx)
+	if err != nil {
+		return nil, err
+	}
+	for _, conflict := range conflicts {
+		if !signalsReplacement(conflict) {
+			return nil, fmt.Errorf("txn-mempool-conflict: %s does not signal replaceability", conflict.TxID)
+		}
+		conflictFee, err := h.fee(conflict)
+		if err != nil {
+			return nil, err
+		}
+		// Rule 6: the replacement pays a higher fee rate than every transaction it directly replaces
+		if fee*int64(conflict.VSize) <= conflictFee*int64(tx.VSize) {
+			return nil, fmt.Errorf("insufficient fee, rejecting replacement %s; fee rate does not exceed %s", tx.TxID, conflict.TxID)
+		}
+	}
+
+	evicted := make(map[string]bool)
+	for _, conflict := range conflicts {
+		h.descendants(conflict.TxID, evicted)
+	}
+	var evictedFees int64
+	for txid := range evicted {
+		evictedFee, err := h.fee(h.mempool[txid])
+		if err != nil {
+			return nil, err
+		}
+		evictedFees += evictedFee
+	}
+	// Rules 3 and 4: the replacement pays for what it evicts plus its own relay
+	if fee < evictedFees+incrementalRelayFeeRate*int64(tx.VSize) {
+		return nil, fmt.Errorf("insufficient fee, rejecting replacement %s; new fee %d < %d + %d", tx.TxID, fee, evictedFees, incrementalRelayFeeRate*int64(tx.VSize))
+	}
+	return evicted, nil
+}
+
+// descendants adds txid and the mempool transactions spending its outputs, recursively, to set
+func (h *BitcoinHarness) descendants(txid string, set map[string]bool) {
+	if set[txid] {
+		return
+	}
+	set[txid] = true
+	for _, pooled := range h.mempool {
+		for _, in := range pooled.Inputs {
+			if in.TxID == txid {
+				h.descendants(pooled.TxID, set)
+				break
+			}
+		}
+	}
+}
+
+// fee returns the fee of a transaction whose inputs spend known transactions
+func (h *BitcoinHarness) fee(tx *bitcoin.Transaction) (int64, error) {
+	var fee int64
+	for _, in := range tx.Inputs {
+		prev, exists := h.mempool[in.TxID]
+		if !exists {
+			prev, exists = h.transactions[in.TxID]
+		}
+		if !exists || int(in.Vout) >= len(prev.Outputs) {
+			return 0, fmt.Errorf("missing inputs: %s spends unknown output %s", tx.TxID, outpoint(in.TxID, in.Vout))
+		}
+		fee += prev.Outputs[in.Vout].Value
+	}
+	for _, out := range tx.Outputs {
+		fee -= out.Value
+	}
+	return fee, nil
+}
 
-	// Create mock transaction
-	tx := &bitcoin.Transaction{
-		TxID:          txHash,
-		Hash:          txHash,
-		Confirmations: 0,
+// signalsReplacement reports whether any input of tx opts in to replacement (BIP-125)
+func signalsReplacement(tx *bitcoin.Transaction) bool {
+	for _, in := range tx.Inputs {
+		if in.Sequence <= maxSequenceRBF {
+			return true
+		}
 	}
+	return false
+}
 
-	h.mempool[txHash] = tx
-	return txHash, nil
+func outpoint(txid string, vout uint32) string {
+	return fmt.Sprintf("%s:%d", txid, vout)
 }
 
 // EstimateFee estimates fee for confirmation in N blocks
@@ -134,6 +263,52 @@ func (h *BitcoinHarness) SetBalance(address string, balance *big.Int) {
 	h.balances[address] = new(big.Int).Set(balance)
 }
 
+// Fund confirms a transaction paying amount to script and adds its output as a UTXO of address,
+// so that the funding transaction can be looked up like a real one (test helper)
+func (h *BitcoinHarness) Fund(address string, script []byte, amount int64) (bitcoin.UTXO, error) {
+	h.mu.Lock()
+	h.funded++
+	funded := h.funded
+	h.mu.Unlock()
+
+	// A coinbase-style transaction whose input commits to a counter, so every funding txid is unique
+	var raw bytes.Buffer
+	_ = binary.Write(&raw, binary.LittleEndian, int32(1))
+	raw.WriteByte(1)
+	raw.Write(make([]byte, 32))
+	_ = binary.Write(&raw, binary.LittleEndian, uint32(0xffffffff))
+	raw.WriteByte(8)
+	_ = binary.Write(&raw, binary.LittleEndian, funded)
+	_ = binary.Write(&raw, binary.LittleEndian, uint32(0xffffffff))
+	raw.WriteByte(1)
+	_ = binary.Write(&raw, binary.LittleEndian, amount)
+	raw.WriteByte(byte(len(script)))
+	raw.Write(script)
+	_ = binary.Write(&raw, binary.LittleEndian, uint32(0))
+
+	tx, err := bitcoin.DecodeRawTransaction(hex.EncodeToString(raw.Bytes()))
+	if err != nil {
+		return bitcoin.UTXO{}, err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	tx.Confirmations = 1
+	tx.BlockHash = fmt.Sprintf("block_%d", h.blockHeight)
+	h.transactions[tx.TxID] = tx
+
+	utxo := bitcoin.UTXO{
+		TxID:          tx.TxID,
+		Vout:          0,
+		Address:       address,
+		ScriptPubKey:  hex.EncodeToString(script),
+		Amount:        amount,
+		Confirmations: 1,
+	}
+	h.utxos[address] = append(h.utxos[address], utxo)
+	return utxo, nil
+}
+
 // AddUTXO adds a UTXO for an address (test helper)
 func (h *BitcoinHarness) AddUTXO(address string, utxo bitcoin.UTXO) {
 	h.mu.Lock()
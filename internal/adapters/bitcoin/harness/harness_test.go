@@ -2,10 +2,16 @@ package harness
 
 import (
 	"context"
+	"encoding/hex"
 	"math/big"
 	"testing"
 
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/base58"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/bitcoin"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/keystore"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -13,10 +19,21 @@ import (
 const (
 	testAddress1 = "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"
 	testAddress2 = "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"
-	testRawTx    = "0100000001abcdef1234567890"
+	// Genesis block coinbase transaction
+	testRawTx    = "01000000010000000000000000000000000000000000000000000000000000000000000000ffffffff4d04ffff001d0104455468652054696d65732030332f4a616e2f32303039204368616e63656c6c6f72206f6e206272696e6b206f66207365636f6e64206261696c6f757420666f722062616e6b73ffffffff0100f2052a01000000434104678afdb0fe5548271967f1a67130b7105cd6a828e03909a67962e0ea1f61deb649f6bc3f4cef38c4f35504e51ec112de5c384df7ba0b8d578a4c702b6bf11d5fac00000000"
+	testRawTxID  = "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b"
 	testAddress3 = "1TestAddress"
 )
 
+// testKeys holds the BIP-143 example key as "bip143"
+func testKeys(t *testing.T) ports.KeyManager {
+	t.Helper()
+	key, _ := hex.DecodeString("619c335025c7f4012e556c2a58b2506e30b8511b53ade95ea316fd8c3286feb9")
+	keys, err := keystore.NewManager(keystore.Key{ID: "bip143", Curve: ports.CurveSecp256k1, PrivateKey: key})
+	require.NoError(t, err)
+	return keys
+}
+
 func TestNewBitcoinHarness(t *testing.T) {
 	h := NewBitcoinHarness()
 
@@ -154,13 +171,58 @@ func TestSendRawTransaction(t *testing.T) {
 
 	txHash, err := h.SendRawTransaction(context.Background(), rawTx)
 	require.NoError(t, err)
-	assert.NotEmpty(t, txHash)
-	assert.Contains(t, txHash, "btc_")
+	assert.Equal(t, testRawTxID, txHash)
 
 	// Verify transaction is in mempool
 	tx, err := h.GetRawTransaction(context.Background(), txHash)
 	require.NoError(t, err)
 	assert.Equal(t, int64(0), tx.Confirmations)
+	require.Len(t, tx.Outputs, 1)
+	assert.Equal(t, int64(5000000000), tx.Outputs[0].Value)
+
+	_, err = h.SendRawTransaction(context.Background(), "0100000001abcdef1234567890")
+	require.Error(t, err)
+	assert.Len(t, h.mempool, 1)
+}
+
+func TestAdapterRoundTrip(t *testing.T) {
+	h := NewBitcoinHarness()
+	adapter := bitcoin.NewAdapter(h, "mainnet")
+	ctx := context.Background()
+
+	// P2PKH address and P2WPKH output of the BIP-143 example key
+	keys := testKeys(t)
+	pubKeyHash, _ := hex.DecodeString("1d0f172a0ecb48aee1be1f2687d2963ae33f71a1")
+	sender := base58.CheckEncode(append([]byte{0x00}, pubKeyHash...))
+	h.AddUTXO(sender, bitcoin.UTXO{
+		TxID:          testRawTxID,
+		Vout:          0,
+		ScriptPubKey:  "0014" + hex.EncodeToString(pubKeyHash),
+		Amount:        100000000,
+		Confirmations: 6,
+	})
+
+	from, err := valueobjects.NewAddress(sender, adapter.GetChainID())
+	require.NoError(t, err)
+	to, err := valueobjects.NewAddress(testAddress2, adapter.GetChainID())
+	require.NoError(t, err)
+
+	tx, err := adapter.BuildTransaction(ctx, entities.TransactionParams{From: from, To: to, Value: big.NewInt(40000000), GasPrice: big.NewInt(10)})
+	require.NoError(t, err)
+	require.NoError(t, adapter.SignTransaction(ctx, tx, keys, "bip143"))
+
+	hash, err := adapter.BroadcastTransaction(ctx, tx)
+	require.NoError(t, err)
+	assert.Equal(t, tx.Hash(), hash)
+
+	status, err := adapter.GetTransactionStatus(ctx, hash)
+	require.NoError(t, err)
+	assert.Equal(t, entities.TxStatusPending, status)
+
+	h.MineBlock()
+	status, err = adapter.GetTransactionStatus(ctx, hash)
+	require.NoError(t, err)
+	assert.Equal(t, entities.TxStatusConfirmed, status)
 }
 
 func TestEstimateFee(t *testing.T) {
@@ -278,3 +340,169 @@ func TestConcurrentAccess(t *testing.T) {
 		<-done
 	}
 }
+
+func TestPSBTRoundTrip(t *testing.T) {
+	h := NewBitcoinHarness()
+	adapter := bitcoin.NewAdapter(h, "mainnet")
+	ctx := context.Background()
+
+	keys := testKeys(t)
+	pubKeyHash, _ := hex.DecodeString("1d0f172a0ecb48aee1be1f2687d2963ae33f71a1")
+	sender := base58.CheckEncode(append([]byte{0x00}, pubKeyHash...))
+	h.AddUTXO(sender, bitcoin.UTXO{
+		TxID:          testRawTxID,
+		Vout:          0,
+		ScriptPubKey:  "0014" + hex.EncodeToString(pubKeyHash),
+		Amount:        100000000,
+		Confirmations: 6,
+	})
+
+	from, err := valueobjects.NewAddress(sender, adapter.GetChainID())
+	require.NoError(t, err)
+	to, err := valueobjects.NewAddress(testAddress2, adapter.GetChainID())
+	require.NoError(t, err)
+
+	tx, err := adapter.BuildTransaction(ctx, entities.TransactionParams{From: from, To: to, Value: big.NewInt(40000000), GasPrice: big.NewInt(10)})
+	require.NoError(t, err)
+	psbt, err := adapter.ExportPSBT(ctx, tx)
+	require.NoError(t, err)
+
+	// The PSBT is signed away from the adapter, as an offline signer would
+	signed, err := adapter.SignPSBT(ctx, psbt, keys, "bip143")
+	require.NoError(t, err)
+
+	hash, err := adapter.BroadcastPSBT(ctx, signed)
+	require.NoError(t, err)
+	require.NoError(t, adapter.ImportPSBT(ctx, tx, signed))
+	assert.Equal(t, tx.Hash(), hash)
+
+	h.MineBlock()
+	status, err := adapter.GetTransactionStatus(ctx, hash)
+	require.NoError(t, err)
+	assert.Equal(t, entities.TxStatusConfirmed, status)
+}
+
+func TestFund(t *testing.T) {
+	h := NewBitcoinHarness()
+	script, _ := hex.DecodeString("00141d0f172a0ecb48aee1be1f2687d2963ae33f71a1")
+
+	first, err := h.Fund(testAddress1, script, 50000)
+	require.NoError(t, err)
+	second, err := h.Fund(testAddress1, script, 50000)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.TxID, second.TxID)
+
+	tx, err := h.GetRawTransaction(context.Background(), first.TxID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), tx.Confirmations)
+	require.Len(t, tx.Outputs, 1)
+	assert.Equal(t, int64(50000), tx.Outputs[0].Value)
+	assert.Equal(t, first.ScriptPubKey, tx.Outputs[0].ScriptPubKey)
+
+	utxos, err := h.ListUnspent(context.Background(), testAddress1)
+	require.NoError(t, err)
+	assert.Len(t, utxos, 2)
+}
+
+func TestMempoolReplacement(t *testing.T) {
+	ctx := context.Background()
+	keys := testKeys(t)
+	pubKeyHash, _ := hex.DecodeString("1d0f172a0ecb48aee1be1f2687d2963ae33f71a1")
+	sender := base58.CheckEncode(append([]byte{0x00}, pubKeyHash...))
+	script, _ := hex.DecodeString("0014" + hex.EncodeToString(pubKeyHash))
+
+	send := func(t *testing.T, h *BitcoinHarness, adapter *bitcoin.Adapter, feeRate int64, options map[string]string) (*entities.Transaction, error) {
+		from, err := valueobjects.NewAddress(sender, adapter.GetChainID())
+		require.NoError(t, err)
+		to, err := valueobjects.NewAddress(testAddress2, adapter.GetChainID())
+		require.NoError(t, err)
+		tx, err := adapter.BuildTransaction(ctx, entities.TransactionParams{From: from, To: to, Value: big.NewInt(40000000), GasPrice: big.NewInt(feeRate), Options: options})
+		require.NoError(t, err)
+		require.NoError(t, adapter.SignTransaction(ctx, tx, keys, "bip143"))
+		_, err = adapter.BroadcastTransaction(ctx, tx)
+		return tx, err
+	}
+	setup := func(t *testing.T) (*BitcoinHarness, *bitcoin.Adapter) {
+		h := NewBitcoinHarness()
+		_, err := h.Fund(sender, script, 100000000)
+		require.NoError(t, err)
+		return h, bitcoin.NewAdapter(h, "mainnet")
+	}
+	inMempool := func(h *BitcoinHarness, tx *entities.Transaction) bool {
+		_, exists := h.mempool[tx.Hash().HexWithoutPrefix()]
+		return exists
+	}
+
+	t.Run("replace by fee", func(t *testing.T) {
+		h, adapter := setup(t)
+		original, err := send(t, h, adapter, 10, nil)
+		require.NoError(t, err)
+
+		replacement, err := adapter.BumpFee(ctx, original.Hash(), big.NewInt(30), keys, "bip143")
+		require.NoError(t, err)
+		_, err = adapter.BroadcastTransaction(ctx, replacement)
+		require.NoError(t, err)
+		assert.False(t, inMempool(h, original))
+		assert.True(t, inMempool(h, replacement))
+
+		// The original pays less than the replacement, so it cannot come back
+		_, err = adapter.BroadcastTransaction(ctx, original)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "insufficient fee, rejecting replacement")
+		assert.True(t, inMempool(h, replacement))
+	})
+
+	t.Run("non-signalling transactions are not replaced", func(t *testing.T) {
+		h, adapter := setup(t)
+		original, err := send(t, h, adapter, 10, map[string]string{bitcoin.OptionReplaceable: "false"})
+		require.NoError(t, err)
+
+		_, err = adapter.BumpFee(ctx, original.Hash(), big.NewInt(30), keys, "bip143")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not signal replace-by-fee")
+
+		// A double spend at a higher fee rate is still rejected by the mempool
+		_, err = send(t, h, adapter, 30, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "txn-mempool-conflict")
+		assert.True(t, inMempool(h, original))
+	})
+
+	t.Run("child pays for parent", func(t *testing.T) {
+		h, adapter := setup(t)
+		parent, err := send(t, h, adapter, 2, nil)
+		require.NoError(t, err)
+
+		child, err := adapter.CPFP(ctx, parent.Hash(), big.NewInt(20), keys, "bip143")
+		require.NoError(t, err)
+		_, err = adapter.BroadcastTransaction(ctx, child)
+		require.NoError(t, err)
+		assert.True(t, inMempool(h, parent))
+		assert.True(t, inMempool(h, child))
+
+		// The package of parent and child pays the requested fee rate
+		parentTx := h.mempool[parent.Hash().HexWithoutPrefix()]
+		childTx := h.mempool[child.Hash().HexWithoutPrefix()]
+		parentFee, err := h.fee(parentTx)
+		require.NoError(t, err)
+		childFee, err := h.fee(childTx)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, parentFee+childFee, 20*int64(parentTx.VSize+childTx.VSize))
+
+		// Replacing the parent evicts the child too, and must pay for both
+		replacement, err := adapter.BumpFee(ctx, parent.Hash(), big.NewInt(20), keys, "bip143")
+		require.NoError(t, err)
+		_, err = adapter.BroadcastTransaction(ctx, replacement)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "insufficient fee, rejecting replacement")
+		assert.True(t, inMempool(h, child))
+
+		replacement, err = adapter.BumpFee(ctx, parent.Hash(), big.NewInt(100), keys, "bip143")
+		require.NoError(t, err)
+		_, err = adapter.BroadcastTransaction(ctx, replacement)
+		require.NoError(t, err)
+		assert.False(t, inMempool(h, parent))
+		assert.False(t, inMempool(h, child))
+		assert.True(t, inMempool(h, replacement))
+	})
+}
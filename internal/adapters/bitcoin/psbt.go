@@ -0,0 +1,627 @@
+package bitcoin
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+)
+
+// PSBT key types (BIP-174)
+const (
+	psbtGlobalUnsignedTx = 0x00
+
+	psbtInNonWitnessUTXO     = 0x00
+	psbtInWitnessUTXO        = 0x01
+	psbtInPartialSig         = 0x02
+	psbtInSigHashType        = 0x03
+	psbtInFinalScriptSig     = 0x07
+	psbtInFinalScriptWitness = 0x08
+
+	psbtSeparator = 0x00
+)
+
+var psbtMagic = []byte{'p', 's', 'b', 't', 0xff}
+
+// psbtPacket is a decoded partially signed transaction; entries this package does not
+// interpret are kept verbatim so that PSBTs round-trip through other signers
+type psbtPacket struct {
+	tx      *msgTx
+	unknown map[string][]byte
+	inputs  []*psbtInput
+	outputs []map[string][]byte
+}
+
+type psbtInput struct {
+	nonWitnessUTXO     *msgTx
+	witnessUTXO        *txOut
+	partialSigs        map[string][]byte // signatures keyed by serialized public key
+	sigHashType        uint32
+	finalScriptSig     []byte
+	finalScriptWitness [][]byte
+	unknown            map[string][]byte
+}
+
+// newPSBT wraps an unsigned transaction in an empty PSBT
+func newPSBT(msg *msgTx) *psbtPacket {
+	p := &psbtPacket{tx: msg, unknown: map[string][]byte{}}
+	for range msg.inputs {
+		p.inputs = append(p.inputs, &psbtInput{partialSigs: map[string][]byte{}, unknown: map[string][]byte{}})
+	}
+	for range msg.outputs {
+		p.outputs = append(p.outputs, map[string][]byte{})
+	}
+	return p
+}
+
+// finalized reports whether the input carries its final scriptSig or witness
+func (in *psbtInput) finalized() bool {
+	return in.finalScriptSig != nil || in.finalScriptWitness != nil
+}
+
+// ExportPSBT encodes the unsigned transaction built by CreateTransaction as a base64 PSBT.
+// P2WPKH inputs carry their spent output; P2PKH inputs carry the full previous transaction
+func (a *Adapter) ExportPSBT(ctx context.Context, tx *entities.Transaction) (string, error) {
+	msg, utxos, err := unsignedTx(tx, a.params)
+	if err != nil {
+		return "", fmt.Errorf("failed to build transaction: %w", err)
+	}
+
+	p := newPSBT(msg)
+	for i, utxo := range utxos {
+		script, err := prevOutScript(utxo, tx.From().Value())
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case isPayToWitnessPubKeyHash(script):
+			p.inputs[i].witnessUTXO = &txOut{value: utxo.Amount, pkScript: script}
+		case isPayToPubKeyHash(script):
+			prevTx, err := a.previousTransaction(ctx, utxo.TxID)
+			if err != nil {
+				return "", err
+			}
+			p.inputs[i].nonWitnessUTXO = prevTx
+		default:
+			return "", fmt.Errorf("unsupported script type for input %d: %x", i, script)
+		}
+	}
+	return p.encode(), nil
+}
+
+// SignPSBT adds signatures of the key keyID to every input of the PSBT locked to its public key
+func (a *Adapter) SignPSBT(ctx context.Context, psbt string, keys ports.KeyManager, keyID string) (string, error) {
+	p, err := decodePSBT(psbt)
+	if err != nil {
+		return "", err
+	}
+	key, err := newSigningKey(ctx, keys, keyID)
+	if err != nil {
+		return "", err
+	}
+	if err := p.sign(ctx, key, a.params); err != nil {
+		return "", err
+	}
+	return p.encode(), nil
+}
+
+// CombinePSBT merges PSBTs of the same transaction signed by different parties
+func (a *Adapter) CombinePSBT(psbts ...string) (string, error) {
+	p, err := combinePSBTs(psbts)
+	if err != nil {
+		return "", err
+	}
+	return p.encode(), nil
+}
+
+// FinalizePSBT completes the inputs of a fully signed PSBT and returns the network transaction in hex
+func (a *Adapter) FinalizePSBT(psbt string) (string, error) {
+	p, err := decodePSBT(psbt)
+	if err != nil {
+		return "", err
+	}
+	msg, err := p.extract(a.params)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(msg.serialize()), nil
+}
+
+// BroadcastPSBT finalizes a fully signed PSBT and sends it to the network
+func (a *Adapter) BroadcastPSBT(ctx context.Context, psbt string) (*valueobjects.Hash, error) {
+	rawTx, err := a.FinalizePSBT(psbt)
+	if err != nil {
+		return nil, err
+	}
+
+	txHash, err := a.rpcClient.SendRawTransaction(ctx, rawTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to broadcast transaction: %w", err)
+	}
+
+	hash, err := valueobjects.NewHash(txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create hash: %w", err)
+	}
+	return hash, nil
+}
+
+// ImportPSBT combines and finalizes signed PSBTs of tx and records the signed transaction on it,
+// after which tx can be broadcast with BroadcastTransaction
+func (a *Adapter) ImportPSBT(ctx context.Context, tx *entities.Transaction, psbts ...string) error {
+	msg, utxos, err := unsignedTx(tx, a.params)
+	if err != nil {
+		return fmt.Errorf("failed to build transaction: %w", err)
+	}
+	p, err := combinePSBTs(psbts)
+	if err != nil {
+		return err
+	}
+	if p.tx.txid() != msg.txid() {
+		return fmt.Errorf("PSBT does not spend transaction %s", tx.ID())
+	}
+
+	signed, err := p.extract(a.params)
+	if err != nil {
+		return err
+	}
+	valid, err := verifyInputs(signed, utxos, tx.From().Value(), a.params)
+	if err != nil {
+		return fmt.Errorf("failed to verify PSBT: %w", err)
+	}
+	if !valid {
+		return fmt.Errorf("PSBT signatures do not match the transaction inputs")
+	}
+	return applySignedTx(tx, signed)
+}
+
+// previousTransaction fetches a funding transaction and rebuilds its network serialization
+func (a *Adapter) previousTransaction(ctx context.Context, txid string) (*msgTx, error) {
+	prev, err := a.rpcClient.GetRawTransaction(ctx, txid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get previous transaction %s: %w", txid, err)
+	}
+	msg, err := wireTransaction(prev)
+	if err != nil {
+		return nil, fmt.Errorf("invalid previous transaction %s: %w", txid, err)
+	}
+	if msg.txid() != txid {
+		return nil, fmt.Errorf("previous transaction %s does not match its ID", txid)
+	}
+	return msg, nil
+}
+
+// wireTransaction converts the RPC representation of a transaction back to its wire form
+func wireTransaction(tx *Transaction) (*msgTx, error) {
+	msg := &msgTx{version: tx.Version, lockTime: tx.LockTime}
+	for _, input := range tx.Inputs {
+		prevHash, err := parseTxID(input.TxID)
+		if err != nil {
+			return nil, err
+		}
+		scriptSig, err := hex.DecodeString(input.ScriptSig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scriptSig: %w", err)
+		}
+		in := &txIn{prevHash: prevHash, prevIndex: input.Vout, scriptSig: scriptSig, sequence: input.Sequence}
+		for _, item := range input.Witness {
+			data, err := hex.DecodeString(item)
+			if err != nil {
+				return nil, fmt.Errorf("invalid witness: %w", err)
+			}
+			in.witness = append(in.witness, data)
+		}
+		msg.inputs = append(msg.inputs, in)
+	}
+	for _, output := range tx.Outputs {
+		script, err := hex.DecodeString(output.ScriptPubKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scriptPubKey: %w", err)
+		}
+		msg.outputs = append(msg.outputs, &txOut{value: output.Value, pkScript: script})
+	}
+	return msg, nil
+}
+
+// combinePSBTs decodes PSBTs of the same unsigned transaction and merges them into the first
+func combinePSBTs(psbts []string) (*psbtPacket, error) {
+	if len(psbts) == 0 {
+		return nil, fmt.Errorf("no PSBTs to combine")
+	}
+	combined, err := decodePSBT(psbts[0])
+	if err != nil {
+		return nil, err
+	}
+	for _, encoded := range psbts[1:] {
+		p, err := decodePSBT(encoded)
+		if err != nil {
+			return nil, err
+		}
+		if p.tx.txid() != combined.tx.txid() {
+			return nil, fmt.Errorf("PSBTs spend different transactions")
+		}
+		combined.merge(p)
+	}
+	return combined, nil
+}
+
+// merge adds the entries of other, which must share the unsigned transaction, that p lacks
+func (p *psbtPacket) merge(other *psbtPacket) {
+	mergeEntries(p.unknown, other.unknown)
+	for i, in := range p.inputs {
+		from := other.inputs[i]
+		if in.nonWitnessUTXO == nil {
+			in.nonWitnessUTXO = from.nonWitnessUTXO
+		}
+		if in.witnessUTXO == nil {
+			in.witnessUTXO = from.witnessUTXO
+		}
+		if in.sigHashType == 0 {
+			in.sigHashType = from.sigHashType
+		}
+		if !in.finalized() {
+			in.finalScriptSig = from.finalScriptSig
+			in.finalScriptWitness = from.finalScriptWitness
+		}
+		mergeEntries(in.partialSigs, from.partialSigs)
+		mergeEntries(in.unknown, from.unknown)
+	}
+	for i, out := range p.outputs {
+		mergeEntries(out, other.outputs[i])
+	}
+}
+
+func mergeEntries(dst, src map[string][]byte) {
+	for key, value := range src {
+		if _, ok := dst[key]; !ok {
+			dst[key] = value
+		}
+	}
+}
+
+// prevOut returns the output spent by input index
+func (p *psbtPacket) prevOut(index int) (*txOut, error) {
+	in := p.inputs[index]
+	if in.witnessUTXO != nil {
+		return in.witnessUTXO, nil
+	}
+	if in.nonWitnessUTXO != nil {
+		outputs := in.nonWitnessUTXO.outputs
+		if vout := p.tx.inputs[index].prevIndex; int(vout) < len(outputs) {
+			return outputs[vout], nil
+		}
+	}
+	return nil, fmt.Errorf("input %d has no UTXO information", index)
+}
+
+// sign adds a partial signature to every unfinalized input locked to key
+func (p *psbtPacket) sign(ctx context.Context, key *signingKey, params *ChainParams) error {
+	pubKeyHash := hash160(key.pubKey)
+
+	signed := 0
+	for i, in := range p.inputs {
+		if in.finalized() {
+			continue
+		}
+		prev, err := p.prevOut(i)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(scriptPubKeyHash(prev.pkScript), pubKeyHash) {
+			continue
+		}
+		if in.sigHashType != 0 && in.sigHashType != params.sigHashType() {
+			return fmt.Errorf("unsupported sighash type %d for input %d", in.sigHashType, i)
+		}
+		if isPayToPubKeyHash(prev.pkScript) && !params.SigHashForkID && in.nonWitnessUTXO == nil {
+			return fmt.Errorf("input %d spends a legacy output without its previous transaction", i)
+		}
+		digest, err := inputSigHash(p.tx, i, prev.pkScript, prev.value, params)
+		if err != nil {
+			return err
+		}
+		sig, err := key.sign(ctx, digest)
+		if err != nil {
+			return err
+		}
+		in.partialSigs[string(key.pubKey)] = append(sig, byte(params.sigHashType()))
+		signed++
+	}
+	if signed == 0 {
+		return fmt.Errorf("key %s does not match any PSBT input", key.id)
+	}
+	return nil
+}
+
+// finalize builds the final scriptSig or witness of every signed input
+func (p *psbtPacket) finalize(params *ChainParams) error {
+	for i, in := range p.inputs {
+		if in.finalized() {
+			continue
+		}
+		prev, err := p.prevOut(i)
+		if err != nil {
+			return err
+		}
+		lockedHash := scriptPubKeyHash(prev.pkScript)
+		if lockedHash == nil {
+			return fmt.Errorf("unsupported script type for input %d: %x", i, prev.pkScript)
+		}
+
+		final := &txIn{}
+		for _, pubKey := range sortedKeys(in.partialSigs) {
+			if !bytes.Equal(hash160([]byte(pubKey)), lockedHash) {
+				continue
+			}
+			sig := in.partialSigs[pubKey]
+			valid, err := verifyInputSignature(p.tx, i, prev.pkScript, prev.value, sig, []byte(pubKey), params)
+			if err != nil {
+				return fmt.Errorf("invalid signature for input %d: %w", i, err)
+			}
+			if !valid {
+				return fmt.Errorf("invalid signature for input %d", i)
+			}
+			setInputSignature(final, prev.pkScript, sig, []byte(pubKey))
+			break
+		}
+		if final.scriptSig == nil && final.witness == nil {
+			return fmt.Errorf("input %d is not signed", i)
+		}
+
+		in.finalScriptSig = final.scriptSig
+		if in.finalScriptSig == nil {
+			in.finalScriptSig = []byte{}
+		}
+		in.finalScriptWitness = final.witness
+		in.partialSigs = map[string][]byte{}
+		in.sigHashType = 0
+	}
+	return nil
+}
+
+// extract finalizes the PSBT and returns the signed transaction, verifying every input
+func (p *psbtPacket) extract(params *ChainParams) (*msgTx, error) {
+	if err := p.finalize(params); err != nil {
+		return nil, fmt.Errorf("failed to finalize PSBT: %w", err)
+	}
+
+	msg := p.tx.copyTx()
+	for i, in := range p.inputs {
+		msg.inputs[i].scriptSig = in.finalScriptSig
+		msg.inputs[i].witness = in.finalScriptWitness
+	}
+	for i := range p.inputs {
+		prev, err := p.prevOut(i)
+		if err != nil {
+			return nil, err
+		}
+		valid, err := verifyInput(msg, i, prev.pkScript, prev.value, params)
+		if err != nil {
+			return nil, err
+		}
+		if !valid {
+			return nil, fmt.Errorf("invalid final signature for input %d", i)
+		}
+	}
+	return msg, nil
+}
+
+// encode serializes the PSBT in base64
+func (p *psbtPacket) encode() string {
+	var buf bytes.Buffer
+	buf.Write(psbtMagic)
+	writePSBTEntry(&buf, []byte{psbtGlobalUnsignedTx}, p.tx.serializeNoWitness())
+	writePSBTEntries(&buf, p.unknown)
+	buf.WriteByte(psbtSeparator)
+
+	for _, in := range p.inputs {
+		if in.nonWitnessUTXO != nil {
+			writePSBTEntry(&buf, []byte{psbtInNonWitnessUTXO}, in.nonWitnessUTXO.serialize())
+		}
+		if in.witnessUTXO != nil {
+			var out bytes.Buffer
+			writeUint64(&out, uint64(in.witnessUTXO.value))
+			writeVarBytes(&out, in.witnessUTXO.pkScript)
+			writePSBTEntry(&buf, []byte{psbtInWitnessUTXO}, out.Bytes())
+		}
+		if in.finalized() {
+			if len(in.finalScriptSig) > 0 {
+				writePSBTEntry(&buf, []byte{psbtInFinalScriptSig}, in.finalScriptSig)
+			}
+			if len(in.finalScriptWitness) > 0 {
+				var witness bytes.Buffer
+				writeVarInt(&witness, uint64(len(in.finalScriptWitness)))
+				for _, item := range in.finalScriptWitness {
+					writeVarBytes(&witness, item)
+				}
+				writePSBTEntry(&buf, []byte{psbtInFinalScriptWitness}, witness.Bytes())
+			}
+		} else {
+			for _, pubKey := range sortedKeys(in.partialSigs) {
+				writePSBTEntry(&buf, append([]byte{psbtInPartialSig}, pubKey...), in.partialSigs[pubKey])
+			}
+			if in.sigHashType != 0 {
+				var hashType bytes.Buffer
+				writeUint32(&hashType, in.sigHashType)
+				writePSBTEntry(&buf, []byte{psbtInSigHashType}, hashType.Bytes())
+			}
+		}
+		writePSBTEntries(&buf, in.unknown)
+		buf.WriteByte(psbtSeparator)
+	}
+
+	for _, out := range p.outputs {
+		writePSBTEntries(&buf, out)
+		buf.WriteByte(psbtSeparator)
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+// decodePSBT parses a base64 PSBT
+func decodePSBT(encoded string) (*psbtPacket, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("invalid PSBT base64: %w", err)
+	}
+	if !bytes.HasPrefix(data, psbtMagic) {
+		return nil, fmt.Errorf("invalid PSBT magic bytes")
+	}
+	r := bytes.NewReader(data[len(psbtMagic):])
+
+	global, err := readPSBTMap(r)
+	if err != nil {
+		return nil, err
+	}
+	unsigned, ok := global[string([]byte{psbtGlobalUnsignedTx})]
+	if !ok {
+		return nil, fmt.Errorf("PSBT has no unsigned transaction")
+	}
+	delete(global, string([]byte{psbtGlobalUnsignedTx}))
+	msg, err := deserializeTx(unsigned)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PSBT unsigned transaction: %w", err)
+	}
+	for i, in := range msg.inputs {
+		if len(in.scriptSig) > 0 || len(in.witness) > 0 {
+			return nil, fmt.Errorf("PSBT unsigned transaction input %d is signed", i)
+		}
+	}
+
+	p := newPSBT(msg)
+	p.unknown = global
+	for i := range p.inputs {
+		entries, err := readPSBTMap(r)
+		if err != nil {
+			return nil, err
+		}
+		if p.inputs[i], err = parsePSBTInput(entries); err != nil {
+			return nil, fmt.Errorf("invalid PSBT input %d: %w", i, err)
+		}
+		if prev := p.inputs[i].nonWitnessUTXO; prev != nil && !bytes.Equal(doubleSHA256(prev.serializeNoWitness()), msg.inputs[i].prevHash[:]) {
+			return nil, fmt.Errorf("invalid PSBT input %d: previous transaction does not match", i)
+		}
+	}
+	for i := range p.outputs {
+		if p.outputs[i], err = readPSBTMap(r); err != nil {
+			return nil, err
+		}
+	}
+	if r.Len() != 0 {
+		return nil, fmt.Errorf("unexpected %d trailing PSBT bytes", r.Len())
+	}
+	return p, nil
+}
+
+func parsePSBTInput(entries map[string][]byte) (*psbtInput, error) {
+	in := &psbtInput{partialSigs: map[string][]byte{}, unknown: map[string][]byte{}}
+	for key, value := range entries {
+		keyType, keyData := key[0], key[1:]
+		switch {
+		case keyType == psbtInNonWitnessUTXO && keyData == "":
+			prev, err := deserializeTx(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid previous transaction: %w", err)
+			}
+			in.nonWitnessUTXO = prev
+		case keyType == psbtInWitnessUTXO && keyData == "":
+			r := bytes.NewReader(value)
+			amount, err := readUint64(r)
+			if err != nil {
+				return nil, err
+			}
+			script, err := readVarBytes(r)
+			if err != nil || r.Len() != 0 {
+				return nil, fmt.Errorf("invalid witness UTXO")
+			}
+			in.witnessUTXO = &txOut{value: int64(amount), pkScript: script}
+		case keyType == psbtInPartialSig:
+			if _, err := secp256k1.ParsePubKey([]byte(keyData)); err != nil {
+				return nil, fmt.Errorf("invalid partial signature public key: %w", err)
+			}
+			in.partialSigs[keyData] = value
+		case keyType == psbtInSigHashType && keyData == "":
+			if len(value) != 4 {
+				return nil, fmt.Errorf("invalid sighash type")
+			}
+			in.sigHashType = binary.LittleEndian.Uint32(value)
+		case keyType == psbtInFinalScriptSig && keyData == "":
+			in.finalScriptSig = value
+		case keyType == psbtInFinalScriptWitness && keyData == "":
+			r := bytes.NewReader(value)
+			count, err := readVarInt(r)
+			if err != nil || count > uint64(r.Len()) {
+				return nil, fmt.Errorf("invalid final script witness")
+			}
+			witness := make([][]byte, 0, count)
+			for j := uint64(0); j < count; j++ {
+				item, err := readVarBytes(r)
+				if err != nil {
+					return nil, fmt.Errorf("invalid final script witness: %w", err)
+				}
+				witness = append(witness, item)
+			}
+			if r.Len() != 0 {
+				return nil, fmt.Errorf("invalid final script witness")
+			}
+			in.finalScriptWitness = witness
+		default:
+			in.unknown[key] = value
+		}
+	}
+	if in.finalized() && in.finalScriptSig == nil {
+		in.finalScriptSig = []byte{}
+	}
+	return in, nil
+}
+
+// readPSBTMap reads key-value entries up to the map separator
+func readPSBTMap(r *bytes.Reader) (map[string][]byte, error) {
+	entries := make(map[string][]byte)
+	for {
+		key, err := readVarBytes(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid PSBT key: %w", err)
+		}
+		if len(key) == 0 {
+			return entries, nil
+		}
+		value, err := readVarBytes(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid PSBT value: %w", err)
+		}
+		if _, ok := entries[string(key)]; ok {
+			return nil, fmt.Errorf("duplicate PSBT key %x", key)
+		}
+		entries[string(key)] = value
+	}
+}
+
+func writePSBTEntry(buf *bytes.Buffer, key, value []byte) {
+	writeVarBytes(buf, key)
+	writeVarBytes(buf, value)
+}
+
+// writePSBTEntries writes raw entries in key order so encoding is deterministic
+func writePSBTEntries(buf *bytes.Buffer, entries map[string][]byte) {
+	for _, key := range sortedKeys(entries) {
+		writePSBTEntry(buf, []byte(key), entries[key])
+	}
+}
+
+func sortedKeys(entries map[string][]byte) []string {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,304 @@
+package bitcoin
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fundingTransaction returns a transaction paying 1 BTC to each script, in RPC form
+func fundingTransaction(t *testing.T, scripts ...[]byte) *Transaction {
+	t.Helper()
+	msg := &msgTx{version: 1, inputs: []*txIn{{prevIndex: 0xffffffff, scriptSig: []byte{0x51}, sequence: sequenceFinal}}}
+	for _, script := range scripts {
+		msg.outputs = append(msg.outputs, &txOut{value: 100000000, pkScript: script})
+	}
+	decoded, err := DecodeRawTransaction(hex.EncodeToString(msg.serialize()))
+	require.NoError(t, err)
+	return decoded
+}
+
+func unsignedTestTransaction(t *testing.T, utxos []UTXO, change string) *entities.Transaction {
+	t.Helper()
+	from := testKeyAddress(t)
+	_, to := testAddresses(t)
+	tx, err := entities.NewTransaction(entities.TransactionParams{ChainID: "bitcoin-mainnet", From: from, To: to, Value: big.NewInt(50000000)})
+	require.NoError(t, err)
+	tx.SetMetadata(MetadataUTXOs, utxos)
+	tx.SetMetadata(MetadataChangeAmount, change)
+	return tx
+}
+
+func TestPSBTSingleSigner(t *testing.T) {
+	ctx := context.Background()
+	p2pkh := payToPubKeyHashScript(mustDecodeHex(t, bip143PubKeyHash))
+	funding := fundingTransaction(t, p2pkh)
+	utxos := []UTXO{
+		{TxID: funding.TxID, Vout: 0, Amount: 100000000},
+		{TxID: testPrevTxID, Vout: 1, ScriptPubKey: "0014" + bip143PubKeyHash, Amount: 100000000},
+	}
+
+	mockRPC := new(MockRPCClient)
+	mockRPC.On("GetRawTransaction", mock.Anything, funding.TxID).Return(funding, nil)
+	adapter := NewAdapter(mockRPC, "mainnet")
+	tx := unsignedTestTransaction(t, utxos, "149990000")
+
+	psbt, err := adapter.ExportPSBT(ctx, tx)
+	require.NoError(t, err)
+	mockRPC.AssertExpectations(t)
+
+	exported, err := decodePSBT(psbt)
+	require.NoError(t, err)
+	msg, _, err := unsignedTx(tx, adapter.params)
+	require.NoError(t, err)
+	assert.Equal(t, msg.serializeNoWitness(), exported.tx.serializeNoWitness())
+	require.Len(t, exported.inputs, 2)
+	require.NotNil(t, exported.inputs[0].nonWitnessUTXO)
+	assert.Equal(t, funding.TxID, exported.inputs[0].nonWitnessUTXO.txid())
+	assert.Nil(t, exported.inputs[0].witnessUTXO)
+	require.NotNil(t, exported.inputs[1].witnessUTXO)
+	assert.Equal(t, int64(100000000), exported.inputs[1].witnessUTXO.value)
+	assert.Len(t, exported.outputs, 2)
+	assert.Equal(t, psbt, exported.encode())
+
+	_, err = adapter.FinalizePSBT(psbt)
+	assert.ErrorContains(t, err, "input 0 is not signed")
+
+	signed, err := adapter.SignPSBT(ctx, psbt, testKeys(t), "test")
+	require.NoError(t, err)
+	require.NoError(t, adapter.ImportPSBT(ctx, tx, signed))
+
+	// Deterministic signatures make the PSBT flow match direct signing byte for byte
+	_, direct := signedTestTransaction(t, utxos, "149990000")
+	assert.Equal(t, direct.Metadata()[MetadataRawTransaction], tx.Metadata()[MetadataRawTransaction])
+	assert.Equal(t, direct.Hash(), tx.Hash())
+	valid, err := adapter.VerifySignature(ctx, tx)
+	require.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestPSBTMultipleSigners(t *testing.T) {
+	ctx := context.Background()
+	keyOne, err := newSigningKey(ctx, testKeys(t), "one")
+	require.NoError(t, err)
+	keyOneHash := hex.EncodeToString(hash160(keyOne.pubKey))
+	utxos := []UTXO{
+		{TxID: testPrevTxID, Vout: 0, ScriptPubKey: "0014" + bip143PubKeyHash, Amount: 30000000},
+		{TxID: genesisCoinbaseID, Vout: 1, ScriptPubKey: "0014" + keyOneHash, Amount: 30000000},
+	}
+
+	mockRPC := new(MockRPCClient)
+	adapter := NewAdapter(mockRPC, "mainnet")
+	tx := unsignedTestTransaction(t, utxos, "9990000")
+
+	psbt, err := adapter.ExportPSBT(ctx, tx)
+	require.NoError(t, err)
+	first, err := adapter.SignPSBT(ctx, psbt, testKeys(t), "test")
+	require.NoError(t, err)
+	second, err := adapter.SignPSBT(ctx, psbt, testKeys(t), "one")
+	require.NoError(t, err)
+
+	_, err = adapter.FinalizePSBT(first)
+	assert.ErrorContains(t, err, "input 1 is not signed")
+	err = adapter.ImportPSBT(ctx, tx, second)
+	assert.ErrorContains(t, err, "input 0 is not signed")
+
+	combined, err := adapter.CombinePSBT(first, second)
+	require.NoError(t, err)
+	decoded, err := decodePSBT(combined)
+	require.NoError(t, err)
+	assert.Len(t, decoded.inputs[0].partialSigs, 1)
+	assert.Len(t, decoded.inputs[1].partialSigs, 1)
+
+	rawTx, err := adapter.FinalizePSBT(combined)
+	require.NoError(t, err)
+	signed, err := DecodeRawTransaction(rawTx)
+	require.NoError(t, err)
+	require.Len(t, signed.Inputs, 2)
+	assert.Len(t, signed.Inputs[0].Witness, 2)
+	assert.Len(t, signed.Inputs[1].Witness, 2)
+
+	mockRPC.On("SendRawTransaction", mock.Anything, rawTx).Return(signed.TxID, nil)
+	hash, err := adapter.BroadcastPSBT(ctx, combined)
+	require.NoError(t, err)
+	assert.Equal(t, signed.TxID, hash.HexWithoutPrefix())
+	mockRPC.AssertExpectations(t)
+
+	require.NoError(t, adapter.ImportPSBT(ctx, tx, first, second))
+	assert.Equal(t, rawTx, tx.Metadata()[MetadataRawTransaction])
+	valid, err := adapter.VerifySignature(ctx, tx)
+	require.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestPSBTEncoding(t *testing.T) {
+	ctx := context.Background()
+	utxos := []UTXO{{TxID: testPrevTxID, Vout: 1, ScriptPubKey: "0014" + bip143PubKeyHash, Amount: 100000000}}
+	adapter := NewAdapter(new(MockRPCClient), "mainnet")
+	psbt, err := adapter.ExportPSBT(ctx, unsignedTestTransaction(t, utxos, "49990000"))
+	require.NoError(t, err)
+
+	t.Run("unknown entries are preserved", func(t *testing.T) {
+		p, err := decodePSBT(psbt)
+		require.NoError(t, err)
+		p.unknown["\xfc\x01"] = []byte{1}
+		p.inputs[0].unknown["\x06\x02"] = []byte{2}
+		p.outputs[1]["\x02\x03"] = []byte{3}
+
+		decoded, err := decodePSBT(p.encode())
+		require.NoError(t, err)
+		assert.Equal(t, []byte{1}, decoded.unknown["\xfc\x01"])
+		assert.Equal(t, []byte{2}, decoded.inputs[0].unknown["\x06\x02"])
+		assert.Equal(t, []byte{3}, decoded.outputs[1]["\x02\x03"])
+		assert.Equal(t, p.encode(), decoded.encode())
+	})
+
+	t.Run("finalized inputs round-trip", func(t *testing.T) {
+		signed, err := adapter.SignPSBT(ctx, psbt, testKeys(t), "test")
+		require.NoError(t, err)
+		p, err := decodePSBT(signed)
+		require.NoError(t, err)
+		require.NoError(t, p.finalize(adapter.params))
+		assert.Empty(t, p.inputs[0].partialSigs)
+
+		decoded, err := decodePSBT(p.encode())
+		require.NoError(t, err)
+		assert.True(t, decoded.inputs[0].finalized())
+		assert.Equal(t, p.inputs[0].finalScriptWitness, decoded.inputs[0].finalScriptWitness)
+
+		_, err = adapter.SignPSBT(ctx, p.encode(), testKeys(t), "test")
+		assert.ErrorContains(t, err, "does not match any PSBT input")
+		rawTx, err := adapter.FinalizePSBT(p.encode())
+		require.NoError(t, err)
+		expected, err := adapter.FinalizePSBT(signed)
+		require.NoError(t, err)
+		assert.Equal(t, expected, rawTx)
+	})
+
+	t.Run("invalid encodings", func(t *testing.T) {
+		raw, err := base64.StdEncoding.DecodeString(psbt)
+		require.NoError(t, err)
+		encode := func(data []byte) string { return base64.StdEncoding.EncodeToString(data) }
+
+		for name, encoded := range map[string]string{
+			"base64":         "not base64!",
+			"magic":          encode(append([]byte("psbx"), raw[4:]...)),
+			"truncated":      encode(raw[:len(raw)-1]),
+			"trailing bytes": encode(append(append([]byte(nil), raw...), 0x00)),
+			"no unsigned tx": encode(append(append([]byte(nil), psbtMagic...), psbtSeparator)),
+		} {
+			_, err := decodePSBT(encoded)
+			assert.Error(t, err, name)
+		}
+
+		// A duplicated global unsigned transaction entry
+		entryEnd := len(psbtMagic) + 3 + int(raw[len(psbtMagic)+2])
+		duplicate := append(append([]byte(nil), raw[:entryEnd]...), raw[len(psbtMagic):]...)
+		_, err = decodePSBT(encode(duplicate))
+		assert.ErrorContains(t, err, "duplicate PSBT key")
+	})
+}
+
+func TestPSBTErrors(t *testing.T) {
+	ctx := context.Background()
+	p2wpkh := "0014" + bip143PubKeyHash
+	utxos := []UTXO{{TxID: testPrevTxID, Vout: 1, ScriptPubKey: p2wpkh, Amount: 100000000}}
+
+	t.Run("export", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		adapter := NewAdapter(mockRPC, "mainnet")
+		funding := fundingTransaction(t, payToPubKeyHashScript(mustDecodeHex(t, bip143PubKeyHash)))
+		mockRPC.On("GetRawTransaction", mock.Anything, funding.TxID).Return(funding, nil)
+		mockRPC.On("GetRawTransaction", mock.Anything, testPrevTxID).Return(nil, assert.AnError)
+		mockRPC.On("GetRawTransaction", mock.Anything, genesisCoinbaseID).Return(funding, nil)
+
+		_, err := adapter.ExportPSBT(ctx, unsignedTestTransaction(t, nil, "0"))
+		assert.ErrorContains(t, err, "no selected UTXOs")
+		_, err = adapter.ExportPSBT(ctx, unsignedTestTransaction(t, []UTXO{{TxID: testPrevTxID, ScriptPubKey: "a914b472a266d0bd89c13706a4132ccfb16f7c3b9fcb87"}}, "0"))
+		assert.ErrorContains(t, err, "unsupported script type")
+		_, err = adapter.ExportPSBT(ctx, unsignedTestTransaction(t, []UTXO{{TxID: testPrevTxID, Amount: 100000000}}, "0"))
+		assert.ErrorContains(t, err, "failed to get previous transaction")
+		_, err = adapter.ExportPSBT(ctx, unsignedTestTransaction(t, []UTXO{{TxID: genesisCoinbaseID, Amount: 100000000}}, "0"))
+		assert.ErrorContains(t, err, "does not match its ID")
+	})
+
+	t.Run("sign", func(t *testing.T) {
+		adapter := NewAdapter(new(MockRPCClient), "mainnet")
+		psbt, err := adapter.ExportPSBT(ctx, unsignedTestTransaction(t, utxos, "49990000"))
+		require.NoError(t, err)
+
+		_, err = adapter.SignPSBT(ctx, psbt, testKeys(t), "one")
+		assert.ErrorContains(t, err, "does not match any PSBT input")
+		_, err = adapter.SignPSBT(ctx, psbt, testKeys(t), "missing")
+		assert.ErrorIs(t, err, ports.ErrKeyNotFound)
+		_, err = adapter.SignPSBT(ctx, "", testKeys(t), "test")
+		assert.Error(t, err)
+
+		p, err := decodePSBT(psbt)
+		require.NoError(t, err)
+		p.inputs[0].sigHashType = 0x83
+		_, err = adapter.SignPSBT(ctx, p.encode(), testKeys(t), "test")
+		assert.ErrorContains(t, err, "unsupported sighash type")
+
+		p.inputs[0].sigHashType = 0
+		p.inputs[0].witnessUTXO = nil
+		_, err = adapter.SignPSBT(ctx, p.encode(), testKeys(t), "test")
+		assert.ErrorContains(t, err, "no UTXO information")
+	})
+
+	t.Run("finalize and import", func(t *testing.T) {
+		adapter := NewAdapter(new(MockRPCClient), "mainnet")
+		tx := unsignedTestTransaction(t, utxos, "49990000")
+		psbt, err := adapter.ExportPSBT(ctx, tx)
+		require.NoError(t, err)
+		signed, err := adapter.SignPSBT(ctx, psbt, testKeys(t), "test")
+		require.NoError(t, err)
+
+		p, err := decodePSBT(signed)
+		require.NoError(t, err)
+		for pubKey, sig := range p.inputs[0].partialSigs {
+			tampered := append([]byte(nil), sig...)
+			tampered[10] ^= 0x01
+			p.inputs[0].partialSigs[pubKey] = tampered
+		}
+		_, err = adapter.FinalizePSBT(p.encode())
+		assert.ErrorContains(t, err, "invalid signature for input 0")
+		_, err = adapter.BroadcastPSBT(ctx, p.encode())
+		assert.Error(t, err)
+
+		other := unsignedTestTransaction(t, []UTXO{{TxID: genesisCoinbaseID, Vout: 1, ScriptPubKey: p2wpkh, Amount: 100000000}}, "49990000")
+		otherPSBT, err := adapter.ExportPSBT(ctx, other)
+		require.NoError(t, err)
+		_, err = adapter.CombinePSBT(signed, otherPSBT)
+		assert.ErrorContains(t, err, "different transactions")
+		_, err = adapter.CombinePSBT()
+		assert.ErrorContains(t, err, "no PSBTs")
+
+		err = adapter.ImportPSBT(ctx, other, signed)
+		assert.ErrorContains(t, err, "does not spend transaction")
+		err = adapter.ImportPSBT(ctx, tx)
+		assert.ErrorContains(t, err, "no PSBTs")
+		assert.Nil(t, tx.Signature())
+	})
+
+	t.Run("broadcast error", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		adapter := NewAdapter(mockRPC, "mainnet")
+		psbt, err := adapter.ExportPSBT(ctx, unsignedTestTransaction(t, utxos, "49990000"))
+		require.NoError(t, err)
+		signed, err := adapter.SignPSBT(ctx, psbt, testKeys(t), "test")
+		require.NoError(t, err)
+		mockRPC.On("SendRawTransaction", mock.Anything, mock.AnythingOfType("string")).Return("", assert.AnError)
+
+		_, err = adapter.BroadcastPSBT(ctx, signed)
+		assert.ErrorContains(t, err, "failed to broadcast transaction")
+	})
+}
@@ -0,0 +1,87 @@
+package bitcoin
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+)
+
+// BroadcastRaw relays a transaction signed elsewhere in its network serialization. The outputs it
+// spends are fetched to check the signatures of P2WPKH and P2PKH inputs with the chain's sighash,
+// which binds fork ID chains to their own transactions; other script types are left to the node.
+// The first output not returning change to the sender is recorded as the payment
+func (a *Adapter) BroadcastRaw(ctx context.Context, raw []byte) (*entities.Transaction, error) {
+	msg, err := deserializeTx(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ports.ErrInvalidRawTransaction, err)
+	}
+	if len(msg.inputs) == 0 || len(msg.outputs) == 0 {
+		return nil, fmt.Errorf("%w: transaction has no inputs or outputs", ports.ErrInvalidRawTransaction)
+	}
+
+	utxos, total, err := a.spentOutputs(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyRawInputs(msg, utxos, a.params); err != nil {
+		return nil, fmt.Errorf("%w: %v", ports.ErrInvalidRawTransaction, err)
+	}
+	fee := total - outputTotal(msg)
+	if fee < 0 {
+		return nil, fmt.Errorf("%w: outputs exceed inputs by %d satoshis", ports.ErrInvalidRawTransaction, -fee)
+	}
+
+	fromScript, err := hex.DecodeString(utxos[0].ScriptPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scriptPubKey: %w", err)
+	}
+	recipient := 0
+	for i, out := range msg.outputs {
+		if !bytes.Equal(out.pkScript, fromScript) {
+			recipient = i
+			break
+		}
+	}
+	vsize := int64(msg.vsize())
+	tx, err := a.signedTransaction(msg, utxos, recipient, (fee+vsize-1)/vsize)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ports.ErrInvalidRawTransaction, err)
+	}
+	tx.SetMetadata(MetadataFee, big.NewInt(fee).String())
+
+	if _, err := a.BroadcastTransaction(ctx, tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// verifyRawInputs checks that every input of msg, which spends utxos in order, is signed, and
+// that the signatures the adapter can verify are valid
+func verifyRawInputs(msg *msgTx, utxos []UTXO, params *ChainParams) error {
+	for i, utxo := range utxos {
+		in := msg.inputs[i]
+		if len(in.scriptSig) == 0 && len(in.witness) == 0 {
+			return fmt.Errorf("input %d is not signed", i)
+		}
+		script, err := hex.DecodeString(utxo.ScriptPubKey)
+		if err != nil {
+			return fmt.Errorf("invalid scriptPubKey for input %d: %w", i, err)
+		}
+		if scriptPubKeyHash(script) == nil {
+			continue
+		}
+		valid, err := verifyInput(msg, i, script, utxo.Amount, params)
+		if err != nil {
+			return err
+		}
+		if !valid {
+			return fmt.Errorf("invalid signature for input %d", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package bitcoin
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcastRaw(t *testing.T) {
+	ctx := context.Background()
+	funding := fundingTransaction(t, payToWitnessScript(0, mustDecodeHex(t, bip143PubKeyHash)))
+	// 1 P2WPKH input and 2 P2WPKH outputs: 141 vbytes paying 10000 satoshis
+	signed := unconfirmedTestTransaction(t, funding, 50000000, 49990000, sequenceRBF)
+	raw := func(t *testing.T, tx *Transaction) []byte {
+		t.Helper()
+		msg, err := wireTransaction(tx)
+		require.NoError(t, err)
+		return msg.serialize()
+	}
+
+	t.Run("relays a signed transaction", func(t *testing.T) {
+		adapter := feeBumpTestAdapter(t, funding)
+		adapter.rpcClient.(*MockRPCClient).On("SendRawTransaction", mock.Anything, hex.EncodeToString(raw(t, signed))).Return(signed.TxID, nil)
+
+		tx, err := adapter.BroadcastRaw(ctx, raw(t, signed))
+		require.NoError(t, err)
+		assert.Equal(t, signed.TxID, tx.Hash().HexWithoutPrefix())
+		keyAddress := &Address{Type: AddressTypeP2WPKH, Chain: ChainBitcoin, Network: "mainnet", Program: mustDecodeHex(t, bip143PubKeyHash)}
+		assert.Equal(t, keyAddress.String(), tx.From().String())
+		assert.NotEqual(t, keyAddress.String(), tx.To().String())
+		assert.Equal(t, int64(50000000), tx.Value().Int64())
+		assert.Equal(t, "10000", tx.Metadata()[MetadataFee])
+		assert.Equal(t, int64(71), tx.GasPrice().Int64())
+		assert.Equal(t, true, tx.Metadata()[MetadataReplaceable])
+		assert.NotNil(t, tx.Signature())
+	})
+
+	t.Run("refuses invalid transactions", func(t *testing.T) {
+		adapter := feeBumpTestAdapter(t, funding)
+
+		_, err := adapter.BroadcastRaw(ctx, []byte{0x02, 0x00})
+		require.ErrorIs(t, err, ports.ErrInvalidRawTransaction)
+
+		// Paying more than signed for invalidates the signature
+		tampered, err := wireTransaction(signed)
+		require.NoError(t, err)
+		tampered.outputs[0].value++
+		_, err = adapter.BroadcastRaw(ctx, tampered.serialize())
+		require.ErrorIs(t, err, ports.ErrInvalidRawTransaction)
+		assert.ErrorContains(t, err, "invalid signature for input 0")
+
+		tampered.inputs[0].witness = nil
+		_, err = adapter.BroadcastRaw(ctx, tampered.serialize())
+		require.ErrorIs(t, err, ports.ErrInvalidRawTransaction)
+		assert.ErrorContains(t, err, "not signed")
+
+		overspent := unconfirmedTestTransaction(t, funding, 50000000, 60000000, sequenceRBF)
+		_, err = adapter.BroadcastRaw(ctx, raw(t, overspent))
+		require.ErrorIs(t, err, ports.ErrInvalidRawTransaction)
+		adapter.rpcClient.(*MockRPCClient).AssertNotCalled(t, "SendRawTransaction", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown inputs", func(t *testing.T) {
+		adapter := feeBumpTestAdapter(t)
+		adapter.rpcClient.(*MockRPCClient).On("GetRawTransaction", mock.Anything, funding.TxID).Return(nil, assert.AnError)
+		_, err := adapter.BroadcastRaw(ctx, raw(t, signed))
+		require.ErrorContains(t, err, "failed to get previous transaction")
+	})
+}
@@ -0,0 +1,129 @@
+package bitcoin
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Script opcodes used by standard output templates
+const (
+	opFalse       = 0x00
+	opPushData1   = 0x4c
+	opPushData2   = 0x4d
+	opDup         = 0x76
+	opEqual       = 0x87
+	opEqualVerify = 0x88
+	opHash160     = 0xa9
+	opCheckSig    = 0xac
+
+	hash160Length = 20
+	sha256Length  = 32
+)
+
+func payToPubKeyHashScript(pubKeyHash []byte) []byte {
+	script := []byte{opDup, opHash160, hash160Length}
+	script = append(script, pubKeyHash...)
+	return append(script, opEqualVerify, opCheckSig)
+}
+
+func payToScriptHashScript(scriptHash []byte) []byte {
+	script := []byte{opHash160, hash160Length}
+	script = append(script, scriptHash...)
+	return append(script, opEqual)
+}
+
+func payToWitnessScript(version byte, program []byte) []byte {
+	op := byte(opFalse)
+	if version > 0 {
+		op = 0x50 + version
+	}
+	script := []byte{op, byte(len(program))}
+	return append(script, program...)
+}
+
+// isPayToPubKeyHash reports whether a script is OP_DUP OP_HASH160 <20> OP_EQUALVERIFY OP_CHECKSIG
+func isPayToPubKeyHash(script []byte) bool {
+	return len(script) == 25 &&
+		script[0] == opDup && script[1] == opHash160 && script[2] == hash160Length &&
+		script[23] == opEqualVerify && script[24] == opCheckSig
+}
+
+// isPayToWitnessPubKeyHash reports whether a script is OP_0 <20>
+func isPayToWitnessPubKeyHash(script []byte) bool {
+	return len(script) == 22 && script[0] == opFalse && script[1] == hash160Length
+}
+
+// isPayToScriptHash reports whether a script is OP_HASH160 <20> OP_EQUAL
+func isPayToScriptHash(script []byte) bool {
+	return len(script) == 23 &&
+		script[0] == opHash160 && script[1] == hash160Length && script[22] == opEqual
+}
+
+// isPayToTaproot reports whether a script is OP_1 <32>
+func isPayToTaproot(script []byte) bool {
+	return len(script) == 34 && script[0] == 0x51 && script[1] == sha256Length
+}
+
+// isWitnessProgram reports whether a script is a segwit output: a version opcode and a 2-40 byte push
+func isWitnessProgram(script []byte) bool {
+	if len(script) < 4 || len(script) > 42 || int(script[1]) != len(script)-2 {
+		return false
+	}
+	return script[0] == opFalse || (script[0] >= 0x51 && script[0] <= 0x60)
+}
+
+// pushData returns the minimal script push of data
+func pushData(data []byte) []byte {
+	var buf bytes.Buffer
+	switch n := len(data); {
+	case n < opPushData1:
+		buf.WriteByte(byte(n))
+	case n <= 0xff:
+		buf.WriteByte(opPushData1)
+		buf.WriteByte(byte(n))
+	default:
+		buf.WriteByte(opPushData2)
+		buf.WriteByte(byte(n))
+		buf.WriteByte(byte(n >> 8))
+	}
+	buf.Write(data)
+	return buf.Bytes()
+}
+
+// parsePushes splits a push-only script (such as a scriptSig) into its data pushes
+func parsePushes(script []byte) ([][]byte, error) {
+	var pushes [][]byte
+	for i := 0; i < len(script); {
+		op := script[i]
+		i++
+		var n int
+		switch {
+		case op < opPushData1:
+			n = int(op)
+		case op == opPushData1 && i < len(script):
+			n = int(script[i])
+			i++
+		case op == opPushData2 && i+1 < len(script):
+			n = int(script[i]) | int(script[i+1])<<8
+			i += 2
+		default:
+			return nil, fmt.Errorf("unsupported script opcode 0x%02x", op)
+		}
+		if i+n > len(script) {
+			return nil, fmt.Errorf("script push exceeds script length")
+		}
+		pushes = append(pushes, script[i:i+n])
+		i += n
+	}
+	return pushes, nil
+}
+
+// addressScript returns the scriptPubKey paying to an address of any known chain and network; the
+// adapter checks the network with ParseAddress before building on an address
+func addressScript(address string) ([]byte, error) {
+	decoded, err := decodeAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	return decoded.ScriptPubKey(), nil
+}
@@ -0,0 +1,145 @@
+package bitcoin
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"golang.org/x/crypto/ripemd160"
+)
+
+// sigHashAll commits to all inputs and outputs
+const sigHashAll = 0x01
+
+// compactSignatureLength is the length of a [R || S || V] key manager signature
+const compactSignatureLength = 65
+
+// legacySigHash computes the pre-segwit signature hash of an input
+func legacySigHash(tx *msgTx, index int, subScript []byte, hashType uint32) ([]byte, error) {
+	if index < 0 || index >= len(tx.inputs) {
+		return nil, fmt.Errorf("input index %d out of range", index)
+	}
+
+	copied := tx.copyTx()
+	for i, in := range copied.inputs {
+		in.witness = nil
+		if i == index {
+			in.scriptSig = subScript
+		} else {
+			in.scriptSig = nil
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.Write(copied.serializeNoWitness())
+	writeUint32(&buf, hashType)
+	return doubleSHA256(buf.Bytes()), nil
+}
+
+// witnessV0SigHash computes the BIP-143 signature hash of a segwit v0 input
+func witnessV0SigHash(tx *msgTx, index int, scriptCode []byte, amount int64, hashType uint32) ([]byte, error) {
+	if index < 0 || index >= len(tx.inputs) {
+		return nil, fmt.Errorf("input index %d out of range", index)
+	}
+
+	var prevouts, sequences, outputs bytes.Buffer
+	for _, in := range tx.inputs {
+		prevouts.Write(in.prevHash[:])
+		writeUint32(&prevouts, in.prevIndex)
+		writeUint32(&sequences, in.sequence)
+	}
+	for _, out := range tx.outputs {
+		writeUint64(&outputs, uint64(out.value))
+		writeVarBytes(&outputs, out.pkScript)
+	}
+
+	in := tx.inputs[index]
+	var buf bytes.Buffer
+	writeUint32(&buf, uint32(tx.version))
+	buf.Write(doubleSHA256(prevouts.Bytes()))
+	buf.Write(doubleSHA256(sequences.Bytes()))
+	buf.Write(in.prevHash[:])
+	writeUint32(&buf, in.prevIndex)
+	writeVarBytes(&buf, scriptCode)
+	writeUint64(&buf, uint64(amount))
+	writeUint32(&buf, in.sequence)
+	buf.Write(doubleSHA256(outputs.Bytes()))
+	writeUint32(&buf, tx.lockTime)
+	writeUint32(&buf, hashType)
+	return doubleSHA256(buf.Bytes()), nil
+}
+
+// hash160 returns RIPEMD160(SHA256(data))
+func hash160(data []byte) []byte {
+	sum := sha256.Sum256(data)
+	h := ripemd160.New()
+	h.Write(sum[:])
+	return h.Sum(nil)
+}
+
+// signingKey is a secp256k1 key of a ports.KeyManager
+type signingKey struct {
+	keys ports.KeyManager
+	id   string
+	// pubKey is the compressed public key
+	pubKey []byte
+}
+
+// newSigningKey looks up the public key of keyID
+func newSigningKey(ctx context.Context, keys ports.KeyManager, keyID string) (*signingKey, error) {
+	publicKey, err := keys.PublicKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := secp256k1.ParsePubKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("key %s is not a secp256k1 key: %w", keyID, err)
+	}
+	return &signingKey{keys: keys, id: keyID, pubKey: pub.SerializeCompressed()}, nil
+}
+
+// sign returns the DER signature of a digest with S normalized to the lower half order (BIP-62)
+func (k *signingKey) sign(ctx context.Context, digest []byte) ([]byte, error) {
+	sig, err := k.keys.Sign(ctx, k.id, digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign with key %s: %w", k.id, err)
+	}
+	if len(sig) != compactSignatureLength {
+		return nil, fmt.Errorf("key %s returned an invalid signature", k.id)
+	}
+	var r, s secp256k1.ModNScalar
+	if overflow := r.SetByteSlice(sig[:32]); overflow || r.IsZero() {
+		return nil, fmt.Errorf("key %s returned an invalid signature", k.id)
+	}
+	if overflow := s.SetByteSlice(sig[32:64]); overflow || s.IsZero() {
+		return nil, fmt.Errorf("key %s returned an invalid signature", k.id)
+	}
+	if s.IsOverHalfOrder() {
+		s.Negate()
+	}
+	der := ecdsa.NewSignature(&r, &s).Serialize()
+	if valid, err := verifyHash(k.pubKey, der, digest); err != nil || !valid {
+		return nil, fmt.Errorf("key %s returned an invalid signature", k.id)
+	}
+	return der, nil
+}
+
+// verifyHash checks a DER signature (without sighash byte) over a digest, rejecting high-S values
+func verifyHash(pubKey, der, digest []byte) (bool, error) {
+	pub, err := secp256k1.ParsePubKey(pubKey)
+	if err != nil {
+		return false, fmt.Errorf("invalid public key: %w", err)
+	}
+	sig, err := ecdsa.ParseDERSignature(der)
+	if err != nil {
+		return false, fmt.Errorf("invalid signature: %w", err)
+	}
+	if s := sig.S(); s.IsOverHalfOrder() {
+		return false, nil
+	}
+	return sig.Verify(digest, pub), nil
+}
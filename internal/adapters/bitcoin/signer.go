@@ -0,0 +1,346 @@
+package bitcoin
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+)
+
+const (
+	// MetadataUTXOs holds the []UTXO selected to fund the transaction
+	MetadataUTXOs = "utxos"
+	// MetadataChangeAmount holds the change in satoshis returned to the sender
+	MetadataChangeAmount = "change_amount"
+	// MetadataFee holds the fee in satoshis paid by the selected UTXOs
+	MetadataFee = ports.MetadataFee
+	// MetadataReplaceable holds whether the inputs signal replace-by-fee (BIP-125)
+	MetadataReplaceable = "replaceable"
+	// MetadataRawTransaction holds the signed transaction in hex
+	MetadataRawTransaction = "raw_transaction"
+	// MetadataVSize holds the virtual size of the signed transaction
+	MetadataVSize = "vsize"
+)
+
+// DecodeRawTransaction decodes a hex-encoded transaction into its RPC representation
+func DecodeRawTransaction(rawTx string) (*Transaction, error) {
+	data, err := hex.DecodeString(rawTx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid raw transaction hex: %w", err)
+	}
+	msg, err := deserializeTx(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid raw transaction: %w", err)
+	}
+
+	result := &Transaction{
+		TxID:     msg.txid(),
+		Hash:     msg.wtxid(),
+		Version:  msg.version,
+		Size:     int32(len(data)),
+		VSize:    int32(msg.vsize()),
+		Weight:   int32(msg.weight()),
+		LockTime: msg.lockTime,
+	}
+	for _, in := range msg.inputs {
+		input := TxInput{
+			TxID:      hashToString(in.prevHash[:]),
+			Vout:      in.prevIndex,
+			ScriptSig: hex.EncodeToString(in.scriptSig),
+			Sequence:  in.sequence,
+		}
+		for _, item := range in.witness {
+			input.Witness = append(input.Witness, hex.EncodeToString(item))
+		}
+		result.Inputs = append(result.Inputs, input)
+	}
+	for i, out := range msg.outputs {
+		result.Outputs = append(result.Outputs, TxOutput{
+			Value:        out.value,
+			N:            uint32(i),
+			ScriptPubKey: hex.EncodeToString(out.pkScript),
+		})
+	}
+	return result, nil
+}
+
+// metadataUTXOs returns the inputs chosen by CreateTransaction
+func metadataUTXOs(tx *entities.Transaction) ([]UTXO, error) {
+	var utxos []UTXO
+	switch v := tx.Metadata()[MetadataUTXOs].(type) {
+	case nil:
+	case []UTXO:
+		utxos = v
+	default:
+		// Values restored from JSON arrive as generic maps
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s metadata: %w", MetadataUTXOs, err)
+		}
+		if err := json.Unmarshal(raw, &utxos); err != nil {
+			return nil, fmt.Errorf("invalid %s metadata: %w", MetadataUTXOs, err)
+		}
+	}
+	if len(utxos) == 0 {
+		return nil, fmt.Errorf("transaction has no selected UTXOs; build it with the bitcoin adapter")
+	}
+	return utxos, nil
+}
+
+// metadataChange returns the change recorded by CreateTransaction
+func metadataChange(tx *entities.Transaction) (*big.Int, error) {
+	switch v := tx.Metadata()[MetadataChangeAmount].(type) {
+	case nil:
+		return big.NewInt(0), nil
+	case *big.Int:
+		return new(big.Int).Set(v), nil
+	case string:
+		change, ok := new(big.Int).SetString(v, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s metadata: %s", MetadataChangeAmount, v)
+		}
+		return change, nil
+	default:
+		return nil, fmt.Errorf("invalid %s metadata type %T", MetadataChangeAmount, v)
+	}
+}
+
+// unsignedTx builds the wire transaction spending the selected UTXOs to the recipient,
+// returning change to the sender when it is above the dust threshold
+func unsignedTx(tx *entities.Transaction, params *ChainParams) (*msgTx, []UTXO, error) {
+	utxos, err := metadataUTXOs(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sequence := uint32(sequenceFinal)
+	if replaceable, _ := tx.Metadata()[MetadataReplaceable].(bool); replaceable {
+		sequence = sequenceRBF
+	}
+
+	msg := &msgTx{version: txVersion}
+	for _, utxo := range utxos {
+		prevHash, err := parseTxID(utxo.TxID)
+		if err != nil {
+			return nil, nil, err
+		}
+		msg.inputs = append(msg.inputs, &txIn{
+			prevHash:  prevHash,
+			prevIndex: utxo.Vout,
+			sequence:  sequence,
+		})
+	}
+
+	value := tx.Value()
+	if !value.IsInt64() {
+		return nil, nil, fmt.Errorf("value exceeds int64: %s", value)
+	}
+	toScript, err := addressScript(tx.To().Value())
+	if err != nil {
+		return nil, nil, err
+	}
+	msg.outputs = append(msg.outputs, &txOut{value: value.Int64(), pkScript: toScript})
+
+	change, err := metadataChange(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if change.Sign() > 0 {
+		if !change.IsInt64() {
+			return nil, nil, fmt.Errorf("change exceeds int64: %s", change)
+		}
+		changeScript, err := addressScript(tx.From().Value())
+		if err != nil {
+			return nil, nil, err
+		}
+		if change.Int64() >= params.dustThreshold(changeScript) {
+			msg.outputs = append(msg.outputs, &txOut{value: change.Int64(), pkScript: changeScript})
+		}
+	}
+	return msg, utxos, nil
+}
+
+// prevOutScript returns the scriptPubKey locking a UTXO
+func prevOutScript(utxo UTXO, from string) ([]byte, error) {
+	if utxo.ScriptPubKey != "" {
+		script, err := hex.DecodeString(utxo.ScriptPubKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scriptPubKey for %s:%d: %w", utxo.TxID, utxo.Vout, err)
+		}
+		return script, nil
+	}
+	if utxo.Address != "" {
+		return addressScript(utxo.Address)
+	}
+	return addressScript(from)
+}
+
+// signInputs signs every input of msg, which spends utxos in order, with a single key
+func signInputs(ctx context.Context, msg *msgTx, utxos []UTXO, from string, key *signingKey, params *ChainParams) error {
+	if len(utxos) != len(msg.inputs) {
+		return fmt.Errorf("expected %d UTXOs, got %d", len(msg.inputs), len(utxos))
+	}
+	pubKeyHash := hash160(key.pubKey)
+
+	for i, utxo := range utxos {
+		script, err := prevOutScript(utxo, from)
+		if err != nil {
+			return err
+		}
+		lockedHash := scriptPubKeyHash(script)
+		if lockedHash == nil {
+			return fmt.Errorf("unsupported script type for input %d: %x", i, script)
+		}
+		if !bytes.Equal(lockedHash, pubKeyHash) {
+			return fmt.Errorf("key %s does not match input %d", key.id, i)
+		}
+		digest, err := inputSigHash(msg, i, script, utxo.Amount, params)
+		if err != nil {
+			return err
+		}
+		sig, err := key.sign(ctx, digest)
+		if err != nil {
+			return err
+		}
+		setInputSignature(msg.inputs[i], script, append(sig, byte(params.sigHashType())), key.pubKey)
+	}
+	return nil
+}
+
+// verifyInputs checks the P2WPKH and P2PKH signatures of every input of msg
+func verifyInputs(msg *msgTx, utxos []UTXO, from string, params *ChainParams) (bool, error) {
+	spent := make(map[string]UTXO, len(utxos))
+	for _, utxo := range utxos {
+		spent[fmt.Sprintf("%s:%d", utxo.TxID, utxo.Vout)] = utxo
+	}
+
+	for i, in := range msg.inputs {
+		utxo, ok := spent[fmt.Sprintf("%s:%d", hashToString(in.prevHash[:]), in.prevIndex)]
+		if !ok {
+			return false, fmt.Errorf("input %d spends an unknown output", i)
+		}
+		script, err := prevOutScript(utxo, from)
+		if err != nil {
+			return false, err
+		}
+		valid, err := verifyInput(msg, i, script, utxo.Amount, params)
+		if err != nil || !valid {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// verifyInput checks the signature carried by input index of msg against the script it spends
+func verifyInput(msg *msgTx, index int, script []byte, amount int64, params *ChainParams) (bool, error) {
+	if scriptPubKeyHash(script) == nil {
+		return false, fmt.Errorf("unsupported script type for input %d: %x", index, script)
+	}
+	in := msg.inputs[index]
+	var sig, pubKey []byte
+	if isPayToWitnessPubKeyHash(script) {
+		if len(in.witness) != 2 {
+			return false, nil
+		}
+		sig, pubKey = in.witness[0], in.witness[1]
+	} else {
+		pushes, err := parsePushes(in.scriptSig)
+		if err != nil || len(pushes) != 2 {
+			return false, nil
+		}
+		sig, pubKey = pushes[0], pushes[1]
+	}
+	return verifyInputSignature(msg, index, script, amount, sig, pubKey, params)
+}
+
+// verifyInputSignature checks a signature, including its sighash byte, of input index of msg
+func verifyInputSignature(msg *msgTx, index int, script []byte, amount int64, sig, pubKey []byte, params *ChainParams) (bool, error) {
+	if !bytes.Equal(hash160(pubKey), scriptPubKeyHash(script)) {
+		return false, nil
+	}
+	if len(sig) == 0 || sig[len(sig)-1] != byte(params.sigHashType()) {
+		return false, nil
+	}
+	digest, err := inputSigHash(msg, index, script, amount, params)
+	if err != nil {
+		return false, err
+	}
+	return verifyHash(pubKey, sig[:len(sig)-1], digest)
+}
+
+// scriptPubKeyHash returns the public key hash locked by a P2WPKH or P2PKH script, or nil for other scripts
+func scriptPubKeyHash(script []byte) []byte {
+	switch {
+	case isPayToWitnessPubKeyHash(script):
+		return script[2:]
+	case isPayToPubKeyHash(script):
+		return script[3:23]
+	default:
+		return nil
+	}
+}
+
+// inputSigHash returns the SIGHASH_ALL digest of an input spending a P2WPKH or P2PKH script;
+// fork ID chains sign P2PKH inputs with BIP-143 digests as well
+func inputSigHash(msg *msgTx, index int, script []byte, amount int64, params *ChainParams) ([]byte, error) {
+	switch {
+	case isPayToWitnessPubKeyHash(script):
+		return witnessV0SigHash(msg, index, payToPubKeyHashScript(script[2:]), amount, params.sigHashType())
+	case isPayToPubKeyHash(script) && params.SigHashForkID:
+		return witnessV0SigHash(msg, index, script, amount, params.sigHashType())
+	case isPayToPubKeyHash(script):
+		return legacySigHash(msg, index, script, sigHashAll)
+	default:
+		return nil, fmt.Errorf("unsupported script type for input %d: %x", index, script)
+	}
+}
+
+// setInputSignature places a signature and public key in the witness or scriptSig of an input
+func setInputSignature(in *txIn, script, sig, pubKey []byte) {
+	if isPayToWitnessPubKeyHash(script) {
+		in.witness = [][]byte{sig, pubKey}
+		return
+	}
+	in.scriptSig = append(pushData(sig), pushData(pubKey)...)
+}
+
+// inputSignature returns the signature of the first input, used as the transaction signature
+func inputSignature(msg *msgTx) []byte {
+	in := msg.inputs[0]
+	if len(in.witness) > 0 {
+		return in.witness[0]
+	}
+	pushes, err := parsePushes(in.scriptSig)
+	if err != nil || len(pushes) == 0 {
+		return nil
+	}
+	return pushes[0]
+}
+
+// applySignedTx records a fully signed transaction on tx
+func applySignedTx(tx *entities.Transaction, msg *msgTx) error {
+	sig, err := valueobjects.NewSignatureFromBytes(inputSignature(msg))
+	if err != nil {
+		return fmt.Errorf("failed to create signature: %w", err)
+	}
+	hash, err := valueobjects.NewHash(msg.txid())
+	if err != nil {
+		return fmt.Errorf("failed to create hash: %w", err)
+	}
+
+	if err := tx.SetSignature(sig); err != nil {
+		return fmt.Errorf("failed to set signature: %w", err)
+	}
+	if err := tx.SetHash(hash); err != nil {
+		return fmt.Errorf("failed to set hash: %w", err)
+	}
+	tx.SetMetadata(MetadataRawTransaction, hex.EncodeToString(msg.serialize()))
+	tx.SetMetadata(MetadataVSize, msg.vsize())
+	return nil
+}
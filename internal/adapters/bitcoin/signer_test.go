@@ -0,0 +1,448 @@
+package bitcoin
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/keystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Native P2WPKH example from BIP-143: input 0 spends a P2PK output, input 1 a P2WPKH output
+const (
+	bip143SignedTx   = "01000000000102fff7f7881a8099afa6940d42d1e7f6362bec38171ea3edf433541db4e4ad969f00000000494830450221008b9d1dc26ba6a9cb62127b02742fa9d754cd3bebf337f7a55d114c8e5cdd30be022040529b194ba3f9281a99f2b1c0a19c0489bc22ede944ccf4ecbab4cc618ef3ed01eeffffffef51e1b804cc89d182d279655c3aa89e815b1b309fe287d9b2b55d57b90ec68a0100000000ffffffff02202cb206000000001976a9148280b37df378db99f66f85c95a783a76ac7a6d5988ac9093510d000000001976a9143bde42dbee7e4dbe6a21b2d50ce2f0167faa815988ac000247304402203609e17b84f6a7d30c80bfa610b5b4542f32a8a0d5447a12fb1366d7f01cc44a0220573a954c4518331561406f90300e8f3358f51928d43c212a8caed02de67eebee0121025476c2e83188368da1ff3e292e7acafcdb3566bb0ad253f62fc70f07aeee635711000000"
+	bip143P2PKScript = "2103c9f4836b9a4f77fc0d81f7bcb01b7f1b35916864b9476c241ce9fc198bd25432ac"
+	bip143PrivateKey = "619c335025c7f4012e556c2a58b2506e30b8511b53ade95ea316fd8c3286feb9"
+	bip143PubKeyHash = "1d0f172a0ecb48aee1be1f2687d2963ae33f71a1"
+
+	genesisCoinbaseTx = "01000000010000000000000000000000000000000000000000000000000000000000000000ffffffff4d04ffff001d0104455468652054696d65732030332f4a616e2f32303039204368616e63656c6c6f72206f6e206272696e6b206f66207365636f6e64206261696c6f757420666f722062616e6b73ffffffff0100f2052a01000000434104678afdb0fe5548271967f1a67130b7105cd6a828e03909a67962e0ea1f61deb649f6bc3f4cef38c4f35504e51ec112de5c384df7ba0b8d578a4c702b6bf11d5fac00000000"
+	genesisCoinbaseID = "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b"
+
+	testPrevTxID = "9f96ade4b41d5433f4eda31e1738ec2b36f6e7d1420d94a6af99801a88f7f7ff"
+)
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func testKey(t *testing.T) []byte {
+	t.Helper()
+	return mustDecodeHex(t, bip143PrivateKey)
+}
+
+// testKeys holds the BIP-143 key as "test", private key 1 as "one" and an ed25519 key
+func testKeys(t *testing.T) ports.KeyManager {
+	t.Helper()
+	keys, err := keystore.NewManager(
+		keystore.Key{ID: "test", Curve: ports.CurveSecp256k1, PrivateKey: testKey(t)},
+		keystore.Key{ID: "one", Curve: ports.CurveSecp256k1, PrivateKey: testPrivateKeyOne(t)},
+		keystore.Key{ID: "ed25519", Curve: ports.CurveEd25519, PrivateKey: bytes.Repeat([]byte{0x07}, 32)},
+	)
+	require.NoError(t, err)
+	return keys
+}
+
+// testKeyAddress returns the P2PKH address of the BIP-143 test key
+func testKeyAddress(t *testing.T) *valueobjects.Address {
+	t.Helper()
+	encoded := (&Address{Type: AddressTypeP2PKH, Chain: ChainBitcoin, Network: "mainnet", Program: mustDecodeHex(t, bip143PubKeyHash)}).String()
+	address, err := valueobjects.NewAddress(encoded, "bitcoin-mainnet")
+	require.NoError(t, err)
+	return address
+}
+
+func TestSerializationRoundTrip(t *testing.T) {
+	for name, raw := range map[string]string{"segwit": bip143SignedTx, "legacy": genesisCoinbaseTx} {
+		t.Run(name, func(t *testing.T) {
+			msg, err := deserializeTx(mustDecodeHex(t, raw))
+			require.NoError(t, err)
+			assert.Equal(t, raw, hex.EncodeToString(msg.serialize()))
+		})
+	}
+
+	genesis, err := deserializeTx(mustDecodeHex(t, genesisCoinbaseTx))
+	require.NoError(t, err)
+	assert.Equal(t, genesisCoinbaseID, genesis.txid())
+	assert.Equal(t, genesis.txid(), genesis.wtxid())
+	assert.Equal(t, len(genesisCoinbaseTx)/2, genesis.vsize())
+
+	segwit, err := deserializeTx(mustDecodeHex(t, bip143SignedTx))
+	require.NoError(t, err)
+	assert.NotEqual(t, segwit.txid(), segwit.wtxid())
+	assert.Less(t, segwit.vsize(), len(bip143SignedTx)/2)
+
+	for _, invalid := range []string{"", "01000000", genesisCoinbaseTx + "00", genesisCoinbaseTx[:len(genesisCoinbaseTx)-2], "0100000000020000"} {
+		_, err := deserializeTx(mustDecodeHex(t, invalid))
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestDecodeRawTransaction(t *testing.T) {
+	decoded, err := DecodeRawTransaction(bip143SignedTx)
+	require.NoError(t, err)
+	assert.Equal(t, "e8151a2af31c368a35053ddd4bdb285a8595c769a3ad83e0fa02314a602d4609", decoded.TxID)
+	assert.Equal(t, int32(1), decoded.Version)
+	assert.Equal(t, uint32(17), decoded.LockTime)
+	require.Len(t, decoded.Inputs, 2)
+	assert.Equal(t, testPrevTxID, decoded.Inputs[0].TxID)
+	assert.Equal(t, uint32(0xffffffee), decoded.Inputs[0].Sequence)
+	assert.Len(t, decoded.Inputs[1].Witness, 2)
+	require.Len(t, decoded.Outputs, 2)
+	assert.Equal(t, int64(112340000), decoded.Outputs[0].Value)
+	assert.Equal(t, uint32(1), decoded.Outputs[1].N)
+	assert.Equal(t, "76a9148280b37df378db99f66f85c95a783a76ac7a6d5988ac", decoded.Outputs[0].ScriptPubKey)
+
+	_, err = DecodeRawTransaction("zz")
+	require.Error(t, err)
+	_, err = DecodeRawTransaction("0100")
+	require.Error(t, err)
+}
+
+func TestSigHashVectors(t *testing.T) {
+	msg, err := deserializeTx(mustDecodeHex(t, bip143SignedTx))
+	require.NoError(t, err)
+
+	t.Run("BIP-143 P2WPKH", func(t *testing.T) {
+		scriptCode := payToPubKeyHashScript(mustDecodeHex(t, bip143PubKeyHash))
+		digest, err := witnessV0SigHash(msg, 1, scriptCode, 600000000, sigHashAll)
+		require.NoError(t, err)
+		assert.Equal(t, "c37af31116d1b27caf68aae9e3ac82f1477929014d5b917657d0eb49478cb670", hex.EncodeToString(digest))
+
+		// RFC 6979 reproduces the signature published in BIP-143
+		key, err := newSigningKey(context.Background(), testKeys(t), "test")
+		require.NoError(t, err)
+		sig, err := key.sign(context.Background(), digest)
+		require.NoError(t, err)
+		witness := msg.inputs[1].witness
+		assert.Equal(t, witness[0][:len(witness[0])-1], sig)
+
+		valid, err := verifyHash(witness[1], witness[0][:len(witness[0])-1], digest)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("legacy P2PK", func(t *testing.T) {
+		script := mustDecodeHex(t, bip143P2PKScript)
+		digest, err := legacySigHash(msg, 0, script, sigHashAll)
+		require.NoError(t, err)
+
+		pushes, err := parsePushes(msg.inputs[0].scriptSig)
+		require.NoError(t, err)
+		sig := pushes[0]
+		valid, err := verifyHash(script[1:34], sig[:len(sig)-1], digest)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("index out of range", func(t *testing.T) {
+		_, err := legacySigHash(msg, 2, nil, sigHashAll)
+		require.Error(t, err)
+		_, err = witnessV0SigHash(msg, -1, nil, 0, sigHashAll)
+		require.Error(t, err)
+	})
+}
+
+func TestSignHashLowS(t *testing.T) {
+	ctx := context.Background()
+	key, err := newSigningKey(ctx, testKeys(t), "test")
+	require.NoError(t, err)
+
+	for i := 0; i < 64; i++ {
+		digest := doubleSHA256([]byte{byte(i)})
+		der, err := key.sign(ctx, digest)
+		require.NoError(t, err)
+		sig, err := ecdsa.ParseDERSignature(der)
+		require.NoError(t, err)
+		s := sig.S()
+		assert.False(t, s.IsOverHalfOrder())
+
+		// The high-S twin of a valid signature is rejected
+		r := sig.R()
+		valid, err := verifyHash(key.pubKey, derSignature(r.Bytes(), s.Negate().Bytes()), digest)
+		require.NoError(t, err)
+		assert.False(t, valid)
+	}
+
+	_, err = newSigningKey(ctx, testKeys(t), "missing")
+	require.ErrorIs(t, err, ports.ErrKeyNotFound)
+	_, err = newSigningKey(ctx, testKeys(t), "ed25519")
+	require.Error(t, err)
+	_, err = key.sign(ctx, []byte("short"))
+	require.Error(t, err)
+	_, err = verifyHash([]byte{1}, nil, nil)
+	require.Error(t, err)
+	_, err = verifyHash(key.pubKey, []byte{1}, nil)
+	require.Error(t, err)
+}
+
+// derSignature encodes r and s without the low-S canonicalization of ecdsa.Signature.Serialize
+func derSignature(r, s [32]byte) []byte {
+	integer := func(b [32]byte) []byte {
+		v := bytes.TrimLeft(b[:], "\x00")
+		if v[0]&0x80 != 0 {
+			v = append([]byte{0}, v...)
+		}
+		return append([]byte{0x02, byte(len(v))}, v...)
+	}
+	body := append(integer(r), integer(s)...)
+	return append([]byte{0x30, byte(len(body))}, body...)
+}
+
+func TestAddressScript(t *testing.T) {
+	tests := map[string]string{
+		"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2":                             "76a91477bff20c60e522dfaa3350c39b030a5d004e839a88ac",
+		"3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy":                             "a914b472a266d0bd89c13706a4132ccfb16f7c3b9fcb87",
+		"BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4":                     "0014751e76e8199196d454941c45d1b3a323f1433bd6",
+		"tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7": "00201863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262",
+	}
+	for address, expected := range tests {
+		script, err := addressScript(address)
+		require.NoError(t, err, address)
+		assert.Equal(t, expected, hex.EncodeToString(script), address)
+	}
+
+	for _, invalid := range []string{
+		"",
+		"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN3",
+		"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t5",
+		"bc1zw508d6qejxtdg4y5r3zarvaryvqyzf3du",
+		"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kV8f3t4",
+		"bc1gmk9yu",
+		"0x742d35cc6634c0532925a3b844bc9e7595f0beb0",
+		"7SeEnXWPaCCALbVrTnszCVGfRU8cGfx",
+	} {
+		_, err := addressScript(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestPushData(t *testing.T) {
+	assert.Equal(t, []byte{2, 0xaa, 0xbb}, pushData([]byte{0xaa, 0xbb}))
+	assert.Equal(t, []byte{opPushData1, 80}, pushData(make([]byte, 80))[:2])
+	assert.Equal(t, []byte{opPushData2, 0x2c, 0x01}, pushData(make([]byte, 300))[:3])
+
+	script := append(append(pushData(make([]byte, 80)), pushData(make([]byte, 300))...), pushData([]byte{1})...)
+	pushes, err := parsePushes(script)
+	require.NoError(t, err)
+	require.Len(t, pushes, 3)
+	assert.Len(t, pushes[1], 300)
+
+	_, err = parsePushes([]byte{opDup})
+	require.Error(t, err)
+	_, err = parsePushes([]byte{5, 1})
+	require.Error(t, err)
+}
+
+// signedTestTransaction builds and signs a transaction spending the given UTXOs with the BIP-143 key
+func signedTestTransaction(t *testing.T, utxos []UTXO, change string) (*Adapter, *entities.Transaction) {
+	t.Helper()
+	mockRPC := new(MockRPCClient)
+	adapter := NewAdapter(mockRPC, "mainnet")
+	from := testKeyAddress(t)
+	_, to := testAddresses(t)
+
+	tx, err := entities.NewTransaction(entities.TransactionParams{ChainID: "bitcoin-mainnet", From: from, To: to, Value: big.NewInt(50000000)})
+	require.NoError(t, err)
+	tx.SetMetadata(MetadataUTXOs, utxos)
+	tx.SetMetadata(MetadataChangeAmount, change)
+
+	require.NoError(t, adapter.SignTransaction(context.Background(), tx, testKeys(t), "test"))
+	return adapter, tx
+}
+
+func TestSignTransaction(t *testing.T) {
+	p2wpkh := "0014" + bip143PubKeyHash
+
+	t.Run("P2WPKH input with change", func(t *testing.T) {
+		adapter, tx := signedTestTransaction(t, []UTXO{
+			{TxID: testPrevTxID, Vout: 1, ScriptPubKey: p2wpkh, Amount: 100000000},
+		}, "49990000")
+
+		decoded, err := DecodeRawTransaction(tx.Metadata()[MetadataRawTransaction].(string))
+		require.NoError(t, err)
+		assert.Equal(t, decoded.TxID, tx.Hash().HexWithoutPrefix())
+		assert.Equal(t, int32(txVersion), decoded.Version)
+		require.Len(t, decoded.Inputs, 1)
+		assert.Equal(t, testPrevTxID, decoded.Inputs[0].TxID)
+		assert.Equal(t, uint32(sequenceFinal), decoded.Inputs[0].Sequence)
+		assert.Empty(t, decoded.Inputs[0].ScriptSig)
+		require.Len(t, decoded.Inputs[0].Witness, 2)
+		assert.Equal(t, decoded.Inputs[0].Witness[0], tx.Signature().HexWithoutPrefix())
+
+		require.Len(t, decoded.Outputs, 2)
+		assert.Equal(t, int64(50000000), decoded.Outputs[0].Value)
+		assert.Equal(t, "76a91477bff20c60e522dfaa3350c39b030a5d004e839a88ac", decoded.Outputs[0].ScriptPubKey)
+		assert.Equal(t, int64(49990000), decoded.Outputs[1].Value)
+		assert.Equal(t, "76a914"+bip143PubKeyHash+"88ac", decoded.Outputs[1].ScriptPubKey)
+		assert.Equal(t, int(decoded.VSize), tx.Metadata()[MetadataVSize])
+
+		valid, err := adapter.VerifySignature(context.Background(), tx)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("P2PKH and P2WPKH inputs without change", func(t *testing.T) {
+		adapter, tx := signedTestTransaction(t, []UTXO{
+			{TxID: testPrevTxID, Vout: 0, Amount: 30000000},
+			{TxID: genesisCoinbaseID, Vout: 2, ScriptPubKey: p2wpkh, Amount: 20000300},
+		}, "300")
+
+		decoded, err := DecodeRawTransaction(tx.Metadata()[MetadataRawTransaction].(string))
+		require.NoError(t, err)
+		require.Len(t, decoded.Inputs, 2)
+		assert.NotEmpty(t, decoded.Inputs[0].ScriptSig)
+		assert.Empty(t, decoded.Inputs[0].Witness)
+		assert.Len(t, decoded.Inputs[1].Witness, 2)
+		// Change below the dust limit is left to the fee
+		assert.Len(t, decoded.Outputs, 1)
+
+		valid, err := adapter.VerifySignature(context.Background(), tx)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("UTXOs restored from JSON", func(t *testing.T) {
+		raw, err := json.Marshal([]UTXO{{TxID: testPrevTxID, Vout: 1, ScriptPubKey: p2wpkh, Amount: 100000000}})
+		require.NoError(t, err)
+		var restored []interface{}
+		require.NoError(t, json.Unmarshal(raw, &restored))
+
+		adapter := NewAdapter(new(MockRPCClient), "mainnet")
+		_, to := testAddresses(t)
+		tx, err := entities.NewTransaction(entities.TransactionParams{ChainID: "bitcoin-mainnet", From: testKeyAddress(t), To: to, Value: big.NewInt(50000000)})
+		require.NoError(t, err)
+		tx.SetMetadata(MetadataUTXOs, restored)
+		tx.SetMetadata(MetadataChangeAmount, "49990000")
+		require.NoError(t, adapter.SignTransaction(context.Background(), tx, testKeys(t), "test"))
+		valid, err := adapter.VerifySignature(context.Background(), tx)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("tampered transaction fails verification", func(t *testing.T) {
+		adapter, tx := signedTestTransaction(t, []UTXO{
+			{TxID: testPrevTxID, Vout: 1, ScriptPubKey: p2wpkh, Amount: 100000000},
+		}, "49990000")
+
+		// Claiming a different spent amount invalidates the BIP-143 signature
+		tx.SetMetadata(MetadataUTXOs, []UTXO{{TxID: testPrevTxID, Vout: 1, ScriptPubKey: p2wpkh, Amount: 100000001}})
+		valid, err := adapter.VerifySignature(context.Background(), tx)
+		require.NoError(t, err)
+		assert.False(t, valid)
+
+		tx.SetMetadata(MetadataUTXOs, []UTXO{{TxID: genesisCoinbaseID, Vout: 1, ScriptPubKey: p2wpkh}})
+		_, err = adapter.VerifySignature(context.Background(), tx)
+		require.Error(t, err)
+
+		tx.SetMetadata(MetadataRawTransaction, "zz")
+		_, err = adapter.VerifySignature(context.Background(), tx)
+		require.Error(t, err)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		adapter := NewAdapter(new(MockRPCClient), "mainnet")
+		from := testKeyAddress(t)
+		_, to := testAddresses(t)
+		ctx := context.Background()
+
+		newTx := func(utxos interface{}, change interface{}) *entities.Transaction {
+			tx, err := entities.NewTransaction(entities.TransactionParams{ChainID: "bitcoin-mainnet", From: from, To: to, Value: big.NewInt(1000)})
+			require.NoError(t, err)
+			if utxos != nil {
+				tx.SetMetadata(MetadataUTXOs, utxos)
+			}
+			if change != nil {
+				tx.SetMetadata(MetadataChangeAmount, change)
+			}
+			return tx
+		}
+		valid := []UTXO{{TxID: testPrevTxID, Amount: 5000, ScriptPubKey: p2wpkh}}
+
+		err := adapter.SignTransaction(ctx, newTx(nil, nil), testKeys(t), "test")
+		assert.ErrorContains(t, err, "no selected UTXOs")
+		err = adapter.SignTransaction(ctx, newTx([]UTXO{{TxID: "abc123"}}, nil), testKeys(t), "test")
+		assert.ErrorContains(t, err, "invalid transaction ID")
+		err = adapter.SignTransaction(ctx, newTx(valid, "x"), testKeys(t), "test")
+		assert.ErrorContains(t, err, "change_amount")
+		err = adapter.SignTransaction(ctx, newTx(valid, 12), testKeys(t), "test")
+		assert.ErrorContains(t, err, "change_amount")
+		err = adapter.SignTransaction(ctx, newTx(valid, nil), testKeys(t), "missing")
+		assert.ErrorIs(t, err, ports.ErrKeyNotFound)
+		err = adapter.SignTransaction(ctx, newTx(valid, nil), testKeys(t), "one")
+		assert.ErrorContains(t, err, "key one does not match input 0")
+		err = adapter.SignTransaction(ctx, newTx([]UTXO{{TxID: testPrevTxID, ScriptPubKey: "a914b472a266d0bd89c13706a4132ccfb16f7c3b9fcb87"}}, nil), testKeys(t), "test")
+		assert.ErrorContains(t, err, "unsupported script type")
+		err = adapter.SignTransaction(ctx, newTx([]UTXO{{TxID: testPrevTxID, ScriptPubKey: "zz"}}, nil), testKeys(t), "test")
+		assert.ErrorContains(t, err, "invalid scriptPubKey")
+
+		_, err = adapter.VerifySignature(ctx, newTx(valid, nil))
+		assert.ErrorContains(t, err, "transaction not signed")
+	})
+}
+
+func testPrivateKeyOne(t *testing.T) []byte {
+	t.Helper()
+	key := make([]byte, 32)
+	key[31] = 1
+	return key
+}
+
+func TestBroadcastTransaction(t *testing.T) {
+	utxos := []UTXO{{TxID: testPrevTxID, Vout: 1, ScriptPubKey: "0014" + bip143PubKeyHash, Amount: 100000000}}
+
+	t.Run("success", func(t *testing.T) {
+		adapter, tx := signedTestTransaction(t, utxos, "49990000")
+		mockRPC := adapter.rpcClient.(*MockRPCClient)
+		rawTx := tx.Metadata()[MetadataRawTransaction].(string)
+		mockRPC.On("SendRawTransaction", mock.Anything, rawTx).Return(tx.Hash().HexWithoutPrefix(), nil)
+
+		hash, err := adapter.BroadcastTransaction(context.Background(), tx)
+		require.NoError(t, err)
+		assert.Equal(t, tx.Hash(), hash)
+		mockRPC.AssertExpectations(t)
+	})
+
+	t.Run("unsigned transaction", func(t *testing.T) {
+		adapter := NewAdapter(new(MockRPCClient), "mainnet")
+		fromAddr, toAddr := testAddresses(t)
+		tx, err := entities.NewTransaction(entities.TransactionParams{ChainID: "bitcoin-mainnet", From: fromAddr, To: toAddr, Value: big.NewInt(50000000)})
+		require.NoError(t, err)
+
+		_, err = adapter.BroadcastTransaction(context.Background(), tx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "transaction not signed")
+	})
+
+	t.Run("broadcast error", func(t *testing.T) {
+		adapter, tx := signedTestTransaction(t, utxos, "49990000")
+		mockRPC := adapter.rpcClient.(*MockRPCClient)
+		mockRPC.On("SendRawTransaction", mock.Anything, mock.AnythingOfType("string")).Return("", assert.AnError)
+
+		_, err := adapter.BroadcastTransaction(context.Background(), tx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to broadcast transaction")
+		mockRPC.AssertExpectations(t)
+	})
+
+	t.Run("invalid txid returned", func(t *testing.T) {
+		adapter, tx := signedTestTransaction(t, utxos, "49990000")
+		mockRPC := adapter.rpcClient.(*MockRPCClient)
+		mockRPC.On("SendRawTransaction", mock.Anything, mock.AnythingOfType("string")).Return("not-hex", nil)
+
+		_, err := adapter.BroadcastTransaction(context.Background(), tx)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,365 @@
+package bitcoin
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+)
+
+const (
+	txVersion = 2
+	// sequenceFinal disables relative lock-time and replacement for an input
+	sequenceFinal = 0xffffffff
+	// sequenceRBF is the highest sequence signalling replaceability (BIP-125)
+	sequenceRBF = 0xfffffffd
+
+	witnessMarker = 0x00
+	witnessFlag   = 0x01
+	// witnessScaleFactor is the weight of a non-witness byte (BIP-141)
+	witnessScaleFactor = 4
+)
+
+// msgTx is the wire representation of a Bitcoin transaction
+type msgTx struct {
+	version  int32
+	inputs   []*txIn
+	outputs  []*txOut
+	lockTime uint32
+}
+
+// txIn spends a previous output; prevHash is kept in internal (little-endian) byte order
+type txIn struct {
+	prevHash  [32]byte
+	prevIndex uint32
+	scriptSig []byte
+	sequence  uint32
+	witness   [][]byte
+}
+
+type txOut struct {
+	value    int64
+	pkScript []byte
+}
+
+// hasWitness reports whether any input carries witness data
+func (tx *msgTx) hasWitness() bool {
+	for _, in := range tx.inputs {
+		if len(in.witness) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// serialize encodes the transaction, using the BIP-144 format when witness data is present
+func (tx *msgTx) serialize() []byte {
+	return tx.encode(tx.hasWitness())
+}
+
+// serializeNoWitness encodes the transaction in the legacy format hashed by txid
+func (tx *msgTx) serializeNoWitness() []byte {
+	return tx.encode(false)
+}
+
+func (tx *msgTx) encode(witness bool) []byte {
+	var buf bytes.Buffer
+	writeUint32(&buf, uint32(tx.version))
+	if witness {
+		buf.WriteByte(witnessMarker)
+		buf.WriteByte(witnessFlag)
+	}
+
+	writeVarInt(&buf, uint64(len(tx.inputs)))
+	for _, in := range tx.inputs {
+		buf.Write(in.prevHash[:])
+		writeUint32(&buf, in.prevIndex)
+		writeVarBytes(&buf, in.scriptSig)
+		writeUint32(&buf, in.sequence)
+	}
+
+	writeVarInt(&buf, uint64(len(tx.outputs)))
+	for _, out := range tx.outputs {
+		writeUint64(&buf, uint64(out.value))
+		writeVarBytes(&buf, out.pkScript)
+	}
+
+	if witness {
+		for _, in := range tx.inputs {
+			writeVarInt(&buf, uint64(len(in.witness)))
+			for _, item := range in.witness {
+				writeVarBytes(&buf, item)
+			}
+		}
+	}
+
+	writeUint32(&buf, tx.lockTime)
+	return buf.Bytes()
+}
+
+// txid returns the transaction ID in display (big-endian) hex
+func (tx *msgTx) txid() string {
+	return hashToString(doubleSHA256(tx.serializeNoWitness()))
+}
+
+// wtxid returns the witness transaction ID in display (big-endian) hex
+func (tx *msgTx) wtxid() string {
+	return hashToString(doubleSHA256(tx.serialize()))
+}
+
+// weight returns the BIP-141 transaction weight
+func (tx *msgTx) weight() int {
+	base := len(tx.serializeNoWitness())
+	return base*(witnessScaleFactor-1) + len(tx.serialize())
+}
+
+// vsize returns the virtual size fees are paid for
+func (tx *msgTx) vsize() int {
+	return (tx.weight() + witnessScaleFactor - 1) / witnessScaleFactor
+}
+
+// copyTx returns a deep copy of the transaction
+func (tx *msgTx) copyTx() *msgTx {
+	clone := &msgTx{version: tx.version, lockTime: tx.lockTime}
+	for _, in := range tx.inputs {
+		copied := *in
+		copied.scriptSig = append([]byte(nil), in.scriptSig...)
+		copied.witness = nil
+		for _, item := range in.witness {
+			copied.witness = append(copied.witness, append([]byte(nil), item...))
+		}
+		clone.inputs = append(clone.inputs, &copied)
+	}
+	for _, out := range tx.outputs {
+		clone.outputs = append(clone.outputs, &txOut{value: out.value, pkScript: append([]byte(nil), out.pkScript...)})
+	}
+	return clone
+}
+
+// deserializeTx decodes a transaction in either the legacy or the BIP-144 format
+func deserializeTx(data []byte) (*msgTx, error) {
+	r := bytes.NewReader(data)
+	tx := &msgTx{}
+
+	version, err := readUint32(r)
+	if err != nil {
+		return nil, err
+	}
+	tx.version = int32(version)
+
+	count, err := readVarInt(r)
+	if err != nil {
+		return nil, err
+	}
+	witness := false
+	if count == 0 {
+		// A zero input count is the BIP-144 marker, followed by the flag
+		flag, err := r.ReadByte()
+		if err != nil || flag != witnessFlag {
+			return nil, fmt.Errorf("invalid witness flag")
+		}
+		witness = true
+		if count, err = readVarInt(r); err != nil {
+			return nil, err
+		}
+	}
+	if count > uint64(r.Len()) {
+		return nil, fmt.Errorf("input count %d exceeds transaction size", count)
+	}
+
+	for i := uint64(0); i < count; i++ {
+		in := &txIn{}
+		if _, err := io.ReadFull(r, in.prevHash[:]); err != nil {
+			return nil, fmt.Errorf("failed to read input: %w", err)
+		}
+		if in.prevIndex, err = readUint32(r); err != nil {
+			return nil, err
+		}
+		if in.scriptSig, err = readVarBytes(r); err != nil {
+			return nil, err
+		}
+		if in.sequence, err = readUint32(r); err != nil {
+			return nil, err
+		}
+		tx.inputs = append(tx.inputs, in)
+	}
+
+	if count, err = readVarInt(r); err != nil {
+		return nil, err
+	}
+	if count > uint64(r.Len()) {
+		return nil, fmt.Errorf("output count %d exceeds transaction size", count)
+	}
+	for i := uint64(0); i < count; i++ {
+		out := &txOut{}
+		value, err := readUint64(r)
+		if err != nil {
+			return nil, err
+		}
+		out.value = int64(value)
+		if out.pkScript, err = readVarBytes(r); err != nil {
+			return nil, err
+		}
+		tx.outputs = append(tx.outputs, out)
+	}
+
+	if witness {
+		for _, in := range tx.inputs {
+			items, err := readVarInt(r)
+			if err != nil {
+				return nil, err
+			}
+			if items > uint64(r.Len()) {
+				return nil, fmt.Errorf("witness item count %d exceeds transaction size", items)
+			}
+			for j := uint64(0); j < items; j++ {
+				item, err := readVarBytes(r)
+				if err != nil {
+					return nil, err
+				}
+				in.witness = append(in.witness, item)
+			}
+		}
+	}
+
+	if tx.lockTime, err = readUint32(r); err != nil {
+		return nil, err
+	}
+	if r.Len() != 0 {
+		return nil, fmt.Errorf("unexpected %d trailing bytes", r.Len())
+	}
+	return tx, nil
+}
+
+// parseTxID decodes a display-order transaction ID into internal byte order
+func parseTxID(txid string) ([32]byte, error) {
+	var hash [32]byte
+	raw, err := hex.DecodeString(txid)
+	if err != nil || len(raw) != len(hash) {
+		return hash, fmt.Errorf("invalid transaction ID: %s", txid)
+	}
+	for i := range raw {
+		hash[i] = raw[len(raw)-1-i]
+	}
+	return hash, nil
+}
+
+// hashToString encodes an internal-order hash in display (reversed) hex
+func hashToString(hash []byte) string {
+	reversed := make([]byte, len(hash))
+	for i := range hash {
+		reversed[i] = hash[len(hash)-1-i]
+	}
+	return hex.EncodeToString(reversed)
+}
+
+func doubleSHA256(data []byte) []byte {
+	first := sha256.Sum256(data)
+	second := sha256.Sum256(first[:])
+	return second[:]
+}
+
+func writeUint32(buf *bytes.Buffer, v uint32) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	buf.Write(b[:])
+}
+
+func writeUint64(buf *bytes.Buffer, v uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	buf.Write(b[:])
+}
+
+// writeVarInt writes a Bitcoin CompactSize integer
+func writeVarInt(buf *bytes.Buffer, v uint64) {
+	switch {
+	case v < 0xfd:
+		buf.WriteByte(byte(v))
+	case v <= 0xffff:
+		buf.WriteByte(0xfd)
+		var b [2]byte
+		binary.LittleEndian.PutUint16(b[:], uint16(v))
+		buf.Write(b[:])
+	case v <= 0xffffffff:
+		buf.WriteByte(0xfe)
+		writeUint32(buf, uint32(v))
+	default:
+		buf.WriteByte(0xff)
+		writeUint64(buf, v)
+	}
+}
+
+// varIntSize returns the encoded length of a Bitcoin CompactSize integer
+func varIntSize(v uint64) int {
+	switch {
+	case v < 0xfd:
+		return 1
+	case v <= 0xffff:
+		return 3
+	case v <= 0xffffffff:
+		return 5
+	default:
+		return 9
+	}
+}
+
+func writeVarBytes(buf *bytes.Buffer, data []byte) {
+	writeVarInt(buf, uint64(len(data)))
+	buf.Write(data)
+}
+
+func readUint32(r *bytes.Reader) (uint32, error) {
+	var b [4]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return 0, fmt.Errorf("unexpected end of transaction: %w", err)
+	}
+	return binary.LittleEndian.Uint32(b[:]), nil
+}
+
+func readUint64(r *bytes.Reader) (uint64, error) {
+	var b [8]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return 0, fmt.Errorf("unexpected end of transaction: %w", err)
+	}
+	return binary.LittleEndian.Uint64(b[:]), nil
+}
+
+func readVarInt(r *bytes.Reader) (uint64, error) {
+	prefix, err := r.ReadByte()
+	if err != nil {
+		return 0, fmt.Errorf("unexpected end of transaction: %w", err)
+	}
+	switch prefix {
+	case 0xfd:
+		var b [2]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return 0, fmt.Errorf("unexpected end of transaction: %w", err)
+		}
+		return uint64(binary.LittleEndian.Uint16(b[:])), nil
+	case 0xfe:
+		v, err := readUint32(r)
+		return uint64(v), err
+	case 0xff:
+		return readUint64(r)
+	default:
+		return uint64(prefix), nil
+	}
+}
+
+func readVarBytes(r *bytes.Reader) ([]byte, error) {
+	length, err := readVarInt(r)
+	if err != nil {
+		return nil, err
+	}
+	if length > uint64(r.Len()) {
+		return nil, fmt.Errorf("length %d exceeds remaining %d bytes", length, r.Len())
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, fmt.Errorf("unexpected end of transaction: %w", err)
+	}
+	return data, nil
+}
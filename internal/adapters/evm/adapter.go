@@ -0,0 +1,651 @@
+package evm
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/jsonrpc"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/rpcpool"
+)
+
+const (
+	// MetadataRawTransaction holds the signed, encoded transaction bytes
+	MetadataRawTransaction = "raw_transaction"
+
+	defaultCurrency      = "ETH"
+	nativeDecimals       = 18
+	defaultPollInterval  = 2 * time.Second
+	feeHistoryBlocks     = 5
+	feeHistoryPercentile = 50
+)
+
+// RPCClient defines the interface for Ethereum JSON-RPC operations
+type RPCClient interface {
+	Call(ctx context.Context, result interface{}, method string, params ...interface{}) error
+}
+
+// NetworkConfig describes an EVM network entry (evm.networks in config.yaml)
+type NetworkConfig struct {
+	Name     string `yaml:"name"`
+	RPCURL   string `yaml:"rpc_url"`
+	ChainID  uint64 `yaml:"chain_id"`
+	Currency string `yaml:"currency"`
+	Testnet  bool   `yaml:"testnet"`
+	// ExplorerURL is the block explorer of the network
+	ExplorerURL string `yaml:"explorer_url"`
+	// Endpoints are further RPC endpoints balanced with rpc_url and failed over to
+	Endpoints []rpcpool.Endpoint `yaml:"endpoints"`
+	// Pool tunes endpoint selection and health tracking when endpoints are set
+	Pool rpcpool.Options `yaml:"pool"`
+}
+
+// Validate checks the network configuration
+func (c NetworkConfig) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("network name cannot be empty")
+	}
+	if c.RPCURL == "" {
+		return fmt.Errorf("rpc_url cannot be empty for network %s", c.Name)
+	}
+	if c.ChainID == 0 {
+		return fmt.Errorf("chain_id cannot be zero for network %s", c.Name)
+	}
+	if err := rpcpool.Validate(c.Endpoints, c.Pool); err != nil {
+		return fmt.Errorf("invalid endpoints for network %s: %w", c.Name, err)
+	}
+	return nil
+}
+
+// Adapter implements the ChainAdapter interface for EVM networks over JSON-RPC
+type Adapter struct {
+	rpcClient    RPCClient
+	config       NetworkConfig
+	pollInterval time.Duration
+	pool         *rpcpool.Pool
+}
+
+// NewAdapter creates a new EVM adapter
+func NewAdapter(rpcClient RPCClient, config NetworkConfig) *Adapter {
+	if config.Currency == "" {
+		config.Currency = defaultCurrency
+	}
+	return &Adapter{
+		rpcClient:    rpcClient,
+		config:       config,
+		pollInterval: defaultPollInterval,
+	}
+}
+
+// NewAdapterFromConfig creates a new EVM adapter backed by an HTTP JSON-RPC client
+func NewAdapterFromConfig(config NetworkConfig) (*Adapter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if len(config.Endpoints) == 0 {
+		return NewAdapter(jsonrpc.NewClient(config.RPCURL, nil), config), nil
+	}
+	endpoints := append([]rpcpool.Endpoint{{URL: config.RPCURL}}, config.Endpoints...)
+	pool, err := rpcpool.New(endpoints, config.Pool, func(ctx context.Context, endpoint string, client *http.Client) (uint64, error) {
+		return NewAdapter(jsonrpc.NewClient(endpoint, client), config).GetBlockNumber(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	adapter := NewAdapter(jsonrpc.NewClient(pool.URL(), pool.Client()), config)
+	adapter.pool = pool
+	return adapter, nil
+}
+
+// NetworkID returns the EIP-155 chain ID of the network
+func (a *Adapter) NetworkID() uint64 {
+	return a.config.ChainID
+}
+
+// GetChainID returns the chain identifier
+func (a *Adapter) GetChainID() string {
+	return a.config.Name
+}
+
+// GetChainType returns the chain type
+func (a *Adapter) GetChainType() entities.ChainType {
+	return entities.ChainTypeEVM
+}
+
+// DescribeChain returns the chain's type, EIP-155 chain ID, testnet flag and native currency
+func (a *Adapter) DescribeChain() (*entities.Chain, error) {
+	chain, err := entities.NewChain(a.config.Name, entities.ChainTypeEVM, strconv.FormatUint(a.config.ChainID, 10), a.config.Testnet, a.config.Currency)
+	if err != nil {
+		return nil, err
+	}
+	chain.SetDecimals(nativeDecimals)
+	return chain, nil
+}
+
+// IsConnected checks if the node answers and serves the configured chain
+func (a *Adapter) IsConnected(ctx context.Context) bool {
+	var chainID string
+	if err := a.rpcClient.Call(ctx, &chainID, "eth_chainId"); err != nil {
+		return false
+	}
+	id, err := decodeUint64(chainID)
+	if err != nil {
+		return false
+	}
+	return id == a.config.ChainID
+}
+
+// GetBlockNumber returns the current block number
+func (a *Adapter) GetBlockNumber(ctx context.Context) (uint64, error) {
+	var result string
+	if err := a.rpcClient.Call(ctx, &result, "eth_blockNumber"); err != nil {
+		return 0, fmt.Errorf("failed to get block number: %w", err)
+	}
+	return decodeUint64(result)
+}
+
+// GetNativeBalance returns the native token balance for an address
+func (a *Adapter) GetNativeBalance(ctx context.Context, address *valueobjects.Address) (*big.Int, error) {
+	var result string
+	if err := a.rpcClient.Call(ctx, &result, "eth_getBalance", address.Value(), "latest"); err != nil {
+		return nil, fmt.Errorf("failed to get balance: %w", err)
+	}
+	return decodeQuantity(result)
+}
+
+// GetBalance returns the native balance for a given address
+func (a *Adapter) GetBalance(ctx context.Context, chainID string, address *valueobjects.Address) (*big.Int, error) {
+	return a.GetNativeBalance(ctx, address)
+}
+
+// BuildTransaction creates a transaction filling nonce, gas and fees from the node
+func (a *Adapter) BuildTransaction(ctx context.Context, params entities.TransactionParams) (*entities.Transaction, error) {
+	if params.ChainID == "" {
+		params.ChainID = a.config.Name
+	}
+
+	tx, err := entities.NewTransaction(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	if tx.Nonce() == nil {
+		if err := a.SetNonce(ctx, tx); err != nil {
+			return nil, err
+		}
+	}
+
+	if tx.GasLimit() == 0 {
+		gas, err := a.EstimateGas(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+		tx.SetGasLimit(gas)
+	}
+
+	if tx.GasPrice() == nil && tx.MaxFeePerGas() == nil {
+		maxFee, tip, err := a.suggestDynamicFees(ctx)
+		if err != nil {
+			gasPrice, gpErr := a.GetGasPrice(ctx)
+			if gpErr != nil {
+				return nil, gpErr
+			}
+			if err := tx.SetGasPrice(gasPrice); err != nil {
+				return nil, err
+			}
+		} else if err := tx.SetDynamicFees(maxFee, tip); err != nil {
+			return nil, err
+		}
+	}
+
+	return tx, nil
+}
+
+// EstimateGas estimates the gas required for a transaction
+func (a *Adapter) EstimateGas(ctx context.Context, tx *entities.Transaction) (uint64, error) {
+	var result string
+	if err := a.rpcClient.Call(ctx, &result, "eth_estimateGas", callObject(tx)); err != nil {
+		return 0, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+	return decodeUint64(result)
+}
+
+// SetNonce sets the next pending nonce of the sender on the transaction
+func (a *Adapter) SetNonce(ctx context.Context, tx *entities.Transaction) error {
+	var result string
+	if err := a.rpcClient.Call(ctx, &result, "eth_getTransactionCount", tx.From().Value(), "pending"); err != nil {
+		return fmt.Errorf("failed to get transaction count: %w", err)
+	}
+	nonce, err := decodeUint64(result)
+	if err != nil {
+		return err
+	}
+	return tx.SetNonce(valueobjects.NewNonce(nonce))
+}
+
+// SignTransaction signs a transaction with the network's EIP-155 chain ID
+func (a *Adapter) SignTransaction(ctx context.Context, tx *entities.Transaction, keys ports.KeyManager, keyID string) error {
+	if err := NewSigner(a.config.ChainID).Sign(ctx, tx, keys, keyID); err != nil {
+		return fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	return nil
+}
+
+// VerifySignature verifies that the transaction was signed by its sender
+func (a *Adapter) VerifySignature(ctx context.Context, tx *entities.Transaction) (bool, error) {
+	return NewSigner(a.config.ChainID).Verify(tx)
+}
+
+// BroadcastTransaction broadcasts a signed transaction to the network
+func (a *Adapter) BroadcastTransaction(ctx context.Context, tx *entities.Transaction) (*valueobjects.Hash, error) {
+	raw, err := rawTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result string
+	if err := a.rpcClient.Call(ctx, &result, "eth_sendRawTransaction", encodeBytes(raw)); err != nil {
+		return nil, fmt.Errorf("failed to broadcast transaction: %w", err)
+	}
+
+	hash, err := valueobjects.NewHash(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create hash: %w", err)
+	}
+
+	if tx.Hash() == nil {
+		if err := tx.SetHash(hash); err != nil {
+			return nil, err
+		}
+	}
+
+	return hash, nil
+}
+
+// BroadcastRaw relays a transaction signed elsewhere, in its RLP or EIP-2718 encoding, after
+// checking its chain ID and signature
+func (a *Adapter) BroadcastRaw(ctx context.Context, raw []byte) (*entities.Transaction, error) {
+	tx, err := NewSigner(a.config.ChainID).Decode(a.config.Name, raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ports.ErrInvalidRawTransaction, err)
+	}
+	if _, err := a.BroadcastTransaction(ctx, tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// rawTransaction extracts the signed payload stored on the transaction
+func rawTransaction(tx *entities.Transaction) ([]byte, error) {
+	switch raw := tx.Metadata()[MetadataRawTransaction].(type) {
+	case []byte:
+		if len(raw) > 0 {
+			return raw, nil
+		}
+	case string:
+		if raw != "" {
+			return decodeBytes(raw)
+		}
+	}
+	return nil, fmt.Errorf("transaction not signed")
+}
+
+// GetTransactionStatus returns the status of a transaction
+func (a *Adapter) GetTransactionStatus(ctx context.Context, hash *valueobjects.Hash) (entities.TxStatus, error) {
+	receipt, err := a.getReceipt(ctx, hash)
+	if err != nil {
+		return entities.TxStatusPending, err
+	}
+	if receipt == nil {
+		return entities.TxStatusPending, nil
+	}
+	return receipt.txStatus(), nil
+}
+
+// GetTransactionReceipt returns the transaction with its on-chain inclusion data
+func (a *Adapter) GetTransactionReceipt(ctx context.Context, hash *valueobjects.Hash) (*entities.Transaction, error) {
+	var rpcTx *rpcTransaction
+	if err := a.rpcClient.Call(ctx, &rpcTx, "eth_getTransactionByHash", hash.Hex()); err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	if rpcTx == nil {
+		return nil, fmt.Errorf("transaction not found: %s", hash.Hex())
+	}
+
+	receipt, err := a.getReceipt(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := rpcTx.toEntity(a.config.Name, receipt)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.SetHash(hash); err != nil {
+		return nil, err
+	}
+
+	if receipt == nil {
+		return tx, nil
+	}
+
+	tx.UpdateStatus(receipt.txStatus())
+	blockNumber, err := decodeUint64(receipt.BlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("invalid receipt block number: %w", err)
+	}
+	tx.SetBlockNumber(blockNumber)
+	if receipt.GasUsed != "" {
+		tx.SetMetadata("gas_used", receipt.GasUsed)
+	}
+	if receipt.EffectiveGasPrice != "" {
+		tx.SetMetadata("effective_gas_price", receipt.EffectiveGasPrice)
+	}
+
+	latest, err := a.GetBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if latest >= blockNumber {
+		tx.SetConfirmations(latest - blockNumber + 1)
+	}
+
+	return tx, nil
+}
+
+// WaitForConfirmation polls the node until the transaction reaches the given confirmations
+func (a *Adapter) WaitForConfirmation(ctx context.Context, hash *valueobjects.Hash, confirmations uint64) error {
+	if confirmations == 0 {
+		confirmations = 1
+	}
+
+	ticker := time.NewTicker(a.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		receipt, err := a.getReceipt(ctx, hash)
+		if err != nil {
+			return err
+		}
+		if receipt != nil {
+			if receipt.txStatus() == entities.TxStatusFailed {
+				return fmt.Errorf("transaction reverted: %s", hash.Hex())
+			}
+			blockNumber, err := decodeUint64(receipt.BlockNumber)
+			if err != nil {
+				return fmt.Errorf("invalid receipt block number: %w", err)
+			}
+			latest, err := a.GetBlockNumber(ctx)
+			if err != nil {
+				return err
+			}
+			if latest >= blockNumber && latest-blockNumber+1 >= confirmations {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// EstimateFee estimates the fee for a transaction
+func (a *Adapter) EstimateFee(ctx context.Context, tx *entities.Transaction) (*entities.Fee, error) {
+	gasLimit := tx.GasLimit()
+	if gasLimit == 0 {
+		gas, err := a.EstimateGas(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+		gasLimit = gas
+	}
+
+	maxFee, tip, err := a.suggestDynamicFees(ctx)
+	if err == nil {
+		return entities.NewEIP1559Fee(gasLimit, maxFee, tip, a.config.Currency)
+	}
+
+	gasPrice, err := a.GetGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return entities.NewFee(gasLimit, gasPrice, a.config.Currency)
+}
+
+// GetGasPrice returns the current legacy gas price
+func (a *Adapter) GetGasPrice(ctx context.Context) (*big.Int, error) {
+	var result string
+	if err := a.rpcClient.Call(ctx, &result, "eth_gasPrice"); err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+	return decodeQuantity(result)
+}
+
+// GetMaxPriorityFee returns the median priority fee paid over recent blocks
+func (a *Adapter) GetMaxPriorityFee(ctx context.Context) (*big.Int, error) {
+	history, err := a.feeHistory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return history.medianReward()
+}
+
+// GetNetworkInfo returns the network information
+func (a *Adapter) GetNetworkInfo(ctx context.Context) (*entities.Network, error) {
+	network, err := entities.NewNetwork(a.config.Name, a.config.Name, a.config.RPCURL)
+	if err != nil {
+		return nil, err
+	}
+	if a.config.ExplorerURL != "" {
+		network.SetExplorerURL(a.config.ExplorerURL)
+	}
+	if a.pool != nil {
+		network.SetEndpoints(a.pool.Health())
+	}
+	return network, nil
+}
+
+// GetPeers returns the number of peers connected to the node
+func (a *Adapter) GetPeers(ctx context.Context) (int, error) {
+	var result string
+	if err := a.rpcClient.Call(ctx, &result, "net_peerCount"); err != nil {
+		return 0, fmt.Errorf("failed to get peer count: %w", err)
+	}
+	peers, err := decodeUint64(result)
+	if err != nil {
+		return 0, err
+	}
+	return int(peers), nil
+}
+
+// GetLatestBlock returns the latest block number
+func (a *Adapter) GetLatestBlock(ctx context.Context) (uint64, error) {
+	return a.GetBlockNumber(ctx)
+}
+
+// suggestDynamicFees derives EIP-1559 fee caps from eth_feeHistory.
+// The max fee leaves room for the base fee to double before inclusion.
+func (a *Adapter) suggestDynamicFees(ctx context.Context) (maxFee, tip *big.Int, err error) {
+	history, err := a.feeHistory(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	baseFee, err := history.nextBaseFee()
+	if err != nil {
+		return nil, nil, err
+	}
+	tip, err = history.medianReward()
+	if err != nil {
+		return nil, nil, err
+	}
+	maxFee = new(big.Int).Mul(baseFee, big.NewInt(2))
+	maxFee.Add(maxFee, tip)
+	return maxFee, tip, nil
+}
+
+func (a *Adapter) feeHistory(ctx context.Context) (*rpcFeeHistory, error) {
+	var history rpcFeeHistory
+	if err := a.rpcClient.Call(ctx, &history, "eth_feeHistory",
+		encodeUint64(feeHistoryBlocks), "latest", []int{feeHistoryPercentile}); err != nil {
+		return nil, fmt.Errorf("failed to get fee history: %w", err)
+	}
+	return &history, nil
+}
+
+func (a *Adapter) getReceipt(ctx context.Context, hash *valueobjects.Hash) (*rpcReceipt, error) {
+	var receipt *rpcReceipt
+	if err := a.rpcClient.Call(ctx, &receipt, "eth_getTransactionReceipt", hash.Hex()); err != nil {
+		return nil, fmt.Errorf("failed to get transaction receipt: %w", err)
+	}
+	return receipt, nil
+}
+
+// callObject builds the JSON-RPC transaction call object
+func callObject(tx *entities.Transaction) map[string]interface{} {
+	call := map[string]interface{}{
+		"from":  tx.From().Value(),
+		"to":    tx.To().Value(),
+		"value": encodeQuantity(tx.Value()),
+	}
+	if len(tx.Data()) > 0 {
+		call["data"] = encodeBytes(tx.Data())
+	}
+	return call
+}
+
+type rpcFeeHistory struct {
+	OldestBlock   string     `json:"oldestBlock"`
+	BaseFeePerGas []string   `json:"baseFeePerGas"`
+	Reward        [][]string `json:"reward"`
+}
+
+// nextBaseFee returns the base fee of the next block
+func (h *rpcFeeHistory) nextBaseFee() (*big.Int, error) {
+	if len(h.BaseFeePerGas) == 0 {
+		return nil, fmt.Errorf("fee history has no base fee")
+	}
+	baseFee, err := decodeQuantity(h.BaseFeePerGas[len(h.BaseFeePerGas)-1])
+	if err != nil {
+		return nil, err
+	}
+	if baseFee.Sign() == 0 {
+		return nil, fmt.Errorf("network does not support EIP-1559")
+	}
+	return baseFee, nil
+}
+
+// medianReward returns the median of the sampled priority fees
+func (h *rpcFeeHistory) medianReward() (*big.Int, error) {
+	rewards := make([]*big.Int, 0, len(h.Reward))
+	for _, block := range h.Reward {
+		if len(block) == 0 {
+			continue
+		}
+		reward, err := decodeQuantity(block[0])
+		if err != nil {
+			return nil, err
+		}
+		rewards = append(rewards, reward)
+	}
+	if len(rewards) == 0 {
+		return nil, fmt.Errorf("fee history has no rewards")
+	}
+	sort.Slice(rewards, func(i, j int) bool { return rewards[i].Cmp(rewards[j]) < 0 })
+	return rewards[len(rewards)/2], nil
+}
+
+type rpcReceipt struct {
+	TransactionHash   string  `json:"transactionHash"`
+	BlockNumber       string  `json:"blockNumber"`
+	From              string  `json:"from"`
+	To                *string `json:"to"`
+	ContractAddress   *string `json:"contractAddress"`
+	Status            string  `json:"status"`
+	GasUsed           string  `json:"gasUsed"`
+	EffectiveGasPrice string  `json:"effectiveGasPrice"`
+}
+
+func (r *rpcReceipt) txStatus() entities.TxStatus {
+	if r.Status == "0x0" {
+		return entities.TxStatusFailed
+	}
+	return entities.TxStatusConfirmed
+}
+
+type rpcTransaction struct {
+	Hash                 string  `json:"hash"`
+	From                 string  `json:"from"`
+	To                   *string `json:"to"`
+	Value                string  `json:"value"`
+	Input                string  `json:"input"`
+	Nonce                string  `json:"nonce"`
+	Gas                  string  `json:"gas"`
+	GasPrice             string  `json:"gasPrice"`
+	MaxFeePerGas         string  `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas string  `json:"maxPriorityFeePerGas"`
+	BlockNumber          *string `json:"blockNumber"`
+}
+
+// toEntity converts a node transaction into a domain transaction
+func (t *rpcTransaction) toEntity(chainID string, receipt *rpcReceipt) (*entities.Transaction, error) {
+	from, err := valueobjects.NewAddress(t.From, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from address: %w", err)
+	}
+
+	toValue := ""
+	if t.To != nil {
+		toValue = *t.To
+	} else if receipt != nil && receipt.ContractAddress != nil {
+		toValue = *receipt.ContractAddress
+	}
+	to, err := valueobjects.NewAddress(toValue, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid to address: %w", err)
+	}
+
+	params := entities.TransactionParams{
+		ChainID: chainID,
+		From:    from,
+		To:      to,
+	}
+
+	if params.Value, err = decodeQuantity(t.Value); err != nil {
+		return nil, fmt.Errorf("invalid value: %w", err)
+	}
+	if params.Data, err = decodeBytes(t.Input); err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+	nonce, err := decodeUint64(t.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nonce: %w", err)
+	}
+	params.Nonce = valueobjects.NewNonce(nonce)
+	if params.GasLimit, err = decodeUint64(t.Gas); err != nil {
+		return nil, fmt.Errorf("invalid gas: %w", err)
+	}
+	if t.MaxFeePerGas != "" {
+		if params.MaxFeePerGas, err = decodeQuantity(t.MaxFeePerGas); err != nil {
+			return nil, fmt.Errorf("invalid max fee per gas: %w", err)
+		}
+		if params.MaxPriorityFee, err = decodeQuantity(t.MaxPriorityFeePerGas); err != nil {
+			return nil, fmt.Errorf("invalid max priority fee: %w", err)
+		}
+	} else if t.GasPrice != "" {
+		if params.GasPrice, err = decodeQuantity(t.GasPrice); err != nil {
+			return nil, fmt.Errorf("invalid gas price: %w", err)
+		}
+	}
+
+	return entities.NewTransaction(params)
+}
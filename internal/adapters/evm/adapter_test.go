@@ -0,0 +1,699 @@
+package evm
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/rpcpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testFrom = "0x742d35cc6634c0532925a3b844bc9e7595f0beb0"
+	testTo   = "0x8ba1f109551bd432803012645ac136ddd64dba72"
+	testHash = "0x88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b"
+)
+
+type rpcHandler func(params []json.RawMessage) (interface{}, error)
+
+// fakeNode is a local JSON-RPC stand-in for an Ethereum node
+type fakeNode struct {
+	mu       sync.Mutex
+	handlers map[string]rpcHandler
+	calls    map[string]int
+	server   *httptest.Server
+}
+
+func newFakeNode(t *testing.T) *fakeNode {
+	t.Helper()
+	node := &fakeNode{
+		handlers: make(map[string]rpcHandler),
+		calls:    make(map[string]int),
+	}
+	node.server = httptest.NewServer(http.HandlerFunc(node.serve))
+	t.Cleanup(node.server.Close)
+	return node
+}
+
+func (n *fakeNode) serve(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     uint64            `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	n.mu.Lock()
+	handler, ok := n.handlers[req.Method]
+	n.calls[req.Method]++
+	n.mu.Unlock()
+
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	if !ok {
+		resp["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+	} else if result, err := handler(req.Params); err != nil {
+		resp["error"] = map[string]interface{}{"code": -32000, "message": err.Error()}
+	} else {
+		resp["result"] = result
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (n *fakeNode) handle(method string, handler rpcHandler) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.handlers[method] = handler
+}
+
+func (n *fakeNode) result(method string, result interface{}) {
+	n.handle(method, func([]json.RawMessage) (interface{}, error) { return result, nil })
+}
+
+func (n *fakeNode) callCount(method string) int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.calls[method]
+}
+
+func newTestAdapter(t *testing.T) (*Adapter, *fakeNode) {
+	t.Helper()
+	node := newFakeNode(t)
+	adapter, err := NewAdapterFromConfig(NetworkConfig{Name: "ethereum", RPCURL: node.server.URL, ChainID: 1, ExplorerURL: "https://etherscan.io"})
+	require.NoError(t, err)
+	adapter.pollInterval = 10 * time.Millisecond
+	return adapter, node
+}
+
+func testAddresses(t *testing.T) (from, to *valueobjects.Address) {
+	t.Helper()
+	from, err := valueobjects.NewAddress(testFrom, "ethereum")
+	require.NoError(t, err)
+	to, err = valueobjects.NewAddress(testTo, "ethereum")
+	require.NoError(t, err)
+	return from, to
+}
+
+func feeHistoryResult() map[string]interface{} {
+	return map[string]interface{}{
+		"oldestBlock":   "0x10",
+		"baseFeePerGas": []string{"0x3b9aca00", "0x3b9aca00", "0x3b9aca00", "0x3b9aca00", "0x3b9aca00", "0x4a817c800"},
+		"reward":        [][]string{{"0x1"}, {"0x77359400"}, {"0x3b9aca00"}, {"0x59682f00"}, {}},
+	}
+}
+
+func TestNetworkConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, NetworkConfig{Name: "ethereum", RPCURL: "http://localhost", ChainID: 1}.Validate())
+	require.Error(t, NetworkConfig{RPCURL: "http://localhost", ChainID: 1}.Validate())
+	require.Error(t, NetworkConfig{Name: "ethereum", ChainID: 1}.Validate())
+	require.Error(t, NetworkConfig{Name: "ethereum", RPCURL: "http://localhost"}.Validate())
+	require.ErrorContains(t, NetworkConfig{Name: "ethereum", RPCURL: "http://localhost", ChainID: 1, Endpoints: []rpcpool.Endpoint{{URL: "localhost:8545"}}}.Validate(), "invalid endpoints")
+
+	_, err := NewAdapterFromConfig(NetworkConfig{Name: "ethereum"})
+	require.Error(t, err)
+}
+
+func TestAdapterChainInfo(t *testing.T) {
+	t.Parallel()
+	adapter, node := newTestAdapter(t)
+	ctx := context.Background()
+
+	assert.Equal(t, "ethereum", adapter.GetChainID())
+	assert.Equal(t, entities.ChainTypeEVM, adapter.GetChainType())
+	assert.Equal(t, uint64(1), adapter.NetworkID())
+
+	chain, err := adapter.DescribeChain()
+	require.NoError(t, err)
+	assert.Equal(t, "1", chain.NetworkID())
+	assert.Equal(t, "ETH", chain.NativeToken())
+	assert.Equal(t, uint8(18), chain.Decimals())
+	assert.False(t, chain.IsTestnet())
+
+	node.result("eth_chainId", "0x1")
+	assert.True(t, adapter.IsConnected(ctx))
+
+	node.result("eth_chainId", "0x89")
+	assert.False(t, adapter.IsConnected(ctx))
+
+	node.result("eth_blockNumber", "0x10d4f")
+	block, err := adapter.GetBlockNumber(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(68943), block)
+
+	latest, err := adapter.GetLatestBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, block, latest)
+
+	node.result("net_peerCount", "0x19")
+	peers, err := adapter.GetPeers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 25, peers)
+
+	network, err := adapter.GetNetworkInfo(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "ethereum", network.ChainID())
+	assert.Equal(t, node.server.URL, network.RPCURL())
+	assert.Equal(t, "https://etherscan.io", network.ExplorerURL())
+}
+
+func TestAdapterEndpointFailover(t *testing.T) {
+	t.Parallel()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	t.Cleanup(down.Close)
+	node := newFakeNode(t)
+	node.result("eth_blockNumber", "0x10d4f")
+
+	adapter, err := NewAdapterFromConfig(NetworkConfig{
+		Name:      "ethereum",
+		RPCURL:    down.URL,
+		ChainID:   1,
+		Endpoints: []rpcpool.Endpoint{{URL: node.server.URL}},
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	for range 3 {
+		block, err := adapter.GetBlockNumber(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(68943), block)
+	}
+
+	network, err := adapter.GetNetworkInfo(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, down.URL, network.RPCURL())
+	endpoints := network.Endpoints()
+	require.Len(t, endpoints, 2)
+	assert.Equal(t, node.server.URL, endpoints[1].URL)
+	assert.True(t, endpoints[1].Healthy)
+	assert.Positive(t, endpoints[0].Failures)
+	assert.Contains(t, endpoints[0].LastError, "502")
+}
+
+func TestAdapterIsConnected_Unreachable(t *testing.T) {
+	t.Parallel()
+	adapter, err := NewAdapterFromConfig(NetworkConfig{Name: "ethereum", RPCURL: "http://127.0.0.1:1", ChainID: 1})
+	require.NoError(t, err)
+	assert.False(t, adapter.IsConnected(context.Background()))
+
+	_, err = adapter.GetBlockNumber(context.Background())
+	require.Error(t, err)
+}
+
+func TestAdapterGetBalance(t *testing.T) {
+	t.Parallel()
+	adapter, node := newTestAdapter(t)
+	ctx := context.Background()
+	from, _ := testAddresses(t)
+
+	node.handle("eth_getBalance", func(params []json.RawMessage) (interface{}, error) {
+		var addr, tag string
+		require.NoError(t, json.Unmarshal(params[0], &addr))
+		require.NoError(t, json.Unmarshal(params[1], &tag))
+		assert.Equal(t, testFrom, addr)
+		assert.Equal(t, "latest", tag)
+		return "0xde0b6b3a7640000", nil
+	})
+
+	balance, err := adapter.GetBalance(ctx, "ethereum", from)
+	require.NoError(t, err)
+	assert.Equal(t, "1000000000000000000", balance.String())
+
+	node.result("eth_getBalance", "0xzz")
+	_, err = adapter.GetNativeBalance(ctx, from)
+	require.Error(t, err)
+}
+
+func TestAdapterBuildTransaction(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	from, to := testAddresses(t)
+
+	t.Run("dynamic fees", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		node.handle("eth_getTransactionCount", func(params []json.RawMessage) (interface{}, error) {
+			var tag string
+			require.NoError(t, json.Unmarshal(params[1], &tag))
+			assert.Equal(t, "pending", tag)
+			return "0x9", nil
+		})
+		node.result("eth_estimateGas", "0x5208")
+		node.result("eth_feeHistory", feeHistoryResult())
+
+		tx, err := adapter.BuildTransaction(ctx, entities.TransactionParams{From: from, To: to, Value: big.NewInt(1)})
+		require.NoError(t, err)
+		assert.Equal(t, "ethereum", tx.ChainID())
+		assert.Equal(t, uint64(9), tx.Nonce().Value())
+		assert.Equal(t, uint64(21000), tx.GasLimit())
+		assert.Nil(t, tx.GasPrice())
+		// median tip of [1, 1gwei, 1.5gwei, 2gwei] is 1.5gwei; next base fee 20gwei
+		assert.Equal(t, "1500000000", tx.MaxPriorityFee().String())
+		assert.Equal(t, "41500000000", tx.MaxFeePerGas().String())
+	})
+
+	t.Run("legacy fallback", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		node.result("eth_getTransactionCount", "0x0")
+		node.result("eth_gasPrice", "0x4a817c800")
+
+		tx, err := adapter.BuildTransaction(ctx, entities.TransactionParams{
+			ChainID: "ethereum", From: from, To: to, GasLimit: 50000,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(50000), tx.GasLimit())
+		assert.Equal(t, "20000000000", tx.GasPrice().String())
+		assert.Nil(t, tx.MaxFeePerGas())
+		assert.Zero(t, node.callCount("eth_estimateGas"))
+	})
+
+	t.Run("keeps provided values", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		tx, err := adapter.BuildTransaction(ctx, entities.TransactionParams{
+			From: from, To: to, Nonce: valueobjects.NewNonce(3), GasLimit: 21000, GasPrice: big.NewInt(5),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), tx.Nonce().Value())
+		assert.Zero(t, node.callCount("eth_getTransactionCount"))
+	})
+
+	t.Run("node errors", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		_, err := adapter.BuildTransaction(ctx, entities.TransactionParams{From: from, To: to})
+		require.Error(t, err)
+
+		node.result("eth_getTransactionCount", "0x1")
+		_, err = adapter.BuildTransaction(ctx, entities.TransactionParams{From: from, To: to})
+		require.ErrorContains(t, err, "failed to estimate gas")
+
+		node.result("eth_estimateGas", "0x5208")
+		_, err = adapter.BuildTransaction(ctx, entities.TransactionParams{From: from, To: to})
+		require.ErrorContains(t, err, "failed to get gas price")
+
+		_, err = adapter.BuildTransaction(ctx, entities.TransactionParams{From: from})
+		require.Error(t, err)
+	})
+}
+
+func TestAdapterEstimateGas_SendsCallObject(t *testing.T) {
+	t.Parallel()
+	adapter, node := newTestAdapter(t)
+	from, to := testAddresses(t)
+
+	node.handle("eth_estimateGas", func(params []json.RawMessage) (interface{}, error) {
+		var call map[string]string
+		require.NoError(t, json.Unmarshal(params[0], &call))
+		assert.Equal(t, testFrom, call["from"])
+		assert.Equal(t, testTo, call["to"])
+		assert.Equal(t, "0x64", call["value"])
+		assert.Equal(t, "0xa9059cbb", call["data"])
+		return "0xb411", nil
+	})
+
+	tx, err := entities.NewTransaction(entities.TransactionParams{
+		ChainID: "ethereum", From: from, To: to, Value: big.NewInt(100), Data: []byte{0xa9, 0x05, 0x9c, 0xbb},
+	})
+	require.NoError(t, err)
+
+	gas, err := adapter.EstimateGas(context.Background(), tx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(46097), gas)
+}
+
+func TestAdapterEstimateFee(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	from, to := testAddresses(t)
+	tx, err := entities.NewTransaction(entities.TransactionParams{ChainID: "ethereum", From: from, To: to})
+	require.NoError(t, err)
+
+	t.Run("eip1559", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		node.result("eth_estimateGas", "0x5208")
+		node.result("eth_feeHistory", feeHistoryResult())
+
+		fee, err := adapter.EstimateFee(ctx, tx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(21000), fee.GasLimit())
+		assert.Equal(t, "41500000000", fee.MaxFeePerGas().String())
+		assert.Equal(t, "1500000000", fee.MaxPriorityFee().String())
+		assert.Equal(t, "ETH", fee.Currency())
+	})
+
+	t.Run("legacy network", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		node.result("eth_estimateGas", "0x5208")
+		node.result("eth_feeHistory", map[string]interface{}{
+			"oldestBlock": "0x1", "baseFeePerGas": []string{"0x0", "0x0"}, "reward": [][]string{{"0x0"}},
+		})
+		node.result("eth_gasPrice", "0x3b9aca00")
+
+		fee, err := adapter.EstimateFee(ctx, tx)
+		require.NoError(t, err)
+		assert.Equal(t, "1000000000", fee.GasPrice().String())
+		assert.Equal(t, "21000000000000", fee.Total().String())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		_, err := adapter.EstimateFee(ctx, tx)
+		require.Error(t, err)
+
+		node.result("eth_estimateGas", "0x5208")
+		_, err = adapter.EstimateFee(ctx, tx)
+		require.Error(t, err)
+
+		_, err = adapter.GetMaxPriorityFee(ctx)
+		require.Error(t, err)
+
+		node.result("eth_feeHistory", map[string]interface{}{"baseFeePerGas": []string{}, "reward": [][]string{}})
+		_, err = adapter.GetMaxPriorityFee(ctx)
+		require.ErrorContains(t, err, "no rewards")
+	})
+}
+
+func TestAdapterGasPriceAndPriorityFee(t *testing.T) {
+	t.Parallel()
+	adapter, node := newTestAdapter(t)
+	ctx := context.Background()
+
+	node.result("eth_gasPrice", "0x4a817c800")
+	gasPrice, err := adapter.GetGasPrice(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "20000000000", gasPrice.String())
+
+	node.handle("eth_feeHistory", func(params []json.RawMessage) (interface{}, error) {
+		var blocks, tag string
+		var percentiles []int
+		require.NoError(t, json.Unmarshal(params[0], &blocks))
+		require.NoError(t, json.Unmarshal(params[1], &tag))
+		require.NoError(t, json.Unmarshal(params[2], &percentiles))
+		assert.Equal(t, "0x5", blocks)
+		assert.Equal(t, "latest", tag)
+		assert.Equal(t, []int{50}, percentiles)
+		return feeHistoryResult(), nil
+	})
+	tip, err := adapter.GetMaxPriorityFee(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "1500000000", tip.String())
+}
+
+func TestAdapterSetNonce(t *testing.T) {
+	t.Parallel()
+	adapter, node := newTestAdapter(t)
+	from, to := testAddresses(t)
+	tx, err := entities.NewTransaction(entities.TransactionParams{ChainID: "ethereum", From: from, To: to})
+	require.NoError(t, err)
+
+	node.result("eth_getTransactionCount", "0x2a")
+	require.NoError(t, adapter.SetNonce(context.Background(), tx))
+	assert.Equal(t, uint64(42), tx.Nonce().Value())
+
+	node.result("eth_getTransactionCount", "nothex")
+	require.Error(t, adapter.SetNonce(context.Background(), tx))
+}
+
+func TestAdapterBroadcastTransaction(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	from, to := testAddresses(t)
+
+	t.Run("unsigned", func(t *testing.T) {
+		t.Parallel()
+		adapter, _ := newTestAdapter(t)
+		tx, _ := entities.NewTransaction(entities.TransactionParams{ChainID: "ethereum", From: from, To: to})
+		_, err := adapter.BroadcastTransaction(ctx, tx)
+		require.ErrorContains(t, err, "not signed")
+	})
+
+	t.Run("raw bytes", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		node.handle("eth_sendRawTransaction", func(params []json.RawMessage) (interface{}, error) {
+			var raw string
+			require.NoError(t, json.Unmarshal(params[0], &raw))
+			assert.Equal(t, "0xf86c", raw)
+			return testHash, nil
+		})
+
+		tx, _ := entities.NewTransaction(entities.TransactionParams{ChainID: "ethereum", From: from, To: to})
+		tx.SetMetadata(MetadataRawTransaction, []byte{0xf8, 0x6c})
+		hash, err := adapter.BroadcastTransaction(ctx, tx)
+		require.NoError(t, err)
+		assert.Equal(t, testHash, hash.Hex())
+		assert.Equal(t, testHash, tx.Hash().Hex())
+	})
+
+	t.Run("raw hex and node error", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		tx, _ := entities.NewTransaction(entities.TransactionParams{ChainID: "ethereum", From: from, To: to})
+		tx.SetMetadata(MetadataRawTransaction, "0xf86c")
+		_, err := adapter.BroadcastTransaction(ctx, tx)
+		require.ErrorContains(t, err, "failed to broadcast transaction")
+
+		node.result("eth_sendRawTransaction", "")
+		_, err = adapter.BroadcastTransaction(ctx, tx)
+		require.Error(t, err)
+	})
+
+	t.Run("externally signed", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		node.handle("eth_sendRawTransaction", func(params []json.RawMessage) (interface{}, error) {
+			var raw string
+			require.NoError(t, json.Unmarshal(params[0], &raw))
+			assert.Equal(t, "0x"+eip155SignedTx, raw)
+			return "0x33469b22e9f636356c4160a87eb19df52b7412e8eac32a4a55ffe88ea8350788", nil
+		})
+
+		raw, _ := hex.DecodeString(eip155SignedTx)
+		tx, err := adapter.BroadcastRaw(ctx, raw)
+		require.NoError(t, err)
+		assert.Equal(t, eip155Address, tx.From().Value())
+		assert.Equal(t, "ethereum", tx.ChainID())
+		assert.Equal(t, "0x33469b22e9f636356c4160a87eb19df52b7412e8eac32a4a55ffe88ea8350788", tx.Hash().Hex())
+
+		_, err = adapter.BroadcastRaw(ctx, raw[:10])
+		require.ErrorIs(t, err, ports.ErrInvalidRawTransaction)
+		assert.Equal(t, 1, node.callCount("eth_sendRawTransaction"))
+	})
+}
+
+func receiptResult(status string) map[string]interface{} {
+	return map[string]interface{}{
+		"transactionHash":   testHash,
+		"blockNumber":       "0x64",
+		"from":              testFrom,
+		"to":                testTo,
+		"status":            status,
+		"gasUsed":           "0x5208",
+		"effectiveGasPrice": "0x3b9aca00",
+	}
+}
+
+func transactionResult() map[string]interface{} {
+	return map[string]interface{}{
+		"hash":                 testHash,
+		"from":                 testFrom,
+		"to":                   testTo,
+		"value":                "0xde0b6b3a7640000",
+		"input":                "0x",
+		"nonce":                "0x9",
+		"gas":                  "0x5208",
+		"maxFeePerGas":         "0x9502f9000",
+		"maxPriorityFeePerGas": "0x59682f00",
+		"blockNumber":          "0x64",
+	}
+}
+
+func TestAdapterTransactionStatus(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	hash, _ := valueobjects.NewHash(testHash)
+
+	adapter, node := newTestAdapter(t)
+	node.result("eth_getTransactionReceipt", nil)
+	status, err := adapter.GetTransactionStatus(ctx, hash)
+	require.NoError(t, err)
+	assert.Equal(t, entities.TxStatusPending, status)
+
+	node.result("eth_getTransactionReceipt", receiptResult("0x1"))
+	status, err = adapter.GetTransactionStatus(ctx, hash)
+	require.NoError(t, err)
+	assert.Equal(t, entities.TxStatusConfirmed, status)
+
+	node.result("eth_getTransactionReceipt", receiptResult("0x0"))
+	status, err = adapter.GetTransactionStatus(ctx, hash)
+	require.NoError(t, err)
+	assert.Equal(t, entities.TxStatusFailed, status)
+
+	failing, _ := newTestAdapter(t)
+	_, err = failing.GetTransactionStatus(ctx, hash)
+	require.Error(t, err)
+}
+
+func TestAdapterGetTransactionReceipt(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	hash, _ := valueobjects.NewHash(testHash)
+
+	t.Run("mined", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		node.result("eth_getTransactionByHash", transactionResult())
+		node.result("eth_getTransactionReceipt", receiptResult("0x1"))
+		node.result("eth_blockNumber", "0x6d")
+
+		tx, err := adapter.GetTransactionReceipt(ctx, hash)
+		require.NoError(t, err)
+		assert.Equal(t, testHash, tx.Hash().Hex())
+		assert.Equal(t, testFrom, tx.From().Value())
+		assert.Equal(t, "1000000000000000000", tx.Value().String())
+		assert.Equal(t, uint64(9), tx.Nonce().Value())
+		assert.Equal(t, "40000000000", tx.MaxFeePerGas().String())
+		assert.Equal(t, entities.TxStatusConfirmed, tx.Status())
+		assert.Equal(t, uint64(100), tx.BlockNumber())
+		assert.Equal(t, uint64(10), tx.Confirmations())
+		assert.Equal(t, "0x5208", tx.Metadata()["gas_used"])
+	})
+
+	t.Run("pending legacy", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		pending := transactionResult()
+		delete(pending, "maxFeePerGas")
+		delete(pending, "maxPriorityFeePerGas")
+		pending["gasPrice"] = "0x3b9aca00"
+		pending["blockNumber"] = nil
+		node.result("eth_getTransactionByHash", pending)
+		node.result("eth_getTransactionReceipt", nil)
+
+		tx, err := adapter.GetTransactionReceipt(ctx, hash)
+		require.NoError(t, err)
+		assert.Equal(t, entities.TxStatusPending, tx.Status())
+		assert.Equal(t, "1000000000", tx.GasPrice().String())
+		assert.Zero(t, tx.BlockNumber())
+	})
+
+	t.Run("contract creation", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		creation := transactionResult()
+		creation["to"] = nil
+		receipt := receiptResult("0x1")
+		receipt["to"] = nil
+		receipt["contractAddress"] = testTo
+		node.result("eth_getTransactionByHash", creation)
+		node.result("eth_getTransactionReceipt", receipt)
+		node.result("eth_blockNumber", "0x64")
+
+		tx, err := adapter.GetTransactionReceipt(ctx, hash)
+		require.NoError(t, err)
+		assert.Equal(t, testTo, tx.To().Value())
+		assert.Equal(t, uint64(1), tx.Confirmations())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		node.result("eth_getTransactionByHash", nil)
+		_, err := adapter.GetTransactionReceipt(ctx, hash)
+		require.ErrorContains(t, err, "transaction not found")
+	})
+
+	t.Run("malformed transaction", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		bad := transactionResult()
+		bad["nonce"] = "0x"
+		node.result("eth_getTransactionByHash", bad)
+		node.result("eth_getTransactionReceipt", nil)
+		_, err := adapter.GetTransactionReceipt(ctx, hash)
+		require.ErrorContains(t, err, "invalid nonce")
+	})
+}
+
+func TestAdapterWaitForConfirmation(t *testing.T) {
+	t.Parallel()
+	hash, _ := valueobjects.NewHash(testHash)
+
+	t.Run("reaches confirmations", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		var mu sync.Mutex
+		head := uint64(0x64)
+		node.result("eth_getTransactionReceipt", receiptResult("0x1"))
+		node.handle("eth_blockNumber", func([]json.RawMessage) (interface{}, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			head++
+			return encodeUint64(head), nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		require.NoError(t, adapter.WaitForConfirmation(ctx, hash, 3))
+	})
+
+	t.Run("reverted", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		node.result("eth_getTransactionReceipt", receiptResult("0x0"))
+		err := adapter.WaitForConfirmation(context.Background(), hash, 0)
+		require.ErrorContains(t, err, "reverted")
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		node.result("eth_getTransactionReceipt", nil)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		err := adapter.WaitForConfirmation(ctx, hash, 1)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestHexHelpers(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "0x0", encodeQuantity(nil))
+	assert.Equal(t, "0x0", encodeQuantity(big.NewInt(0)))
+	assert.Equal(t, "0x5208", encodeUint64(21000))
+
+	_, err := decodeQuantity("0x")
+	require.Error(t, err)
+	_, err = decodeQuantity("0xgg")
+	require.Error(t, err)
+	_, err = decodeUint64("0x10000000000000000")
+	require.Error(t, err)
+
+	b, err := decodeBytes("0xabc")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x0a, 0xbc}, b)
+	_, err = decodeBytes("0xzz")
+	require.Error(t, err)
+}
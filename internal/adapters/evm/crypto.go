@@ -0,0 +1,163 @@
+package evm
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	privateKeyLength = 32
+	signatureLength  = 65
+	addressLength    = 20
+)
+
+// Keccak256 returns the legacy Keccak-256 digest used across Ethereum
+func Keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+// AddressFromPrivateKey derives the EIP-55 checksummed address of a private key
+func AddressFromPrivateKey(privateKey []byte) (string, error) {
+	key, err := parsePrivateKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+	return addressFromPublicKey(key.PubKey()), nil
+}
+
+// AddressFromPublicKey derives the EIP-55 checksummed address of a serialized public key
+func AddressFromPublicKey(publicKey []byte) (string, error) {
+	pub, err := secp256k1.ParsePubKey(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("invalid public key: %w", err)
+	}
+	return addressFromPublicKey(pub), nil
+}
+
+// ChecksumAddress returns the EIP-55 mixed-case form of a hex address
+func ChecksumAddress(address string) (string, error) {
+	raw, err := parseAddress(address)
+	if err != nil {
+		return "", err
+	}
+	return checksumAddress(raw), nil
+}
+
+// signDigest signs a 32-byte digest with the key keyID, which must control address, returning
+// [R || S || V] with V in {0, 1}
+func signDigest(ctx context.Context, keys ports.KeyManager, keyID string, digest []byte, address string) ([]byte, error) {
+	publicKey, err := keys.PublicKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := AddressFromPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("key %s is not a secp256k1 key: %w", keyID, err)
+	}
+	if !strings.EqualFold(signer, address) {
+		return nil, fmt.Errorf("key %s does not match sender %s", keyID, address)
+	}
+
+	sig, err := keys.Sign(ctx, keyID, digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign with key %s: %w", keyID, err)
+	}
+	if recovered, err := recoverAddress(digest, sig); err != nil || recovered != signer {
+		return nil, fmt.Errorf("key %s returned an invalid signature", keyID)
+	}
+	return sig, nil
+}
+
+// recoverAddress recovers the signer address from a digest and [R || S || V] signature
+func recoverAddress(digest, sig []byte) (string, error) {
+	if len(sig) != signatureLength {
+		return "", fmt.Errorf("invalid signature length: %d", len(sig))
+	}
+	if sig[64] > 1 {
+		return "", fmt.Errorf("invalid signature recovery id: %d", sig[64])
+	}
+	compact := make([]byte, signatureLength)
+	compact[0] = sig[64] + 27
+	copy(compact[1:], sig[:64])
+	pub, _, err := ecdsa.RecoverCompact(compact, digest)
+	if err != nil {
+		return "", fmt.Errorf("failed to recover public key: %w", err)
+	}
+	return addressFromPublicKey(pub), nil
+}
+
+// signatureFromValues builds an [R || S || V] signature from the values of a signed
+// transaction, refusing the malleable high-S form as Ethereum nodes do
+func signatureFromValues(r, s []byte, recoveryID uint64) ([]byte, error) {
+	var rScalar, sScalar secp256k1.ModNScalar
+	if len(r) > 32 || rScalar.SetByteSlice(r) || rScalar.IsZero() {
+		return nil, fmt.Errorf("invalid signature R value")
+	}
+	if len(s) > 32 || sScalar.SetByteSlice(s) || sScalar.IsZero() || sScalar.IsOverHalfOrder() {
+		return nil, fmt.Errorf("invalid signature S value")
+	}
+	if recoveryID > 1 {
+		return nil, fmt.Errorf("invalid signature recovery id: %d", recoveryID)
+	}
+	sig := make([]byte, signatureLength)
+	copy(sig[32-len(r):32], r)
+	copy(sig[64-len(s):64], s)
+	sig[64] = byte(recoveryID)
+	return sig, nil
+}
+
+func parsePrivateKey(privateKey []byte) (*secp256k1.PrivateKey, error) {
+	if len(privateKey) != privateKeyLength {
+		return nil, fmt.Errorf("private key must be %d bytes, got %d", privateKeyLength, len(privateKey))
+	}
+	var scalar secp256k1.ModNScalar
+	if overflow := scalar.SetByteSlice(privateKey); overflow || scalar.IsZero() {
+		return nil, fmt.Errorf("invalid private key")
+	}
+	return secp256k1.NewPrivateKey(&scalar), nil
+}
+
+func addressFromPublicKey(pub *secp256k1.PublicKey) string {
+	uncompressed := pub.SerializeUncompressed()
+	return checksumAddress(Keccak256(uncompressed[1:])[12:])
+}
+
+// parseAddress decodes a 0x-prefixed 20-byte hex address
+func parseAddress(address string) ([]byte, error) {
+	cleaned := strings.TrimPrefix(strings.TrimPrefix(address, "0x"), "0X")
+	raw, err := hex.DecodeString(cleaned)
+	if err != nil || len(raw) != addressLength {
+		return nil, fmt.Errorf("invalid EVM address: %s", address)
+	}
+	return raw, nil
+}
+
+func checksumAddress(raw []byte) string {
+	lower := hex.EncodeToString(raw)
+	hash := Keccak256([]byte(lower))
+	out := []byte(lower)
+	for i, c := range out {
+		if c < 'a' {
+			continue
+		}
+		nibble := hash[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if nibble&0x0f >= 8 {
+			out[i] = c - 32
+		}
+	}
+	return "0x" + string(out)
+}
@@ -0,0 +1,143 @@
+package evm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+)
+
+// ERC-20 function selectors
+var (
+	selectorBalanceOf = []byte{0x70, 0xa0, 0x82, 0x31}
+	selectorDecimals  = []byte{0x31, 0x3c, 0xe5, 0x67}
+	selectorSymbol    = []byte{0x95, 0xd8, 0x9b, 0x41}
+	selectorTransfer  = []byte{0xa9, 0x05, 0x9c, 0xbb}
+)
+
+const abiWordLength = 32
+
+// EncodeTokenTransfer returns the ERC-20 transfer(address,uint256) calldata
+func EncodeTokenTransfer(to *valueobjects.Address, amount *big.Int) ([]byte, error) {
+	if to == nil {
+		return nil, fmt.Errorf("recipient address cannot be nil")
+	}
+	if amount == nil || amount.Sign() < 0 {
+		return nil, fmt.Errorf("token amount must be non-negative")
+	}
+	if amount.BitLen() > 256 {
+		return nil, fmt.Errorf("token amount exceeds uint256")
+	}
+	recipient, err := parseAddress(to.Value())
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 0, len(selectorTransfer)+2*abiWordLength)
+	data = append(data, selectorTransfer...)
+	data = append(data, abiWord(recipient)...)
+	data = append(data, abiWord(amount.Bytes())...)
+	return data, nil
+}
+
+// EncodeTokenTransfer returns the ERC-20 transfer calldata for the network
+func (a *Adapter) EncodeTokenTransfer(to *valueobjects.Address, amount *big.Int) ([]byte, error) {
+	return EncodeTokenTransfer(to, amount)
+}
+
+// GetTokenBalance returns the ERC-20 balanceOf for a given address and token
+func (a *Adapter) GetTokenBalance(ctx context.Context, chainID string, address, tokenAddress *valueobjects.Address) (*big.Int, error) {
+	owner, err := parseAddress(address.Value())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := a.callContract(ctx, tokenAddress, append(append([]byte{}, selectorBalanceOf...), abiWord(owner)...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token balance: %w", err)
+	}
+	if len(result) < abiWordLength {
+		return nil, fmt.Errorf("failed to get token balance: unexpected response length %d", len(result))
+	}
+	return new(big.Int).SetBytes(result[:abiWordLength]), nil
+}
+
+// GetTokenMetadata returns the ERC-20 symbol and decimals of a token contract
+func (a *Adapter) GetTokenMetadata(ctx context.Context, tokenAddress *valueobjects.Address) (*entities.Token, error) {
+	result, err := a.callContract(ctx, tokenAddress, selectorDecimals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token decimals: %w", err)
+	}
+	if len(result) < abiWordLength {
+		return nil, fmt.Errorf("failed to get token decimals: unexpected response length %d", len(result))
+	}
+	decimals := new(big.Int).SetBytes(result[:abiWordLength])
+	if !decimals.IsUint64() || decimals.Uint64() > 255 {
+		return nil, fmt.Errorf("invalid token decimals: %s", decimals)
+	}
+
+	result, err = a.callContract(ctx, tokenAddress, selectorSymbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token symbol: %w", err)
+	}
+	symbol, err := decodeABIString(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token symbol: %w", err)
+	}
+
+	return entities.NewToken(tokenAddress, symbol, uint8(decimals.Uint64()))
+}
+
+// callContract performs a read-only eth_call against the latest block
+func (a *Adapter) callContract(ctx context.Context, contract *valueobjects.Address, data []byte) ([]byte, error) {
+	if contract == nil {
+		return nil, fmt.Errorf("contract address cannot be nil")
+	}
+	if _, err := parseAddress(contract.Value()); err != nil {
+		return nil, err
+	}
+
+	call := map[string]interface{}{
+		"to":   contract.Value(),
+		"data": encodeBytes(data),
+	}
+
+	var result string
+	if err := a.rpcClient.Call(ctx, &result, "eth_call", call, "latest"); err != nil {
+		return nil, err
+	}
+	return decodeBytes(result)
+}
+
+// abiWord left-pads a value to a 32-byte ABI word
+func abiWord(b []byte) []byte {
+	word := make([]byte, abiWordLength)
+	copy(word[abiWordLength-len(b):], b)
+	return word
+}
+
+// decodeABIString decodes an ABI string return value, accepting legacy bytes32 symbols
+func decodeABIString(data []byte) (string, error) {
+	if len(data) == abiWordLength {
+		// Tokens such as MKR return the symbol as a right-padded bytes32
+		return string(bytes.TrimRight(data, "\x00")), nil
+	}
+	if len(data) < 2*abiWordLength {
+		return "", fmt.Errorf("unexpected response length %d", len(data))
+	}
+
+	offset := new(big.Int).SetBytes(data[:abiWordLength])
+	if !offset.IsUint64() || offset.Uint64()+abiWordLength > uint64(len(data)) {
+		return "", fmt.Errorf("invalid string offset")
+	}
+	start := offset.Uint64()
+	length := new(big.Int).SetBytes(data[start : start+abiWordLength])
+	if !length.IsUint64() || start+abiWordLength+length.Uint64() > uint64(len(data)) {
+		return "", fmt.Errorf("invalid string length")
+	}
+	begin := start + abiWordLength
+	return string(data[begin : begin+length.Uint64()]), nil
+}
@@ -0,0 +1,190 @@
+package evm
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testToken = "0xdac17f958d2ee523a2206206994597c13d831ec7"
+	// ABI encoding of the string "USDT"
+	usdtSymbolResult = "0x" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000004" +
+		"5553445400000000000000000000000000000000000000000000000000000000"
+)
+
+// tokenContract dispatches eth_call requests on their ERC-20 selector
+func tokenContract(t *testing.T, responses map[string]interface{}) rpcHandler {
+	return func(params []json.RawMessage) (interface{}, error) {
+		var call map[string]string
+		require.NoError(t, json.Unmarshal(params[0], &call))
+		require.Equal(t, testToken, call["to"])
+		selector := call["data"][:10]
+		switch v := responses[selector].(type) {
+		case error:
+			return nil, v
+		case nil:
+			return nil, errors.New("execution reverted")
+		default:
+			return v, nil
+		}
+	}
+}
+
+func tokenAddress(t *testing.T) *valueobjects.Address {
+	t.Helper()
+	token, err := valueobjects.NewAddress(testToken, "ethereum")
+	require.NoError(t, err)
+	return token
+}
+
+func TestAdapterGetTokenBalance(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	from, _ := testAddresses(t)
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		node.handle("eth_call", func(params []json.RawMessage) (interface{}, error) {
+			var call map[string]string
+			require.NoError(t, json.Unmarshal(params[0], &call))
+			assert.Equal(t, "0x70a08231000000000000000000000000"+strings.TrimPrefix(testFrom, "0x"), call["data"])
+			return "0x00000000000000000000000000000000000000000000000000000000000f4240", nil
+		})
+
+		balance, err := adapter.GetTokenBalance(ctx, "ethereum", from, tokenAddress(t))
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(1000000), balance)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		node.handle("eth_call", tokenContract(t, map[string]interface{}{}))
+		_, err := adapter.GetTokenBalance(ctx, "ethereum", from, tokenAddress(t))
+		require.Error(t, err)
+
+		node.result("eth_call", "0x01")
+		_, err = adapter.GetTokenBalance(ctx, "ethereum", from, tokenAddress(t))
+		require.Error(t, err)
+
+		invalid, _ := valueobjects.NewAddress("0x1234", "ethereum")
+		_, err = adapter.GetTokenBalance(ctx, "ethereum", invalid, tokenAddress(t))
+		require.Error(t, err)
+		_, err = adapter.GetTokenBalance(ctx, "ethereum", from, invalid)
+		require.Error(t, err)
+	})
+}
+
+func TestAdapterGetTokenMetadata(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	decimals := "0x0000000000000000000000000000000000000000000000000000000000000006"
+
+	t.Run("string symbol", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		node.handle("eth_call", tokenContract(t, map[string]interface{}{
+			"0x313ce567": decimals,
+			"0x95d89b41": usdtSymbolResult,
+		}))
+
+		token, err := adapter.GetTokenMetadata(ctx, tokenAddress(t))
+		require.NoError(t, err)
+		assert.Equal(t, "USDT", token.Symbol())
+		assert.Equal(t, uint8(6), token.Decimals())
+		assert.Equal(t, testToken, token.Address().Value())
+	})
+
+	t.Run("bytes32 symbol", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		node.handle("eth_call", tokenContract(t, map[string]interface{}{
+			"0x313ce567": decimals,
+			"0x95d89b41": "0x4d4b520000000000000000000000000000000000000000000000000000000000",
+		}))
+
+		token, err := adapter.GetTokenMetadata(ctx, tokenAddress(t))
+		require.NoError(t, err)
+		assert.Equal(t, "MKR", token.Symbol())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		node.handle("eth_call", tokenContract(t, map[string]interface{}{}))
+		_, err := adapter.GetTokenMetadata(ctx, tokenAddress(t))
+		require.Error(t, err)
+
+		node.handle("eth_call", tokenContract(t, map[string]interface{}{
+			"0x313ce567": "0x0000000000000000000000000000000000000000000000000000000000000100",
+		}))
+		_, err = adapter.GetTokenMetadata(ctx, tokenAddress(t))
+		require.Error(t, err)
+
+		node.handle("eth_call", tokenContract(t, map[string]interface{}{"0x313ce567": decimals}))
+		_, err = adapter.GetTokenMetadata(ctx, tokenAddress(t))
+		require.Error(t, err)
+
+		node.handle("eth_call", tokenContract(t, map[string]interface{}{"0x313ce567": decimals, "0x95d89b41": "0x01"}))
+		_, err = adapter.GetTokenMetadata(ctx, tokenAddress(t))
+		require.Error(t, err)
+	})
+}
+
+func TestEncodeTokenTransfer(t *testing.T) {
+	t.Parallel()
+	adapter, _ := newTestAdapter(t)
+	var _ ports.TokenTransferEncoder = adapter
+	var _ ports.TokenMetadataProvider = adapter
+	_, to := testAddresses(t)
+
+	data, err := adapter.EncodeTokenTransfer(to, big.NewInt(1000000))
+	require.NoError(t, err)
+	assert.Equal(t,
+		"a9059cbb"+
+			"000000000000000000000000"+strings.TrimPrefix(testTo, "0x")+
+			"00000000000000000000000000000000000000000000000000000000000f4240",
+		hex.EncodeToString(data))
+
+	_, err = EncodeTokenTransfer(nil, big.NewInt(1))
+	require.Error(t, err)
+	_, err = EncodeTokenTransfer(to, big.NewInt(-1))
+	require.Error(t, err)
+	_, err = EncodeTokenTransfer(to, new(big.Int).Lsh(big.NewInt(1), 256))
+	require.Error(t, err)
+	invalid, _ := valueobjects.NewAddress("0xabc", "ethereum")
+	_, err = EncodeTokenTransfer(invalid, big.NewInt(1))
+	require.Error(t, err)
+}
+
+func TestDecodeABIString(t *testing.T) {
+	t.Parallel()
+
+	raw, _ := decodeBytes(usdtSymbolResult)
+	symbol, err := decodeABIString(raw)
+	require.NoError(t, err)
+	assert.Equal(t, "USDT", symbol)
+
+	badOffset := append([]byte{}, raw...)
+	badOffset[31] = 0xff
+	_, err = decodeABIString(badOffset)
+	require.Error(t, err)
+
+	badLength := append([]byte{}, raw...)
+	badLength[63] = 0xff
+	_, err = decodeABIString(badLength)
+	require.Error(t, err)
+}
@@ -2,12 +2,14 @@ package harness
 
 import (
 	"context"
-	"crypto/rand"
 	"fmt"
 	"math/big"
+	"strconv"
 	"sync"
 
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/evm"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
 )
 
@@ -17,9 +19,13 @@ type EVMHarness struct {
 	transactions map[string]*entities.Transaction
 	blockNumber  uint64
 	gasPrice     *big.Int
+	networkID    uint64
 	mu           sync.RWMutex
 }
 
+// defaultNetworkID is the EIP-155 chain ID used by local development nodes
+const defaultNetworkID = 1337
+
 func NewEVMHarness(chainID string) *EVMHarness {
 	return &EVMHarness{
 		chainID:      chainID,
@@ -27,9 +33,23 @@ func NewEVMHarness(chainID string) *EVMHarness {
 		transactions: make(map[string]*entities.Transaction),
 		blockNumber:  1,
 		gasPrice:     big.NewInt(20000000000),
+		networkID:    defaultNetworkID,
 	}
 }
 
+// SetNetworkID sets the EIP-155 chain ID used for signing
+func (h *EVMHarness) SetNetworkID(networkID uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.networkID = networkID
+}
+
+func (h *EVMHarness) signer() *evm.Signer {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return evm.NewSigner(h.networkID)
+}
+
 func (h *EVMHarness) GetChainID() string {
 	return h.chainID
 }
@@ -38,6 +58,18 @@ func (h *EVMHarness) GetChainType() entities.ChainType {
 	return entities.ChainTypeEVM
 }
 
+// DescribeChain describes the simulated chain as a testnet paying fees in ETH
+func (h *EVMHarness) DescribeChain() (*entities.Chain, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	chain, err := entities.NewChain(h.chainID, entities.ChainTypeEVM, strconv.FormatUint(h.networkID, 10), true, "ETH")
+	if err != nil {
+		return nil, err
+	}
+	chain.SetDecimals(18)
+	return chain, nil
+}
+
 func (h *EVMHarness) IsConnected(ctx context.Context) bool {
 	return true
 }
@@ -98,28 +130,12 @@ func (h *EVMHarness) SetNonce(ctx context.Context, tx *entities.Transaction) err
 	return nil
 }
 
-func (h *EVMHarness) SignTransaction(ctx context.Context, tx *entities.Transaction, privateKey []byte) error {
-	if len(privateKey) == 0 {
-		return fmt.Errorf("private key cannot be empty")
-	}
-	hashBytes := make([]byte, 32)
-	if _, err := rand.Read(hashBytes); err != nil {
-		return fmt.Errorf("failed to generate random hash: %w", err)
-	}
-	hash, _ := valueobjects.NewHashFromBytes(hashBytes)
-	sigBytes := make([]byte, 65)
-	if _, err := rand.Read(sigBytes); err != nil {
-		return fmt.Errorf("failed to generate random signature: %w", err)
-	}
-	sig, _ := valueobjects.NewSignatureFromBytes(sigBytes)
-	if err := tx.SetHash(hash); err != nil {
-		return err
-	}
-	return tx.SetSignature(sig)
+func (h *EVMHarness) SignTransaction(ctx context.Context, tx *entities.Transaction, keys ports.KeyManager, keyID string) error {
+	return h.signer().Sign(ctx, tx, keys, keyID)
 }
 
 func (h *EVMHarness) VerifySignature(ctx context.Context, tx *entities.Transaction) (bool, error) {
-	return tx.Signature() != nil, nil
+	return h.signer().Verify(tx)
 }
 
 func (h *EVMHarness) BroadcastTransaction(ctx context.Context, tx *entities.Transaction) (*valueobjects.Hash, error) {
@@ -136,6 +152,18 @@ func (h *EVMHarness) BroadcastTransaction(ctx context.Context, tx *entities.Tran
 	return tx.Hash(), nil
 }
 
+// BroadcastRaw decodes a transaction signed elsewhere and records it as mined
+func (h *EVMHarness) BroadcastRaw(ctx context.Context, raw []byte) (*entities.Transaction, error) {
+	tx, err := h.signer().Decode(h.chainID, raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ports.ErrInvalidRawTransaction, err)
+	}
+	if _, err := h.BroadcastTransaction(ctx, tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
 func (h *EVMHarness) GetTransactionStatus(ctx context.Context, hash *valueobjects.Hash) (entities.TxStatus, error) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
@@ -202,3 +230,7 @@ func (h *EVMHarness) SetTokenBalance(address, tokenAddress string, balance *big.
 	key := fmt.Sprintf("%s:%s", address, tokenAddress)
 	h.accounts[key] = new(big.Int).Set(balance)
 }
+
+func (h *EVMHarness) EncodeTokenTransfer(to *valueobjects.Address, amount *big.Int) ([]byte, error) {
+	return evm.EncodeTokenTransfer(to, amount)
+}
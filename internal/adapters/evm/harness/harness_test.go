@@ -1,34 +1,57 @@
 package harness
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
 	"math/big"
+	"strings"
 	"testing"
 
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/evm"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/keystore"
 	"github.com/stretchr/testify/require"
 )
 
+const testRecipient = "0x3535353535353535353535353535353535353535"
+
+// testAccount returns a key manager holding a deterministic key as "test" and its address
+func testAccount(t *testing.T) (ports.KeyManager, string) {
+	t.Helper()
+	key := bytes.Repeat([]byte{0x46}, 32)
+	address, err := evm.AddressFromPrivateKey(key)
+	require.NoError(t, err)
+	keys, err := keystore.NewManager(keystore.Key{ID: "test", Curve: ports.CurveSecp256k1, PrivateKey: key})
+	require.NoError(t, err)
+	return keys, address
+}
+
 func TestHarnessBasicFlows(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
 	h := NewEVMHarness("evm-mainnet")
+	keys, from := testAccount(t)
 
 	// balances
-	addr, _ := valueobjects.NewAddress("0xabc", "evm-mainnet")
+	addr, _ := valueobjects.NewAddress(from, "evm-mainnet")
 	h.SetBalance(addr.String(), big.NewInt(100))
 	bal, err := h.GetBalance(ctx, "evm-mainnet", addr)
 	require.NoError(t, err)
 	require.Equal(t, big.NewInt(100), bal)
 
 	// build + sign + broadcast
-	to, _ := valueobjects.NewAddress("0xdef", "evm-mainnet")
+	to, _ := valueobjects.NewAddress(testRecipient, "evm-mainnet")
 	tx, err := h.BuildTransaction(ctx, entities.TransactionParams{ChainID: "evm-mainnet", From: addr, To: to, Value: big.NewInt(1)})
 	require.NoError(t, err)
 	require.NotNil(t, tx)
 
-	err = h.SignTransaction(ctx, tx, []byte("pk"))
+	err = h.SignTransaction(ctx, tx, keys, "missing")
+	require.Error(t, err)
+
+	err = h.SignTransaction(ctx, tx, keys, "test")
 	require.NoError(t, err)
 	require.NotNil(t, tx.Hash())
 
@@ -49,12 +72,30 @@ func TestHarnessBasicFlows(t *testing.T) {
 	fee, err := h.EstimateFee(ctx, tx)
 	require.NoError(t, err)
 	require.NotNil(t, fee)
+
+	// relay of a transaction signed elsewhere
+	signed, err := h.BuildTransaction(ctx, entities.TransactionParams{ChainID: "evm-mainnet", From: addr, To: to, Value: big.NewInt(2)})
+	require.NoError(t, err)
+	require.NoError(t, evm.NewSigner(defaultNetworkID).Sign(ctx, signed, keys, "test"))
+	raw, err := hex.DecodeString(strings.TrimPrefix(signed.Metadata()[evm.MetadataRawTransaction].(string), "0x"))
+	require.NoError(t, err)
+	relayed, err := h.BroadcastRaw(ctx, raw)
+	require.NoError(t, err)
+	require.Equal(t, signed.Hash().Hex(), relayed.Hash().Hex())
+	status, err = h.GetTransactionStatus(ctx, relayed.Hash())
+	require.NoError(t, err)
+	require.Equal(t, entities.TxStatusConfirmed, status)
+
+	_, err = h.BroadcastRaw(ctx, []byte{0x01})
+	require.ErrorIs(t, err, ports.ErrInvalidRawTransaction)
 }
 
 func TestHarnessAllMethods(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
 	h := NewEVMHarness("evm-test")
+	h.SetNetworkID(31337)
+	keys, from := testAccount(t)
 
 	// chain info
 	require.Equal(t, "evm-test", h.GetChainID())
@@ -86,7 +127,7 @@ func TestHarnessAllMethods(t *testing.T) {
 	require.Greater(t, peers, 0)
 
 	// balances with native and token
-	addr, _ := valueobjects.NewAddress("0x123", "evm-test")
+	addr, _ := valueobjects.NewAddress(from, "evm-test")
 	h.SetBalance(addr.String(), big.NewInt(500))
 	nb, err := h.GetNativeBalance(ctx, addr)
 	require.NoError(t, err)
@@ -98,8 +139,13 @@ func TestHarnessAllMethods(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, big.NewInt(999), tb)
 
+	recipient, _ := valueobjects.NewAddress(testRecipient, "evm-test")
+	calldata, err := h.EncodeTokenTransfer(recipient, big.NewInt(999))
+	require.NoError(t, err)
+	require.Len(t, calldata, 68)
+
 	// transaction flow with all steps
-	to, _ := valueobjects.NewAddress("0xto", "evm-test")
+	to, _ := valueobjects.NewAddress(testRecipient, "evm-test")
 	tx, _ := h.BuildTransaction(ctx, entities.TransactionParams{ChainID: "evm-test", From: addr, To: to, Value: big.NewInt(10)})
 
 	gas, err := h.EstimateGas(ctx, tx)
@@ -109,13 +155,19 @@ func TestHarnessAllMethods(t *testing.T) {
 	err = h.SetNonce(ctx, tx)
 	require.NoError(t, err)
 
-	err = h.SignTransaction(ctx, tx, []byte("key"))
+	err = h.SignTransaction(ctx, tx, keys, "test")
 	require.NoError(t, err)
 
 	valid, err := h.VerifySignature(ctx, tx)
 	require.NoError(t, err)
 	require.True(t, valid)
 
+	// a different network ID recovers a different sender
+	h.SetNetworkID(1)
+	valid, err = h.VerifySignature(ctx, tx)
+	require.NoError(t, err)
+	require.False(t, valid)
+
 	hash, _ := h.BroadcastTransaction(ctx, tx)
 	err = h.WaitForConfirmation(ctx, hash, 1)
 	require.NoError(t, err)
@@ -0,0 +1,64 @@
+package evm
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// encodeQuantity encodes an integer as a 0x-prefixed hex quantity
+func encodeQuantity(v *big.Int) string {
+	if v == nil || v.Sign() == 0 {
+		return "0x0"
+	}
+	return "0x" + v.Text(16)
+}
+
+// encodeUint64 encodes a uint64 as a 0x-prefixed hex quantity
+func encodeUint64(v uint64) string {
+	return encodeQuantity(new(big.Int).SetUint64(v))
+}
+
+// decodeQuantity decodes a 0x-prefixed hex quantity
+func decodeQuantity(s string) (*big.Int, error) {
+	cleaned := strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	if cleaned == "" {
+		return nil, fmt.Errorf("empty hex quantity")
+	}
+	v, ok := new(big.Int).SetString(cleaned, 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid hex quantity: %s", s)
+	}
+	return v, nil
+}
+
+// decodeUint64 decodes a 0x-prefixed hex quantity that must fit in a uint64
+func decodeUint64(s string) (uint64, error) {
+	v, err := decodeQuantity(s)
+	if err != nil {
+		return 0, err
+	}
+	if !v.IsUint64() {
+		return 0, fmt.Errorf("hex quantity overflows uint64: %s", s)
+	}
+	return v.Uint64(), nil
+}
+
+// encodeBytes encodes bytes as 0x-prefixed hex data
+func encodeBytes(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+// decodeBytes decodes 0x-prefixed hex data
+func decodeBytes(s string) ([]byte, error) {
+	cleaned := strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	if len(cleaned)%2 == 1 {
+		cleaned = "0" + cleaned
+	}
+	b, err := hex.DecodeString(cleaned)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex data: %w", err)
+	}
+	return b, nil
+}
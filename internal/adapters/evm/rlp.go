@@ -0,0 +1,176 @@
+package evm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+)
+
+// rlpBytes encodes a byte string using Recursive Length Prefix encoding
+func rlpBytes(b []byte) []byte {
+	if len(b) == 1 && b[0] < 0x80 {
+		return []byte{b[0]}
+	}
+	return append(rlpHeader(0x80, len(b)), b...)
+}
+
+// rlpUint encodes an unsigned integer as a minimal big-endian byte string
+func rlpUint(v uint64) []byte {
+	if v == 0 {
+		return rlpBytes(nil)
+	}
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], v)
+	i := 0
+	for buf[i] == 0 {
+		i++
+	}
+	return rlpBytes(buf[i:])
+}
+
+// rlpBigInt encodes a non-negative big integer; nil encodes as zero
+func rlpBigInt(v *big.Int) []byte {
+	if v == nil {
+		return rlpBytes(nil)
+	}
+	return rlpBytes(v.Bytes())
+}
+
+// rlpList encodes already-encoded items as an RLP list
+func rlpList(items ...[]byte) []byte {
+	size := 0
+	for _, item := range items {
+		size += len(item)
+	}
+	out := rlpHeader(0xc0, size)
+	for _, item := range items {
+		out = append(out, item...)
+	}
+	return out
+}
+
+// rlpHeader builds the prefix for a string (0x80) or list (0xc0) payload
+func rlpHeader(offset byte, size int) []byte {
+	if size < 56 {
+		return []byte{offset + byte(size)}
+	}
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(size))
+	i := 0
+	for buf[i] == 0 {
+		i++
+	}
+	header := []byte{offset + 55 + byte(8-i)}
+	return append(header, buf[i:]...)
+}
+
+// rlpSplit splits the first item off an RLP encoding, returning its payload, whether it is a
+// list and the bytes that follow it
+func rlpSplit(b []byte) (payload []byte, isList bool, rest []byte, err error) {
+	if len(b) == 0 {
+		return nil, false, nil, fmt.Errorf("unexpected end of RLP data")
+	}
+	prefix := b[0]
+	var offset, size uint64
+	switch {
+	case prefix < 0x80:
+		return b[:1], false, b[1:], nil
+	case prefix < 0xb8:
+		offset, size = 1, uint64(prefix-0x80)
+		if size == 1 && len(b) > 1 && b[1] < 0x80 {
+			return nil, false, nil, fmt.Errorf("non-canonical RLP single byte")
+		}
+	case prefix < 0xc0:
+		offset, size, err = rlpLongSize(b, prefix-0xb7)
+	case prefix < 0xf8:
+		offset, size, isList = 1, uint64(prefix-0xc0), true
+	default:
+		offset, size, err = rlpLongSize(b, prefix-0xf7)
+		isList = true
+	}
+	if err != nil {
+		return nil, false, nil, err
+	}
+	if size > uint64(len(b))-offset {
+		return nil, false, nil, fmt.Errorf("RLP item exceeds input")
+	}
+	return b[offset : offset+size], isList, b[offset+size:], nil
+}
+
+// rlpLongSize reads the big-endian size of a payload of 56 bytes or more
+func rlpLongSize(b []byte, lengthOfSize byte) (offset, size uint64, err error) {
+	n := uint64(lengthOfSize)
+	if n > 8 || uint64(len(b)) < 1+n {
+		return 0, 0, fmt.Errorf("invalid RLP size")
+	}
+	if b[1] == 0 {
+		return 0, 0, fmt.Errorf("non-canonical RLP size")
+	}
+	for _, c := range b[1 : 1+n] {
+		size = size<<8 | uint64(c)
+	}
+	if size < 56 {
+		return 0, 0, fmt.Errorf("non-canonical RLP size")
+	}
+	return 1 + n, size, nil
+}
+
+// rlpDecodeList decodes a single RLP list, returning the encodings of its items
+func rlpDecodeList(b []byte) ([][]byte, error) {
+	payload, isList, rest, err := rlpSplit(b)
+	if err != nil {
+		return nil, err
+	}
+	if !isList {
+		return nil, fmt.Errorf("expected RLP list")
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("trailing bytes after RLP list")
+	}
+
+	var items [][]byte
+	for len(payload) > 0 {
+		start := payload
+		if _, _, payload, err = rlpSplit(payload); err != nil {
+			return nil, err
+		}
+		items = append(items, start[:len(start)-len(payload)])
+	}
+	return items, nil
+}
+
+// rlpString decodes an encoded RLP byte string
+func rlpString(item []byte) ([]byte, error) {
+	payload, isList, _, err := rlpSplit(item)
+	if err != nil {
+		return nil, err
+	}
+	if isList {
+		return nil, fmt.Errorf("expected RLP string")
+	}
+	return payload, nil
+}
+
+// rlpToBigInt decodes an encoded RLP unsigned integer
+func rlpToBigInt(item []byte) (*big.Int, error) {
+	payload, err := rlpString(item)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) > 0 && payload[0] == 0 {
+		return nil, fmt.Errorf("non-canonical RLP integer")
+	}
+	return new(big.Int).SetBytes(payload), nil
+}
+
+// rlpToUint64 decodes an encoded RLP unsigned integer that must fit in a uint64
+func rlpToUint64(item []byte) (uint64, error) {
+	v, err := rlpToBigInt(item)
+	if err != nil {
+		return 0, err
+	}
+	if !v.IsUint64() {
+		return 0, fmt.Errorf("RLP integer overflows uint64")
+	}
+	return v.Uint64(), nil
+}
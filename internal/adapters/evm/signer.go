@@ -0,0 +1,491 @@
+package evm
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+)
+
+// TxType identifies the EVM transaction envelope
+type TxType byte
+
+const (
+	// LegacyTxType is a pre-EIP-2718 transaction protected by EIP-155
+	LegacyTxType TxType = 0x00
+	// AccessListTxType is an EIP-2930 transaction
+	AccessListTxType TxType = 0x01
+	// DynamicFeeTxType is an EIP-1559 transaction
+	DynamicFeeTxType TxType = 0x02
+)
+
+const (
+	// MetadataAccessList holds the optional EIP-2930 access list
+	MetadataAccessList = "access_list"
+	// MetadataTxType records the envelope type used when signing
+	MetadataTxType = "tx_type"
+)
+
+// AccessTuple is an EIP-2930 access list entry
+type AccessTuple struct {
+	Address     string   `json:"address"`
+	StorageKeys []string `json:"storageKeys"`
+}
+
+// Signer signs and verifies EVM transactions for a specific EIP-155 chain ID
+type Signer struct {
+	chainID uint64
+}
+
+// NewSigner creates a new Signer for the given chain ID
+func NewSigner(chainID uint64) *Signer {
+	return &Signer{chainID: chainID}
+}
+
+// ChainID returns the EIP-155 chain ID
+func (s *Signer) ChainID() uint64 {
+	return s.chainID
+}
+
+// TxType returns the envelope type the transaction will be signed with
+func (s *Signer) TxType(tx *entities.Transaction) TxType {
+	if tx.MaxFeePerGas() != nil {
+		return DynamicFeeTxType
+	}
+	if _, ok := tx.Metadata()[MetadataAccessList]; ok {
+		return AccessListTxType
+	}
+	return LegacyTxType
+}
+
+// Hash returns the digest that is signed for the transaction
+func (s *Signer) Hash(tx *entities.Transaction) ([]byte, error) {
+	payload, err := s.signingPayload(tx)
+	if err != nil {
+		return nil, err
+	}
+	return Keccak256(payload), nil
+}
+
+// Sign signs the transaction with the sender's key keyID, storing the signature, hash and raw
+// encoding on it
+func (s *Signer) Sign(ctx context.Context, tx *entities.Transaction, keys ports.KeyManager, keyID string) error {
+	digest, err := s.Hash(tx)
+	if err != nil {
+		return err
+	}
+
+	sig, err := signDigest(ctx, keys, keyID, digest, tx.From().Value())
+	if err != nil {
+		return err
+	}
+
+	raw, err := s.encodeSigned(tx, sig)
+	if err != nil {
+		return err
+	}
+
+	signature, err := valueobjects.NewSignatureFromBytes(sig)
+	if err != nil {
+		return fmt.Errorf("failed to create signature: %w", err)
+	}
+	hash, err := valueobjects.NewHashFromBytes(Keccak256(raw))
+	if err != nil {
+		return fmt.Errorf("failed to create hash: %w", err)
+	}
+
+	if err := tx.SetSignature(signature); err != nil {
+		return err
+	}
+	if err := tx.SetHash(hash); err != nil {
+		return err
+	}
+	tx.SetMetadata(MetadataRawTransaction, encodeBytes(raw))
+	tx.SetMetadata(MetadataTxType, int(s.TxType(tx)))
+	return nil
+}
+
+// Sender recovers the address that signed the transaction
+func (s *Signer) Sender(tx *entities.Transaction) (string, error) {
+	if tx.Signature() == nil {
+		return "", fmt.Errorf("transaction not signed")
+	}
+	digest, err := s.Hash(tx)
+	if err != nil {
+		return "", err
+	}
+	return recoverAddress(digest, tx.Signature().Bytes())
+}
+
+// Verify reports whether the transaction signature was produced by its sender
+func (s *Signer) Verify(tx *entities.Transaction) (bool, error) {
+	sender, err := s.Sender(tx)
+	if err != nil {
+		return false, err
+	}
+	return strings.EqualFold(sender, tx.From().Value()), nil
+}
+
+// Decode parses a signed transaction in its network encoding, checking that it was signed for
+// the signer's chain ID and recovering its sender
+func (s *Signer) Decode(chainID string, raw []byte) (*entities.Transaction, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("empty transaction")
+	}
+
+	txType, payload := LegacyTxType, raw
+	if raw[0] < 0xc0 {
+		// EIP-2718 envelopes start with the type, legacy transactions with an RLP list
+		txType, payload = TxType(raw[0]), raw[1:]
+	}
+	items, err := rlpDecodeList(payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction encoding: %w", err)
+	}
+
+	decoded, err := s.decodeFields(txType, items)
+	if err != nil {
+		return nil, err
+	}
+
+	sender, err := recoverAddress(Keccak256(decoded.signingPayload), decoded.signature)
+	if err != nil {
+		return nil, err
+	}
+	decoded.params.ChainID = chainID
+	if decoded.params.From, err = valueobjects.NewAddress(sender, chainID); err != nil {
+		return nil, fmt.Errorf("invalid sender: %w", err)
+	}
+	if decoded.params.To, err = valueobjects.NewAddress(decoded.to, chainID); err != nil {
+		return nil, fmt.Errorf("invalid recipient: %w", err)
+	}
+
+	tx, err := entities.NewTransaction(decoded.params)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := valueobjects.NewSignatureFromBytes(decoded.signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signature: %w", err)
+	}
+	hash, err := valueobjects.NewHashFromBytes(Keccak256(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create hash: %w", err)
+	}
+	if err := tx.SetSignature(signature); err != nil {
+		return nil, err
+	}
+	if err := tx.SetHash(hash); err != nil {
+		return nil, err
+	}
+	if decoded.accessList != nil {
+		tx.SetMetadata(MetadataAccessList, decoded.accessList)
+	}
+	tx.SetMetadata(MetadataRawTransaction, encodeBytes(raw))
+	tx.SetMetadata(MetadataTxType, int(txType))
+	return tx, nil
+}
+
+// decodedTransaction holds the fields of a signed transaction read by Decode
+type decodedTransaction struct {
+	params         entities.TransactionParams
+	to             string
+	accessList     []AccessTuple
+	signature      []byte
+	signingPayload []byte
+}
+
+// decodeFields reads the RLP items of a signed transaction of the given type
+func (s *Signer) decodeFields(txType TxType, items [][]byte) (*decodedTransaction, error) {
+	var count int
+	switch txType {
+	case LegacyTxType:
+		count = 9
+	case AccessListTxType:
+		count = 11
+	case DynamicFeeTxType:
+		count = 12
+	default:
+		return nil, fmt.Errorf("unsupported transaction type: %d", txType)
+	}
+	if len(items) != count {
+		return nil, fmt.Errorf("type %d transaction must have %d fields, got %d", txType, count, len(items))
+	}
+
+	// The signature closes every envelope; the fields before it are what was signed
+	decoded := &decodedTransaction{}
+	unsigned, signature := items[:count-3], items[count-3:]
+	fields := unsigned
+	if txType == LegacyTxType {
+		eip155 := append(append([][]byte{}, unsigned...), rlpUint(s.chainID), rlpUint(0), rlpUint(0))
+		decoded.signingPayload = rlpList(eip155...)
+	} else {
+		chainID, err := rlpToUint64(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid chain ID: %w", err)
+		}
+		if chainID != s.chainID {
+			return nil, fmt.Errorf("transaction is signed for chain ID %d, expected %d", chainID, s.chainID)
+		}
+		decoded.signingPayload = append([]byte{byte(txType)}, rlpList(unsigned...)...)
+		fields = fields[1:]
+	}
+
+	nonce, err := rlpToUint64(fields[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid nonce: %w", err)
+	}
+	decoded.params.Nonce = valueobjects.NewNonce(nonce)
+	if txType == DynamicFeeTxType {
+		if decoded.params.MaxPriorityFee, err = rlpToBigInt(fields[1]); err != nil {
+			return nil, fmt.Errorf("invalid max priority fee: %w", err)
+		}
+		if decoded.params.MaxFeePerGas, err = rlpToBigInt(fields[2]); err != nil {
+			return nil, fmt.Errorf("invalid max fee per gas: %w", err)
+		}
+		fields = fields[3:]
+	} else {
+		if decoded.params.GasPrice, err = rlpToBigInt(fields[1]); err != nil {
+			return nil, fmt.Errorf("invalid gas price: %w", err)
+		}
+		fields = fields[2:]
+	}
+
+	// What remains is gas limit, recipient, value, data and, on typed transactions, the access list
+	if decoded.params.GasLimit, err = rlpToUint64(fields[0]); err != nil {
+		return nil, fmt.Errorf("invalid gas limit: %w", err)
+	}
+	to, err := rlpString(fields[1])
+	if err != nil || len(to) != addressLength {
+		// Contract creations have no recipient
+		return nil, fmt.Errorf("invalid recipient")
+	}
+	decoded.to = checksumAddress(to)
+	if decoded.params.Value, err = rlpToBigInt(fields[2]); err != nil {
+		return nil, fmt.Errorf("invalid value: %w", err)
+	}
+	if decoded.params.Data, err = rlpString(fields[3]); err != nil {
+		return nil, fmt.Errorf("invalid data: %w", err)
+	}
+	if txType != LegacyTxType {
+		if decoded.accessList, err = decodeAccessList(fields[4]); err != nil {
+			return nil, err
+		}
+	}
+
+	v, err := rlpToBigInt(signature[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature V value: %w", err)
+	}
+	r, err := rlpString(signature[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature R value: %w", err)
+	}
+	sv, err := rlpString(signature[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature S value: %w", err)
+	}
+
+	var recoveryID uint64
+	if txType == LegacyTxType {
+		if recoveryID, err = s.legacyRecoveryID(v); err != nil {
+			return nil, err
+		}
+	} else {
+		if !v.IsUint64() {
+			return nil, fmt.Errorf("invalid signature recovery id")
+		}
+		recoveryID = v.Uint64()
+	}
+	if decoded.signature, err = signatureFromValues(r, sv, recoveryID); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// legacyRecoveryID extracts the recovery ID from an EIP-155 V value, refusing transactions that
+// are not bound to the signer's chain ID
+func (s *Signer) legacyRecoveryID(v *big.Int) (uint64, error) {
+	if v.Cmp(big.NewInt(35)) < 0 {
+		return 0, fmt.Errorf("transaction is not replay-protected (EIP-155)")
+	}
+	offset := new(big.Int).Sub(v, big.NewInt(35))
+	chainID := new(big.Int).Rsh(offset, 1)
+	if !chainID.IsUint64() || chainID.Uint64() != s.chainID {
+		return 0, fmt.Errorf("transaction is signed for chain ID %s, expected %d", chainID, s.chainID)
+	}
+	return offset.Uint64() & 1, nil
+}
+
+// signingPayload returns the pre-image of the signing hash
+func (s *Signer) signingPayload(tx *entities.Transaction) ([]byte, error) {
+	fields, err := s.fields(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch s.TxType(tx) {
+	case DynamicFeeTxType, AccessListTxType:
+		return append([]byte{byte(s.TxType(tx))}, rlpList(fields...)...), nil
+	default:
+		// EIP-155: sign over [..., chainID, 0, 0]
+		fields = append(fields, rlpUint(s.chainID), rlpUint(0), rlpUint(0))
+		return rlpList(fields...), nil
+	}
+}
+
+// encodeSigned returns the network encoding of a signed transaction
+func (s *Signer) encodeSigned(tx *entities.Transaction, sig []byte) ([]byte, error) {
+	fields, err := s.fields(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	r := new(big.Int).SetBytes(sig[:32])
+	sv := new(big.Int).SetBytes(sig[32:64])
+	recID := uint64(sig[64])
+
+	switch s.TxType(tx) {
+	case DynamicFeeTxType, AccessListTxType:
+		fields = append(fields, rlpUint(recID), rlpBigInt(r), rlpBigInt(sv))
+		return append([]byte{byte(s.TxType(tx))}, rlpList(fields...)...), nil
+	default:
+		v := new(big.Int).SetUint64(s.chainID)
+		v.Mul(v, big.NewInt(2))
+		v.Add(v, big.NewInt(int64(35+recID)))
+		fields = append(fields, rlpBigInt(v), rlpBigInt(r), rlpBigInt(sv))
+		return rlpList(fields...), nil
+	}
+}
+
+// fields returns the RLP-encoded unsigned fields for the transaction type
+func (s *Signer) fields(tx *entities.Transaction) ([][]byte, error) {
+	if tx.Nonce() == nil {
+		return nil, fmt.Errorf("transaction nonce is not set")
+	}
+	if tx.GasLimit() == 0 {
+		return nil, fmt.Errorf("transaction gas limit is not set")
+	}
+	to, err := parseAddress(tx.To().Value())
+	if err != nil {
+		return nil, err
+	}
+
+	common := [][]byte{rlpUint(tx.GasLimit()), rlpBytes(to), rlpBigInt(tx.Value()), rlpBytes(tx.Data())}
+	nonce := rlpUint(tx.Nonce().Value())
+
+	switch s.TxType(tx) {
+	case DynamicFeeTxType:
+		if tx.MaxPriorityFee() == nil {
+			return nil, fmt.Errorf("max priority fee is not set")
+		}
+		accessList, err := encodeAccessList(tx)
+		if err != nil {
+			return nil, err
+		}
+		fields := [][]byte{rlpUint(s.chainID), nonce, rlpBigInt(tx.MaxPriorityFee()), rlpBigInt(tx.MaxFeePerGas())}
+		return append(append(fields, common...), accessList), nil
+	case AccessListTxType:
+		if tx.GasPrice() == nil {
+			return nil, fmt.Errorf("gas price is not set")
+		}
+		accessList, err := encodeAccessList(tx)
+		if err != nil {
+			return nil, err
+		}
+		fields := [][]byte{rlpUint(s.chainID), nonce, rlpBigInt(tx.GasPrice())}
+		return append(append(fields, common...), accessList), nil
+	default:
+		if tx.GasPrice() == nil {
+			return nil, fmt.Errorf("gas price is not set")
+		}
+		fields := [][]byte{nonce, rlpBigInt(tx.GasPrice())}
+		return append(fields, common...), nil
+	}
+}
+
+// AccessListFromMetadata reads the access list stored on a transaction
+func AccessListFromMetadata(tx *entities.Transaction) ([]AccessTuple, error) {
+	switch v := tx.Metadata()[MetadataAccessList].(type) {
+	case nil:
+		return nil, nil
+	case []AccessTuple:
+		return v, nil
+	default:
+		// Values restored from JSON arrive as generic maps
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid access list: %w", err)
+		}
+		var list []AccessTuple
+		if err := json.Unmarshal(raw, &list); err != nil {
+			return nil, fmt.Errorf("invalid access list: %w", err)
+		}
+		return list, nil
+	}
+}
+
+// decodeAccessList reads an encoded EIP-2930 access list
+func decodeAccessList(item []byte) ([]AccessTuple, error) {
+	tuples, err := rlpDecodeList(item)
+	if err != nil {
+		return nil, fmt.Errorf("invalid access list: %w", err)
+	}
+
+	list := make([]AccessTuple, 0, len(tuples))
+	for _, encoded := range tuples {
+		fields, err := rlpDecodeList(encoded)
+		if err != nil || len(fields) != 2 {
+			return nil, fmt.Errorf("invalid access list entry")
+		}
+		address, err := rlpString(fields[0])
+		if err != nil || len(address) != addressLength {
+			return nil, fmt.Errorf("invalid access list address")
+		}
+		keys, err := rlpDecodeList(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid access list storage keys: %w", err)
+		}
+		tuple := AccessTuple{Address: checksumAddress(address), StorageKeys: make([]string, 0, len(keys))}
+		for _, key := range keys {
+			decoded, err := rlpString(key)
+			if err != nil || len(decoded) != 32 {
+				return nil, fmt.Errorf("invalid storage key")
+			}
+			tuple.StorageKeys = append(tuple.StorageKeys, encodeBytes(decoded))
+		}
+		list = append(list, tuple)
+	}
+	return list, nil
+}
+
+func encodeAccessList(tx *entities.Transaction) ([]byte, error) {
+	list, err := AccessListFromMetadata(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	tuples := make([][]byte, 0, len(list))
+	for _, tuple := range list {
+		address, err := parseAddress(tuple.Address)
+		if err != nil {
+			return nil, err
+		}
+		keys := make([][]byte, 0, len(tuple.StorageKeys))
+		for _, key := range tuple.StorageKeys {
+			decoded, err := hex.DecodeString(strings.TrimPrefix(key, "0x"))
+			if err != nil || len(decoded) != 32 {
+				return nil, fmt.Errorf("invalid storage key: %s", key)
+			}
+			keys = append(keys, rlpBytes(decoded))
+		}
+		tuples = append(tuples, rlpList(rlpBytes(address), rlpList(keys...)))
+	}
+	return rlpList(tuples...), nil
+}
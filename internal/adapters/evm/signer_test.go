@@ -0,0 +1,371 @@
+package evm
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/keystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	eip155Key     = "4646464646464646464646464646464646464646464646464646464646464646"
+	eip155Address = "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F"
+	eip155To      = "0x3535353535353535353535353535353535353535"
+	// eip155SignedTx is the signed transaction of the EIP-155 specification
+	eip155SignedTx = "f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83"
+)
+
+func eip155PrivateKey(t *testing.T) []byte {
+	t.Helper()
+	key, err := hex.DecodeString(eip155Key)
+	require.NoError(t, err)
+	return key
+}
+
+// testKeys holds the EIP-155 key as "eip155", an unrelated key as "other" and an ed25519 key
+func testKeys(t *testing.T) ports.KeyManager {
+	t.Helper()
+	keys, err := keystore.NewManager(
+		keystore.Key{ID: "eip155", Curve: ports.CurveSecp256k1, PrivateKey: eip155PrivateKey(t)},
+		keystore.Key{ID: "other", Curve: ports.CurveSecp256k1, PrivateKey: bytes.Repeat([]byte{0x01}, 32)},
+		keystore.Key{ID: "ed25519", Curve: ports.CurveEd25519, PrivateKey: bytes.Repeat([]byte{0x07}, 32)},
+	)
+	require.NoError(t, err)
+	return keys
+}
+
+func newSignableTx(t *testing.T, chain string, nonce uint64, value *big.Int, data []byte) *entities.Transaction {
+	t.Helper()
+	from, err := valueobjects.NewAddress(eip155Address, chain)
+	require.NoError(t, err)
+	to, err := valueobjects.NewAddress(eip155To, chain)
+	require.NoError(t, err)
+	tx, err := entities.NewTransaction(entities.TransactionParams{
+		ChainID: chain, From: from, To: to, Value: value, Data: data, GasLimit: 21000,
+	})
+	require.NoError(t, err)
+	require.NoError(t, tx.SetNonce(valueobjects.NewNonce(nonce)))
+	return tx
+}
+
+func TestAddressDerivation(t *testing.T) {
+	t.Parallel()
+
+	address, err := AddressFromPrivateKey(eip155PrivateKey(t))
+	require.NoError(t, err)
+	assert.Equal(t, eip155Address, address)
+
+	checksummed, err := ChecksumAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+	require.NoError(t, err)
+	assert.Equal(t, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", checksummed)
+
+	_, err = ChecksumAddress("0x1234")
+	require.Error(t, err)
+	_, err = AddressFromPrivateKey([]byte("short"))
+	require.Error(t, err)
+	_, err = AddressFromPrivateKey(make([]byte, 32))
+	require.Error(t, err)
+	_, err = AddressFromPublicKey([]byte{0x04})
+	require.Error(t, err)
+}
+
+func TestSignerLegacyEIP155Vector(t *testing.T) {
+	t.Parallel()
+
+	// Test vector from the EIP-155 specification
+	value, _ := new(big.Int).SetString("1000000000000000000", 10)
+	tx := newSignableTx(t, "ethereum", 9, value, nil)
+	require.NoError(t, tx.SetGasPrice(big.NewInt(20000000000)))
+
+	signer := NewSigner(1)
+	assert.Equal(t, uint64(1), signer.ChainID())
+	assert.Equal(t, LegacyTxType, signer.TxType(tx))
+
+	digest, err := signer.Hash(tx)
+	require.NoError(t, err)
+	assert.Equal(t, "daf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53", hex.EncodeToString(digest))
+
+	require.NoError(t, signer.Sign(context.Background(), tx, testKeys(t), "eip155"))
+	assert.Equal(t, "0x"+eip155SignedTx, tx.Metadata()[MetadataRawTransaction])
+	assert.Equal(t, "0x33469b22e9f636356c4160a87eb19df52b7412e8eac32a4a55ffe88ea8350788", tx.Hash().Hex())
+	assert.Equal(t, int(LegacyTxType), tx.Metadata()[MetadataTxType])
+
+	sender, err := signer.Sender(tx)
+	require.NoError(t, err)
+	assert.Equal(t, eip155Address, sender)
+
+	valid, err := signer.Verify(tx)
+	require.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestSignerTypedTransactions(t *testing.T) {
+	t.Parallel()
+
+	accessList := []AccessTuple{{
+		Address: "0x000000000000000000000000000000000000aaaa",
+		StorageKeys: []string{
+			"0x0000000000000000000000000000000000000000000000000000000000000001",
+			"0x0000000000000000000000000000000000000000000000000000000000000002",
+		},
+	}}
+
+	t.Run("access list", func(t *testing.T) {
+		t.Parallel()
+		tx := newSignableTx(t, "ethereum", 3, big.NewInt(12345), []byte{0xde, 0xad, 0xbe, 0xef})
+		require.NoError(t, tx.SetGasPrice(big.NewInt(30000000000)))
+		tx.SetMetadata(MetadataAccessList, accessList)
+
+		signer := NewSigner(1)
+		assert.Equal(t, AccessListTxType, signer.TxType(tx))
+		require.NoError(t, signer.Sign(context.Background(), tx, testKeys(t), "eip155"))
+
+		raw, err := rawTransaction(tx)
+		require.NoError(t, err)
+		assert.Equal(t, byte(AccessListTxType), raw[0])
+		assert.Equal(t, Keccak256(raw), tx.Hash().Bytes())
+
+		valid, err := signer.Verify(tx)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("dynamic fee", func(t *testing.T) {
+		t.Parallel()
+		tx := newSignableTx(t, "polygon", 0, big.NewInt(0), []byte{0xa9, 0x05, 0x9c, 0xbb})
+		require.NoError(t, tx.SetDynamicFees(big.NewInt(100000000000), big.NewInt(30000000000)))
+		// JSON-restored access lists are decoded from generic maps
+		tx.SetMetadata(MetadataAccessList, []interface{}{map[string]interface{}{
+			"address":     accessList[0].Address,
+			"storageKeys": []interface{}{accessList[0].StorageKeys[0], accessList[0].StorageKeys[1]},
+		}})
+
+		signer := NewSigner(137)
+		assert.Equal(t, DynamicFeeTxType, signer.TxType(tx))
+		require.NoError(t, signer.Sign(context.Background(), tx, testKeys(t), "eip155"))
+
+		raw, err := rawTransaction(tx)
+		require.NoError(t, err)
+		assert.Equal(t, byte(DynamicFeeTxType), raw[0])
+		assert.True(t, bytes.Contains(raw, []byte{0x81, 0x89}), "chain id 137 must be encoded")
+
+		sender, err := signer.Sender(tx)
+		require.NoError(t, err)
+		assert.Equal(t, eip155Address, sender)
+
+		// A signer for a different chain must not recover the same sender
+		valid, err := NewSigner(1).Verify(tx)
+		require.NoError(t, err)
+		assert.False(t, valid)
+	})
+}
+
+func TestSignerDecode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("legacy", func(t *testing.T) {
+		t.Parallel()
+		raw, _ := hex.DecodeString(eip155SignedTx)
+		tx, err := NewSigner(1).Decode("ethereum", raw)
+		require.NoError(t, err)
+		assert.Equal(t, eip155Address, tx.From().Value())
+		assert.Equal(t, eip155To, tx.To().Value())
+		assert.Equal(t, "1000000000000000000", tx.Value().String())
+		assert.Equal(t, uint64(9), tx.Nonce().Value())
+		assert.Equal(t, uint64(21000), tx.GasLimit())
+		assert.Equal(t, big.NewInt(20000000000), tx.GasPrice())
+		assert.Equal(t, "0x33469b22e9f636356c4160a87eb19df52b7412e8eac32a4a55ffe88ea8350788", tx.Hash().Hex())
+		assert.Equal(t, "0x"+eip155SignedTx, tx.Metadata()[MetadataRawTransaction])
+
+		valid, err := NewSigner(1).Verify(tx)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("typed round trip", func(t *testing.T) {
+		t.Parallel()
+		signer := NewSigner(137)
+		accessList := []AccessTuple{{
+			Address:     "0x000000000000000000000000000000000000aaaa",
+			StorageKeys: []string{"0x0000000000000000000000000000000000000000000000000000000000000001"},
+		}}
+
+		signed := newSignableTx(t, "polygon", 7, big.NewInt(5), []byte{0xa9, 0x05, 0x9c, 0xbb})
+		require.NoError(t, signed.SetDynamicFees(big.NewInt(100000000000), big.NewInt(30000000000)))
+		signed.SetMetadata(MetadataAccessList, accessList)
+		require.NoError(t, signer.Sign(context.Background(), signed, testKeys(t), "eip155"))
+		raw, err := rawTransaction(signed)
+		require.NoError(t, err)
+
+		tx, err := signer.Decode("polygon", raw)
+		require.NoError(t, err)
+		assert.Equal(t, eip155Address, tx.From().Value())
+		assert.Equal(t, signed.Hash().Hex(), tx.Hash().Hex())
+		assert.Equal(t, signed.Data(), tx.Data())
+		assert.Equal(t, signed.MaxFeePerGas(), tx.MaxFeePerGas())
+		assert.Equal(t, signed.MaxPriorityFee(), tx.MaxPriorityFee())
+		assert.Equal(t, accessList, tx.Metadata()[MetadataAccessList])
+		assert.Equal(t, signed.Signature().Bytes(), tx.Signature().Bytes())
+
+		legacy := newSignableTx(t, "polygon", 8, big.NewInt(5), nil)
+		require.NoError(t, legacy.SetGasPrice(big.NewInt(30000000000)))
+		legacy.SetMetadata(MetadataAccessList, []AccessTuple{})
+		require.NoError(t, signer.Sign(context.Background(), legacy, testKeys(t), "eip155"))
+		raw, err = rawTransaction(legacy)
+		require.NoError(t, err)
+		tx, err = signer.Decode("polygon", raw)
+		require.NoError(t, err)
+		assert.Equal(t, AccessListTxType, signer.TxType(tx))
+		assert.Equal(t, legacy.Hash().Hex(), tx.Hash().Hex())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		raw, _ := hex.DecodeString(eip155SignedTx)
+
+		_, err := NewSigner(5).Decode("goerli", raw)
+		require.ErrorContains(t, err, "chain ID")
+
+		// Pre-EIP-155 signatures can be replayed on every chain
+		items, err := rlpDecodeList(raw)
+		require.NoError(t, err)
+		unprotected := rlpList(append(append([][]byte{}, items[:6]...), rlpUint(27), items[7], items[8])...)
+		_, err = NewSigner(1).Decode("ethereum", unprotected)
+		require.ErrorContains(t, err, "replay-protected")
+
+		// The high-S twin of a valid signature
+		sValue, _ := rlpToBigInt(items[8])
+		order, _ := new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+		malleable := rlpList(append(append([][]byte{}, items[:8]...), rlpBigInt(new(big.Int).Sub(order, sValue)))...)
+		_, err = NewSigner(1).Decode("ethereum", malleable)
+		require.ErrorContains(t, err, "S value")
+
+		for _, invalid := range [][]byte{nil, {0x05, 0xc0}, raw[:len(raw)-1], append(raw, 0x00), rlpList(items[:8]...)} {
+			_, err = NewSigner(1).Decode("ethereum", invalid)
+			require.Error(t, err)
+		}
+	})
+}
+
+func TestSignerErrors(t *testing.T) {
+	t.Parallel()
+	signer := NewSigner(1)
+	ctx := context.Background()
+	keys := testKeys(t)
+
+	t.Run("key does not match sender", func(t *testing.T) {
+		t.Parallel()
+		tx := newSignableTx(t, "ethereum", 0, big.NewInt(1), nil)
+		require.NoError(t, tx.SetGasPrice(big.NewInt(1)))
+		require.ErrorContains(t, signer.Sign(ctx, tx, keys, "other"), "does not match sender")
+		require.Error(t, signer.Sign(ctx, tx, keys, "ed25519"))
+		require.ErrorIs(t, signer.Sign(ctx, tx, keys, "missing"), ports.ErrKeyNotFound)
+	})
+
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+		tx := newSignableTx(t, "ethereum", 0, big.NewInt(1), nil)
+		require.Error(t, signer.Sign(ctx, tx, keys, "eip155"), "gas price is required")
+
+		from, to := tx.From(), tx.To()
+		noNonce, err := entities.NewTransaction(entities.TransactionParams{ChainID: "ethereum", From: from, To: to, GasPrice: big.NewInt(1)})
+		require.NoError(t, err)
+		require.Error(t, signer.Sign(ctx, noNonce, keys, "eip155"))
+	})
+
+	t.Run("invalid access list", func(t *testing.T) {
+		t.Parallel()
+		tx := newSignableTx(t, "ethereum", 0, big.NewInt(1), nil)
+		require.NoError(t, tx.SetGasPrice(big.NewInt(1)))
+		tx.SetMetadata(MetadataAccessList, []AccessTuple{{Address: "0xaaaa"}})
+		require.Error(t, signer.Sign(ctx, tx, keys, "eip155"))
+
+		tx.SetMetadata(MetadataAccessList, []AccessTuple{{Address: eip155To, StorageKeys: []string{"0x01"}}})
+		require.Error(t, signer.Sign(ctx, tx, keys, "eip155"))
+
+		tx.SetMetadata(MetadataAccessList, "bogus")
+		require.Error(t, signer.Sign(ctx, tx, keys, "eip155"))
+	})
+
+	t.Run("unsigned transaction", func(t *testing.T) {
+		t.Parallel()
+		tx := newSignableTx(t, "ethereum", 0, big.NewInt(1), nil)
+		_, err := signer.Verify(tx)
+		require.Error(t, err)
+	})
+}
+
+func TestAdapterSignAndVerify(t *testing.T) {
+	t.Parallel()
+	adapter, _ := newTestAdapter(t)
+	ctx := context.Background()
+
+	tx := newSignableTx(t, "ethereum", 1, big.NewInt(1), nil)
+	require.NoError(t, tx.SetDynamicFees(big.NewInt(2000000000), big.NewInt(1000000000)))
+
+	require.ErrorIs(t, adapter.SignTransaction(ctx, tx, testKeys(t), "missing"), ports.ErrKeyNotFound)
+	require.NoError(t, adapter.SignTransaction(ctx, tx, testKeys(t), "eip155"))
+
+	valid, err := adapter.VerifySignature(ctx, tx)
+	require.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestRLPEncoding(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []byte{0x80}, rlpBytes(nil))
+	assert.Equal(t, []byte{0x7f}, rlpBytes([]byte{0x7f}))
+	assert.Equal(t, []byte{0x81, 0x80}, rlpBytes([]byte{0x80}))
+	assert.Equal(t, []byte{0x80}, rlpUint(0))
+	assert.Equal(t, []byte{0x82, 0x04, 0x00}, rlpUint(1024))
+	assert.Equal(t, []byte{0x80}, rlpBigInt(nil))
+	assert.Equal(t, []byte{0xc0}, rlpList())
+
+	long := bytes.Repeat([]byte{0x61}, 56)
+	assert.Equal(t, append([]byte{0xb8, 0x38}, long...), rlpBytes(long))
+}
+
+func TestRLPDecoding(t *testing.T) {
+	t.Parallel()
+
+	long := bytes.Repeat([]byte{0x61}, 56)
+	items, err := rlpDecodeList(rlpList(rlpUint(0), rlpUint(1024), rlpBytes(long), rlpList()))
+	require.NoError(t, err)
+	require.Len(t, items, 4)
+
+	zero, err := rlpToUint64(items[0])
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), zero)
+	value, err := rlpToUint64(items[1])
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1024), value)
+	decoded, err := rlpString(items[2])
+	require.NoError(t, err)
+	assert.Equal(t, long, decoded)
+	_, err = rlpString(items[3])
+	require.Error(t, err)
+
+	// Non-canonical and truncated encodings
+	for _, invalid := range [][]byte{
+		{0x81, 0x01},
+		{0xb8, 0x01, 0x61},
+		{0x82, 0x00, 0x01},
+		{0x83, 0x61},
+	} {
+		_, err := rlpToBigInt(invalid)
+		assert.Error(t, err, "%x", invalid)
+	}
+	_, err = rlpToUint64(rlpBytes(bytes.Repeat([]byte{0xff}, 9)))
+	require.Error(t, err)
+	_, err = rlpDecodeList(rlpUint(1))
+	require.Error(t, err)
+}
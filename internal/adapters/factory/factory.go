@@ -0,0 +1,137 @@
+package factory
+
+import (
+	"fmt"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/bitcoin"
+	bitcoinharness "github.com/gabrielksneiva/ChainSystemPro/internal/adapters/bitcoin/harness"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/evm"
+	evmharness "github.com/gabrielksneiva/ChainSystemPro/internal/adapters/evm/harness"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/solana"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/tron"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/config"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+)
+
+// harnessURL is the RPC URL harness networks report
+const harnessURL = "memory://localhost"
+
+// NewAdapter creates the adapter serving a configured network
+func NewAdapter(network config.Network) (ports.ChainAdapter, error) {
+	switch entry := network.Entry.(type) {
+	case config.EVMNetwork:
+		if network.Adapter == config.AdapterHarness {
+			return newEVMHarness(entry)
+		}
+		return evm.NewAdapterFromConfig(entry.NetworkConfig)
+	case config.BitcoinNetwork:
+		if network.Adapter == config.AdapterHarness {
+			return newBitcoinHarness(entry)
+		}
+		return bitcoin.NewAdapterFromConfig(entry.NetworkConfig)
+	case config.TronNetwork:
+		if network.Adapter == config.AdapterHarness {
+			return nil, fmt.Errorf("no harness is available for tron network %s", entry.Name)
+		}
+		return tron.NewAdapterFromConfig(entry.NetworkConfig)
+	case config.SolanaNetwork:
+		if network.Adapter == config.AdapterHarness {
+			return nil, fmt.Errorf("no harness is available for solana network %s", entry.Name)
+		}
+		return solana.NewAdapterFromConfig(entry.NetworkConfig)
+	default:
+		return nil, fmt.Errorf("unknown network entry %T", network.Entry)
+	}
+}
+
+// Register creates and registers an adapter for every network of the enabled chains
+func Register(registry ports.ChainRegistry, cfg *config.Config) error {
+	for _, network := range cfg.Networks() {
+		adapter, err := NewAdapter(network)
+		if err != nil {
+			return fmt.Errorf("failed to create adapter for %s: %w", network.ChainID, err)
+		}
+		if err := registry.Register(network.ChainID, adapter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ChainFactory creates the adapters of networks registered at runtime from the same entries
+// as config.yaml
+type ChainFactory struct{}
+
+// NewChain creates the adapter of a network spec, whose settings are keys of a network entry
+func (ChainFactory) NewChain(spec ports.ChainSpec) (string, ports.ChainAdapter, error) {
+	settings := make(map[string]interface{}, len(spec.Settings)+1)
+	for key, value := range spec.Settings {
+		settings[key] = value
+	}
+	settings["name"] = spec.Network
+
+	network, err := config.ParseNetwork(spec.Type, settings)
+	if err != nil {
+		return "", nil, err
+	}
+	adapter, err := NewAdapter(network)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create adapter for %s: %w", network.ChainID, err)
+	}
+	return network.ChainID, adapter, nil
+}
+
+// newEVMHarness creates an in-memory EVM chain with the configured balances
+func newEVMHarness(entry config.EVMNetwork) (ports.ChainAdapter, error) {
+	h := evmharness.NewEVMHarness(entry.Name)
+	if entry.ChainID != 0 {
+		h.SetNetworkID(entry.ChainID)
+	}
+	for address, balance := range entry.Balances {
+		value, err := config.ParseBalance(balance)
+		if err != nil {
+			return nil, err
+		}
+		h.SetBalance(address, value)
+	}
+	return h, nil
+}
+
+// newBitcoinHarness creates an in-memory UTXO chain funding each configured address with one output
+func newBitcoinHarness(entry config.BitcoinNetwork) (ports.ChainAdapter, error) {
+	chain := entry.Chain
+	if chain == "" {
+		chain = bitcoin.ChainBitcoin
+	}
+	params, err := bitcoin.LookupChainParams(chain, entry.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	h := bitcoinharness.NewBitcoinHarness()
+	for address, balance := range entry.Balances {
+		value, err := config.ParseBalance(balance)
+		if err != nil {
+			return nil, err
+		}
+		if !value.IsInt64() {
+			return nil, fmt.Errorf("balance of %s is too large", address)
+		}
+		decoded, err := bitcoin.ParseChainAddress(address, chain, entry.Name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := h.Fund(address, decoded.ScriptPubKey(), value.Int64()); err != nil {
+			return nil, fmt.Errorf("failed to fund %s: %w", address, err)
+		}
+		h.SetBalance(address, value)
+	}
+	return bitcoin.NewAdapterWithConfig(h, bitcoin.NetworkConfig{
+		Chain:            params.Chain,
+		Name:             params.Network,
+		RPCURL:           harnessURL,
+		ExplorerURL:      entry.ExplorerURL,
+		CoinSelection:    entry.CoinSelection,
+		MinConfirmations: entry.MinConfirmations,
+	})
+}
@@ -0,0 +1,134 @@
+package factory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/evm"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/config"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/registry"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = `
+chains:
+  enabled: [evm, bitcoin, tron, solana]
+evm:
+  networks:
+    - name: ethereum
+      rpc_url: https://eth.example.com
+      chain_id: 1
+    - name: localnet
+      adapter: harness
+      chain_id: 1337
+      balances:
+        "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb": "1000000000000000000"
+bitcoin:
+  networks:
+    - name: regtest
+      adapter: harness
+      balances:
+        bcrt1qw508d6qejxtdg4y5r3zarvary0c5xw7kygt080: "50000"
+    - chain: litecoin
+      name: mainnet
+      rpc_url: https://litecoin.example.com/api
+tron:
+  networks:
+    - name: tron
+      api_url: https://tron.example.com
+solana:
+  networks:
+    - name: solana
+      rpc_url: https://solana.example.com
+`
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	provider, err := config.Parse([]byte(testConfig), func(string) (string, bool) { return "", false })
+	require.NoError(t, err)
+
+	chains := registry.NewChainRegistry(mocks.NewMockLogger())
+	require.NoError(t, Register(chains, provider.Config()))
+	assert.ElementsMatch(t, []string{"ethereum", "localnet", "bitcoin-regtest", "litecoin-mainnet", "tron", "solana"}, chains.List())
+
+	ethereum, err := chains.Get("ethereum")
+	require.NoError(t, err)
+	assert.IsType(t, &evm.Adapter{}, ethereum)
+
+	localnet, err := chains.Get("localnet")
+	require.NoError(t, err)
+	assert.Equal(t, "localnet", localnet.GetChainID())
+	address, err := valueobjects.NewAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb", "localnet")
+	require.NoError(t, err)
+	balance, err := localnet.GetBalance(context.Background(), "localnet", address)
+	require.NoError(t, err)
+	assert.Equal(t, "1000000000000000000", balance.String())
+
+	regtest, err := chains.Get("bitcoin-regtest")
+	require.NoError(t, err)
+	assert.Equal(t, entities.ChainTypeBitcoin, regtest.GetChainType())
+	address, err = valueobjects.NewAddress("bcrt1qw508d6qejxtdg4y5r3zarvary0c5xw7kygt080", "bitcoin-regtest")
+	require.NoError(t, err)
+	balance, err = regtest.GetBalance(context.Background(), "bitcoin-regtest", address)
+	require.NoError(t, err)
+	assert.Equal(t, int64(50000), balance.Int64())
+
+	litecoin, err := chains.Get("litecoin-mainnet")
+	require.NoError(t, err)
+	assert.Equal(t, "litecoin-mainnet", litecoin.GetChainID())
+
+	// A second registration of the same networks is rejected
+	require.Error(t, Register(chains, provider.Config()))
+}
+
+func TestNewAdapterWithoutHarness(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewAdapter(config.Network{
+		Kind:    config.ChainSolana,
+		ChainID: "solana",
+		Adapter: config.AdapterHarness,
+		Entry:   config.SolanaNetwork{Adapter: config.AdapterHarness},
+	})
+	require.ErrorContains(t, err, "no harness is available for solana network")
+
+	_, err = NewAdapter(config.Network{Kind: "cosmos", Entry: "cosmoshub"})
+	require.ErrorContains(t, err, "unknown network entry string")
+}
+
+func TestChainFactory(t *testing.T) {
+	t.Parallel()
+
+	chainID, adapter, err := ChainFactory{}.NewChain(ports.ChainSpec{
+		Type:     config.ChainEVM,
+		Network:  "sepolia",
+		Settings: map[string]interface{}{"rpc_url": "https://sepolia.example.com", "chain_id": 11155111, "testnet": true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "sepolia", chainID)
+	chain, err := adapter.(ports.ChainDescriber).DescribeChain()
+	require.NoError(t, err)
+	assert.Equal(t, "11155111", chain.NetworkID())
+	assert.True(t, chain.IsTestnet())
+
+	// Harness networks report an in-memory network
+	chainID, adapter, err = ChainFactory{}.NewChain(ports.ChainSpec{
+		Type:     config.ChainBitcoin,
+		Network:  "regtest",
+		Settings: map[string]interface{}{"adapter": "harness"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "bitcoin-regtest", chainID)
+	network, err := adapter.GetNetworkInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "memory://localhost", network.RPCURL())
+
+	_, _, err = ChainFactory{}.NewChain(ports.ChainSpec{Type: config.ChainSolana, Network: "solana"})
+	require.ErrorContains(t, err, "invalid solana network")
+}
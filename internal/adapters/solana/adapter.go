@@ -0,0 +1,700 @@
+package solana
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/base58"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/rpcpool"
+)
+
+const (
+	defaultCurrency     = "SOL"
+	defaultPollInterval = 2 * time.Second
+	defaultCommitment   = CommitmentConfirmed
+	// nativeDecimals converts lamports to SOL
+	nativeDecimals = 9
+
+	// lamportsPerSignature is the base fee charged for every transaction signature
+	lamportsPerSignature = 5000
+	// maxComputeUnitLimit is the most compute units a transaction may request
+	maxComputeUnitLimit = 1_400_000
+	// computeUnitMargin pads simulated compute units, in percent, before they are requested
+	computeUnitMargin = 10
+	// microLamportsPerLamport converts compute-unit prices to lamports
+	microLamportsPerLamport = 1_000_000
+)
+
+// Commitment levels a network can read and confirm at
+const (
+	CommitmentConfirmed = "confirmed"
+	CommitmentFinalized = "finalized"
+)
+
+// NetworkConfig describes a Solana network entry (solana.networks in config.yaml)
+type NetworkConfig struct {
+	Name       string `yaml:"name"`
+	RPCURL     string `yaml:"rpc_url"`
+	Commitment string `yaml:"commitment"`
+	Testnet    bool   `yaml:"testnet"`
+	// ExplorerURL is the block explorer of the cluster
+	ExplorerURL string `yaml:"explorer_url"`
+	// Endpoints are further RPC endpoints balanced with rpc_url and failed over to
+	Endpoints []rpcpool.Endpoint `yaml:"endpoints"`
+	// Pool tunes endpoint selection and health tracking when endpoints are set
+	Pool rpcpool.Options `yaml:"pool"`
+}
+
+// Validate checks the network configuration
+func (c NetworkConfig) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("network name cannot be empty")
+	}
+	if c.RPCURL == "" {
+		return fmt.Errorf("rpc_url cannot be empty for network %s", c.Name)
+	}
+	switch c.Commitment {
+	case "", CommitmentConfirmed, CommitmentFinalized:
+	default:
+		return fmt.Errorf("unknown commitment %q for network %s", c.Commitment, c.Name)
+	}
+	if err := rpcpool.Validate(c.Endpoints, c.Pool); err != nil {
+		return fmt.Errorf("invalid endpoints for network %s: %w", c.Name, err)
+	}
+	return nil
+}
+
+// Adapter implements the ChainAdapter interface for Solana over JSON-RPC
+type Adapter struct {
+	client       RPCClient
+	config       NetworkConfig
+	pollInterval time.Duration
+	pool         *rpcpool.Pool
+}
+
+// NewAdapter creates a new Solana adapter
+func NewAdapter(client RPCClient, config NetworkConfig) *Adapter {
+	if config.Commitment == "" {
+		config.Commitment = defaultCommitment
+	}
+	return &Adapter{
+		client:       client,
+		config:       config,
+		pollInterval: defaultPollInterval,
+	}
+}
+
+// NewAdapterFromConfig creates a new Solana adapter backed by a JSON-RPC client
+func NewAdapterFromConfig(config NetworkConfig) (*Adapter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if len(config.Endpoints) == 0 {
+		return NewAdapter(NewClient(config.RPCURL, nil), config), nil
+	}
+	endpoints := append([]rpcpool.Endpoint{{URL: config.RPCURL}}, config.Endpoints...)
+	pool, err := rpcpool.New(endpoints, config.Pool, func(ctx context.Context, endpoint string, client *http.Client) (uint64, error) {
+		return NewAdapter(NewClient(endpoint, client), config).GetBlockNumber(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	adapter := NewAdapter(NewClient(pool.URL(), pool.Client()), config)
+	adapter.pool = pool
+	return adapter, nil
+}
+
+// GetChainID returns the chain identifier
+func (a *Adapter) GetChainID() string {
+	return a.config.Name
+}
+
+// GetChainType returns the chain type
+func (a *Adapter) GetChainType() entities.ChainType {
+	return entities.ChainTypeSolana
+}
+
+// DescribeChain returns the chain's type, cluster, testnet flag and native currency
+func (a *Adapter) DescribeChain() (*entities.Chain, error) {
+	chain, err := entities.NewChain(a.config.Name, entities.ChainTypeSolana, a.config.Name, a.config.Testnet, defaultCurrency)
+	if err != nil {
+		return nil, err
+	}
+	chain.SetDecimals(nativeDecimals)
+	return chain, nil
+}
+
+// IsConnected checks if the node reports itself healthy
+func (a *Adapter) IsConnected(ctx context.Context) bool {
+	var health string
+	if err := a.client.Call(ctx, "getHealth", nil, &health); err != nil {
+		return false
+	}
+	return health == "ok"
+}
+
+// GetBlockNumber returns the current slot
+func (a *Adapter) GetBlockNumber(ctx context.Context) (uint64, error) {
+	var slot uint64
+	if err := a.client.Call(ctx, "getSlot", []interface{}{a.commitment()}, &slot); err != nil {
+		return 0, fmt.Errorf("failed to get slot: %w", err)
+	}
+	return slot, nil
+}
+
+// GetNativeBalance returns the SOL balance in lamports for an address
+func (a *Adapter) GetNativeBalance(ctx context.Context, address *valueobjects.Address) (*big.Int, error) {
+	if _, err := DecodeAddress(address.Value()); err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Value uint64 `json:"value"`
+	}
+	if err := a.client.Call(ctx, "getBalance", []interface{}{address.Value(), a.commitment()}, &result); err != nil {
+		return nil, fmt.Errorf("failed to get balance: %w", err)
+	}
+	return new(big.Int).SetUint64(result.Value), nil
+}
+
+// GetBalance returns the SOL balance for a given address
+func (a *Adapter) GetBalance(ctx context.Context, chainID string, address *valueobjects.Address) (*big.Int, error) {
+	return a.GetNativeBalance(ctx, address)
+}
+
+// BuildTransaction creates a SOL transfer, or an SPL token transfer to the mint in To when
+// data is set, anchored to the latest blockhash and priced with the recent compute-unit price
+func (a *Adapter) BuildTransaction(ctx context.Context, params entities.TransactionParams) (*entities.Transaction, error) {
+	if params.ChainID == "" {
+		params.ChainID = a.config.Name
+	}
+
+	tx, err := entities.NewTransaction(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+	if _, err := DecodeAddress(tx.From().Value()); err != nil {
+		return nil, err
+	}
+	if _, err := DecodeAddress(tx.To().Value()); err != nil {
+		return nil, err
+	}
+	if len(tx.Data()) > 0 {
+		if err := a.setTokenTransfer(ctx, tx); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := a.SetNonce(ctx, tx); err != nil {
+		return nil, err
+	}
+	if tx.GasPrice() == nil {
+		price, err := a.GetGasPrice(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := tx.SetGasPrice(price); err != nil {
+			return nil, err
+		}
+	}
+	if tx.GasLimit() == 0 {
+		units, err := a.EstimateGas(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+		tx.SetGasLimit(computeUnitLimit(units))
+	}
+	return tx, nil
+}
+
+// EstimateGas simulates the transaction and returns the compute units it consumes
+func (a *Adapter) EstimateGas(ctx context.Context, tx *entities.Transaction) (uint64, error) {
+	blockhash := make([]byte, PublicKeyLength)
+	if recent, ok := tx.Metadata()[MetadataRecentBlockhash].(string); ok {
+		decoded, err := base58.Decode(recent)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s metadata: %w", MetadataRecentBlockhash, err)
+		}
+		blockhash = decoded
+	}
+	// The maximum limit keeps the requested limit from failing the simulation
+	message, err := messageWithBlockhash(tx, maxComputeUnitLimit, blockhash)
+	if err != nil {
+		return 0, err
+	}
+	unsigned := encodeTransaction([][]byte{make([]byte, signatureLength)}, message)
+
+	var result struct {
+		Value struct {
+			Err           interface{} `json:"err"`
+			Logs          []string    `json:"logs"`
+			UnitsConsumed uint64      `json:"unitsConsumed"`
+		} `json:"value"`
+	}
+	if err := a.client.Call(ctx, "simulateTransaction", []interface{}{
+		base64.StdEncoding.EncodeToString(unsigned),
+		map[string]interface{}{
+			"encoding":               "base64",
+			"sigVerify":              false,
+			"replaceRecentBlockhash": true,
+			"commitment":             a.config.Commitment,
+		},
+	}, &result); err != nil {
+		return 0, fmt.Errorf("failed to simulate transaction: %w", err)
+	}
+	if result.Value.Err != nil {
+		return 0, fmt.Errorf("transaction simulation failed: %v %v", result.Value.Err, result.Value.Logs)
+	}
+	return result.Value.UnitsConsumed, nil
+}
+
+// SetNonce anchors the transaction to the latest blockhash, which Solana uses in place of an
+// account nonce; the nonce itself is left at zero
+func (a *Adapter) SetNonce(ctx context.Context, tx *entities.Transaction) error {
+	var result struct {
+		Value struct {
+			Blockhash            string `json:"blockhash"`
+			LastValidBlockHeight uint64 `json:"lastValidBlockHeight"`
+		} `json:"value"`
+	}
+	if err := a.client.Call(ctx, "getLatestBlockhash", []interface{}{a.commitment()}, &result); err != nil {
+		return fmt.Errorf("failed to get latest blockhash: %w", err)
+	}
+	if _, err := DecodeAddress(result.Value.Blockhash); err != nil {
+		return fmt.Errorf("invalid latest blockhash: %w", err)
+	}
+	tx.SetMetadata(MetadataRecentBlockhash, result.Value.Blockhash)
+	tx.SetMetadata(MetadataLastValidBlockHeight, result.Value.LastValidBlockHeight)
+
+	if tx.Nonce() != nil {
+		return nil
+	}
+	return tx.SetNonce(valueobjects.NewNonce(0))
+}
+
+// SignTransaction signs the serialized message with the fee payer's ed25519 key
+func (a *Adapter) SignTransaction(ctx context.Context, tx *entities.Transaction, keys ports.KeyManager, keyID string) error {
+	if err := signTransaction(ctx, tx, keys, keyID); err != nil {
+		return fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	return nil
+}
+
+// VerifySignature verifies that the transaction was signed by its sender
+func (a *Adapter) VerifySignature(ctx context.Context, tx *entities.Transaction) (bool, error) {
+	return verifyTransaction(tx)
+}
+
+// BroadcastTransaction broadcasts a signed transaction to the network
+func (a *Adapter) BroadcastTransaction(ctx context.Context, tx *entities.Transaction) (*valueobjects.Hash, error) {
+	raw, ok := tx.Metadata()[MetadataRawTransaction].(string)
+	if !ok || raw == "" {
+		return nil, fmt.Errorf("transaction not signed")
+	}
+
+	var signature string
+	if err := a.client.Call(ctx, "sendTransaction", []interface{}{
+		raw,
+		map[string]interface{}{
+			"encoding":            "base64",
+			"preflightCommitment": a.config.Commitment,
+		},
+	}, &signature); err != nil {
+		return nil, fmt.Errorf("failed to broadcast transaction: %w", err)
+	}
+
+	sig, err := base58.Decode(signature)
+	if err != nil || len(sig) != signatureLength {
+		return nil, fmt.Errorf("invalid transaction signature: %s", signature)
+	}
+	hash, err := valueobjects.NewHashFromBytes(sig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create hash: %w", err)
+	}
+	return hash, nil
+}
+
+// GetTransactionStatus returns the status of a transaction
+func (a *Adapter) GetTransactionStatus(ctx context.Context, hash *valueobjects.Hash) (entities.TxStatus, error) {
+	status, err := a.signatureStatus(ctx, hash)
+	if err != nil {
+		return entities.TxStatusPending, err
+	}
+	return status.txStatus(), nil
+}
+
+// GetTransactionReceipt returns the transaction with its on-chain inclusion data
+func (a *Adapter) GetTransactionReceipt(ctx context.Context, hash *valueobjects.Hash) (*entities.Transaction, error) {
+	signature, err := encodeSignature(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	var rpcTx *rpcTransaction
+	if err := a.client.Call(ctx, "getTransaction", []interface{}{
+		signature,
+		map[string]interface{}{
+			"encoding":                       "json",
+			"commitment":                     a.config.Commitment,
+			"maxSupportedTransactionVersion": 0,
+		},
+	}, &rpcTx); err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	if rpcTx == nil {
+		return nil, fmt.Errorf("transaction not found: %s", signature)
+	}
+
+	tx, err := rpcTx.toEntity(a.config.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.SetHash(hash); err != nil {
+		return nil, err
+	}
+	tx.SetMetadata(MetadataSignature, signature)
+	tx.SetBlockNumber(rpcTx.Slot)
+
+	latest, err := a.GetBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if latest >= rpcTx.Slot {
+		tx.SetConfirmations(latest - rpcTx.Slot + 1)
+	}
+	return tx, nil
+}
+
+// WaitForConfirmation polls the node until the transaction is confirmed by the cluster and
+// the given number of blocks; finalized transactions satisfy any number of confirmations
+func (a *Adapter) WaitForConfirmation(ctx context.Context, hash *valueobjects.Hash, confirmations uint64) error {
+	if confirmations == 0 {
+		confirmations = 1
+	}
+
+	ticker := time.NewTicker(a.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		status, err := a.signatureStatus(ctx, hash)
+		if err != nil {
+			return err
+		}
+		if status != nil {
+			if status.Err != nil {
+				return fmt.Errorf("transaction failed: %s", base58.Encode(hash.Bytes()))
+			}
+			if status.confirmed(confirmations) {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// EstimateFee estimates the lamports paid for the signature and the requested compute units
+func (a *Adapter) EstimateFee(ctx context.Context, tx *entities.Transaction) (*entities.Fee, error) {
+	units := tx.GasLimit()
+	if units == 0 {
+		consumed, err := a.EstimateGas(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+		units = computeUnitLimit(consumed)
+	}
+	price := tx.GasPrice()
+	if price == nil {
+		var err error
+		if price, err = a.GetGasPrice(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	// Transactions are signed by the fee payer alone
+	total := big.NewInt(lamportsPerSignature)
+	total.Add(total, priorityFee(units, price))
+	return entities.NewComputeUnitFee(units, price, total, defaultCurrency)
+}
+
+// GetGasPrice returns the median compute-unit price, in micro-lamports, paid in recent slots
+func (a *Adapter) GetGasPrice(ctx context.Context) (*big.Int, error) {
+	var fees []struct {
+		Slot              uint64 `json:"slot"`
+		PrioritizationFee uint64 `json:"prioritizationFee"`
+	}
+	if err := a.client.Call(ctx, "getRecentPrioritizationFees", nil, &fees); err != nil {
+		return nil, fmt.Errorf("failed to get prioritization fees: %w", err)
+	}
+	if len(fees) == 0 {
+		return big.NewInt(0), nil
+	}
+
+	prices := make([]uint64, len(fees))
+	for i, fee := range fees {
+		prices[i] = fee.PrioritizationFee
+	}
+	sort.Slice(prices, func(i, j int) bool { return prices[i] < prices[j] })
+	return new(big.Int).SetUint64(prices[len(prices)/2]), nil
+}
+
+// GetMaxPriorityFee returns the compute-unit price, which is Solana's priority fee
+func (a *Adapter) GetMaxPriorityFee(ctx context.Context) (*big.Int, error) {
+	return a.GetGasPrice(ctx)
+}
+
+// GetNetworkInfo returns the network information
+func (a *Adapter) GetNetworkInfo(ctx context.Context) (*entities.Network, error) {
+	network, err := entities.NewNetwork(a.config.Name, a.config.Name, a.config.RPCURL)
+	if err != nil {
+		return nil, err
+	}
+	if a.config.ExplorerURL != "" {
+		network.SetExplorerURL(a.config.ExplorerURL)
+	}
+	if a.pool != nil {
+		network.SetEndpoints(a.pool.Health())
+	}
+	return network, nil
+}
+
+// GetPeers returns the number of nodes in the cluster
+func (a *Adapter) GetPeers(ctx context.Context) (int, error) {
+	var nodes []interface{}
+	if err := a.client.Call(ctx, "getClusterNodes", nil, &nodes); err != nil {
+		return 0, fmt.Errorf("failed to get cluster nodes: %w", err)
+	}
+	return len(nodes), nil
+}
+
+// GetLatestBlock returns the latest slot
+func (a *Adapter) GetLatestBlock(ctx context.Context) (uint64, error) {
+	return a.GetBlockNumber(ctx)
+}
+
+// commitment returns the configuration object selecting the network commitment
+func (a *Adapter) commitment() map[string]interface{} {
+	return map[string]interface{}{"commitment": a.config.Commitment}
+}
+
+func (a *Adapter) signatureStatus(ctx context.Context, hash *valueobjects.Hash) (*rpcSignatureStatus, error) {
+	signature, err := encodeSignature(hash)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		Value []*rpcSignatureStatus `json:"value"`
+	}
+	if err := a.client.Call(ctx, "getSignatureStatuses", []interface{}{
+		[]string{signature},
+		map[string]interface{}{"searchTransactionHistory": true},
+	}, &result); err != nil {
+		return nil, fmt.Errorf("failed to get signature status: %w", err)
+	}
+	if len(result.Value) == 0 {
+		return nil, nil
+	}
+	return result.Value[0], nil
+}
+
+// encodeSignature returns the base58 signature identifying a transaction
+func encodeSignature(hash *valueobjects.Hash) (string, error) {
+	sig := hash.Bytes()
+	if len(sig) != signatureLength {
+		return "", fmt.Errorf("invalid transaction signature length: %d", len(sig))
+	}
+	return base58.Encode(sig), nil
+}
+
+// computeUnitLimit pads consumed compute units into the limit a transaction requests
+func computeUnitLimit(consumed uint64) uint64 {
+	limit := consumed + (consumed*computeUnitMargin+99)/100
+	if limit > maxComputeUnitLimit {
+		return maxComputeUnitLimit
+	}
+	return limit
+}
+
+// priorityFee returns the lamports paid for units at a price in micro-lamports, rounded up
+func priorityFee(units uint64, price *big.Int) *big.Int {
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(units), price)
+	fee.Add(fee, big.NewInt(microLamportsPerLamport-1))
+	return fee.Div(fee, big.NewInt(microLamportsPerLamport))
+}
+
+type rpcSignatureStatus struct {
+	Slot               uint64      `json:"slot"`
+	Confirmations      *uint64     `json:"confirmations"`
+	Err                interface{} `json:"err"`
+	ConfirmationStatus string      `json:"confirmationStatus"`
+}
+
+// txStatus maps the signature status to a domain status; unknown transactions are pending
+func (s *rpcSignatureStatus) txStatus() entities.TxStatus {
+	switch {
+	case s == nil:
+		return entities.TxStatusPending
+	case s.Err != nil:
+		return entities.TxStatusFailed
+	case s.ConfirmationStatus == CommitmentConfirmed || s.ConfirmationStatus == CommitmentFinalized:
+		return entities.TxStatusConfirmed
+	default:
+		return entities.TxStatusPending
+	}
+}
+
+// confirmed reports whether the cluster confirmed the transaction and enough blocks followed
+func (s *rpcSignatureStatus) confirmed(confirmations uint64) bool {
+	switch s.ConfirmationStatus {
+	case CommitmentFinalized:
+		return true
+	case CommitmentConfirmed:
+		// confirmations counts the blocks after the one including the transaction
+		return s.Confirmations == nil || *s.Confirmations+1 >= confirmations
+	default:
+		return false
+	}
+}
+
+type rpcInstruction struct {
+	ProgramIDIndex int    `json:"programIdIndex"`
+	Accounts       []int  `json:"accounts"`
+	Data           string `json:"data"`
+}
+
+type rpcTransaction struct {
+	Slot uint64 `json:"slot"`
+	Meta *struct {
+		Err                  interface{} `json:"err"`
+		Fee                  uint64      `json:"fee"`
+		ComputeUnitsConsumed uint64      `json:"computeUnitsConsumed"`
+		PostTokenBalances    []struct {
+			AccountIndex int    `json:"accountIndex"`
+			Mint         string `json:"mint"`
+			Owner        string `json:"owner"`
+		} `json:"postTokenBalances"`
+		LoadedAddresses struct {
+			Writable []string `json:"writable"`
+			Readonly []string `json:"readonly"`
+		} `json:"loadedAddresses"`
+	} `json:"meta"`
+	Transaction struct {
+		Message struct {
+			AccountKeys  []string         `json:"accountKeys"`
+			Instructions []rpcInstruction `json:"instructions"`
+		} `json:"message"`
+	} `json:"transaction"`
+}
+
+// toEntity rebuilds the transaction from its first SOL or SPL token transfer; other program
+// calls are reported as sent to the program of their last instruction
+func (t *rpcTransaction) toEntity(chainID string) (*entities.Transaction, error) {
+	keys := append([]string{}, t.Transaction.Message.AccountKeys...)
+	if t.Meta != nil {
+		keys = append(append(keys, t.Meta.LoadedAddresses.Writable...), t.Meta.LoadedAddresses.Readonly...)
+	}
+	instructions := t.Transaction.Message.Instructions
+	if len(keys) == 0 || len(instructions) == 0 {
+		return nil, fmt.Errorf("transaction has no instructions")
+	}
+	key := func(index int) (string, error) {
+		if index < 0 || index >= len(keys) {
+			return "", fmt.Errorf("account index out of range: %d", index)
+		}
+		return keys[index], nil
+	}
+
+	params := entities.TransactionParams{ChainID: chainID, Value: big.NewInt(0)}
+	metadata := map[string]interface{}{}
+	var to string
+	for _, ix := range instructions {
+		program, err := key(ix.ProgramIDIndex)
+		if err != nil {
+			return nil, err
+		}
+		data, err := base58.Decode(ix.Data)
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case program == SystemProgramID && len(data) == 12 &&
+			binary.LittleEndian.Uint32(data) == systemInstructionTransfer && len(ix.Accounts) >= 2:
+			if to, err = key(ix.Accounts[1]); err != nil {
+				return nil, err
+			}
+			params.Value = new(big.Int).SetUint64(binary.LittleEndian.Uint64(data[4:]))
+		case (program == TokenProgramID || program == Token2022ProgramID) && len(data) == transferCheckedInstructionLength &&
+			data[0] == tokenInstructionTransferChecked && len(ix.Accounts) >= 3:
+			if to, err = key(ix.Accounts[1]); err != nil {
+				return nil, err
+			}
+			recipient, err := t.tokenOwner(ix.Accounts[2])
+			if err != nil {
+				return nil, err
+			}
+			params.Data = append(recipient, data[1:9]...)
+			metadata[MetadataTokenProgram] = program
+			metadata[MetadataTokenDecimals] = data[9]
+		default:
+			continue
+		}
+		break
+	}
+	if to == "" {
+		var err error
+		if to, err = key(instructions[len(instructions)-1].ProgramIDIndex); err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	if params.From, err = valueobjects.NewAddress(keys[0], chainID); err != nil {
+		return nil, fmt.Errorf("invalid from address: %w", err)
+	}
+	if params.To, err = valueobjects.NewAddress(to, chainID); err != nil {
+		return nil, fmt.Errorf("invalid to address: %w", err)
+	}
+
+	tx, err := entities.NewTransaction(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+	for k, v := range metadata {
+		tx.SetMetadata(k, v)
+	}
+	tx.UpdateStatus(entities.TxStatusConfirmed)
+	if t.Meta != nil {
+		if t.Meta.Err != nil {
+			tx.UpdateStatus(entities.TxStatusFailed)
+		}
+		tx.SetMetadata("fee", t.Meta.Fee)
+		tx.SetMetadata("compute_units_consumed", t.Meta.ComputeUnitsConsumed)
+	}
+	return tx, nil
+}
+
+// tokenOwner returns the wallet owning a token account of the transaction
+func (t *rpcTransaction) tokenOwner(accountIndex int) ([]byte, error) {
+	if t.Meta != nil {
+		for _, balance := range t.Meta.PostTokenBalances {
+			if balance.AccountIndex == accountIndex {
+				return DecodeAddress(balance.Owner)
+			}
+		}
+	}
+	return nil, fmt.Errorf("no token balance for account index %d", accountIndex)
+}
@@ -0,0 +1,520 @@
+package solana
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/base58"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/keystore"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/rpcpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSlot = 250000000
+
+var _ ports.ChainAdapter = (*Adapter)(nil)
+
+type rpcHandler func(params []interface{}) (interface{}, error)
+
+// fakeNode is a local JSON-RPC stand-in for a Solana validator
+type fakeNode struct {
+	mu       sync.Mutex
+	handlers map[string]rpcHandler
+	calls    map[string]int
+	server   *httptest.Server
+}
+
+func newFakeNode(t *testing.T) *fakeNode {
+	t.Helper()
+	node := &fakeNode{
+		handlers: make(map[string]rpcHandler),
+		calls:    make(map[string]int),
+	}
+	node.server = httptest.NewServer(http.HandlerFunc(node.serve))
+	t.Cleanup(node.server.Close)
+	node.result("getSlot", testSlot)
+	node.result("getLatestBlockhash", map[string]interface{}{
+		"context": map[string]interface{}{"slot": testSlot},
+		"value":   map[string]interface{}{"blockhash": testBlockhash(), "lastValidBlockHeight": 228000150},
+	})
+	return node
+}
+
+func (n *fakeNode) serve(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     uint64        `json:"id"`
+		Method string        `json:"method"`
+		Params []interface{} `json:"params"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	n.mu.Lock()
+	handler, ok := n.handlers[req.Method]
+	n.calls[req.Method]++
+	n.mu.Unlock()
+
+	response := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	if !ok {
+		response["error"] = &RPCError{Code: -32601, Message: "Method not found"}
+		_ = json.NewEncoder(w).Encode(response)
+		return
+	}
+	result, err := handler(req.Params)
+	if rpcErr, isRPC := err.(*RPCError); isRPC {
+		response["error"] = rpcErr
+	} else if err != nil {
+		response["error"] = &RPCError{Code: -32000, Message: err.Error()}
+	} else {
+		response["result"] = result
+	}
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (n *fakeNode) handle(method string, handler rpcHandler) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.handlers[method] = handler
+}
+
+func (n *fakeNode) result(method string, result interface{}) {
+	n.handle(method, func([]interface{}) (interface{}, error) { return result, nil })
+}
+
+func (n *fakeNode) callCount(method string) int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.calls[method]
+}
+
+func newTestAdapter(t *testing.T) (*Adapter, *fakeNode) {
+	t.Helper()
+	node := newFakeNode(t)
+	adapter, err := NewAdapterFromConfig(NetworkConfig{Name: "solana", RPCURL: node.server.URL})
+	require.NoError(t, err)
+	adapter.pollInterval = 10 * time.Millisecond
+	return adapter, node
+}
+
+func testBlockhash() string {
+	return base58.Encode(bytes.Repeat([]byte{0x11}, 32))
+}
+
+func testPrivateKey() []byte {
+	return bytes.Repeat([]byte{0x46}, 32)
+}
+
+// testKeys holds the sender key as "test" and the recipient key as "recipient"
+func testKeys(t *testing.T) ports.KeyManager {
+	t.Helper()
+	keys, err := keystore.NewManager(
+		keystore.Key{ID: "test", Curve: ports.CurveEd25519, PrivateKey: testPrivateKey()},
+		keystore.Key{ID: "recipient", Curve: ports.CurveEd25519, PrivateKey: bytes.Repeat([]byte{0x47}, 32)},
+	)
+	require.NoError(t, err)
+	return keys
+}
+
+func testAddresses(t *testing.T) (from, to *valueobjects.Address) {
+	t.Helper()
+	sender, err := AddressFromPrivateKey(testPrivateKey())
+	require.NoError(t, err)
+	recipient, err := AddressFromPrivateKey(bytes.Repeat([]byte{0x47}, 32))
+	require.NoError(t, err)
+	from, err = valueobjects.NewAddress(sender, "solana")
+	require.NoError(t, err)
+	to, err = valueobjects.NewAddress(recipient, "solana")
+	require.NoError(t, err)
+	return from, to
+}
+
+// decodeTransaction splits a base64 wire transaction into its signatures and message
+func decodeTransaction(t *testing.T, raw string) ([][]byte, []byte) {
+	t.Helper()
+	data, err := base64.StdEncoding.DecodeString(raw)
+	require.NoError(t, err)
+	require.NotEmpty(t, data)
+	count := int(data[0])
+	require.Less(t, count, 0x80)
+	signatures := make([][]byte, count)
+	for i := range signatures {
+		signatures[i] = data[1+i*signatureLength : 1+(i+1)*signatureLength]
+	}
+	return signatures, data[1+count*signatureLength:]
+}
+
+func TestNetworkConfigValidate(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, NetworkConfig{Name: "solana", RPCURL: "https://api.mainnet-beta.solana.com"}.Validate())
+	require.NoError(t, NetworkConfig{Name: "solana", RPCURL: "https://api.mainnet-beta.solana.com", Commitment: CommitmentFinalized}.Validate())
+	require.Error(t, NetworkConfig{RPCURL: "https://api.mainnet-beta.solana.com"}.Validate())
+	require.Error(t, NetworkConfig{Name: "solana"}.Validate())
+	require.ErrorContains(t, NetworkConfig{Name: "solana", RPCURL: "http://localhost:8899", Commitment: "processed"}.Validate(), "unknown commitment")
+	require.ErrorContains(t, NetworkConfig{Name: "solana", RPCURL: "http://localhost:8899", Endpoints: []rpcpool.Endpoint{{}}}.Validate(), "invalid endpoints")
+	_, err := NewAdapterFromConfig(NetworkConfig{Name: "solana"})
+	require.Error(t, err)
+}
+
+func TestAdapterChainInfo(t *testing.T) {
+	t.Parallel()
+	adapter, node := newTestAdapter(t)
+	ctx := context.Background()
+
+	assert.Equal(t, "solana", adapter.GetChainID())
+	assert.Equal(t, entities.ChainTypeSolana, adapter.GetChainType())
+
+	node.result("getHealth", "ok")
+	assert.True(t, adapter.IsConnected(ctx))
+
+	node.handle("getSlot", func(params []interface{}) (interface{}, error) {
+		assert.Equal(t, []interface{}{map[string]interface{}{"commitment": "confirmed"}}, params)
+		return testSlot, nil
+	})
+	slot, err := adapter.GetBlockNumber(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(testSlot), slot)
+	latest, err := adapter.GetLatestBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, slot, latest)
+
+	node.result("getClusterNodes", []interface{}{map[string]interface{}{}, map[string]interface{}{}, map[string]interface{}{}})
+	peers, err := adapter.GetPeers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, peers)
+
+	node.result("getRecentPrioritizationFees", []map[string]interface{}{
+		{"slot": 1, "prioritizationFee": 0},
+		{"slot": 2, "prioritizationFee": 50000},
+		{"slot": 3, "prioritizationFee": 1000},
+		{"slot": 4, "prioritizationFee": 20000},
+		{"slot": 5, "prioritizationFee": 0},
+	})
+	price, err := adapter.GetGasPrice(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1000), price)
+	tip, err := adapter.GetMaxPriorityFee(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, price, tip)
+
+	node.result("getRecentPrioritizationFees", []interface{}{})
+	price, err = adapter.GetGasPrice(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), price.Int64())
+
+	network, err := adapter.GetNetworkInfo(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, node.server.URL, network.RPCURL())
+
+	node.handle("getHealth", func([]interface{}) (interface{}, error) {
+		return nil, &RPCError{Code: -32005, Message: "Node is behind by 42 slots"}
+	})
+	assert.False(t, adapter.IsConnected(ctx))
+}
+
+func TestAdapterUnreachable(t *testing.T) {
+	t.Parallel()
+	adapter, err := NewAdapterFromConfig(NetworkConfig{Name: "solana", RPCURL: "http://127.0.0.1:1"})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	assert.False(t, adapter.IsConnected(ctx))
+	_, err = adapter.GetBlockNumber(ctx)
+	require.Error(t, err)
+	_, err = adapter.GetPeers(ctx)
+	require.Error(t, err)
+	_, err = adapter.GetGasPrice(ctx)
+	require.Error(t, err)
+}
+
+func TestAdapterGetBalance(t *testing.T) {
+	t.Parallel()
+	adapter, node := newTestAdapter(t)
+	ctx := context.Background()
+	from, _ := testAddresses(t)
+
+	node.handle("getBalance", func(params []interface{}) (interface{}, error) {
+		assert.Equal(t, from.Value(), params[0])
+		return map[string]interface{}{"context": map[string]interface{}{"slot": testSlot}, "value": 2500000000}, nil
+	})
+	balance, err := adapter.GetBalance(ctx, "solana", from)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(2500000000), balance)
+
+	invalid, err := valueobjects.NewAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb", "solana")
+	require.NoError(t, err)
+	_, err = adapter.GetNativeBalance(ctx, invalid)
+	require.Error(t, err)
+	assert.Equal(t, 1, node.callCount("getBalance"))
+}
+
+func TestAdapterTransferFlow(t *testing.T) {
+	t.Parallel()
+	adapter, node := newTestAdapter(t)
+	ctx := context.Background()
+	from, to := testAddresses(t)
+
+	node.result("getRecentPrioritizationFees", []map[string]interface{}{{"slot": 1, "prioritizationFee": 10000}})
+	node.handle("simulateTransaction", func(params []interface{}) (interface{}, error) {
+		options := params[1].(map[string]interface{})
+		assert.Equal(t, false, options["sigVerify"])
+		assert.Equal(t, true, options["replaceRecentBlockhash"])
+		_, message := decodeTransaction(t, params[0].(string))
+		// The simulation requests the maximum compute units
+		assert.Contains(t, string(message), string([]byte{computeBudgetSetUnitLimit, 0xc0, 0x5c, 0x15, 0x00}))
+		return map[string]interface{}{"value": map[string]interface{}{"err": nil, "unitsConsumed": 450}}, nil
+	})
+
+	tx, err := adapter.BuildTransaction(ctx, entities.TransactionParams{From: from, To: to, Value: big.NewInt(1500000)})
+	require.NoError(t, err)
+	assert.Equal(t, "solana", tx.ChainID())
+	assert.Equal(t, uint64(0), tx.Nonce().Value())
+	assert.Equal(t, testBlockhash(), tx.Metadata()[MetadataRecentBlockhash])
+	assert.Equal(t, uint64(228000150), tx.Metadata()[MetadataLastValidBlockHeight])
+	assert.Equal(t, big.NewInt(10000), tx.GasPrice())
+	assert.Equal(t, uint64(495), tx.GasLimit())
+
+	fee, err := adapter.EstimateFee(ctx, tx)
+	require.NoError(t, err)
+	// 5000 lamports per signature plus 495 units at 0.01 lamports, rounded up
+	assert.Equal(t, big.NewInt(5005), fee.Total())
+	assert.Equal(t, uint64(495), fee.GasLimit())
+	assert.Equal(t, "SOL", fee.Currency())
+
+	require.ErrorContains(t, adapter.SignTransaction(ctx, tx, testKeys(t), "recipient"), "does not match sender")
+	_, err = adapter.VerifySignature(ctx, tx)
+	require.Error(t, err)
+	_, err = adapter.BroadcastTransaction(ctx, tx)
+	require.ErrorContains(t, err, "not signed")
+
+	require.NoError(t, adapter.SignTransaction(ctx, tx, testKeys(t), "test"))
+	valid, err := adapter.VerifySignature(ctx, tx)
+	require.NoError(t, err)
+	assert.True(t, valid)
+	assert.Equal(t, tx.Signature().Bytes(), tx.Hash().Bytes())
+	assert.Equal(t, base58.Encode(tx.Hash().Bytes()), tx.Metadata()[MetadataSignature])
+
+	signatures, message := decodeTransaction(t, tx.Metadata()[MetadataRawTransaction].(string))
+	require.Len(t, signatures, 1)
+	fromKey, err := DecodeAddress(from.Value())
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(fromKey, message, signatures[0]))
+	assert.Equal(t, base64.StdEncoding.EncodeToString(message), tx.Metadata()[MetadataMessage])
+
+	node.handle("sendTransaction", func(params []interface{}) (interface{}, error) {
+		assert.Equal(t, tx.Metadata()[MetadataRawTransaction], params[0])
+		assert.Equal(t, "base64", params[1].(map[string]interface{})["encoding"])
+		sigs, _ := decodeTransaction(t, params[0].(string))
+		return base58.Encode(sigs[0]), nil
+	})
+	hash, err := adapter.BroadcastTransaction(ctx, tx)
+	require.NoError(t, err)
+	assert.True(t, hash.Equals(tx.Hash()))
+
+	node.handle("sendTransaction", func([]interface{}) (interface{}, error) {
+		return nil, &RPCError{Code: -32002, Message: "Transaction simulation failed: Blockhash not found"}
+	})
+	_, err = adapter.BroadcastTransaction(ctx, tx)
+	require.ErrorContains(t, err, "Blockhash not found")
+
+	// Changing the transfer invalidates the signature
+	tampered, err := entities.NewTransaction(entities.TransactionParams{ChainID: "solana", From: from, To: to, Value: big.NewInt(1500001), GasLimit: tx.GasLimit(), GasPrice: tx.GasPrice()})
+	require.NoError(t, err)
+	tampered.SetMetadata(MetadataRecentBlockhash, testBlockhash())
+	require.NoError(t, tampered.SetSignature(tx.Signature()))
+	valid, err = adapter.VerifySignature(ctx, tampered)
+	require.NoError(t, err)
+	assert.False(t, valid)
+}
+
+func TestAdapterBuildTransactionErrors(t *testing.T) {
+	t.Parallel()
+	adapter, node := newTestAdapter(t)
+	ctx := context.Background()
+	from, to := testAddresses(t)
+
+	invalid, err := valueobjects.NewAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", "solana")
+	require.NoError(t, err)
+	_, err = adapter.BuildTransaction(ctx, entities.TransactionParams{From: from, To: invalid, Value: big.NewInt(1)})
+	require.Error(t, err)
+	_, err = adapter.BuildTransaction(ctx, entities.TransactionParams{From: from, To: to, Value: big.NewInt(-1)})
+	require.Error(t, err)
+
+	node.result("getRecentPrioritizationFees", []interface{}{})
+	node.result("simulateTransaction", map[string]interface{}{"value": map[string]interface{}{
+		"err":  map[string]interface{}{"InstructionError": []interface{}{0, map[string]interface{}{"Custom": 1}}},
+		"logs": []string{"Transfer: insufficient lamports 0, need 1"},
+	}})
+	_, err = adapter.BuildTransaction(ctx, entities.TransactionParams{From: from, To: to, Value: big.NewInt(1)})
+	require.ErrorContains(t, err, "insufficient lamports")
+
+	// A caller-provided compute budget skips the estimates
+	tx, err := adapter.BuildTransaction(ctx, entities.TransactionParams{From: from, To: to, Value: big.NewInt(1), GasLimit: 300, GasPrice: big.NewInt(0)})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(300), tx.GasLimit())
+	assert.Equal(t, 1, node.callCount("simulateTransaction"))
+	assert.Equal(t, 1, node.callCount("getRecentPrioritizationFees"))
+
+	node.result("getLatestBlockhash", map[string]interface{}{"value": map[string]interface{}{"blockhash": "bad"}})
+	_, err = adapter.BuildTransaction(ctx, entities.TransactionParams{From: from, To: to, Value: big.NewInt(1)})
+	require.ErrorContains(t, err, "invalid latest blockhash")
+}
+
+func TestAdapterTransactionStatus(t *testing.T) {
+	t.Parallel()
+	adapter, node := newTestAdapter(t)
+	ctx := context.Background()
+	sig := bytes.Repeat([]byte{0x5a}, signatureLength)
+	hash, err := valueobjects.NewHashFromBytes(sig)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var status interface{}
+	setStatus := func(s interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		status = s
+	}
+	node.handle("getSignatureStatuses", func(params []interface{}) (interface{}, error) {
+		assert.Equal(t, []interface{}{base58.Encode(sig)}, params[0])
+		mu.Lock()
+		defer mu.Unlock()
+		return map[string]interface{}{"value": []interface{}{status}}, nil
+	})
+
+	for expected, s := range map[entities.TxStatus]interface{}{
+		entities.TxStatusPending:   map[string]interface{}{"slot": testSlot, "confirmations": 0, "err": nil, "confirmationStatus": "processed"},
+		entities.TxStatusConfirmed: map[string]interface{}{"slot": testSlot, "confirmations": 3, "err": nil, "confirmationStatus": "confirmed"},
+		entities.TxStatusFailed:    map[string]interface{}{"slot": testSlot, "confirmations": nil, "err": map[string]interface{}{"InstructionError": []interface{}{0, "InvalidAccountData"}}, "confirmationStatus": "finalized"},
+	} {
+		setStatus(s)
+		got, err := adapter.GetTransactionStatus(ctx, hash)
+		require.NoError(t, err)
+		assert.Equal(t, expected, got)
+	}
+	setStatus(nil)
+	got, err := adapter.GetTransactionStatus(ctx, hash)
+	require.NoError(t, err)
+	assert.Equal(t, entities.TxStatusPending, got)
+
+	short, err := valueobjects.NewHash("0xabcd")
+	require.NoError(t, err)
+	_, err = adapter.GetTransactionStatus(ctx, short)
+	require.ErrorContains(t, err, "invalid transaction signature length")
+
+	// Confirmed with too few blocks after it, then enough
+	setStatus(map[string]interface{}{"slot": testSlot, "confirmations": 1, "err": nil, "confirmationStatus": "confirmed"})
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		setStatus(map[string]interface{}{"slot": testSlot, "confirmations": 4, "err": nil, "confirmationStatus": "confirmed"})
+	}()
+	require.NoError(t, adapter.WaitForConfirmation(ctx, hash, 5))
+
+	setStatus(map[string]interface{}{"slot": testSlot, "confirmations": nil, "err": nil, "confirmationStatus": "finalized"})
+	require.NoError(t, adapter.WaitForConfirmation(ctx, hash, 100))
+
+	setStatus(map[string]interface{}{"slot": testSlot, "confirmations": 0, "err": "AccountInUse", "confirmationStatus": "processed"})
+	require.ErrorContains(t, adapter.WaitForConfirmation(ctx, hash, 1), "transaction failed")
+
+	setStatus(nil)
+	timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, adapter.WaitForConfirmation(timeout, hash, 0), context.DeadlineExceeded)
+}
+
+func TestAdapterGetTransactionReceipt(t *testing.T) {
+	t.Parallel()
+	adapter, node := newTestAdapter(t)
+	ctx := context.Background()
+	from, to := testAddresses(t)
+	sig := bytes.Repeat([]byte{0x5a}, signatureLength)
+	hash, err := valueobjects.NewHashFromBytes(sig)
+	require.NoError(t, err)
+
+	transfer := make([]byte, 12)
+	binary.LittleEndian.PutUint32(transfer, systemInstructionTransfer)
+	binary.LittleEndian.PutUint64(transfer[4:], 1500000)
+	var rpcTx interface{} = map[string]interface{}{
+		"slot": testSlot - 9,
+		"meta": map[string]interface{}{"err": nil, "fee": 5005, "computeUnitsConsumed": 450},
+		"transaction": map[string]interface{}{
+			"signatures": []string{base58.Encode(sig)},
+			"message": map[string]interface{}{
+				"accountKeys": []string{from.Value(), to.Value(), SystemProgramID, ComputeBudgetProgramID},
+				"instructions": []map[string]interface{}{
+					{"programIdIndex": 3, "accounts": []int{}, "data": base58.Encode([]byte{computeBudgetSetUnitLimit, 0xef, 0x01, 0, 0})},
+					{"programIdIndex": 2, "accounts": []int{0, 1}, "data": base58.Encode(transfer)},
+				},
+			},
+		},
+	}
+	node.handle("getTransaction", func(params []interface{}) (interface{}, error) {
+		assert.Equal(t, base58.Encode(sig), params[0])
+		options := params[1].(map[string]interface{})
+		assert.Equal(t, "json", options["encoding"])
+		assert.Equal(t, float64(0), options["maxSupportedTransactionVersion"])
+		return rpcTx, nil
+	})
+
+	tx, err := adapter.GetTransactionReceipt(ctx, hash)
+	require.NoError(t, err)
+	assert.Equal(t, from.Value(), tx.From().Value())
+	assert.Equal(t, to.Value(), tx.To().Value())
+	assert.Equal(t, big.NewInt(1500000), tx.Value())
+	assert.Equal(t, entities.TxStatusConfirmed, tx.Status())
+	assert.Equal(t, uint64(testSlot-9), tx.BlockNumber())
+	assert.Equal(t, uint64(10), tx.Confirmations())
+	assert.Equal(t, uint64(5005), tx.Metadata()["fee"])
+	assert.Equal(t, uint64(450), tx.Metadata()["compute_units_consumed"])
+	assert.True(t, hash.Equals(tx.Hash()))
+
+	// Other program calls are reported as sent to the program
+	rpcTx = map[string]interface{}{
+		"slot": testSlot,
+		"meta": map[string]interface{}{"err": map[string]interface{}{"InstructionError": []interface{}{0, "Custom"}}, "fee": 5000},
+		"transaction": map[string]interface{}{
+			"message": map[string]interface{}{
+				"accountKeys":  []string{from.Value(), "MemoSq4gqABAXKb96qnH8TysNcWxMyWCqXgDLGmfcHr"},
+				"instructions": []map[string]interface{}{{"programIdIndex": 1, "accounts": []int{0}, "data": base58.Encode([]byte("hello"))}},
+			},
+		},
+	}
+	tx, err = adapter.GetTransactionReceipt(ctx, hash)
+	require.NoError(t, err)
+	assert.Equal(t, "MemoSq4gqABAXKb96qnH8TysNcWxMyWCqXgDLGmfcHr", tx.To().Value())
+	assert.Equal(t, entities.TxStatusFailed, tx.Status())
+
+	rpcTx = map[string]interface{}{
+		"slot":        testSlot,
+		"transaction": map[string]interface{}{"message": map[string]interface{}{"accountKeys": []string{from.Value()}, "instructions": []map[string]interface{}{{"programIdIndex": 4}}}},
+	}
+	_, err = adapter.GetTransactionReceipt(ctx, hash)
+	require.ErrorContains(t, err, "account index out of range")
+
+	rpcTx = nil
+	_, err = adapter.GetTransactionReceipt(ctx, hash)
+	require.ErrorContains(t, err, "transaction not found")
+}
+
+func TestComputeUnitLimitAndPriorityFee(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, uint64(495), computeUnitLimit(450))
+	assert.Equal(t, uint64(0), computeUnitLimit(0))
+	assert.Equal(t, uint64(maxComputeUnitLimit), computeUnitLimit(1_300_000))
+
+	assert.Equal(t, int64(0), priorityFee(200000, big.NewInt(0)).Int64())
+	assert.Equal(t, int64(1), priorityFee(495, big.NewInt(1)).Int64())
+	assert.Equal(t, int64(2000), priorityFee(200000, big.NewInt(10000)).Int64())
+}
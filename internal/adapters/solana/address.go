@@ -0,0 +1,183 @@
+package solana
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/base58"
+)
+
+const (
+	// PublicKeyLength is the size of an ed25519 public key, which is a Solana address
+	PublicKeyLength = 32
+
+	signatureLength = ed25519.SignatureSize
+
+	// maxSeeds and maxSeedLength bound the seeds of a program derived address
+	maxSeeds      = 16
+	maxSeedLength = 32
+
+	pdaMarker = "ProgramDerivedAddress"
+)
+
+// Well-known program addresses
+const (
+	SystemProgramID          = "11111111111111111111111111111111"
+	ComputeBudgetProgramID   = "ComputeBudget111111111111111111111111111111"
+	TokenProgramID           = "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"
+	Token2022ProgramID       = "TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb"
+	AssociatedTokenProgramID = "ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL"
+)
+
+var (
+	systemProgram          = mustDecodeAddress(SystemProgramID)
+	computeBudgetProgram   = mustDecodeAddress(ComputeBudgetProgramID)
+	tokenProgram           = mustDecodeAddress(TokenProgramID)
+	token2022Program       = mustDecodeAddress(Token2022ProgramID)
+	associatedTokenProgram = mustDecodeAddress(AssociatedTokenProgramID)
+)
+
+// Curve25519 field prime 2^255-19 and the twisted Edwards constant d = -121665/121666
+var (
+	fieldPrime = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+	edwardsD   = new(big.Int).Mod(
+		new(big.Int).Mul(big.NewInt(-121665), new(big.Int).ModInverse(big.NewInt(121666), fieldPrime)),
+		fieldPrime,
+	)
+)
+
+// DecodeAddress decodes a base58 Solana address into its 32-byte public key
+func DecodeAddress(address string) ([]byte, error) {
+	address = strings.TrimSpace(address)
+	raw, err := base58.Decode(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Solana address %s: %w", address, err)
+	}
+	if len(raw) != PublicKeyLength {
+		return nil, fmt.Errorf("invalid Solana address: %s", address)
+	}
+	return raw, nil
+}
+
+// EncodeAddress encodes a 32-byte public key as a base58 Solana address
+func EncodeAddress(raw []byte) (string, error) {
+	if len(raw) != PublicKeyLength {
+		return "", fmt.Errorf("invalid Solana public key length: %d", len(raw))
+	}
+	return base58.Encode(raw), nil
+}
+
+// AddressFromPrivateKey derives the address controlled by a 32-byte ed25519 seed or a
+// 64-byte keypair (seed followed by public key, as written by solana-keygen)
+func AddressFromPrivateKey(privateKey []byte) (string, error) {
+	key, err := parsePrivateKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+	return EncodeAddress(key.Public().(ed25519.PublicKey))
+}
+
+func parsePrivateKey(privateKey []byte) (ed25519.PrivateKey, error) {
+	switch len(privateKey) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(privateKey), nil
+	case ed25519.PrivateKeySize:
+		key := ed25519.NewKeyFromSeed(privateKey[:ed25519.SeedSize])
+		if !bytes.Equal(key[ed25519.SeedSize:], privateKey[ed25519.SeedSize:]) {
+			return nil, fmt.Errorf("keypair public key does not match its seed")
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("private key must be %d or %d bytes, got %d", ed25519.SeedSize, ed25519.PrivateKeySize, len(privateKey))
+	}
+}
+
+// IsOnCurve reports whether a 32-byte value decodes to a point on the ed25519 curve, i.e.
+// whether it can be a public key with a private key behind it
+func IsOnCurve(point []byte) bool {
+	if len(point) != PublicKeyLength {
+		return false
+	}
+
+	// The encoding is the little-endian y coordinate with the sign of x in the top bit
+	encoded := make([]byte, PublicKeyLength)
+	for i, b := range point {
+		encoded[PublicKeyLength-1-i] = b
+	}
+	encoded[0] &= 0x7f
+	y := new(big.Int).SetBytes(encoded)
+	y.Mod(y, fieldPrime)
+
+	// x^2 = (y^2 - 1) / (d*y^2 + 1) must be a square for the point to exist
+	y2 := new(big.Int).Mul(y, y)
+	y2.Mod(y2, fieldPrime)
+	u := new(big.Int).Sub(y2, big.NewInt(1))
+	v := new(big.Int).Mul(edwardsD, y2)
+	v.Add(v, big.NewInt(1))
+	v.Mod(v, fieldPrime)
+	inverse := new(big.Int).ModInverse(v, fieldPrime)
+	if inverse == nil {
+		return false
+	}
+	x2 := u.Mul(u, inverse)
+	x2.Mod(x2, fieldPrime)
+	return big.Jacobi(x2, fieldPrime) >= 0
+}
+
+// CreateProgramAddress derives a program address from seeds, failing when the result is
+// on the ed25519 curve
+func CreateProgramAddress(seeds [][]byte, programID []byte) ([]byte, error) {
+	if len(seeds) > maxSeeds {
+		return nil, fmt.Errorf("too many seeds: %d", len(seeds))
+	}
+	h := sha256.New()
+	for _, seed := range seeds {
+		if len(seed) > maxSeedLength {
+			return nil, fmt.Errorf("seed exceeds %d bytes", maxSeedLength)
+		}
+		h.Write(seed)
+	}
+	h.Write(programID)
+	h.Write([]byte(pdaMarker))
+	address := h.Sum(nil)
+	if IsOnCurve(address) {
+		return nil, fmt.Errorf("program address is on the ed25519 curve")
+	}
+	return address, nil
+}
+
+// FindProgramAddress returns the program derived address for seeds together with its bump
+// seed, the highest one that yields an address off the ed25519 curve
+func FindProgramAddress(seeds [][]byte, programID []byte) ([]byte, uint8, error) {
+	withBump := append(append([][]byte{}, seeds...), nil)
+	for bump := 255; bump >= 0; bump-- {
+		withBump[len(seeds)] = []byte{byte(bump)}
+		address, err := CreateProgramAddress(withBump, programID)
+		if err == nil {
+			return address, uint8(bump), nil
+		}
+	}
+	return nil, 0, fmt.Errorf("no viable bump seed for program address")
+}
+
+// AssociatedTokenAddress returns the associated token account of an owner for a mint
+// managed by tokenProgramID
+func AssociatedTokenAddress(owner, mint, tokenProgramID []byte) ([]byte, error) {
+	address, _, err := FindProgramAddress([][]byte{owner, tokenProgramID, mint}, associatedTokenProgram)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive associated token account: %w", err)
+	}
+	return address, nil
+}
+
+func mustDecodeAddress(address string) []byte {
+	raw, err := DecodeAddress(address)
+	if err != nil {
+		panic(err)
+	}
+	return raw
+}
@@ -0,0 +1,141 @@
+package solana
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressEncoding(t *testing.T) {
+	t.Parallel()
+
+	raw, err := DecodeAddress(TokenProgramID)
+	require.NoError(t, err)
+	assert.Equal(t, "06ddf6e1d765a193d9cbe146ceeb79ac1cb485ed5f5b37913a8cf5857eff00a9", hex.EncodeToString(raw))
+	encoded, err := EncodeAddress(raw)
+	require.NoError(t, err)
+	assert.Equal(t, TokenProgramID, encoded)
+
+	// Leading zero bytes are encoded as ones
+	system, err := DecodeAddress(SystemProgramID)
+	require.NoError(t, err)
+	assert.Equal(t, make([]byte, PublicKeyLength), system)
+
+	for _, id := range []string{ComputeBudgetProgramID, Token2022ProgramID, AssociatedTokenProgramID} {
+		_, err := DecodeAddress(id)
+		assert.NoError(t, err, id)
+	}
+	for _, invalid := range []string{
+		"",
+		"0x742d35cc6634c0532925a3b844bc9e7595f0beb0",
+		"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+		"1111111111111111111111111111111111",
+		"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5D0",
+	} {
+		_, err := DecodeAddress(invalid)
+		assert.Error(t, err, invalid)
+	}
+	_, err = EncodeAddress([]byte{0x01})
+	require.Error(t, err)
+}
+
+func TestAddressFromPrivateKey(t *testing.T) {
+	t.Parallel()
+
+	// RFC 8032 test vector 1
+	seed, err := hex.DecodeString("9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60")
+	require.NoError(t, err)
+	address, err := AddressFromPrivateKey(seed)
+	require.NoError(t, err)
+	raw, err := DecodeAddress(address)
+	require.NoError(t, err)
+	assert.Equal(t, "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a", hex.EncodeToString(raw))
+
+	// solana-keygen keypairs hold the seed followed by the public key
+	keypair, err := AddressFromPrivateKey(append(append([]byte{}, seed...), raw...))
+	require.NoError(t, err)
+	assert.Equal(t, address, keypair)
+
+	_, err = AddressFromPrivateKey(append(append([]byte{}, seed...), make([]byte, 32)...))
+	require.ErrorContains(t, err, "does not match")
+	_, err = AddressFromPrivateKey([]byte("short"))
+	require.Error(t, err)
+}
+
+func TestIsOnCurve(t *testing.T) {
+	t.Parallel()
+
+	for i := byte(0); i < 16; i++ {
+		pub := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{i}, ed25519.SeedSize)).Public().(ed25519.PublicKey)
+		assert.True(t, IsOnCurve(pub), "key %d", i)
+	}
+	// y = 1 is the identity point, y = 2 has no x coordinate
+	identity := make([]byte, PublicKeyLength)
+	identity[0] = 1
+	assert.True(t, IsOnCurve(identity))
+	offCurve := make([]byte, PublicKeyLength)
+	offCurve[0] = 2
+	assert.False(t, IsOnCurve(offCurve))
+	assert.False(t, IsOnCurve([]byte{1}))
+}
+
+func TestProgramAddress(t *testing.T) {
+	t.Parallel()
+
+	// Vectors from the Solana SDK
+	program, err := DecodeAddress("BPFLoaderUpgradeab1e11111111111111111111111")
+	require.NoError(t, err)
+	for expected, seeds := range map[string][][]byte{
+		"BwqrghZA2htAcqq8dzP1WDAhTXYTYWj7CHxF5j7TDBAe": {[]byte(""), {1}},
+		"13yWmRpaTR4r5nAktwLqMpRNr28tnVUZw26rTvPSSB19": {[]byte("☉"), {0}},
+		"2fnQrngrQT4SeLcdToJAD96phoEjNL2man2kfRLCASVk": {[]byte("Talking"), []byte("Squirrels")},
+	} {
+		address, err := CreateProgramAddress(seeds, program)
+		require.NoError(t, err)
+		encoded, err := EncodeAddress(address)
+		require.NoError(t, err)
+		assert.Equal(t, expected, encoded)
+	}
+
+	_, err = CreateProgramAddress([][]byte{[]byte("☉")}, program)
+	require.ErrorContains(t, err, "on the ed25519 curve")
+	_, err = CreateProgramAddress([][]byte{make([]byte, maxSeedLength+1)}, program)
+	require.Error(t, err)
+	_, err = CreateProgramAddress(make([][]byte, maxSeeds+1), program)
+	require.Error(t, err)
+
+	address, bump, err := FindProgramAddress([][]byte{[]byte("Lil'"), []byte("Bits")}, program)
+	require.NoError(t, err)
+	assert.False(t, IsOnCurve(address))
+	recreated, err := CreateProgramAddress([][]byte{[]byte("Lil'"), []byte("Bits"), {bump}}, program)
+	require.NoError(t, err)
+	assert.Equal(t, address, recreated)
+	for higher := int(bump) + 1; higher <= 255; higher++ {
+		_, err := CreateProgramAddress([][]byte{[]byte("Lil'"), []byte("Bits"), {byte(higher)}}, program)
+		assert.Error(t, err, "bump %d", higher)
+	}
+}
+
+func TestAssociatedTokenAddress(t *testing.T) {
+	t.Parallel()
+
+	// Vector from the SPL token client
+	owner, err := DecodeAddress("B8UwBUUnKwCyKuGMbFKWaG7exYdDk2ozZrPg72NyVbfj")
+	require.NoError(t, err)
+	mint, err := DecodeAddress("7o36UsWR1JQLpZ9PE2gn9L4SQ69CNNiWAXd4Jt7rqz9Z")
+	require.NoError(t, err)
+	account, err := AssociatedTokenAddress(owner, mint, tokenProgram)
+	require.NoError(t, err)
+	encoded, err := EncodeAddress(account)
+	require.NoError(t, err)
+	assert.Equal(t, "DShWnroshVbeUp28oopA3Pu7oFPDBtC1DBmPECXXAQ9n", encoded)
+
+	// Token-2022 accounts are derived with their own program in the seeds
+	account2022, err := AssociatedTokenAddress(owner, mint, token2022Program)
+	require.NoError(t, err)
+	assert.NotEqual(t, account, account2022)
+}
@@ -0,0 +1,64 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCall(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		var req struct {
+			JSONRPC string        `json:"jsonrpc"`
+			ID      uint64        `json:"id"`
+			Method  string        `json:"method"`
+			Params  []interface{} `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "2.0", req.JSONRPC)
+		assert.NotZero(t, req.ID)
+
+		switch req.Method {
+		case "echo":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": req.Params})
+		case "getTokenAccountBalance":
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"Invalid param: could not find account"}}`))
+		case "broken":
+			_, _ = w.Write([]byte("not json"))
+		default:
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewClient(srv.URL+"/", nil)
+	ctx := context.Background()
+
+	var out []string
+	require.NoError(t, client.Call(ctx, "echo", []interface{}{"x"}, &out))
+	assert.Equal(t, []string{"x"}, out)
+	require.NoError(t, client.Call(ctx, "echo", nil, &out))
+	assert.Empty(t, out)
+	require.NoError(t, client.Call(ctx, "echo", nil, nil))
+
+	err := client.Call(ctx, "getTokenAccountBalance", nil, &out)
+	require.ErrorContains(t, err, "getTokenAccountBalance failed: Invalid param: could not find account (code -32602)")
+	var rpcErr *RPCError
+	require.True(t, errors.As(err, &rpcErr))
+	assert.Equal(t, errCodeInvalidParams, rpcErr.Code)
+
+	require.ErrorContains(t, client.Call(ctx, "broken", nil, &out), "failed to decode broken response")
+	require.ErrorContains(t, client.Call(ctx, "echo", []interface{}{1}, &out), "failed to decode echo result")
+	require.ErrorContains(t, client.Call(ctx, "unknown", nil, &out), "429")
+	require.Error(t, client.Call(ctx, "echo", []interface{}{make(chan int)}, nil))
+	require.Error(t, NewClient("http://127.0.0.1:1", nil).Call(ctx, "getSlot", nil, nil))
+}
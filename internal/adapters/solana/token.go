@@ -0,0 +1,244 @@
+package solana
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+)
+
+// Instruction discriminators of the SPL token and associated token account programs
+const (
+	tokenInstructionTransferChecked = 12
+	associatedTokenCreateIdempotent = 1
+
+	tokenTransferDataLength          = PublicKeyLength + 8
+	transferCheckedInstructionLength = 10
+)
+
+// SPL mint account layout: mint authority option (36 bytes), supply (8), decimals, is_initialized
+const (
+	mintAccountLength     = 82
+	mintDecimalsOffset    = 44
+	mintInitializedOffset = 45
+)
+
+// mintInfo describes an SPL token mint
+type mintInfo struct {
+	program  string
+	decimals uint8
+}
+
+// EncodeTokenTransfer returns the payload of an SPL token transfer: the recipient wallet
+// followed by the amount in base units as a little-endian u64. The transaction is sent to
+// the mint, and the adapter routes it between the associated token accounts
+func EncodeTokenTransfer(to *valueobjects.Address, amount *big.Int) ([]byte, error) {
+	if to == nil {
+		return nil, fmt.Errorf("recipient address cannot be nil")
+	}
+	if amount == nil || amount.Sign() < 0 {
+		return nil, fmt.Errorf("token amount must be non-negative")
+	}
+	if !amount.IsUint64() {
+		return nil, fmt.Errorf("token amount exceeds u64")
+	}
+	recipient, err := DecodeAddress(to.Value())
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, tokenTransferDataLength)
+	copy(data, recipient)
+	binary.LittleEndian.PutUint64(data[PublicKeyLength:], amount.Uint64())
+	return data, nil
+}
+
+// decodeTokenTransfer splits an EncodeTokenTransfer payload into recipient and amount
+func decodeTokenTransfer(data []byte) ([]byte, uint64, error) {
+	if len(data) != tokenTransferDataLength {
+		return nil, 0, fmt.Errorf("invalid token transfer data length: %d", len(data))
+	}
+	return data[:PublicKeyLength], binary.LittleEndian.Uint64(data[PublicKeyLength:]), nil
+}
+
+// tokenTransferInstructions creates the recipient's associated token account when missing
+// and transfers the tokens into it from the sender's associated token account
+func tokenTransferInstructions(tx *entities.Transaction, owner, mint []byte) ([]instruction, error) {
+	recipient, amount, err := decodeTokenTransfer(tx.Data())
+	if err != nil {
+		return nil, err
+	}
+	metadata := tx.Metadata()
+	programAddress, ok := metadata[MetadataTokenProgram].(string)
+	if !ok {
+		return nil, fmt.Errorf("token transfer has no token program; build it with the solana adapter")
+	}
+	program, err := DecodeAddress(programAddress)
+	if err != nil {
+		return nil, err
+	}
+	decimals, err := metadataInt64(metadata[MetadataTokenDecimals])
+	if err != nil || decimals < 0 || decimals > 255 {
+		return nil, fmt.Errorf("invalid %s metadata", MetadataTokenDecimals)
+	}
+
+	source, err := AssociatedTokenAddress(owner, mint, program)
+	if err != nil {
+		return nil, err
+	}
+	destination, err := AssociatedTokenAddress(recipient, mint, program)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, transferCheckedInstructionLength)
+	data[0] = tokenInstructionTransferChecked
+	binary.LittleEndian.PutUint64(data[1:], amount)
+	data[9] = byte(decimals)
+
+	return []instruction{
+		{
+			programID: associatedTokenProgram,
+			accounts: []accountMeta{
+				{pubkey: owner, signer: true, writable: true},
+				{pubkey: destination, writable: true},
+				{pubkey: recipient},
+				{pubkey: mint},
+				{pubkey: systemProgram},
+				{pubkey: program},
+			},
+			data: []byte{associatedTokenCreateIdempotent},
+		},
+		{
+			programID: program,
+			accounts: []accountMeta{
+				{pubkey: source, writable: true},
+				{pubkey: mint},
+				{pubkey: destination, writable: true},
+				{pubkey: owner, signer: true},
+			},
+			data: data,
+		},
+	}, nil
+}
+
+// EncodeTokenTransfer returns the SPL token transfer payload for the network
+func (a *Adapter) EncodeTokenTransfer(to *valueobjects.Address, amount *big.Int) ([]byte, error) {
+	return EncodeTokenTransfer(to, amount)
+}
+
+// GetTokenBalance returns the balance of the owner's associated token account for a mint;
+// owners without the account hold no tokens
+func (a *Adapter) GetTokenBalance(ctx context.Context, chainID string, address, tokenAddress *valueobjects.Address) (*big.Int, error) {
+	owner, err := DecodeAddress(address.Value())
+	if err != nil {
+		return nil, err
+	}
+	info, err := a.mintAccount(ctx, tokenAddress)
+	if err != nil {
+		return nil, err
+	}
+	mintKey, err := DecodeAddress(tokenAddress.Value())
+	if err != nil {
+		return nil, err
+	}
+	account, err := AssociatedTokenAddress(owner, mintKey, mustDecodeAddress(info.program))
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := EncodeAddress(account)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Value struct {
+			Amount string `json:"amount"`
+		} `json:"value"`
+	}
+	err = a.client.Call(ctx, "getTokenAccountBalance", []interface{}{encoded, a.commitment()}, &result)
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) && rpcErr.Code == errCodeInvalidParams {
+		return big.NewInt(0), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token balance: %w", err)
+	}
+	balance, ok := new(big.Int).SetString(result.Value.Amount, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid token balance: %q", result.Value.Amount)
+	}
+	return balance, nil
+}
+
+// GetTokenMetadata returns the decimals of an SPL mint; mints carry no symbol on chain
+func (a *Adapter) GetTokenMetadata(ctx context.Context, tokenAddress *valueobjects.Address) (*entities.Token, error) {
+	info, err := a.mintAccount(ctx, tokenAddress)
+	if err != nil {
+		return nil, err
+	}
+	return entities.NewToken(tokenAddress, "", info.decimals)
+}
+
+// setTokenTransfer records the token program and decimals of the mint a transfer is sent to
+func (a *Adapter) setTokenTransfer(ctx context.Context, tx *entities.Transaction) error {
+	if tx.Value().Sign() != 0 {
+		return fmt.Errorf("token transfers cannot carry a SOL value")
+	}
+	if _, _, err := decodeTokenTransfer(tx.Data()); err != nil {
+		return err
+	}
+	info, err := a.mintAccount(ctx, tx.To())
+	if err != nil {
+		return err
+	}
+	tx.SetMetadata(MetadataTokenProgram, info.program)
+	tx.SetMetadata(MetadataTokenDecimals, info.decimals)
+	return nil
+}
+
+// mintAccount reads an SPL mint owned by the token or token-2022 program
+func (a *Adapter) mintAccount(ctx context.Context, address *valueobjects.Address) (*mintInfo, error) {
+	if address == nil {
+		return nil, fmt.Errorf("token address cannot be nil")
+	}
+	if _, err := DecodeAddress(address.Value()); err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Value *struct {
+			Owner string   `json:"owner"`
+			Data  []string `json:"data"`
+		} `json:"value"`
+	}
+	if err := a.client.Call(ctx, "getAccountInfo", []interface{}{
+		address.Value(),
+		map[string]interface{}{"encoding": "base64", "commitment": a.config.Commitment},
+	}, &result); err != nil {
+		return nil, fmt.Errorf("failed to get mint account: %w", err)
+	}
+	if result.Value == nil {
+		return nil, fmt.Errorf("mint account not found: %s", address.Value())
+	}
+	if result.Value.Owner != TokenProgramID && result.Value.Owner != Token2022ProgramID {
+		return nil, fmt.Errorf("account %s is not an SPL token mint", address.Value())
+	}
+	if len(result.Value.Data) == 0 {
+		return nil, fmt.Errorf("mint account %s has no data", address.Value())
+	}
+	data, err := base64.StdEncoding.DecodeString(result.Value.Data[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid mint account data: %w", err)
+	}
+	// Token-2022 mints append extensions after the base layout
+	if len(data) < mintAccountLength || data[mintInitializedOffset] != 1 {
+		return nil, fmt.Errorf("account %s is not an initialized SPL token mint", address.Value())
+	}
+	return &mintInfo{program: result.Value.Owner, decimals: data[mintDecimalsOffset]}, nil
+}
@@ -0,0 +1,234 @@
+package solana
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"math/big"
+	"testing"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/base58"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMint = "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"
+
+// mintAccountInfo returns a getAccountInfo result for an initialized mint
+func mintAccountInfo(owner string, decimals byte) map[string]interface{} {
+	data := make([]byte, mintAccountLength)
+	data[mintDecimalsOffset] = decimals
+	data[mintInitializedOffset] = 1
+	return map[string]interface{}{
+		"context": map[string]interface{}{"slot": testSlot},
+		"value": map[string]interface{}{
+			"owner":      owner,
+			"lamports":   1461600,
+			"executable": false,
+			"data":       []string{base64.StdEncoding.EncodeToString(data), "base64"},
+		},
+	}
+}
+
+func TestEncodeTokenTransfer(t *testing.T) {
+	t.Parallel()
+	_, to := testAddresses(t)
+	recipient, err := DecodeAddress(to.Value())
+	require.NoError(t, err)
+
+	data, err := EncodeTokenTransfer(to, big.NewInt(2500000))
+	require.NoError(t, err)
+	assert.Equal(t, append(recipient, 0xa0, 0x25, 0x26, 0, 0, 0, 0, 0), data)
+
+	decoded, amount, err := decodeTokenTransfer(data)
+	require.NoError(t, err)
+	assert.Equal(t, recipient, decoded)
+	assert.Equal(t, uint64(2500000), amount)
+	_, _, err = decodeTokenTransfer(data[1:])
+	require.Error(t, err)
+
+	adapter := NewAdapter(nil, NetworkConfig{Name: "solana"})
+	viaAdapter, err := adapter.EncodeTokenTransfer(to, big.NewInt(2500000))
+	require.NoError(t, err)
+	assert.Equal(t, data, viaAdapter)
+
+	_, err = EncodeTokenTransfer(nil, big.NewInt(1))
+	require.Error(t, err)
+	_, err = EncodeTokenTransfer(to, big.NewInt(-1))
+	require.Error(t, err)
+	_, err = EncodeTokenTransfer(to, new(big.Int).Lsh(big.NewInt(1), 64))
+	require.ErrorContains(t, err, "exceeds u64")
+	invalid, err := valueobjects.NewAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", "solana")
+	require.NoError(t, err)
+	_, err = EncodeTokenTransfer(invalid, big.NewInt(1))
+	require.Error(t, err)
+}
+
+func TestAdapterGetTokenBalance(t *testing.T) {
+	t.Parallel()
+	adapter, node := newTestAdapter(t)
+	ctx := context.Background()
+	from, _ := testAddresses(t)
+	mint, err := valueobjects.NewAddress(testMint, "solana")
+	require.NoError(t, err)
+
+	owner, err := DecodeAddress(from.Value())
+	require.NoError(t, err)
+	mintKey, err := DecodeAddress(testMint)
+	require.NoError(t, err)
+	account, err := AssociatedTokenAddress(owner, mintKey, tokenProgram)
+	require.NoError(t, err)
+	accountAddress, err := EncodeAddress(account)
+	require.NoError(t, err)
+
+	node.handle("getAccountInfo", func(params []interface{}) (interface{}, error) {
+		assert.Equal(t, testMint, params[0])
+		assert.Equal(t, "base64", params[1].(map[string]interface{})["encoding"])
+		return mintAccountInfo(TokenProgramID, 6), nil
+	})
+	node.handle("getTokenAccountBalance", func(params []interface{}) (interface{}, error) {
+		assert.Equal(t, accountAddress, params[0])
+		return map[string]interface{}{"value": map[string]interface{}{"amount": "18446744073709551615", "decimals": 6, "uiAmountString": "18446744073709.551615"}}, nil
+	})
+	balance, err := adapter.GetTokenBalance(ctx, "solana", from, mint)
+	require.NoError(t, err)
+	assert.Equal(t, "18446744073709551615", balance.String())
+
+	token, err := adapter.GetTokenMetadata(ctx, mint)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(6), token.Decimals())
+	assert.Equal(t, testMint, token.Address().Value())
+
+	// Owners without an associated token account hold nothing
+	node.handle("getTokenAccountBalance", func([]interface{}) (interface{}, error) {
+		return nil, &RPCError{Code: errCodeInvalidParams, Message: "Invalid param: could not find account"}
+	})
+	balance, err = adapter.GetTokenBalance(ctx, "solana", from, mint)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), balance.Int64())
+
+	node.handle("getTokenAccountBalance", func([]interface{}) (interface{}, error) {
+		return nil, &RPCError{Code: -32005, Message: "Node is unhealthy"}
+	})
+	_, err = adapter.GetTokenBalance(ctx, "solana", from, mint)
+	require.ErrorContains(t, err, "Node is unhealthy")
+}
+
+func TestAdapterMintAccountErrors(t *testing.T) {
+	t.Parallel()
+	adapter, node := newTestAdapter(t)
+	ctx := context.Background()
+	from, _ := testAddresses(t)
+	mint, err := valueobjects.NewAddress(testMint, "solana")
+	require.NoError(t, err)
+
+	node.result("getAccountInfo", map[string]interface{}{"value": nil})
+	_, err = adapter.GetTokenBalance(ctx, "solana", from, mint)
+	require.ErrorContains(t, err, "mint account not found")
+
+	node.result("getAccountInfo", mintAccountInfo(SystemProgramID, 6))
+	_, err = adapter.GetTokenMetadata(ctx, mint)
+	require.ErrorContains(t, err, "is not an SPL token mint")
+
+	uninitialized := mintAccountInfo(TokenProgramID, 6)
+	uninitialized["value"].(map[string]interface{})["data"] = []string{base64.StdEncoding.EncodeToString(make([]byte, mintAccountLength)), "base64"}
+	node.result("getAccountInfo", uninitialized)
+	_, err = adapter.GetTokenMetadata(ctx, mint)
+	require.ErrorContains(t, err, "not an initialized SPL token mint")
+
+	_, err = adapter.GetTokenMetadata(ctx, nil)
+	require.Error(t, err)
+}
+
+func TestAdapterTokenTransfer(t *testing.T) {
+	t.Parallel()
+	adapter, node := newTestAdapter(t)
+	ctx := context.Background()
+	from, to := testAddresses(t)
+	mint, err := valueobjects.NewAddress(testMint, "solana")
+	require.NoError(t, err)
+
+	node.result("getAccountInfo", mintAccountInfo(Token2022ProgramID, 9))
+	node.result("getRecentPrioritizationFees", []map[string]interface{}{{"slot": 1, "prioritizationFee": 5000}})
+	node.result("simulateTransaction", map[string]interface{}{"value": map[string]interface{}{"err": nil, "unitsConsumed": 30000}})
+
+	data, err := adapter.EncodeTokenTransfer(to, big.NewInt(750))
+	require.NoError(t, err)
+	_, err = adapter.BuildTransaction(ctx, entities.TransactionParams{From: from, To: mint, Value: big.NewInt(1), Data: data})
+	require.ErrorContains(t, err, "cannot carry a SOL value")
+	_, err = adapter.BuildTransaction(ctx, entities.TransactionParams{From: from, To: mint, Data: []byte{1, 2, 3}})
+	require.ErrorContains(t, err, "invalid token transfer data length")
+
+	tx, err := adapter.BuildTransaction(ctx, entities.TransactionParams{From: from, To: mint, Data: data})
+	require.NoError(t, err)
+	assert.Equal(t, Token2022ProgramID, tx.Metadata()[MetadataTokenProgram])
+	assert.Equal(t, uint8(9), tx.Metadata()[MetadataTokenDecimals])
+	assert.Equal(t, uint64(33000), tx.GasLimit())
+
+	require.NoError(t, adapter.SignTransaction(ctx, tx, testKeys(t), "test"))
+	valid, err := adapter.VerifySignature(ctx, tx)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	owner, err := DecodeAddress(from.Value())
+	require.NoError(t, err)
+	recipient, err := DecodeAddress(to.Value())
+	require.NoError(t, err)
+	mintKey, err := DecodeAddress(testMint)
+	require.NoError(t, err)
+	source, err := AssociatedTokenAddress(owner, mintKey, token2022Program)
+	require.NoError(t, err)
+	destination, err := AssociatedTokenAddress(recipient, mintKey, token2022Program)
+	require.NoError(t, err)
+
+	_, message := decodeTransaction(t, tx.Metadata()[MetadataRawTransaction].(string))
+	// Fee payer, then writable token accounts, then read-only accounts and programs
+	assert.Equal(t, []byte{1, 0, 6, 9}, message[:4])
+	keys := make([][]byte, 9)
+	for i := range keys {
+		keys[i] = message[4+32*i : 4+32*(i+1)]
+	}
+	assert.Equal(t, [][]byte{
+		owner, destination, source,
+		computeBudgetProgram, recipient, mintKey, systemProgram, token2022Program, associatedTokenProgram,
+	}, keys)
+	instructions := message[4+32*9+32:]
+	assert.Equal(t, byte(4), instructions[0])
+	// CreateIdempotent for the recipient, then TransferChecked of 750 with 9 decimals
+	create := []byte{8, 6, 0, 1, 4, 5, 6, 7, 1, associatedTokenCreateIdempotent}
+	transfer := []byte{7, 4, 2, 5, 1, 0, 10, tokenInstructionTransferChecked, 0xee, 0x02, 0, 0, 0, 0, 0, 0, 9}
+	assert.True(t, bytes.HasSuffix(instructions, append(create, transfer...)))
+
+	// Receipts rebuild the transfer from the recipient's post-transaction token balance
+	node.result("getTransaction", map[string]interface{}{
+		"slot": testSlot,
+		"meta": map[string]interface{}{
+			"err": nil,
+			"fee": 5165,
+			"postTokenBalances": []map[string]interface{}{
+				{"accountIndex": 2, "mint": testMint, "owner": from.Value()},
+				{"accountIndex": 1, "mint": testMint, "owner": to.Value()},
+			},
+		},
+		"transaction": map[string]interface{}{
+			"message": map[string]interface{}{
+				"accountKeys": []string{
+					from.Value(), base58.Encode(destination), base58.Encode(source),
+					ComputeBudgetProgramID, to.Value(), testMint, SystemProgramID, Token2022ProgramID, AssociatedTokenProgramID,
+				},
+				"instructions": []map[string]interface{}{
+					{"programIdIndex": 8, "accounts": []int{0, 1, 4, 5, 6, 7}, "data": base58.Encode([]byte{associatedTokenCreateIdempotent})},
+					{"programIdIndex": 7, "accounts": []int{2, 5, 1, 0}, "data": base58.Encode(transfer[7:])},
+				},
+			},
+		},
+	})
+	receipt, err := adapter.GetTransactionReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, testMint, receipt.To().Value())
+	assert.Equal(t, data, receipt.Data())
+	assert.Equal(t, int64(0), receipt.Value().Int64())
+	assert.Equal(t, uint8(9), receipt.Metadata()[MetadataTokenDecimals])
+}
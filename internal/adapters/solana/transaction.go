@@ -0,0 +1,335 @@
+package solana
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/base58"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+)
+
+const (
+	// MetadataRecentBlockhash holds the base58 blockhash the transaction is anchored to
+	MetadataRecentBlockhash = "recent_blockhash"
+	// MetadataLastValidBlockHeight holds the block height after which the blockhash expires
+	MetadataLastValidBlockHeight = "last_valid_block_height"
+	// MetadataTokenProgram holds the program owning the mint of an SPL token transfer
+	MetadataTokenProgram = "token_program"
+	// MetadataTokenDecimals holds the decimals of the mint of an SPL token transfer
+	MetadataTokenDecimals = "token_decimals"
+	// MetadataMessage holds the base64 serialized message that was signed
+	MetadataMessage = "message"
+	// MetadataRawTransaction holds the base64 signed transaction
+	MetadataRawTransaction = "raw_transaction"
+	// MetadataSignature holds the base58 transaction signature, which identifies it on chain
+	MetadataSignature = "signature"
+)
+
+// Instruction discriminators of the native programs
+const (
+	systemInstructionTransfer = 2
+	computeBudgetSetUnitLimit = 2
+	computeBudgetSetUnitPrice = 3
+)
+
+// accountMeta describes an account referenced by an instruction
+type accountMeta struct {
+	pubkey   []byte
+	signer   bool
+	writable bool
+}
+
+// instruction is a program invocation before it is compiled into a message
+type instruction struct {
+	programID []byte
+	accounts  []accountMeta
+	data      []byte
+}
+
+// buildInstructions returns the compute budget instructions followed by a system transfer,
+// or an SPL TransferChecked to the recipient's associated token account when data is set
+func buildInstructions(tx *entities.Transaction, computeUnits uint64) ([]instruction, error) {
+	from, err := DecodeAddress(tx.From().Value())
+	if err != nil {
+		return nil, err
+	}
+	to, err := DecodeAddress(tx.To().Value())
+	if err != nil {
+		return nil, err
+	}
+
+	var instructions []instruction
+	if computeUnits > 0 {
+		if computeUnits > maxComputeUnitLimit {
+			return nil, fmt.Errorf("compute unit limit exceeds %d: %d", maxComputeUnitLimit, computeUnits)
+		}
+		data := make([]byte, 5)
+		data[0] = computeBudgetSetUnitLimit
+		binary.LittleEndian.PutUint32(data[1:], uint32(computeUnits))
+		instructions = append(instructions, instruction{programID: computeBudgetProgram, data: data})
+	}
+	if price := tx.GasPrice(); price != nil && price.Sign() > 0 {
+		if !price.IsUint64() {
+			return nil, fmt.Errorf("compute unit price exceeds u64: %s", price)
+		}
+		data := make([]byte, 9)
+		data[0] = computeBudgetSetUnitPrice
+		binary.LittleEndian.PutUint64(data[1:], price.Uint64())
+		instructions = append(instructions, instruction{programID: computeBudgetProgram, data: data})
+	}
+
+	if len(tx.Data()) == 0 {
+		value := tx.Value()
+		if !value.IsUint64() {
+			return nil, fmt.Errorf("value exceeds u64: %s", value)
+		}
+		data := make([]byte, 12)
+		binary.LittleEndian.PutUint32(data, systemInstructionTransfer)
+		binary.LittleEndian.PutUint64(data[4:], value.Uint64())
+		return append(instructions, instruction{
+			programID: systemProgram,
+			accounts: []accountMeta{
+				{pubkey: from, signer: true, writable: true},
+				{pubkey: to, writable: true},
+			},
+			data: data,
+		}), nil
+	}
+
+	transfer, err := tokenTransferInstructions(tx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return append(instructions, transfer...), nil
+}
+
+// compileMessage serializes a legacy message paid by payer. Accounts are ordered as the
+// runtime requires: writable signers, read-only signers, writable and read-only non-signers
+func compileMessage(payer []byte, instructions []instruction, blockhash []byte) ([]byte, error) {
+	if len(blockhash) != PublicKeyLength {
+		return nil, fmt.Errorf("invalid recent blockhash length: %d", len(blockhash))
+	}
+
+	metas := []accountMeta{{pubkey: payer, signer: true, writable: true}}
+	index := map[string]int{string(payer): 0}
+	add := func(meta accountMeta) {
+		if i, ok := index[string(meta.pubkey)]; ok {
+			metas[i].signer = metas[i].signer || meta.signer
+			metas[i].writable = metas[i].writable || meta.writable
+			return
+		}
+		index[string(meta.pubkey)] = len(metas)
+		metas = append(metas, meta)
+	}
+	for _, ix := range instructions {
+		for _, account := range ix.accounts {
+			add(account)
+		}
+		add(accountMeta{pubkey: ix.programID})
+	}
+
+	// The fee payer stays first; accounts keep their insertion order within each class
+	rank := func(meta accountMeta) int {
+		switch {
+		case meta.signer && meta.writable:
+			return 0
+		case meta.signer:
+			return 1
+		case meta.writable:
+			return 2
+		default:
+			return 3
+		}
+	}
+	ordered := make([]accountMeta, 0, len(metas))
+	for class := 0; class < 4; class++ {
+		for _, meta := range metas {
+			if rank(meta) == class {
+				ordered = append(ordered, meta)
+			}
+		}
+	}
+	if len(ordered) > 256 {
+		return nil, fmt.Errorf("too many accounts: %d", len(ordered))
+	}
+
+	var header [3]byte
+	position := make(map[string]int, len(ordered))
+	for i, meta := range ordered {
+		position[string(meta.pubkey)] = i
+		switch {
+		case meta.signer:
+			header[0]++
+			if !meta.writable {
+				header[1]++
+			}
+		case !meta.writable:
+			header[2]++
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.Write(header[:])
+	writeCompactU16(&buf, len(ordered))
+	for _, meta := range ordered {
+		buf.Write(meta.pubkey)
+	}
+	buf.Write(blockhash)
+	writeCompactU16(&buf, len(instructions))
+	for _, ix := range instructions {
+		buf.WriteByte(byte(position[string(ix.programID)]))
+		writeCompactU16(&buf, len(ix.accounts))
+		for _, account := range ix.accounts {
+			buf.WriteByte(byte(position[string(account.pubkey)]))
+		}
+		writeCompactU16(&buf, len(ix.data))
+		buf.Write(ix.data)
+	}
+	return buf.Bytes(), nil
+}
+
+// writeCompactU16 writes the variable-length length prefix used by Solana (ShortVec)
+func writeCompactU16(buf *bytes.Buffer, n int) {
+	v := uint16(n)
+	for v >= 0x80 {
+		buf.WriteByte(byte(v) | 0x80)
+		v >>= 7
+	}
+	buf.WriteByte(byte(v))
+}
+
+// serializeMessage compiles the message of a transaction anchored by BuildTransaction
+func serializeMessage(tx *entities.Transaction) ([]byte, error) {
+	blockhash, ok := tx.Metadata()[MetadataRecentBlockhash].(string)
+	if !ok || blockhash == "" {
+		return nil, fmt.Errorf("transaction has no recent blockhash; build it with the solana adapter")
+	}
+	hash, err := base58.Decode(blockhash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s metadata: %w", MetadataRecentBlockhash, err)
+	}
+	return messageWithBlockhash(tx, tx.GasLimit(), hash)
+}
+
+// messageWithBlockhash compiles the message of a transaction requesting computeUnits
+func messageWithBlockhash(tx *entities.Transaction, computeUnits uint64, blockhash []byte) ([]byte, error) {
+	payer, err := DecodeAddress(tx.From().Value())
+	if err != nil {
+		return nil, err
+	}
+	instructions, err := buildInstructions(tx, computeUnits)
+	if err != nil {
+		return nil, err
+	}
+	return compileMessage(payer, instructions, blockhash)
+}
+
+// encodeTransaction prefixes a message with its signatures
+func encodeTransaction(signatures [][]byte, message []byte) []byte {
+	var buf bytes.Buffer
+	writeCompactU16(&buf, len(signatures))
+	for _, sig := range signatures {
+		buf.Write(sig)
+	}
+	buf.Write(message)
+	return buf.Bytes()
+}
+
+// signTransaction signs the message with the fee payer key keyID, storing the signature, the
+// transaction hash and the wire encoding on it
+func signTransaction(ctx context.Context, tx *entities.Transaction, keys ports.KeyManager, keyID string) error {
+	publicKey, err := keys.PublicKey(ctx, keyID)
+	if err != nil {
+		return err
+	}
+	from, err := DecodeAddress(tx.From().Value())
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(publicKey, from) {
+		return fmt.Errorf("key %s does not match sender %s", keyID, tx.From().Value())
+	}
+
+	message, err := serializeMessage(tx)
+	if err != nil {
+		return err
+	}
+	sig, err := keys.Sign(ctx, keyID, message)
+	if err != nil {
+		return fmt.Errorf("failed to sign with key %s: %w", keyID, err)
+	}
+	if len(sig) != signatureLength || !ed25519.Verify(from, message, sig) {
+		return fmt.Errorf("key %s returned an invalid signature", keyID)
+	}
+
+	signature, err := valueobjects.NewSignatureFromBytes(sig)
+	if err != nil {
+		return fmt.Errorf("failed to create signature: %w", err)
+	}
+	// The first signature is the transaction ID
+	hash, err := valueobjects.NewHashFromBytes(sig)
+	if err != nil {
+		return fmt.Errorf("failed to create hash: %w", err)
+	}
+
+	if err := tx.SetSignature(signature); err != nil {
+		return err
+	}
+	if err := tx.SetHash(hash); err != nil {
+		return err
+	}
+	tx.SetMetadata(MetadataMessage, base64.StdEncoding.EncodeToString(message))
+	tx.SetMetadata(MetadataRawTransaction, base64.StdEncoding.EncodeToString(encodeTransaction([][]byte{sig}, message)))
+	tx.SetMetadata(MetadataSignature, base58.Encode(sig))
+	return nil
+}
+
+// verifyTransaction reports whether the signature over the message was produced by the sender
+func verifyTransaction(tx *entities.Transaction) (bool, error) {
+	if tx.Signature() == nil {
+		return false, fmt.Errorf("transaction not signed")
+	}
+	sig := tx.Signature().Bytes()
+	if len(sig) != signatureLength {
+		return false, fmt.Errorf("invalid signature length: %d", len(sig))
+	}
+	message, err := serializeMessage(tx)
+	if err != nil {
+		return false, err
+	}
+	from, err := DecodeAddress(tx.From().Value())
+	if err != nil {
+		return false, err
+	}
+	return ed25519.Verify(from, message, sig), nil
+}
+
+// metadataInt64 reads integers that may have been restored from JSON
+func metadataInt64(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case uint8:
+		return int64(v), nil
+	case uint64:
+		return int64(v), nil
+	case float64:
+		return int64(v), nil
+	case json.Number:
+		return v.Int64()
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, fmt.Errorf("unexpected type %T", value)
+	}
+}
@@ -0,0 +1,110 @@
+package solana
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCompactU16(t *testing.T) {
+	t.Parallel()
+	for n, expected := range map[int][]byte{
+		0:     {0x00},
+		127:   {0x7f},
+		128:   {0x80, 0x01},
+		255:   {0xff, 0x01},
+		16383: {0xff, 0x7f},
+		16384: {0x80, 0x80, 0x01},
+		65535: {0xff, 0xff, 0x03},
+	} {
+		var buf bytes.Buffer
+		writeCompactU16(&buf, n)
+		assert.Equal(t, expected, buf.Bytes(), "%d", n)
+	}
+}
+
+func TestSerializeMessage(t *testing.T) {
+	t.Parallel()
+	from, to := testAddresses(t)
+	fromKey, err := DecodeAddress(from.Value())
+	require.NoError(t, err)
+	toKey, err := DecodeAddress(to.Value())
+	require.NoError(t, err)
+
+	tx, err := entities.NewTransaction(entities.TransactionParams{
+		ChainID:  "solana",
+		From:     from,
+		To:       to,
+		Value:    big.NewInt(1000000000),
+		GasLimit: 500,
+		GasPrice: big.NewInt(25000),
+	})
+	require.NoError(t, err)
+	_, err = serializeMessage(tx)
+	require.ErrorContains(t, err, "no recent blockhash")
+
+	tx.SetMetadata(MetadataRecentBlockhash, testBlockhash())
+	message, err := serializeMessage(tx)
+	require.NoError(t, err)
+
+	var expected bytes.Buffer
+	// One writable signer, no read-only signers, two read-only programs
+	expected.Write([]byte{1, 0, 2})
+	expected.WriteByte(4)
+	expected.Write(fromKey)
+	expected.Write(toKey)
+	expected.Write(computeBudgetProgram)
+	expected.Write(systemProgram)
+	expected.Write(bytes.Repeat([]byte{0x11}, 32))
+	expected.WriteByte(3)
+	// SetComputeUnitLimit(500)
+	expected.Write([]byte{2, 0, 5, computeBudgetSetUnitLimit, 0xf4, 0x01, 0x00, 0x00})
+	// SetComputeUnitPrice(25000)
+	expected.Write([]byte{2, 0, 9, computeBudgetSetUnitPrice, 0xa8, 0x61, 0, 0, 0, 0, 0, 0})
+	// Transfer 1 SOL from account 0 to account 1
+	expected.Write([]byte{3, 2, 0, 1, 12, 2, 0, 0, 0, 0x00, 0xca, 0x9a, 0x3b, 0, 0, 0, 0})
+	assert.Equal(t, expected.Bytes(), message)
+
+	// Without a compute budget only the transfer remains
+	plain, err := entities.NewTransaction(entities.TransactionParams{ChainID: "solana", From: from, To: to, Value: big.NewInt(1)})
+	require.NoError(t, err)
+	plain.SetMetadata(MetadataRecentBlockhash, testBlockhash())
+	message, err = serializeMessage(plain)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 0, 1, 3}, message[:4])
+
+	tx.SetGasLimit(maxComputeUnitLimit + 1)
+	_, err = serializeMessage(tx)
+	require.ErrorContains(t, err, "compute unit limit exceeds")
+}
+
+func TestCompileMessageMergesAccounts(t *testing.T) {
+	t.Parallel()
+	payer := bytes.Repeat([]byte{1}, 32)
+	signer := bytes.Repeat([]byte{2}, 32)
+	writable := bytes.Repeat([]byte{3}, 32)
+	program := bytes.Repeat([]byte{4}, 32)
+
+	message, err := compileMessage(payer, []instruction{
+		{programID: program, accounts: []accountMeta{{pubkey: writable}, {pubkey: signer, signer: true}}},
+		{programID: program, accounts: []accountMeta{{pubkey: writable, writable: true}, {pubkey: payer}}},
+	}, make([]byte, 32))
+	require.NoError(t, err)
+
+	// Accounts are promoted to the strongest role any instruction gives them
+	assert.Equal(t, []byte{2, 1, 1, 4}, message[:4])
+	assert.Equal(t, payer, message[4:36])
+	assert.Equal(t, signer, message[36:68])
+	assert.Equal(t, writable, message[68:100])
+	assert.Equal(t, program, message[100:132])
+	instructions := message[164:]
+	assert.Equal(t, []byte{2, 3, 2, 2, 1, 0}, instructions[:6])
+	assert.Equal(t, []byte{3, 2, 2, 0, 0}, instructions[6:])
+
+	_, err = compileMessage(payer, nil, []byte{1})
+	require.Error(t, err)
+}
@@ -0,0 +1,746 @@
+package tron
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/rpcpool"
+)
+
+const (
+	defaultCurrency     = "TRX"
+	defaultPollInterval = 3 * time.Second
+	// nativeDecimals converts sun to TRX
+	nativeDecimals = 6
+	// defaultFeeLimit caps the TRX burned for energy by contract calls (100 TRX)
+	defaultFeeLimit = 100_000_000
+	// expirationWindow is how long a transaction stays valid after its reference block
+	expirationWindow = 60 * time.Second
+
+	// Fallback chain parameters in sun, used when the node omits them
+	defaultBandwidthPrice = 1000
+	defaultEnergyPrice    = 420
+
+	// bandwidthOverhead accounts for the result field the node stores with a transaction
+	bandwidthOverhead = 64
+)
+
+// NetworkConfig describes a Tron network entry (tron.networks in config.yaml)
+type NetworkConfig struct {
+	Name     string `yaml:"name"`
+	APIURL   string `yaml:"api_url"`
+	APIKey   string `yaml:"api_key"`
+	FeeLimit uint64 `yaml:"fee_limit"`
+	Testnet  bool   `yaml:"testnet"`
+	// ExplorerURL is the block explorer of the network
+	ExplorerURL string `yaml:"explorer_url"`
+	// Endpoints are further RPC endpoints balanced with api_url and failed over to
+	Endpoints []rpcpool.Endpoint `yaml:"endpoints"`
+	// Pool tunes endpoint selection and health tracking when endpoints are set
+	Pool rpcpool.Options `yaml:"pool"`
+}
+
+// Validate checks the network configuration
+func (c NetworkConfig) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("network name cannot be empty")
+	}
+	if c.APIURL == "" {
+		return fmt.Errorf("api_url cannot be empty for network %s", c.Name)
+	}
+	if err := rpcpool.Validate(c.Endpoints, c.Pool); err != nil {
+		return fmt.Errorf("invalid endpoints for network %s: %w", c.Name, err)
+	}
+	return nil
+}
+
+// Adapter implements the ChainAdapter interface for Tron over the full-node HTTP API
+type Adapter struct {
+	client       HTTPClient
+	config       NetworkConfig
+	pollInterval time.Duration
+	pool         *rpcpool.Pool
+}
+
+// NewAdapter creates a new Tron adapter
+func NewAdapter(client HTTPClient, config NetworkConfig) *Adapter {
+	if config.FeeLimit == 0 {
+		config.FeeLimit = defaultFeeLimit
+	}
+	return &Adapter{
+		client:       client,
+		config:       config,
+		pollInterval: defaultPollInterval,
+	}
+}
+
+// NewAdapterFromConfig creates a new Tron adapter backed by an HTTP API client
+func NewAdapterFromConfig(config NetworkConfig) (*Adapter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if len(config.Endpoints) == 0 {
+		return NewAdapter(NewClient(config.APIURL, config.APIKey, nil), config), nil
+	}
+	endpoints := append([]rpcpool.Endpoint{{URL: config.APIURL}}, config.Endpoints...)
+	pool, err := rpcpool.New(endpoints, config.Pool, func(ctx context.Context, endpoint string, client *http.Client) (uint64, error) {
+		return NewAdapter(NewClient(endpoint, config.APIKey, client), config).GetBlockNumber(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	adapter := NewAdapter(NewClient(pool.URL(), config.APIKey, pool.Client()), config)
+	adapter.pool = pool
+	return adapter, nil
+}
+
+// GetChainID returns the chain identifier
+func (a *Adapter) GetChainID() string {
+	return a.config.Name
+}
+
+// GetChainType returns the chain type
+func (a *Adapter) GetChainType() entities.ChainType {
+	return entities.ChainTypeTron
+}
+
+// DescribeChain returns the chain's type, network, testnet flag and native currency
+func (a *Adapter) DescribeChain() (*entities.Chain, error) {
+	chain, err := entities.NewChain(a.config.Name, entities.ChainTypeTron, a.config.Name, a.config.Testnet, defaultCurrency)
+	if err != nil {
+		return nil, err
+	}
+	chain.SetDecimals(nativeDecimals)
+	return chain, nil
+}
+
+// IsConnected checks if the node answers with its latest block
+func (a *Adapter) IsConnected(ctx context.Context) bool {
+	_, err := a.nowBlock(ctx)
+	return err == nil
+}
+
+// GetBlockNumber returns the current block number
+func (a *Adapter) GetBlockNumber(ctx context.Context) (uint64, error) {
+	block, err := a.nowBlock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(block.BlockHeader.RawData.Number), nil
+}
+
+// GetNativeBalance returns the TRX balance in sun for an address
+func (a *Adapter) GetNativeBalance(ctx context.Context, address *valueobjects.Address) (*big.Int, error) {
+	owner, err := ToBase58(address.Value())
+	if err != nil {
+		return nil, err
+	}
+
+	var account apiAccount
+	if err := a.client.Post(ctx, "/wallet/getaccount", map[string]interface{}{
+		"address": owner,
+		"visible": true,
+	}, &account); err != nil {
+		return nil, fmt.Errorf("failed to get balance: %w", err)
+	}
+	return big.NewInt(account.Balance), nil
+}
+
+// GetBalance returns the TRX balance for a given address
+func (a *Adapter) GetBalance(ctx context.Context, chainID string, address *valueobjects.Address) (*big.Int, error) {
+	return a.GetNativeBalance(ctx, address)
+}
+
+// BuildTransaction creates a TRX transfer, or a TriggerSmartContract call when data is set,
+// anchored to the latest block
+func (a *Adapter) BuildTransaction(ctx context.Context, params entities.TransactionParams) (*entities.Transaction, error) {
+	if params.ChainID == "" {
+		params.ChainID = a.config.Name
+	}
+	if len(params.Data) > 0 && params.GasLimit == 0 {
+		params.GasLimit = a.config.FeeLimit
+	}
+
+	tx, err := entities.NewTransaction(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+	if _, err := DecodeAddress(tx.From().Value()); err != nil {
+		return nil, err
+	}
+	if _, err := DecodeAddress(tx.To().Value()); err != nil {
+		return nil, err
+	}
+
+	block, err := a.nowBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := block.refBlock()
+	if err != nil {
+		return nil, err
+	}
+	setRefBlock(tx, ref)
+
+	// Tron has no account nonce; raw_data is identified by its reference block
+	if err := a.SetNonce(ctx, tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// EstimateGas estimates the energy a transaction consumes; TRX transfers consume none
+func (a *Adapter) EstimateGas(ctx context.Context, tx *entities.Transaction) (uint64, error) {
+	if len(tx.Data()) == 0 {
+		return 0, nil
+	}
+
+	result, err := a.triggerConstant(ctx, tx.From().Value(), tx.To().Value(), tx.Data(), tx.Value())
+	if err != nil {
+		return 0, fmt.Errorf("failed to estimate energy: %w", err)
+	}
+	return uint64(result.EnergyUsed), nil
+}
+
+// SetNonce sets a zero nonce, since Tron transactions are not sequenced per account
+func (a *Adapter) SetNonce(ctx context.Context, tx *entities.Transaction) error {
+	if tx.Nonce() != nil {
+		return nil
+	}
+	return tx.SetNonce(valueobjects.NewNonce(0))
+}
+
+// SignTransaction signs the sha256 of the protobuf-encoded raw_data
+func (a *Adapter) SignTransaction(ctx context.Context, tx *entities.Transaction, keys ports.KeyManager, keyID string) error {
+	if err := signTransaction(ctx, tx, keys, keyID); err != nil {
+		return fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	return nil
+}
+
+// VerifySignature verifies that the transaction was signed by its sender
+func (a *Adapter) VerifySignature(ctx context.Context, tx *entities.Transaction) (bool, error) {
+	return verifyTransaction(tx)
+}
+
+// BroadcastTransaction broadcasts a signed transaction to the network
+func (a *Adapter) BroadcastTransaction(ctx context.Context, tx *entities.Transaction) (*valueobjects.Hash, error) {
+	raw, ok := tx.Metadata()[MetadataRawTransaction].(string)
+	if !ok || raw == "" {
+		return nil, fmt.Errorf("transaction not signed")
+	}
+
+	var result apiBroadcastResult
+	if err := a.client.Post(ctx, "/wallet/broadcasthex", map[string]interface{}{
+		"transaction": raw,
+	}, &result); err != nil {
+		return nil, fmt.Errorf("failed to broadcast transaction: %w", err)
+	}
+	if !result.Result {
+		return nil, fmt.Errorf("failed to broadcast transaction: %s %s", result.Code, decodeMessage(result.Message))
+	}
+
+	hash, err := valueobjects.NewHash(result.TxID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create hash: %w", err)
+	}
+	return hash, nil
+}
+
+// BroadcastRaw relays a transaction signed elsewhere in its protobuf encoding, after checking
+// that its owner signed it and that it has not expired at the node's latest block
+func (a *Adapter) BroadcastRaw(ctx context.Context, raw []byte) (*entities.Transaction, error) {
+	tx, err := decodeTransaction(a.config.Name, raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ports.ErrInvalidRawTransaction, err)
+	}
+
+	block, err := a.nowBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := refBlockFromMetadata(tx)
+	if err != nil {
+		return nil, err
+	}
+	if ref.expiration <= block.BlockHeader.RawData.Timestamp {
+		return nil, fmt.Errorf("%w: transaction expired", ports.ErrInvalidRawTransaction)
+	}
+
+	if _, err := a.BroadcastTransaction(ctx, tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// GetTransactionStatus returns the status of a transaction
+func (a *Adapter) GetTransactionStatus(ctx context.Context, hash *valueobjects.Hash) (entities.TxStatus, error) {
+	info, err := a.transactionInfo(ctx, hash)
+	if err != nil {
+		return entities.TxStatusPending, err
+	}
+	return info.txStatus(), nil
+}
+
+// GetTransactionReceipt returns the transaction with its on-chain inclusion data
+func (a *Adapter) GetTransactionReceipt(ctx context.Context, hash *valueobjects.Hash) (*entities.Transaction, error) {
+	var apiTx apiTransaction
+	if err := a.client.Post(ctx, "/wallet/gettransactionbyid", map[string]interface{}{
+		"value":   hash.HexWithoutPrefix(),
+		"visible": true,
+	}, &apiTx); err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	if apiTx.TxID == "" {
+		return nil, fmt.Errorf("transaction not found: %s", hash.Hex())
+	}
+
+	tx, err := apiTx.toEntity(a.config.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.SetHash(hash); err != nil {
+		return nil, err
+	}
+
+	info, err := a.transactionInfo(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	tx.UpdateStatus(info.txStatus())
+	if info.ID == "" {
+		return tx, nil
+	}
+
+	blockNumber := uint64(info.BlockNumber)
+	tx.SetBlockNumber(blockNumber)
+	tx.SetMetadata("fee", info.Fee)
+	tx.SetMetadata("energy_used", info.Receipt.EnergyUsageTotal)
+	tx.SetMetadata("net_usage", info.Receipt.NetUsage)
+
+	latest, err := a.GetBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if latest >= blockNumber {
+		tx.SetConfirmations(latest - blockNumber + 1)
+	}
+	return tx, nil
+}
+
+// WaitForConfirmation polls the node until the transaction reaches the given confirmations
+func (a *Adapter) WaitForConfirmation(ctx context.Context, hash *valueobjects.Hash, confirmations uint64) error {
+	if confirmations == 0 {
+		confirmations = 1
+	}
+
+	ticker := time.NewTicker(a.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		info, err := a.transactionInfo(ctx, hash)
+		if err != nil {
+			return err
+		}
+		if info.ID != "" {
+			if info.txStatus() == entities.TxStatusFailed {
+				return fmt.Errorf("transaction failed: %s", hash.Hex())
+			}
+			latest, err := a.GetBlockNumber(ctx)
+			if err != nil {
+				return err
+			}
+			blockNumber := uint64(info.BlockNumber)
+			if latest >= blockNumber && latest-blockNumber+1 >= confirmations {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// EstimateFee estimates the TRX burned for bandwidth and energy after the sender's
+// staked and free resources are consumed
+func (a *Adapter) EstimateFee(ctx context.Context, tx *entities.Transaction) (*entities.Fee, error) {
+	bandwidth, err := estimateBandwidth(tx)
+	if err != nil {
+		return nil, err
+	}
+	energy, err := a.EstimateGas(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	prices, err := a.chainPrices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := a.accountResources(ctx, tx.From().Value())
+	if err != nil {
+		return nil, err
+	}
+
+	total := new(big.Int)
+	if !resources.coversBandwidth(bandwidth) {
+		// Bandwidth is burned for the whole transaction when staked and free bandwidth fall short
+		total.Add(total, new(big.Int).Mul(big.NewInt(int64(bandwidth)), prices.bandwidth))
+	}
+	if burned := resources.uncoveredEnergy(energy); burned > 0 {
+		total.Add(total, new(big.Int).Mul(big.NewInt(int64(burned)), prices.energy))
+	}
+
+	return entities.NewResourceFee(bandwidth, energy, prices.energy, total, defaultCurrency)
+}
+
+// GetGasPrice returns the energy price in sun
+func (a *Adapter) GetGasPrice(ctx context.Context) (*big.Int, error) {
+	prices, err := a.chainPrices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return prices.energy, nil
+}
+
+// GetMaxPriorityFee returns zero, since Tron has no priority fees
+func (a *Adapter) GetMaxPriorityFee(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(0), nil
+}
+
+// GetNetworkInfo returns the network information
+func (a *Adapter) GetNetworkInfo(ctx context.Context) (*entities.Network, error) {
+	network, err := entities.NewNetwork(a.config.Name, a.config.Name, a.config.APIURL)
+	if err != nil {
+		return nil, err
+	}
+	if a.config.ExplorerURL != "" {
+		network.SetExplorerURL(a.config.ExplorerURL)
+	}
+	if a.pool != nil {
+		network.SetEndpoints(a.pool.Health())
+	}
+	return network, nil
+}
+
+// GetPeers returns the number of nodes known to the connected node
+func (a *Adapter) GetPeers(ctx context.Context) (int, error) {
+	var result struct {
+		Nodes []interface{} `json:"nodes"`
+	}
+	if err := a.client.Post(ctx, "/wallet/listnodes", map[string]interface{}{}, &result); err != nil {
+		return 0, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	return len(result.Nodes), nil
+}
+
+// GetLatestBlock returns the latest block number
+func (a *Adapter) GetLatestBlock(ctx context.Context) (uint64, error) {
+	return a.GetBlockNumber(ctx)
+}
+
+func (a *Adapter) nowBlock(ctx context.Context) (*apiBlock, error) {
+	var block apiBlock
+	if err := a.client.Post(ctx, "/wallet/getnowblock", map[string]interface{}{}, &block); err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	if block.BlockID == "" {
+		return nil, fmt.Errorf("failed to get latest block: empty response")
+	}
+	return &block, nil
+}
+
+func (a *Adapter) transactionInfo(ctx context.Context, hash *valueobjects.Hash) (*apiTransactionInfo, error) {
+	var info apiTransactionInfo
+	if err := a.client.Post(ctx, "/wallet/gettransactioninfobyid", map[string]interface{}{
+		"value": hash.HexWithoutPrefix(),
+	}, &info); err != nil {
+		return nil, fmt.Errorf("failed to get transaction info: %w", err)
+	}
+	return &info, nil
+}
+
+// triggerConstant executes a contract call without creating a transaction
+func (a *Adapter) triggerConstant(ctx context.Context, owner, contract string, data []byte, callValue *big.Int) (*apiConstantResult, error) {
+	ownerAddress, err := ToBase58(owner)
+	if err != nil {
+		return nil, err
+	}
+	contractAddress, err := ToBase58(contract)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{
+		"owner_address":    ownerAddress,
+		"contract_address": contractAddress,
+		"data":             hex.EncodeToString(data),
+		"visible":          true,
+	}
+	if callValue != nil && callValue.Sign() > 0 {
+		body["call_value"] = callValue.Int64()
+	}
+
+	var result apiConstantResult
+	if err := a.client.Post(ctx, "/wallet/triggerconstantcontract", body, &result); err != nil {
+		return nil, err
+	}
+	if !result.Result.Result {
+		return nil, fmt.Errorf("contract call failed: %s %s", result.Result.Code, decodeMessage(result.Result.Message))
+	}
+	return &result, nil
+}
+
+type resourcePrices struct {
+	bandwidth *big.Int
+	energy    *big.Int
+}
+
+func (a *Adapter) chainPrices(ctx context.Context) (*resourcePrices, error) {
+	var result struct {
+		ChainParameter []struct {
+			Key   string `json:"key"`
+			Value int64  `json:"value"`
+		} `json:"chainParameter"`
+	}
+	if err := a.client.Post(ctx, "/wallet/getchainparameters", map[string]interface{}{}, &result); err != nil {
+		return nil, fmt.Errorf("failed to get chain parameters: %w", err)
+	}
+
+	prices := &resourcePrices{
+		bandwidth: big.NewInt(defaultBandwidthPrice),
+		energy:    big.NewInt(defaultEnergyPrice),
+	}
+	for _, param := range result.ChainParameter {
+		switch param.Key {
+		case "getTransactionFee":
+			prices.bandwidth = big.NewInt(param.Value)
+		case "getEnergyFee":
+			prices.energy = big.NewInt(param.Value)
+		}
+	}
+	return prices, nil
+}
+
+func (a *Adapter) accountResources(ctx context.Context, address string) (*apiAccountResource, error) {
+	owner, err := ToBase58(address)
+	if err != nil {
+		return nil, err
+	}
+	var resources apiAccountResource
+	if err := a.client.Post(ctx, "/wallet/getaccountresource", map[string]interface{}{
+		"address": owner,
+		"visible": true,
+	}, &resources); err != nil {
+		return nil, fmt.Errorf("failed to get account resources: %w", err)
+	}
+	return &resources, nil
+}
+
+// estimateBandwidth returns the bytes a signed transaction consumes on chain
+func estimateBandwidth(tx *entities.Transaction) (uint64, error) {
+	ref, err := refBlockFromMetadata(tx)
+	if err != nil {
+		// Unanchored transactions are sized with a placeholder reference block
+		now := time.Now()
+		ref = refBlock{
+			bytes:      make([]byte, 2),
+			hash:       make([]byte, 8),
+			expiration: now.Add(expirationWindow).UnixMilli(),
+			timestamp:  now.UnixMilli(),
+		}
+	}
+	raw, err := encodeRawData(tx, ref)
+	if err != nil {
+		return 0, err
+	}
+
+	var signed protoBuffer
+	signed.bytesField(1, raw)
+	signed.bytesField(2, make([]byte, signatureLength))
+	return uint64(len(signed.bytes()) + bandwidthOverhead), nil
+}
+
+// decodeMessage decodes the hex-encoded error messages returned by java-tron
+func decodeMessage(message string) string {
+	decoded, err := hex.DecodeString(message)
+	if err != nil {
+		return message
+	}
+	return string(decoded)
+}
+
+type apiAccount struct {
+	Balance int64 `json:"balance"`
+}
+
+type apiAccountResource struct {
+	FreeNetUsed  int64 `json:"freeNetUsed"`
+	FreeNetLimit int64 `json:"freeNetLimit"`
+	NetUsed      int64 `json:"NetUsed"`
+	NetLimit     int64 `json:"NetLimit"`
+	EnergyUsed   int64 `json:"EnergyUsed"`
+	EnergyLimit  int64 `json:"EnergyLimit"`
+}
+
+// coversBandwidth reports whether staked or free bandwidth alone covers the transaction
+func (r *apiAccountResource) coversBandwidth(bandwidth uint64) bool {
+	needed := int64(bandwidth)
+	return r.NetLimit-r.NetUsed >= needed || r.FreeNetLimit-r.FreeNetUsed >= needed
+}
+
+// uncoveredEnergy returns the energy that must be paid for by burning TRX
+func (r *apiAccountResource) uncoveredEnergy(energy uint64) uint64 {
+	available := r.EnergyLimit - r.EnergyUsed
+	if available <= 0 {
+		return energy
+	}
+	if uint64(available) >= energy {
+		return 0
+	}
+	return energy - uint64(available)
+}
+
+type apiBlock struct {
+	BlockID     string `json:"blockID"`
+	BlockHeader struct {
+		RawData struct {
+			Number    int64 `json:"number"`
+			Timestamp int64 `json:"timestamp"`
+		} `json:"raw_data"`
+	} `json:"block_header"`
+}
+
+// refBlock derives the TaPoS reference (ref_block_bytes, ref_block_hash) from the block
+func (b *apiBlock) refBlock() (refBlock, error) {
+	id, err := hex.DecodeString(b.BlockID)
+	if err != nil || len(id) != 32 {
+		return refBlock{}, fmt.Errorf("invalid block ID: %s", b.BlockID)
+	}
+	var number [8]byte
+	binary.BigEndian.PutUint64(number[:], uint64(b.BlockHeader.RawData.Number))
+
+	timestamp := b.BlockHeader.RawData.Timestamp
+	return refBlock{
+		bytes:      number[6:8],
+		hash:       id[8:16],
+		expiration: timestamp + expirationWindow.Milliseconds(),
+		timestamp:  timestamp,
+	}, nil
+}
+
+type apiBroadcastResult struct {
+	Result  bool   `json:"result"`
+	TxID    string `json:"txid"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type apiConstantResult struct {
+	Result struct {
+		Result  bool   `json:"result"`
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"result"`
+	ConstantResult []string `json:"constant_result"`
+	EnergyUsed     int64    `json:"energy_used"`
+}
+
+type apiTransactionInfo struct {
+	ID          string `json:"id"`
+	Fee         int64  `json:"fee"`
+	BlockNumber int64  `json:"blockNumber"`
+	Result      string `json:"result"`
+	Receipt     struct {
+		EnergyUsageTotal int64  `json:"energy_usage_total"`
+		NetUsage         int64  `json:"net_usage"`
+		Result           string `json:"result"`
+	} `json:"receipt"`
+}
+
+// txStatus maps the transaction info to a domain status; unknown transactions are pending
+func (i *apiTransactionInfo) txStatus() entities.TxStatus {
+	switch {
+	case i.ID == "":
+		return entities.TxStatusPending
+	case i.Result == "FAILED":
+		return entities.TxStatusFailed
+	case i.Receipt.Result != "" && i.Receipt.Result != "SUCCESS":
+		return entities.TxStatusFailed
+	default:
+		return entities.TxStatusConfirmed
+	}
+}
+
+type apiTransaction struct {
+	TxID    string `json:"txID"`
+	RawData struct {
+		Contract []struct {
+			Type      string `json:"type"`
+			Parameter struct {
+				Value struct {
+					Amount          int64  `json:"amount"`
+					OwnerAddress    string `json:"owner_address"`
+					ToAddress       string `json:"to_address"`
+					ContractAddress string `json:"contract_address"`
+					Data            string `json:"data"`
+					CallValue       int64  `json:"call_value"`
+				} `json:"value"`
+			} `json:"parameter"`
+		} `json:"contract"`
+		FeeLimit uint64 `json:"fee_limit"`
+	} `json:"raw_data"`
+}
+
+func (t *apiTransaction) toEntity(chainID string) (*entities.Transaction, error) {
+	if len(t.RawData.Contract) == 0 {
+		return nil, fmt.Errorf("transaction %s has no contract", t.TxID)
+	}
+	contract := t.RawData.Contract[0]
+	value := contract.Parameter.Value
+
+	params := entities.TransactionParams{ChainID: chainID, GasLimit: t.RawData.FeeLimit}
+	var to string
+	switch contract.Type {
+	case "TransferContract":
+		to = value.ToAddress
+		params.Value = big.NewInt(value.Amount)
+	case "TriggerSmartContract":
+		to = value.ContractAddress
+		params.Value = big.NewInt(value.CallValue)
+		data, err := hex.DecodeString(strings.TrimPrefix(value.Data, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid contract data: %w", err)
+		}
+		params.Data = data
+	default:
+		return nil, fmt.Errorf("unsupported contract type: %s", contract.Type)
+	}
+
+	var err error
+	if params.From, err = valueobjects.NewAddress(value.OwnerAddress, chainID); err != nil {
+		return nil, fmt.Errorf("invalid from address: %w", err)
+	}
+	if params.To, err = valueobjects.NewAddress(to, chainID); err != nil {
+		return nil, fmt.Errorf("invalid to address: %w", err)
+	}
+
+	tx, err := entities.NewTransaction(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+	tx.SetMetadata("contract_type", contract.Type)
+	return tx, nil
+}
@@ -0,0 +1,609 @@
+package tron
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/keystore"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/rpcpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testToken     = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	testRecipient = "TJRabPrwbZy45sbavfcjinPJC18kjpRTv8"
+	testBlockID   = "0000000003a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b"
+	testTxID      = "7c2d4206c03a883dd9066d6c839d0deaef32dc5a0d9b15f6d06e506906c90332"
+	testBlockTime = int64(1700000000000)
+)
+
+var _ ports.ChainAdapter = (*Adapter)(nil)
+
+type apiHandler func(body map[string]interface{}) (interface{}, error)
+
+// fakeNode is a local HTTP stand-in for a java-tron full node
+type fakeNode struct {
+	mu       sync.Mutex
+	handlers map[string]apiHandler
+	calls    map[string]int
+	server   *httptest.Server
+}
+
+func newFakeNode(t *testing.T) *fakeNode {
+	t.Helper()
+	node := &fakeNode{
+		handlers: make(map[string]apiHandler),
+		calls:    make(map[string]int),
+	}
+	node.server = httptest.NewServer(http.HandlerFunc(node.serve))
+	t.Cleanup(node.server.Close)
+	node.result("/wallet/getnowblock", map[string]interface{}{
+		"blockID": testBlockID,
+		"block_header": map[string]interface{}{
+			"raw_data": map[string]interface{}{"number": 60928707, "timestamp": testBlockTime},
+		},
+	})
+	return node
+}
+
+func (n *fakeNode) serve(w http.ResponseWriter, r *http.Request) {
+	var body map[string]interface{}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+
+	n.mu.Lock()
+	handler, ok := n.handlers[r.URL.Path]
+	n.calls[r.URL.Path]++
+	n.mu.Unlock()
+
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	result, err := handler(body)
+	if err != nil {
+		result = map[string]interface{}{"Error": err.Error()}
+	}
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+func (n *fakeNode) handle(path string, handler apiHandler) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.handlers[path] = handler
+}
+
+func (n *fakeNode) result(path string, result interface{}) {
+	n.handle(path, func(map[string]interface{}) (interface{}, error) { return result, nil })
+}
+
+func (n *fakeNode) callCount(path string) int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.calls[path]
+}
+
+func newTestAdapter(t *testing.T) (*Adapter, *fakeNode) {
+	t.Helper()
+	node := newFakeNode(t)
+	adapter, err := NewAdapterFromConfig(NetworkConfig{Name: "tron", APIURL: node.server.URL})
+	require.NoError(t, err)
+	adapter.pollInterval = 10 * time.Millisecond
+	return adapter, node
+}
+
+func testPrivateKey() []byte {
+	return bytes.Repeat([]byte{0x46}, 32)
+}
+
+// testKeys holds the sender key as "test" and an unrelated key as "other"
+func testKeys(t *testing.T) ports.KeyManager {
+	t.Helper()
+	keys, err := keystore.NewManager(
+		keystore.Key{ID: "test", Curve: ports.CurveSecp256k1, PrivateKey: testPrivateKey()},
+		keystore.Key{ID: "other", Curve: ports.CurveSecp256k1, PrivateKey: bytes.Repeat([]byte{0x01}, 32)},
+	)
+	require.NoError(t, err)
+	return keys
+}
+
+func testAddresses(t *testing.T) (from, to *valueobjects.Address) {
+	t.Helper()
+	sender, err := AddressFromPrivateKey(testPrivateKey())
+	require.NoError(t, err)
+	from, err = valueobjects.NewAddress(sender, "tron")
+	require.NoError(t, err)
+	to, err = valueobjects.NewAddress(testRecipient, "tron")
+	require.NoError(t, err)
+	return from, to
+}
+
+func TestNetworkConfigValidate(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, NetworkConfig{Name: "tron", APIURL: "https://api.trongrid.io"}.Validate())
+	require.Error(t, NetworkConfig{APIURL: "https://api.trongrid.io"}.Validate())
+	require.Error(t, NetworkConfig{Name: "tron"}.Validate())
+	require.ErrorContains(t, NetworkConfig{Name: "tron", APIURL: "https://api.trongrid.io", Pool: rpcpool.Options{Strategy: "random"}}.Validate(), "invalid endpoints")
+	_, err := NewAdapterFromConfig(NetworkConfig{Name: "tron"})
+	require.Error(t, err)
+}
+
+func TestAdapterChainInfo(t *testing.T) {
+	t.Parallel()
+	adapter, node := newTestAdapter(t)
+	ctx := context.Background()
+
+	assert.Equal(t, "tron", adapter.GetChainID())
+	assert.Equal(t, entities.ChainTypeTron, adapter.GetChainType())
+	assert.True(t, adapter.IsConnected(ctx))
+
+	number, err := adapter.GetBlockNumber(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(60928707), number)
+	latest, err := adapter.GetLatestBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, number, latest)
+
+	node.result("/wallet/listnodes", map[string]interface{}{"nodes": []interface{}{map[string]interface{}{}, map[string]interface{}{}}})
+	peers, err := adapter.GetPeers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, peers)
+
+	node.result("/wallet/getchainparameters", map[string]interface{}{
+		"chainParameter": []map[string]interface{}{{"key": "getEnergyFee", "value": 210}},
+	})
+	price, err := adapter.GetGasPrice(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(210), price)
+	tip, err := adapter.GetMaxPriorityFee(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), tip.Int64())
+
+	network, err := adapter.GetNetworkInfo(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, node.server.URL, network.RPCURL())
+
+	node.result("/wallet/getnowblock", map[string]interface{}{})
+	assert.False(t, adapter.IsConnected(ctx))
+	_, err = adapter.GetBlockNumber(ctx)
+	require.Error(t, err)
+}
+
+func TestAdapterUnreachable(t *testing.T) {
+	t.Parallel()
+	adapter, err := NewAdapterFromConfig(NetworkConfig{Name: "tron", APIURL: "http://127.0.0.1:1"})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	assert.False(t, adapter.IsConnected(ctx))
+	_, err = adapter.GetPeers(ctx)
+	require.Error(t, err)
+	_, err = adapter.GetGasPrice(ctx)
+	require.Error(t, err)
+}
+
+func TestAdapterGetBalance(t *testing.T) {
+	t.Parallel()
+	adapter, node := newTestAdapter(t)
+	ctx := context.Background()
+	from, _ := testAddresses(t)
+
+	node.handle("/wallet/getaccount", func(body map[string]interface{}) (interface{}, error) {
+		assert.Equal(t, from.Value(), body["address"])
+		assert.Equal(t, true, body["visible"])
+		return map[string]interface{}{"balance": 25000000}, nil
+	})
+	balance, err := adapter.GetBalance(ctx, "tron", from)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(25000000), balance)
+
+	// Inactive accounts are returned as an empty object
+	node.result("/wallet/getaccount", map[string]interface{}{})
+	balance, err = adapter.GetNativeBalance(ctx, from)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), balance.Int64())
+
+	invalid, _ := valueobjects.NewAddress("0xabc", "tron")
+	_, err = adapter.GetBalance(ctx, "tron", invalid)
+	require.Error(t, err)
+
+	node.handle("/wallet/getaccount", func(map[string]interface{}) (interface{}, error) { return nil, errors.New("boom") })
+	_, err = adapter.GetBalance(ctx, "tron", from)
+	require.Error(t, err)
+}
+
+func TestAdapterTransferLifecycle(t *testing.T) {
+	t.Parallel()
+	adapter, node := newTestAdapter(t)
+	ctx := context.Background()
+	from, to := testAddresses(t)
+
+	tx, err := adapter.BuildTransaction(ctx, entities.TransactionParams{From: from, To: to, Value: big.NewInt(1500000)})
+	require.NoError(t, err)
+	assert.Equal(t, "tron", tx.ChainID())
+	assert.Equal(t, uint64(0), tx.Nonce().Value())
+	assert.Equal(t, uint64(0), tx.GasLimit())
+	assert.Equal(t, "b2c3", tx.Metadata()[MetadataRefBlockBytes])
+	assert.Equal(t, "d4e5f60718293a4b", tx.Metadata()[MetadataRefBlockHash])
+	assert.Equal(t, testBlockTime+60000, tx.Metadata()[MetadataExpiration])
+
+	// Canonical raw_data layout of a TransferContract
+	owner, _ := DecodeAddress(from.Value())
+	recipient, _ := DecodeAddress(to.Value())
+	parameter := "0a15" + hex.EncodeToString(owner) + "1215" + hex.EncodeToString(recipient) + "18e0c65b"
+	typeURL := hex.EncodeToString([]byte(typeURLTransfer))
+	wrapped := "0a2d" + typeURL + "1232" + parameter
+	contract := "0801" + "1263" + wrapped
+	expectedRaw := "0a02b2c3" + "2208d4e5f60718293a4b" + "40e0a499ffbc31" + "5a67" + contract + "7080d095ffbc31"
+
+	raw, err := rawData(tx)
+	require.NoError(t, err)
+	assert.Equal(t, expectedRaw, hex.EncodeToString(raw))
+
+	_, err = adapter.VerifySignature(ctx, tx)
+	require.Error(t, err)
+	require.Error(t, adapter.SignTransaction(ctx, tx, testKeys(t), "other"))
+	require.NoError(t, adapter.SignTransaction(ctx, tx, testKeys(t), "test"))
+
+	id := sha256.Sum256(raw)
+	assert.Equal(t, hex.EncodeToString(id[:]), tx.Hash().HexWithoutPrefix())
+	assert.Equal(t, expectedRaw, tx.Metadata()[MetadataRawData])
+	valid, err := adapter.VerifySignature(ctx, tx)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	signed := tx.Metadata()[MetadataRawTransaction].(string)
+	assert.Equal(t, "0a8501"+expectedRaw+"1241"+tx.Signature().HexWithoutPrefix(), signed)
+
+	node.handle("/wallet/broadcasthex", func(body map[string]interface{}) (interface{}, error) {
+		assert.Equal(t, signed, body["transaction"])
+		return map[string]interface{}{"result": true, "txid": tx.Hash().HexWithoutPrefix()}, nil
+	})
+	hash, err := adapter.BroadcastTransaction(ctx, tx)
+	require.NoError(t, err)
+	assert.Equal(t, tx.Hash().Hex(), hash.Hex())
+
+	node.result("/wallet/broadcasthex", map[string]interface{}{
+		"result": false, "code": "SIGERROR", "message": hex.EncodeToString([]byte("validate signature error")),
+	})
+	_, err = adapter.BroadcastTransaction(ctx, tx)
+	require.ErrorContains(t, err, "validate signature error")
+
+	unsigned, _ := entities.NewTransaction(entities.TransactionParams{ChainID: "tron", From: from, To: to})
+	_, err = adapter.BroadcastTransaction(ctx, unsigned)
+	require.Error(t, err)
+	require.Error(t, adapter.SignTransaction(ctx, unsigned, testKeys(t), "test"), "reference block is required")
+}
+
+func TestAdapterBroadcastRaw(t *testing.T) {
+	t.Parallel()
+	adapter, node := newTestAdapter(t)
+	ctx := context.Background()
+	from, to := testAddresses(t)
+
+	built, err := adapter.BuildTransaction(ctx, entities.TransactionParams{From: from, To: to, Value: big.NewInt(1500000)})
+	require.NoError(t, err)
+	require.NoError(t, adapter.SignTransaction(ctx, built, testKeys(t), "test"))
+	signed := built.Metadata()[MetadataRawTransaction].(string)
+	raw, err := hex.DecodeString(signed)
+	require.NoError(t, err)
+
+	node.handle("/wallet/broadcasthex", func(body map[string]interface{}) (interface{}, error) {
+		assert.Equal(t, signed, body["transaction"])
+		return map[string]interface{}{"result": true, "txid": built.Hash().HexWithoutPrefix()}, nil
+	})
+	tx, err := adapter.BroadcastRaw(ctx, raw)
+	require.NoError(t, err)
+	assert.Equal(t, "tron", tx.ChainID())
+	assert.Equal(t, from.Value(), tx.From().Value())
+	assert.Equal(t, to.Value(), tx.To().Value())
+	assert.Equal(t, big.NewInt(1500000), tx.Value())
+	assert.Equal(t, uint64(0), tx.Nonce().Value())
+	assert.Equal(t, built.Hash().Hex(), tx.Hash().Hex())
+	assert.Equal(t, built.Signature().Hex(), tx.Signature().Hex())
+	assert.Equal(t, built.Metadata()[MetadataRawData], tx.Metadata()[MetadataRawData])
+	assert.Equal(t, testBlockTime+60000, tx.Metadata()[MetadataExpiration])
+	valid, err := adapter.VerifySignature(ctx, tx)
+	require.NoError(t, err)
+	assert.True(t, valid)
+	assert.Equal(t, 1, node.callCount("/wallet/broadcasthex"))
+
+	data, err := rawData(built)
+	require.NoError(t, err)
+	var unsigned protoBuffer
+	unsigned.bytesField(1, data)
+	tampered := bytes.Clone(raw)
+	tampered[len(tampered)-70] ^= 0x01
+	for name, invalid := range map[string][]byte{
+		"garbage":   {0xff, 0xff},
+		"unsigned":  unsigned.bytes(),
+		"tampered":  tampered,
+		"truncated": raw[:len(raw)-10],
+	} {
+		_, err := adapter.BroadcastRaw(ctx, invalid)
+		require.ErrorIs(t, err, ports.ErrInvalidRawTransaction, name)
+	}
+
+	node.result("/wallet/getnowblock", map[string]interface{}{
+		"blockID": testBlockID,
+		"block_header": map[string]interface{}{
+			"raw_data": map[string]interface{}{"number": 60928727, "timestamp": testBlockTime + 60000},
+		},
+	})
+	_, err = adapter.BroadcastRaw(ctx, raw)
+	require.ErrorIs(t, err, ports.ErrInvalidRawTransaction)
+	require.ErrorContains(t, err, "expired")
+	assert.Equal(t, 1, node.callCount("/wallet/broadcasthex"))
+}
+
+func TestAdapterBuildTransactionErrors(t *testing.T) {
+	t.Parallel()
+	adapter, node := newTestAdapter(t)
+	ctx := context.Background()
+	from, to := testAddresses(t)
+	invalid, _ := valueobjects.NewAddress("0x742d35cc6634c0532925a3b844bc9e7595f0beb0", "tron")
+
+	_, err := adapter.BuildTransaction(ctx, entities.TransactionParams{From: from})
+	require.Error(t, err)
+	_, err = adapter.BuildTransaction(ctx, entities.TransactionParams{From: invalid, To: to})
+	require.Error(t, err)
+	_, err = adapter.BuildTransaction(ctx, entities.TransactionParams{From: from, To: invalid})
+	require.Error(t, err)
+
+	node.result("/wallet/getnowblock", map[string]interface{}{"blockID": "zz"})
+	_, err = adapter.BuildTransaction(ctx, entities.TransactionParams{From: from, To: to})
+	require.Error(t, err)
+
+	tooLarge := new(big.Int).Lsh(big.NewInt(1), 70)
+	tx, _ := entities.NewTransaction(entities.TransactionParams{ChainID: "tron", From: from, To: to, Value: tooLarge})
+	setRefBlock(tx, refBlock{bytes: []byte{1, 2}, hash: make([]byte, 8), expiration: 2, timestamp: 1})
+	require.Error(t, adapter.SignTransaction(ctx, tx, testKeys(t), "test"))
+}
+
+func TestRefBlockMetadataFromJSON(t *testing.T) {
+	t.Parallel()
+	from, to := testAddresses(t)
+	tx, _ := entities.NewTransaction(entities.TransactionParams{ChainID: "tron", From: from, To: to, Value: big.NewInt(1)})
+	setRefBlock(tx, refBlock{bytes: []byte{1, 2}, hash: make([]byte, 8), expiration: testBlockTime + 60000, timestamp: testBlockTime})
+	expected, err := TransactionID(tx)
+	require.NoError(t, err)
+
+	// Metadata persisted as JSON comes back with float64 numbers
+	tx.SetMetadata(MetadataExpiration, float64(testBlockTime+60000))
+	tx.SetMetadata(MetadataTimestamp, json.Number("1700000000000"))
+	restored, err := TransactionID(tx)
+	require.NoError(t, err)
+	assert.Equal(t, expected, restored)
+
+	tx.SetMetadata(MetadataTimestamp, true)
+	_, err = TransactionID(tx)
+	require.Error(t, err)
+	tx.SetMetadata(MetadataRefBlockHash, "00")
+	_, err = TransactionID(tx)
+	require.Error(t, err)
+	tx.SetMetadata(MetadataRefBlockBytes, "zz")
+	_, err = TransactionID(tx)
+	require.Error(t, err)
+}
+
+func TestAdapterEstimateFee(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	from, to := testAddresses(t)
+
+	setupNode := func(node *fakeNode, resources map[string]interface{}) {
+		node.result("/wallet/getchainparameters", map[string]interface{}{
+			"chainParameter": []map[string]interface{}{
+				{"key": "getTransactionFee", "value": 1000},
+				{"key": "getEnergyFee", "value": 420},
+			},
+		})
+		node.result("/wallet/getaccountresource", resources)
+	}
+
+	t.Run("transfer burns bandwidth", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		setupNode(node, map[string]interface{}{"freeNetLimit": 600, "freeNetUsed": 500})
+
+		tx, err := adapter.BuildTransaction(ctx, entities.TransactionParams{From: from, To: to, Value: big.NewInt(1)})
+		require.NoError(t, err)
+		fee, err := adapter.EstimateFee(ctx, tx)
+		require.NoError(t, err)
+		assert.Greater(t, fee.Bandwidth(), uint64(200))
+		assert.Equal(t, uint64(0), fee.Energy())
+		assert.Equal(t, new(big.Int).Mul(big.NewInt(int64(fee.Bandwidth())), big.NewInt(1000)), fee.Total())
+		assert.Equal(t, "TRX", fee.Currency())
+	})
+
+	t.Run("free bandwidth covers transfer", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		setupNode(node, map[string]interface{}{"freeNetLimit": 600})
+
+		// Unanchored transactions are estimated with a placeholder reference block
+		tx, _ := entities.NewTransaction(entities.TransactionParams{ChainID: "tron", From: from, To: to, Value: big.NewInt(1)})
+		fee, err := adapter.EstimateFee(ctx, tx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), fee.Total().Int64())
+	})
+
+	t.Run("contract call burns uncovered energy", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		setupNode(node, map[string]interface{}{"NetLimit": 5000, "EnergyLimit": 10000, "EnergyUsed": 650})
+		node.handle("/wallet/triggerconstantcontract", func(body map[string]interface{}) (interface{}, error) {
+			assert.Equal(t, testToken, body["contract_address"])
+			assert.Equal(t, from.Value(), body["owner_address"])
+			return map[string]interface{}{"result": map[string]interface{}{"result": true}, "energy_used": 29650}, nil
+		})
+
+		token, _ := valueobjects.NewAddress(testToken, "tron")
+		data, err := adapter.EncodeTokenTransfer(to, big.NewInt(1000000))
+		require.NoError(t, err)
+		tx, err := adapter.BuildTransaction(ctx, entities.TransactionParams{From: from, To: token, Data: data})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(defaultFeeLimit), tx.GasLimit())
+
+		fee, err := adapter.EstimateFee(ctx, tx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(29650), fee.Energy())
+		assert.Equal(t, big.NewInt(420), fee.GasPrice())
+		assert.Equal(t, big.NewInt((29650-9350)*420), fee.Total())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		tx, _ := entities.NewTransaction(entities.TransactionParams{ChainID: "tron", From: from, To: to})
+
+		_, err := adapter.EstimateFee(ctx, tx)
+		require.Error(t, err, "chain parameters unavailable")
+
+		node.result("/wallet/getchainparameters", map[string]interface{}{})
+		_, err = adapter.EstimateFee(ctx, tx)
+		require.Error(t, err, "account resources unavailable")
+
+		node.result("/wallet/triggerconstantcontract", map[string]interface{}{
+			"result": map[string]interface{}{"result": false, "code": "CONTRACT_VALIDATE_ERROR", "message": "6e6f"},
+		})
+		token, _ := valueobjects.NewAddress(testToken, "tron")
+		call, _ := entities.NewTransaction(entities.TransactionParams{ChainID: "tron", From: from, To: token, Data: []byte{1}})
+		_, err = adapter.EstimateFee(ctx, call)
+		require.ErrorContains(t, err, "CONTRACT_VALIDATE_ERROR")
+	})
+}
+
+func TestAdapterTransactionStatus(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	hash, _ := valueobjects.NewHash(testTxID)
+	from, to := testAddresses(t)
+
+	t.Run("pending", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		node.result("/wallet/gettransactioninfobyid", map[string]interface{}{})
+		status, err := adapter.GetTransactionStatus(ctx, hash)
+		require.NoError(t, err)
+		assert.Equal(t, entities.TxStatusPending, status)
+	})
+
+	t.Run("failed contract call", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		node.result("/wallet/gettransactioninfobyid", map[string]interface{}{
+			"id": testTxID, "blockNumber": 60928700, "receipt": map[string]interface{}{"result": "REVERT"},
+		})
+		status, err := adapter.GetTransactionStatus(ctx, hash)
+		require.NoError(t, err)
+		assert.Equal(t, entities.TxStatusFailed, status)
+		require.Error(t, adapter.WaitForConfirmation(ctx, hash, 1))
+
+		node.result("/wallet/gettransactioninfobyid", map[string]interface{}{"id": testTxID, "result": "FAILED"})
+		status, err = adapter.GetTransactionStatus(ctx, hash)
+		require.NoError(t, err)
+		assert.Equal(t, entities.TxStatusFailed, status)
+	})
+
+	t.Run("receipt of transfer", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		node.handle("/wallet/gettransactionbyid", func(body map[string]interface{}) (interface{}, error) {
+			assert.Equal(t, testTxID, body["value"])
+			return map[string]interface{}{
+				"txID": testTxID,
+				"raw_data": map[string]interface{}{
+					"contract": []map[string]interface{}{{
+						"type": "TransferContract",
+						"parameter": map[string]interface{}{"value": map[string]interface{}{
+							"amount": 1500000, "owner_address": from.Value(), "to_address": to.Value(),
+						}},
+					}},
+				},
+			}, nil
+		})
+		node.result("/wallet/gettransactioninfobyid", map[string]interface{}{
+			"id": testTxID, "blockNumber": 60928700, "fee": 267000, "receipt": map[string]interface{}{"net_usage": 267},
+		})
+
+		tx, err := adapter.GetTransactionReceipt(ctx, hash)
+		require.NoError(t, err)
+		assert.Equal(t, entities.TxStatusConfirmed, tx.Status())
+		assert.Equal(t, uint64(60928700), tx.BlockNumber())
+		assert.Equal(t, uint64(8), tx.Confirmations())
+		assert.Equal(t, big.NewInt(1500000), tx.Value())
+		assert.Equal(t, to.Value(), tx.To().Value())
+		assert.Equal(t, int64(267000), tx.Metadata()["fee"])
+		assert.Equal(t, "0x"+testTxID, tx.Hash().Hex())
+
+		require.NoError(t, adapter.WaitForConfirmation(ctx, hash, 0))
+		require.NoError(t, adapter.WaitForConfirmation(ctx, hash, 8))
+
+		timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, adapter.WaitForConfirmation(timeout, hash, 20), context.DeadlineExceeded)
+	})
+
+	t.Run("receipt of contract call", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		node.result("/wallet/gettransactionbyid", map[string]interface{}{
+			"txID": testTxID,
+			"raw_data": map[string]interface{}{
+				"fee_limit": 100000000,
+				"contract": []map[string]interface{}{{
+					"type": "TriggerSmartContract",
+					"parameter": map[string]interface{}{"value": map[string]interface{}{
+						"owner_address": from.Value(), "contract_address": testToken, "data": "a9059cbb",
+					}},
+				}},
+			},
+		})
+		node.result("/wallet/gettransactioninfobyid", map[string]interface{}{})
+
+		tx, err := adapter.GetTransactionReceipt(ctx, hash)
+		require.NoError(t, err)
+		assert.Equal(t, entities.TxStatusPending, tx.Status())
+		assert.Equal(t, []byte{0xa9, 0x05, 0x9c, 0xbb}, tx.Data())
+		assert.Equal(t, uint64(100000000), tx.GasLimit())
+		assert.Equal(t, "TriggerSmartContract", tx.Metadata()["contract_type"])
+	})
+
+	t.Run("receipt errors", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		node.result("/wallet/gettransactionbyid", map[string]interface{}{})
+		_, err := adapter.GetTransactionReceipt(ctx, hash)
+		require.ErrorContains(t, err, "not found")
+
+		node.result("/wallet/gettransactionbyid", map[string]interface{}{"txID": testTxID, "raw_data": map[string]interface{}{}})
+		_, err = adapter.GetTransactionReceipt(ctx, hash)
+		require.Error(t, err)
+
+		node.result("/wallet/gettransactionbyid", map[string]interface{}{
+			"txID":     testTxID,
+			"raw_data": map[string]interface{}{"contract": []map[string]interface{}{{"type": "FreezeBalanceContract"}}},
+		})
+		_, err = adapter.GetTransactionReceipt(ctx, hash)
+		require.ErrorContains(t, err, "unsupported contract type")
+
+		// Malformed transactions fail before their execution info is requested
+		assert.Equal(t, 0, node.callCount("/wallet/gettransactioninfobyid"))
+		_, err = adapter.GetTransactionStatus(ctx, hash)
+		require.Error(t, err)
+	})
+}
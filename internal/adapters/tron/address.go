@@ -0,0 +1,144 @@
+package tron
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/base58"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+)
+
+const (
+	// AddressPrefix is the leading byte of every mainnet Tron address
+	AddressPrefix byte = 0x41
+
+	addressLength    = 21
+	privateKeyLength = 32
+	signatureLength  = 65
+)
+
+// DecodeAddress decodes a base58check T-address or 41-prefixed hex address into its 21 raw bytes
+func DecodeAddress(address string) ([]byte, error) {
+	address = strings.TrimSpace(address)
+
+	var raw []byte
+	var err error
+	if strings.HasPrefix(address, "T") {
+		raw, err = base58.CheckDecode(address)
+	} else {
+		raw, err = hex.DecodeString(strings.TrimPrefix(address, "0x"))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid Tron address %s: %w", address, err)
+	}
+	if len(raw) != addressLength || raw[0] != AddressPrefix {
+		return nil, fmt.Errorf("invalid Tron address: %s", address)
+	}
+	return raw, nil
+}
+
+// EncodeAddress encodes 21 raw address bytes as a base58check T-address
+func EncodeAddress(raw []byte) (string, error) {
+	if len(raw) != addressLength || raw[0] != AddressPrefix {
+		return "", fmt.Errorf("invalid Tron address bytes: %x", raw)
+	}
+	return base58.CheckEncode(raw), nil
+}
+
+// ToBase58 normalizes a hex or base58 address into its T-address form
+func ToBase58(address string) (string, error) {
+	raw, err := DecodeAddress(address)
+	if err != nil {
+		return "", err
+	}
+	return EncodeAddress(raw)
+}
+
+// AddressFromPrivateKey derives the T-address controlled by a private key
+func AddressFromPrivateKey(privateKey []byte) (string, error) {
+	key, err := parsePrivateKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+	return EncodeAddress(addressFromPublicKey(key.PubKey()))
+}
+
+func parsePrivateKey(privateKey []byte) (*secp256k1.PrivateKey, error) {
+	if len(privateKey) != privateKeyLength {
+		return nil, fmt.Errorf("private key must be %d bytes, got %d", privateKeyLength, len(privateKey))
+	}
+	var scalar secp256k1.ModNScalar
+	if overflow := scalar.SetByteSlice(privateKey); overflow || scalar.IsZero() {
+		return nil, fmt.Errorf("invalid private key")
+	}
+	return secp256k1.NewPrivateKey(&scalar), nil
+}
+
+func addressFromPublicKey(pub *secp256k1.PublicKey) []byte {
+	uncompressed := pub.SerializeUncompressed()
+	h := sha3.NewLegacyKeccak256()
+	h.Write(uncompressed[1:])
+	return append([]byte{AddressPrefix}, h.Sum(nil)[12:]...)
+}
+
+// signDigest signs a digest with the key keyID, which must control the T-address sender,
+// returning [R || S || V] with V in {27, 28} as TronWeb does
+func signDigest(ctx context.Context, keys ports.KeyManager, keyID string, digest []byte, sender string) ([]byte, error) {
+	publicKey, err := keys.PublicKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := secp256k1.ParsePubKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("key %s is not a secp256k1 key: %w", keyID, err)
+	}
+	signer := addressFromPublicKey(pub)
+	address, err := EncodeAddress(signer)
+	if err != nil {
+		return nil, err
+	}
+	if address != sender {
+		return nil, fmt.Errorf("key %s does not match sender %s", keyID, sender)
+	}
+
+	sig, err := keys.Sign(ctx, keyID, digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign with key %s: %w", keyID, err)
+	}
+	if recovered, err := recoverAddress(digest, sig); err != nil || !bytes.Equal(recovered, signer) {
+		return nil, fmt.Errorf("key %s returned an invalid signature", keyID)
+	}
+	if sig[64] < 27 {
+		sig[64] += 27
+	}
+	return sig, nil
+}
+
+// recoverAddress recovers the raw signer address from a digest and signature
+func recoverAddress(digest, sig []byte) ([]byte, error) {
+	if len(sig) != signatureLength {
+		return nil, fmt.Errorf("invalid signature length: %d", len(sig))
+	}
+	v := sig[64]
+	if v < 27 {
+		v += 27
+	}
+	if v != 27 && v != 28 {
+		return nil, fmt.Errorf("invalid signature recovery id: %d", sig[64])
+	}
+	compact := make([]byte, signatureLength)
+	compact[0] = v
+	copy(compact[1:], sig[:64])
+	pub, _, err := ecdsa.RecoverCompact(compact, digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover public key: %w", err)
+	}
+	return addressFromPublicKey(pub), nil
+}
@@ -0,0 +1,93 @@
+package tron
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressEncoding(t *testing.T) {
+	t.Parallel()
+
+	raw, err := DecodeAddress(testToken)
+	require.NoError(t, err)
+	assert.Equal(t, "41a614f803b6fd780986a42c78ec9c7f77e6ded13c", hex.EncodeToString(raw))
+
+	fromHex, err := ToBase58("41a614f803b6fd780986a42c78ec9c7f77e6ded13c")
+	require.NoError(t, err)
+	assert.Equal(t, testToken, fromHex)
+
+	encoded, err := EncodeAddress(raw)
+	require.NoError(t, err)
+	assert.Equal(t, testToken, encoded)
+
+	for _, invalid := range []string{
+		"",
+		"0x742d35cc6634c0532925a3b844bc9e7595f0beb0",
+		"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6u",
+		"42a614f803b6fd780986a42c78ec9c7f77e6ded13c",
+		"zz",
+	} {
+		_, err := DecodeAddress(invalid)
+		assert.Error(t, err, invalid)
+	}
+	_, err = EncodeAddress([]byte{0x41})
+	require.Error(t, err)
+}
+
+func TestAddressFromPrivateKey(t *testing.T) {
+	t.Parallel()
+
+	// Private key 1 controls 0x7e5f4552091a69125d5dfcb7b8c2659029395bdf on Ethereum
+	key := make([]byte, 32)
+	key[31] = 1
+	address, err := AddressFromPrivateKey(key)
+	require.NoError(t, err)
+	raw, err := DecodeAddress(address)
+	require.NoError(t, err)
+	assert.Equal(t, "417e5f4552091a69125d5dfcb7b8c2659029395bdf", hex.EncodeToString(raw))
+
+	_, err = AddressFromPrivateKey([]byte("short"))
+	require.Error(t, err)
+	_, err = AddressFromPrivateKey(make([]byte, 32))
+	require.Error(t, err)
+	_, err = AddressFromPrivateKey(bytes.Repeat([]byte{0xff}, 32))
+	require.Error(t, err)
+}
+
+func TestSignatureRecovery(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	keys := testKeys(t)
+	digest := bytes.Repeat([]byte{0xab}, 32)
+	expected, _ := AddressFromPrivateKey(testPrivateKey())
+
+	sig, err := signDigest(ctx, keys, "test", digest, expected)
+	require.NoError(t, err)
+	require.Contains(t, []byte{27, 28}, sig[64])
+
+	signer, err := recoverAddress(digest, sig)
+	require.NoError(t, err)
+	encoded, _ := EncodeAddress(signer)
+	assert.Equal(t, expected, encoded)
+
+	// Recovery ids without the 27 offset are accepted as well
+	sig[64] -= 27
+	_, err = recoverAddress(digest, sig)
+	require.NoError(t, err)
+
+	sig[64] = 5
+	_, err = recoverAddress(digest, sig)
+	require.Error(t, err)
+	_, err = recoverAddress(digest, sig[:10])
+	require.Error(t, err)
+	_, err = signDigest(ctx, keys, "other", digest, expected)
+	require.ErrorContains(t, err, "does not match sender")
+	_, err = signDigest(ctx, keys, "missing", digest, expected)
+	require.ErrorIs(t, err, ports.ErrKeyNotFound)
+}
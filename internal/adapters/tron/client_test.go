@@ -0,0 +1,52 @@
+package tron
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientPost(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		switch r.URL.Path {
+		case "/wallet/echo":
+			require.Equal(t, "secret", r.Header.Get("TRON-PRO-API-KEY"))
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			_ = json.NewEncoder(w).Encode(body)
+		case "/wallet/error":
+			_, _ = w.Write([]byte(`{"Error":"class java.lang.NullPointerException"}`))
+		case "/wallet/broken":
+			_, _ = w.Write([]byte("not json"))
+		default:
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewClient(srv.URL+"/", "secret", nil)
+	ctx := context.Background()
+
+	var out map[string]string
+	require.NoError(t, client.Post(ctx, "/wallet/echo", map[string]string{"value": "x"}, &out))
+	require.Equal(t, "x", out["value"])
+	require.NoError(t, client.Post(ctx, "/wallet/echo", map[string]string{}, nil))
+
+	err := client.Post(ctx, "/wallet/error", nil, &out)
+	require.ErrorContains(t, err, "NullPointerException")
+
+	require.Error(t, client.Post(ctx, "/wallet/broken", nil, &out))
+
+	err = client.Post(ctx, "/wallet/unknown", nil, &out)
+	require.ErrorContains(t, err, "429")
+
+	require.Error(t, client.Post(ctx, "/wallet/echo", make(chan int), nil))
+	require.Error(t, NewClient("http://127.0.0.1:1", "", nil).Post(ctx, "/wallet/getnowblock", nil, nil))
+}
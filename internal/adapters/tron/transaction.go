@@ -0,0 +1,508 @@
+package tron
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+)
+
+const (
+	// MetadataRawTransaction holds the signed, protobuf-encoded transaction bytes
+	MetadataRawTransaction = "raw_transaction"
+	// MetadataRawData holds the protobuf-encoded raw_data that was signed
+	MetadataRawData = "raw_data"
+	// MetadataRefBlockBytes holds bytes 6..8 of the reference block number
+	MetadataRefBlockBytes = "ref_block_bytes"
+	// MetadataRefBlockHash holds bytes 8..16 of the reference block ID
+	MetadataRefBlockHash = "ref_block_hash"
+	// MetadataExpiration holds the expiration time in milliseconds
+	MetadataExpiration = "expiration"
+	// MetadataTimestamp holds the creation time in milliseconds
+	MetadataTimestamp = "timestamp"
+)
+
+// Contract types from the java-tron protocol
+const (
+	contractTypeTransfer     = 1
+	contractTypeTriggerSmart = 31
+
+	typeURLTransfer     = "type.googleapis.com/protocol.TransferContract"
+	typeURLTriggerSmart = "type.googleapis.com/protocol.TriggerSmartContract"
+)
+
+// refBlock is the block a transaction is anchored to (TaPoS)
+type refBlock struct {
+	bytes      []byte
+	hash       []byte
+	expiration int64
+	timestamp  int64
+}
+
+// setRefBlock records the reference block on the transaction metadata
+func setRefBlock(tx *entities.Transaction, ref refBlock) {
+	tx.SetMetadata(MetadataRefBlockBytes, hex.EncodeToString(ref.bytes))
+	tx.SetMetadata(MetadataRefBlockHash, hex.EncodeToString(ref.hash))
+	tx.SetMetadata(MetadataExpiration, ref.expiration)
+	tx.SetMetadata(MetadataTimestamp, ref.timestamp)
+}
+
+// refBlockFromMetadata reads the reference block recorded by BuildTransaction
+func refBlockFromMetadata(tx *entities.Transaction) (refBlock, error) {
+	var ref refBlock
+	metadata := tx.Metadata()
+
+	refBytes, ok := metadata[MetadataRefBlockBytes].(string)
+	if !ok {
+		return ref, fmt.Errorf("transaction has no reference block; build it with the tron adapter")
+	}
+	var err error
+	if ref.bytes, err = hex.DecodeString(refBytes); err != nil || len(ref.bytes) != 2 {
+		return ref, fmt.Errorf("invalid %s metadata", MetadataRefBlockBytes)
+	}
+	refHash, _ := metadata[MetadataRefBlockHash].(string)
+	if ref.hash, err = hex.DecodeString(refHash); err != nil || len(ref.hash) != 8 {
+		return ref, fmt.Errorf("invalid %s metadata", MetadataRefBlockHash)
+	}
+	if ref.expiration, err = metadataInt64(metadata[MetadataExpiration]); err != nil {
+		return ref, fmt.Errorf("invalid %s metadata: %w", MetadataExpiration, err)
+	}
+	if ref.timestamp, err = metadataInt64(metadata[MetadataTimestamp]); err != nil {
+		return ref, fmt.Errorf("invalid %s metadata: %w", MetadataTimestamp, err)
+	}
+	return ref, nil
+}
+
+// metadataInt64 reads integers that may have been restored from JSON
+func metadataInt64(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case float64:
+		return int64(v), nil
+	case json.Number:
+		return v.Int64()
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, fmt.Errorf("unexpected type %T", value)
+	}
+}
+
+// rawData encodes the raw_data of a transaction anchored by BuildTransaction
+func rawData(tx *entities.Transaction) ([]byte, error) {
+	ref, err := refBlockFromMetadata(tx)
+	if err != nil {
+		return nil, err
+	}
+	return encodeRawData(tx, ref)
+}
+
+// encodeRawData builds the canonical protobuf encoding of Transaction.raw
+func encodeRawData(tx *entities.Transaction, ref refBlock) ([]byte, error) {
+	owner, err := DecodeAddress(tx.From().Value())
+	if err != nil {
+		return nil, err
+	}
+	to, err := DecodeAddress(tx.To().Value())
+	if err != nil {
+		return nil, err
+	}
+	value := tx.Value()
+	if !value.IsInt64() {
+		return nil, fmt.Errorf("value exceeds int64: %s", value)
+	}
+
+	var contractType uint64
+	var typeURL string
+	var parameter protoBuffer
+	var feeLimit uint64
+
+	if len(tx.Data()) > 0 {
+		contractType, typeURL = contractTypeTriggerSmart, typeURLTriggerSmart
+		parameter.bytesField(1, owner)
+		parameter.bytesField(2, to)
+		parameter.uintField(3, uint64(value.Int64()))
+		parameter.bytesField(4, tx.Data())
+		if tx.GasLimit() > math.MaxInt64 {
+			return nil, fmt.Errorf("fee limit exceeds int64: %d", tx.GasLimit())
+		}
+		feeLimit = tx.GasLimit()
+	} else {
+		contractType, typeURL = contractTypeTransfer, typeURLTransfer
+		parameter.bytesField(1, owner)
+		parameter.bytesField(2, to)
+		parameter.uintField(3, uint64(value.Int64()))
+	}
+
+	var wrapped protoBuffer
+	wrapped.bytesField(1, []byte(typeURL))
+	wrapped.bytesField(2, parameter.bytes())
+
+	var contract protoBuffer
+	contract.uintField(1, contractType)
+	contract.bytesField(2, wrapped.bytes())
+
+	var raw protoBuffer
+	raw.bytesField(1, ref.bytes)
+	raw.bytesField(4, ref.hash)
+	raw.uintField(8, uint64(ref.expiration))
+	raw.bytesField(11, contract.bytes())
+	raw.uintField(14, uint64(ref.timestamp))
+	raw.uintField(18, feeLimit)
+	return raw.bytes(), nil
+}
+
+// TransactionID returns the txID of a transaction: sha256 of its raw_data
+func TransactionID(tx *entities.Transaction) ([]byte, error) {
+	raw, err := rawData(tx)
+	if err != nil {
+		return nil, err
+	}
+	id := sha256.Sum256(raw)
+	return id[:], nil
+}
+
+// signTransaction signs the transaction raw_data with the sender's key keyID, storing the txID,
+// signature and encoding on it
+func signTransaction(ctx context.Context, tx *entities.Transaction, keys ports.KeyManager, keyID string) error {
+	from, err := ToBase58(tx.From().Value())
+	if err != nil {
+		return err
+	}
+
+	raw, err := rawData(tx)
+	if err != nil {
+		return err
+	}
+	id := sha256.Sum256(raw)
+
+	sig, err := signDigest(ctx, keys, keyID, id[:], from)
+	if err != nil {
+		return err
+	}
+
+	var signed protoBuffer
+	signed.bytesField(1, raw)
+	signed.bytesField(2, sig)
+
+	signature, err := valueobjects.NewSignatureFromBytes(sig)
+	if err != nil {
+		return fmt.Errorf("failed to create signature: %w", err)
+	}
+	hash, err := valueobjects.NewHashFromBytes(id[:])
+	if err != nil {
+		return fmt.Errorf("failed to create hash: %w", err)
+	}
+
+	if err := tx.SetSignature(signature); err != nil {
+		return err
+	}
+	if err := tx.SetHash(hash); err != nil {
+		return err
+	}
+	tx.SetMetadata(MetadataRawData, hex.EncodeToString(raw))
+	tx.SetMetadata(MetadataRawTransaction, hex.EncodeToString(signed.bytes()))
+	return nil
+}
+
+// verifyTransaction reports whether the signature over raw_data was produced by the sender
+func verifyTransaction(tx *entities.Transaction) (bool, error) {
+	if tx.Signature() == nil {
+		return false, fmt.Errorf("transaction not signed")
+	}
+	id, err := TransactionID(tx)
+	if err != nil {
+		return false, err
+	}
+	signer, err := recoverAddress(id, tx.Signature().Bytes())
+	if err != nil {
+		return false, err
+	}
+	from, err := DecodeAddress(tx.From().Value())
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(signer, from), nil
+}
+
+// decodeTransaction parses a signed, protobuf-encoded transaction holding a single TransferContract
+// or TriggerSmartContract, checking that it carries one signature, by the contract owner
+func decodeTransaction(chainID string, raw []byte) (*entities.Transaction, error) {
+	fields, err := decodeProto(raw)
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	var signatures [][]byte
+	for _, field := range fields {
+		switch field.number {
+		case 1:
+			data = field.bytes
+		case 2:
+			signatures = append(signatures, field.bytes)
+		}
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("transaction has no raw_data")
+	}
+	if len(signatures) != 1 {
+		return nil, fmt.Errorf("expected one signature, got %d", len(signatures))
+	}
+
+	decoded, err := decodeRawData(data)
+	if err != nil {
+		return nil, err
+	}
+	id := sha256.Sum256(data)
+	signer, err := recoverAddress(id[:], signatures[0])
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(signer, decoded.owner) {
+		return nil, fmt.Errorf("transaction is not signed by its owner")
+	}
+
+	owner, err := EncodeAddress(decoded.owner)
+	if err != nil {
+		return nil, err
+	}
+	to, err := EncodeAddress(decoded.to)
+	if err != nil {
+		return nil, err
+	}
+	params := entities.TransactionParams{
+		ChainID:  chainID,
+		Value:    new(big.Int).SetUint64(decoded.value),
+		Data:     decoded.data,
+		GasLimit: decoded.feeLimit,
+		Nonce:    valueobjects.NewNonce(0),
+	}
+	if params.From, err = valueobjects.NewAddress(owner, chainID); err != nil {
+		return nil, fmt.Errorf("invalid from address: %w", err)
+	}
+	if params.To, err = valueobjects.NewAddress(to, chainID); err != nil {
+		return nil, fmt.Errorf("invalid to address: %w", err)
+	}
+	tx, err := entities.NewTransaction(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	signature, err := valueobjects.NewSignatureFromBytes(signatures[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signature: %w", err)
+	}
+	hash, err := valueobjects.NewHashFromBytes(id[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create hash: %w", err)
+	}
+	if err := tx.SetSignature(signature); err != nil {
+		return nil, err
+	}
+	if err := tx.SetHash(hash); err != nil {
+		return nil, err
+	}
+	setRefBlock(tx, decoded.ref)
+	tx.SetMetadata(MetadataRawData, hex.EncodeToString(data))
+	tx.SetMetadata(MetadataRawTransaction, hex.EncodeToString(raw))
+	return tx, nil
+}
+
+// decodedRawData holds the fields of Transaction.raw read by decodeRawData
+type decodedRawData struct {
+	ref      refBlock
+	owner    []byte
+	to       []byte
+	value    uint64
+	data     []byte
+	feeLimit uint64
+}
+
+// decodeRawData reads the reference block, fee limit and contract of Transaction.raw
+func decodeRawData(data []byte) (*decodedRawData, error) {
+	fields, err := decodeProto(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid raw_data: %w", err)
+	}
+
+	decoded := &decodedRawData{}
+	var contracts [][]byte
+	for _, field := range fields {
+		switch field.number {
+		case 1:
+			decoded.ref.bytes = field.bytes
+		case 4:
+			decoded.ref.hash = field.bytes
+		case 8:
+			decoded.ref.expiration = int64(field.varint)
+		case 11:
+			contracts = append(contracts, field.bytes)
+		case 14:
+			decoded.ref.timestamp = int64(field.varint)
+		case 18:
+			decoded.feeLimit = field.varint
+		}
+	}
+	if len(decoded.ref.bytes) != 2 || len(decoded.ref.hash) != 8 {
+		return nil, fmt.Errorf("transaction has no reference block")
+	}
+	if len(contracts) != 1 {
+		return nil, fmt.Errorf("expected one contract, got %d", len(contracts))
+	}
+
+	contract, err := decodeProto(contracts[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid contract: %w", err)
+	}
+	var contractType uint64
+	var typeURL string
+	var parameter []byte
+	for _, field := range contract {
+		switch field.number {
+		case 1:
+			contractType = field.varint
+		case 2:
+			wrapped, err := decodeProto(field.bytes)
+			if err != nil {
+				return nil, fmt.Errorf("invalid contract parameter: %w", err)
+			}
+			for _, f := range wrapped {
+				switch f.number {
+				case 1:
+					typeURL = string(f.bytes)
+				case 2:
+					parameter = f.bytes
+				}
+			}
+		}
+	}
+	switch {
+	case contractType == contractTypeTransfer && typeURL == typeURLTransfer:
+	case contractType == contractTypeTriggerSmart && typeURL == typeURLTriggerSmart:
+	default:
+		return nil, fmt.Errorf("unsupported contract type %d (%s)", contractType, typeURL)
+	}
+
+	values, err := decodeProto(parameter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid contract parameter: %w", err)
+	}
+	// TransferContract and TriggerSmartContract share the owner, recipient and amount fields
+	for _, field := range values {
+		switch field.number {
+		case 1:
+			decoded.owner = field.bytes
+		case 2:
+			decoded.to = field.bytes
+		case 3:
+			decoded.value = field.varint
+		case 4:
+			if contractType == contractTypeTriggerSmart {
+				decoded.data = field.bytes
+			}
+		}
+	}
+	if decoded.value > math.MaxInt64 {
+		return nil, fmt.Errorf("amount exceeds int64: %d", decoded.value)
+	}
+	return decoded, nil
+}
+
+// protoField is a decoded protobuf field; varint holds wire type 0 values and bytes
+// length-delimited ones
+type protoField struct {
+	number int
+	varint uint64
+	bytes  []byte
+}
+
+// decodeProto reads the top-level fields of a protobuf message
+func decodeProto(data []byte) ([]protoField, error) {
+	var fields []protoField
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, fmt.Errorf("invalid protobuf field key")
+		}
+		data = data[n:]
+		field := protoField{number: int(key >> 3)}
+
+		switch key & 7 {
+		case 0:
+			if field.varint, n = binary.Uvarint(data); n <= 0 {
+				return nil, fmt.Errorf("invalid protobuf varint in field %d", field.number)
+			}
+			data = data[n:]
+		case 1, 5:
+			// fixed64 and fixed32 values are not used by the fields read here
+			size := 8
+			if key&7 == 5 {
+				size = 4
+			}
+			if len(data) < size {
+				return nil, fmt.Errorf("truncated protobuf field %d", field.number)
+			}
+			data = data[size:]
+		case 2:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || length > uint64(len(data)-n) {
+				return nil, fmt.Errorf("truncated protobuf field %d", field.number)
+			}
+			field.bytes = data[n : n+int(length)]
+			data = data[n+int(length):]
+		default:
+			return nil, fmt.Errorf("unsupported protobuf wire type %d in field %d", key&7, field.number)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// protoBuffer is a minimal protobuf writer producing canonical encodings
+type protoBuffer struct {
+	buf []byte
+}
+
+func (p *protoBuffer) bytes() []byte {
+	return p.buf
+}
+
+func (p *protoBuffer) varint(v uint64) {
+	for v >= 0x80 {
+		p.buf = append(p.buf, byte(v)|0x80)
+		v >>= 7
+	}
+	p.buf = append(p.buf, byte(v))
+}
+
+// uintField writes a varint field, omitting proto3 zero values
+func (p *protoBuffer) uintField(field int, v uint64) {
+	if v == 0 {
+		return
+	}
+	p.varint(uint64(field) << 3)
+	p.varint(v)
+}
+
+// bytesField writes a length-delimited field, omitting empty values
+func (p *protoBuffer) bytesField(field int, data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	p.varint(uint64(field)<<3 | 2)
+	p.varint(uint64(len(data)))
+	p.buf = append(p.buf, data...)
+}
@@ -0,0 +1,183 @@
+package tron
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ABI encoding of the string "USDT"
+const usdtSymbolResult = "" +
+	"0000000000000000000000000000000000000000000000000000000000000020" +
+	"0000000000000000000000000000000000000000000000000000000000000004" +
+	"5553445400000000000000000000000000000000000000000000000000000000"
+
+var (
+	_ ports.TokenTransferEncoder  = (*Adapter)(nil)
+	_ ports.TokenMetadataProvider = (*Adapter)(nil)
+)
+
+// tokenContract dispatches triggerconstantcontract requests on their TRC-20 selector
+func tokenContract(t *testing.T, responses map[string]string) apiHandler {
+	return func(body map[string]interface{}) (interface{}, error) {
+		require.Equal(t, testToken, body["contract_address"])
+		data, _ := body["data"].(string)
+		result, ok := responses[data[:8]]
+		if !ok {
+			return map[string]interface{}{
+				"result": map[string]interface{}{"code": "CONTRACT_EXE_ERROR", "message": hex.EncodeToString([]byte("REVERT opcode executed"))},
+			}, nil
+		}
+		return map[string]interface{}{
+			"result":          map[string]interface{}{"result": true},
+			"constant_result": []string{result},
+		}, nil
+	}
+}
+
+func tokenAddress(t *testing.T) *valueobjects.Address {
+	t.Helper()
+	token, err := valueobjects.NewAddress(testToken, "tron")
+	require.NoError(t, err)
+	return token
+}
+
+func TestEncodeTokenTransfer(t *testing.T) {
+	t.Parallel()
+	_, to := testAddresses(t)
+	recipient, _ := DecodeAddress(to.Value())
+
+	data, err := EncodeTokenTransfer(to, big.NewInt(1000000))
+	require.NoError(t, err)
+	expected := "a9059cbb" +
+		strings.Repeat("0", 24) + hex.EncodeToString(recipient[1:]) +
+		strings.Repeat("0", 59) + "f4240"
+	assert.Equal(t, expected, hex.EncodeToString(data))
+
+	_, err = EncodeTokenTransfer(nil, big.NewInt(1))
+	require.Error(t, err)
+	_, err = EncodeTokenTransfer(to, big.NewInt(-1))
+	require.Error(t, err)
+	_, err = EncodeTokenTransfer(to, new(big.Int).Lsh(big.NewInt(1), 256))
+	require.Error(t, err)
+	invalid, _ := valueobjects.NewAddress("0x742d35cc6634c0532925a3b844bc9e7595f0beb0", "tron")
+	_, err = EncodeTokenTransfer(invalid, big.NewInt(1))
+	require.Error(t, err)
+}
+
+func TestAdapterGetTokenBalance(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	from, _ := testAddresses(t)
+	owner, _ := DecodeAddress(from.Value())
+
+	adapter, node := newTestAdapter(t)
+	node.handle("/wallet/triggerconstantcontract", func(body map[string]interface{}) (interface{}, error) {
+		assert.Equal(t, "70a08231"+hex.EncodeToString(abiWord(owner[1:])), body["data"])
+		return tokenContract(t, map[string]string{
+			"70a08231": hex.EncodeToString(abiWord(big.NewInt(2500000).Bytes())),
+		})(body)
+	})
+	balance, err := adapter.GetTokenBalance(ctx, "tron", from, tokenAddress(t))
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(2500000), balance)
+
+	node.handle("/wallet/triggerconstantcontract", tokenContract(t, map[string]string{"70a08231": "01"}))
+	_, err = adapter.GetTokenBalance(ctx, "tron", from, tokenAddress(t))
+	require.ErrorContains(t, err, "unexpected response length")
+
+	node.handle("/wallet/triggerconstantcontract", tokenContract(t, nil))
+	_, err = adapter.GetTokenBalance(ctx, "tron", from, tokenAddress(t))
+	require.ErrorContains(t, err, "REVERT opcode executed")
+
+	_, err = adapter.GetTokenBalance(ctx, "tron", from, nil)
+	require.Error(t, err)
+}
+
+func TestAdapterGetTokenMetadata(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	decimals := hex.EncodeToString(abiWord([]byte{6}))
+
+	t.Run("string symbol", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		node.handle("/wallet/triggerconstantcontract", tokenContract(t, map[string]string{
+			"313ce567": decimals,
+			"95d89b41": usdtSymbolResult,
+		}))
+		token, err := adapter.GetTokenMetadata(ctx, tokenAddress(t))
+		require.NoError(t, err)
+		assert.Equal(t, "USDT", token.Symbol())
+		assert.Equal(t, uint8(6), token.Decimals())
+		assert.Equal(t, testToken, token.Address().Value())
+	})
+
+	t.Run("bytes32 symbol", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+		node.handle("/wallet/triggerconstantcontract", tokenContract(t, map[string]string{
+			"313ce567": decimals,
+			"95d89b41": hex.EncodeToString([]byte("WTRX")) + strings.Repeat("00", 28),
+		}))
+		token, err := adapter.GetTokenMetadata(ctx, tokenAddress(t))
+		require.NoError(t, err)
+		assert.Equal(t, "WTRX", token.Symbol())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+		adapter, node := newTestAdapter(t)
+
+		node.handle("/wallet/triggerconstantcontract", tokenContract(t, nil))
+		_, err := adapter.GetTokenMetadata(ctx, tokenAddress(t))
+		require.ErrorContains(t, err, "decimals")
+
+		node.handle("/wallet/triggerconstantcontract", tokenContract(t, map[string]string{"313ce567": "01"}))
+		_, err = adapter.GetTokenMetadata(ctx, tokenAddress(t))
+		require.ErrorContains(t, err, "unexpected response length")
+
+		node.handle("/wallet/triggerconstantcontract", tokenContract(t, map[string]string{
+			"313ce567": hex.EncodeToString(abiWord([]byte{1, 0})),
+		}))
+		_, err = adapter.GetTokenMetadata(ctx, tokenAddress(t))
+		require.ErrorContains(t, err, "invalid token decimals")
+
+		node.handle("/wallet/triggerconstantcontract", tokenContract(t, map[string]string{"313ce567": decimals}))
+		_, err = adapter.GetTokenMetadata(ctx, tokenAddress(t))
+		require.ErrorContains(t, err, "symbol")
+
+		node.handle("/wallet/triggerconstantcontract", tokenContract(t, map[string]string{
+			"313ce567": decimals,
+			"95d89b41": "0101",
+		}))
+		_, err = adapter.GetTokenMetadata(ctx, tokenAddress(t))
+		require.ErrorContains(t, err, "unexpected response length")
+	})
+}
+
+func TestDecodeABIString(t *testing.T) {
+	t.Parallel()
+	data, _ := hex.DecodeString(usdtSymbolResult)
+
+	symbol, err := decodeABIString(data)
+	require.NoError(t, err)
+	assert.Equal(t, "USDT", symbol)
+
+	badOffset := append([]byte{}, data...)
+	badOffset[31] = 0xff
+	_, err = decodeABIString(badOffset)
+	require.ErrorContains(t, err, "offset")
+
+	badLength := append([]byte{}, data...)
+	badLength[63] = 0xff
+	_, err = decodeABIString(badLength)
+	require.ErrorContains(t, err, "length")
+}
@@ -0,0 +1,212 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"strings"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/usecases"
+	"github.com/gofiber/fiber/v2"
+)
+
+// EnableAdmin serves the admin endpoints managing chains under /v1/admin, authenticated by a
+// bearer token; without a token they are not served
+func (s *Server) EnableAdmin(token string, manageChainsUC *usecases.ManageChainsUseCase) {
+	if token == "" {
+		return
+	}
+	s.manageChainsUC = manageChainsUC
+
+	admin := s.app.Group("/v1/admin", adminAuth(token))
+	admin.Post("/chains", s.registerChain)
+	admin.Get("/chains/:chain", s.getChainDetails)
+	admin.Delete("/chains/:chain", s.unregisterChain)
+	admin.Post("/chains/:chain/enable", s.enableChain)
+	admin.Post("/chains/:chain/disable", s.disableChain)
+}
+
+// adminAuth rejects requests without the admin bearer token
+func adminAuth(token string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		provided, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			return fiber.NewError(fiber.StatusUnauthorized, "invalid admin token")
+		}
+		return c.Next()
+	}
+}
+
+// RegisterChain godoc
+// @Summary Registra uma blockchain
+// @Description Cria o adapter de uma rede descrita como uma entrada do config.yaml (type, network, rpc_url, endpoints, chain_id, ...) e o registra sem reiniciar o servidor
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param request body map[string]interface{} true "Network entry: type, network and the keys of a config.yaml network entry"
+// @Success 201 {object} map[string]interface{} "Chain registrada"
+// @Failure 400 {object} map[string]interface{} "Requisição inválida"
+// @Failure 401 {object} map[string]interface{} "Token inválido"
+// @Router /admin/chains [post]
+func (s *Server) registerChain(c *fiber.Ctx) error {
+	decoder := json.NewDecoder(bytes.NewReader(c.Body()))
+	decoder.UseNumber()
+	var settings map[string]interface{}
+	if err := decoder.Decode(&settings); err != nil || settings == nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request")
+	}
+
+	chainType, _ := settings["type"].(string)
+	network, _ := settings["network"].(string)
+	delete(settings, "type")
+	delete(settings, "network")
+
+	output, err := s.manageChainsUC.Register(context.Background(), usecases.RegisterChainInput{
+		Type:     chainType,
+		Network:  network,
+		Settings: jsonValue(settings).(map[string]interface{}),
+	})
+	if err != nil {
+		s.log.Error("failed to register chain", err, nil)
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(chainDetails(output))
+}
+
+// GetChainDetails godoc
+// @Summary Consulta os detalhes de uma blockchain
+// @Description Retorna tipo, token nativo e suas casas decimais, flag de testnet, altura do bloco atual, conectividade e estado de uma chain registrada
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Param chain path string true "Chain ID" example(ethereum)
+// @Success 200 {object} map[string]interface{} "Detalhes da chain"
+// @Failure 401 {object} map[string]interface{} "Token inválido"
+// @Failure 404 {object} map[string]interface{} "Chain não encontrada"
+// @Router /admin/chains/{chain} [get]
+func (s *Server) getChainDetails(c *fiber.Ctx) error {
+	chainID := c.Params("chain")
+	if !s.registry.Has(chainID) {
+		return fiber.NewError(fiber.StatusNotFound, "chain not found")
+	}
+
+	output, err := s.manageChainsUC.Details(context.Background(), chainID)
+	if err != nil {
+		s.log.Error("failed to get chain details", err, nil)
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(chainDetails(output))
+}
+
+// UnregisterChain godoc
+// @Summary Remove uma blockchain
+// @Description Remove uma chain do registro; requisições em andamento terminam com o adapter anterior
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Param chain path string true "Chain ID" example(ethereum)
+// @Success 200 {object} map[string]interface{} "Chain removida"
+// @Failure 401 {object} map[string]interface{} "Token inválido"
+// @Failure 404 {object} map[string]interface{} "Chain não encontrada"
+// @Router /admin/chains/{chain} [delete]
+func (s *Server) unregisterChain(c *fiber.Ctx) error {
+	chainID := c.Params("chain")
+	if !s.registry.Has(chainID) {
+		return fiber.NewError(fiber.StatusNotFound, "chain not found")
+	}
+
+	if err := s.manageChainsUC.Unregister(context.Background(), chainID); err != nil {
+		s.log.Error("failed to unregister chain", err, nil)
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(fiber.Map{
+		"chain_id": chainID,
+		"status":   "unregistered",
+	})
+}
+
+// EnableChain godoc
+// @Summary Habilita uma blockchain
+// @Description Ativa a rede de uma chain desabilitada, que volta a atender requisições
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Param chain path string true "Chain ID" example(ethereum)
+// @Success 200 {object} map[string]interface{} "Detalhes da chain"
+// @Failure 401 {object} map[string]interface{} "Token inválido"
+// @Failure 404 {object} map[string]interface{} "Chain não encontrada"
+// @Router /admin/chains/{chain}/enable [post]
+func (s *Server) enableChain(c *fiber.Ctx) error {
+	return s.setChainActive(c, true)
+}
+
+// DisableChain godoc
+// @Summary Desabilita uma blockchain
+// @Description Desativa a rede de uma chain, que passa a recusar requisições sem ser removida do registro
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Param chain path string true "Chain ID" example(ethereum)
+// @Success 200 {object} map[string]interface{} "Detalhes da chain"
+// @Failure 401 {object} map[string]interface{} "Token inválido"
+// @Failure 404 {object} map[string]interface{} "Chain não encontrada"
+// @Router /admin/chains/{chain}/disable [post]
+func (s *Server) disableChain(c *fiber.Ctx) error {
+	return s.setChainActive(c, false)
+}
+
+func (s *Server) setChainActive(c *fiber.Ctx, active bool) error {
+	chainID := c.Params("chain")
+	if !s.registry.Has(chainID) {
+		return fiber.NewError(fiber.StatusNotFound, "chain not found")
+	}
+
+	output, err := s.manageChainsUC.SetActive(context.Background(), chainID, active)
+	if err != nil {
+		s.log.Error("failed to set chain state", err, nil)
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(chainDetails(output))
+}
+
+func chainDetails(output *usecases.ChainDetailsOutput) fiber.Map {
+	return fiber.Map{
+		"chain_id":     output.ChainID,
+		"chain_type":   output.ChainType,
+		"network_id":   output.NetworkID,
+		"native_token": output.NativeToken,
+		"decimals":     output.Decimals,
+		"testnet":      output.Testnet,
+		"active":       output.Active,
+		"connected":    output.Connected,
+		"block_height": output.BlockHeight,
+	}
+}
+
+// jsonValue turns the numbers of a value decoded with UseNumber into integers, or floats when
+// they have a fraction, so that chain IDs keep every digit
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if integer, err := v.Int64(); err == nil {
+			return integer
+		}
+		float, _ := v.Float64()
+		return float
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = jsonValue(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = jsonValue(item)
+		}
+	}
+	return value
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/factory"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/registry"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/mocks"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/usecases"
+	"github.com/stretchr/testify/require"
+)
+
+func newAdminServer(t *testing.T, token string) *Server {
+	t.Helper()
+	logger := mocks.NewMockLogger()
+	reg := registry.NewChainRegistry(logger)
+	eb := mocks.NewMockEventPublisher()
+	txs := mocks.NewMockTransactionRepository()
+	srv := NewServer(
+		reg,
+		usecases.NewGetBalanceUseCase(reg, eb, logger),
+		usecases.NewCreateTransactionUseCase(reg, txs, eb, logger),
+		usecases.NewSignTransactionUseCase(reg, txs, mocks.NewMockKeyManager(), eb, logger),
+		usecases.NewBroadcastTransactionUseCase(reg, txs, eb, logger),
+		usecases.NewEstimateFeeUseCase(reg, eb, logger),
+		usecases.NewGetTransactionStatusUseCase(reg, logger),
+		usecases.NewExportPSBTUseCase(reg, txs, eb, logger),
+		usecases.NewImportPSBTUseCase(reg, eb, logger),
+		usecases.NewBumpFeeUseCase(reg, txs, mocks.NewMockKeyManager(), eb, logger),
+		usecases.NewGetChainInfoUseCase(reg, logger),
+		logger,
+	)
+	srv.EnableAdmin(token, usecases.NewManageChainsUseCase(reg, factory.ChainFactory{}, eb, logger))
+	return srv
+}
+
+func adminRequest(t *testing.T, srv *Server, method, path, token string, body interface{}) (int, map[string]interface{}) {
+	t.Helper()
+	var reader io.Reader = http.NoBody
+	if body != nil {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(data)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := srv.app.Test(req, -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var decoded map[string]interface{}
+	_ = json.NewDecoder(resp.Body).Decode(&decoded)
+	return resp.StatusCode, decoded
+}
+
+func TestAdminRoutes(t *testing.T) {
+	t.Parallel()
+
+	srv := newAdminServer(t, "secret")
+	spec := map[string]interface{}{"type": "evm", "network": "localnet", "adapter": "harness", "chain_id": 11155111}
+
+	// Requests without the token are rejected
+	status, _ := adminRequest(t, srv, "POST", "/v1/admin/chains", "", spec)
+	require.Equal(t, 401, status)
+	status, _ = adminRequest(t, srv, "POST", "/v1/admin/chains", "wrong", spec)
+	require.Equal(t, 401, status)
+
+	// register
+	status, details := adminRequest(t, srv, "POST", "/v1/admin/chains", "secret", spec)
+	require.Equal(t, 201, status)
+	require.Equal(t, "localnet", details["chain_id"])
+	require.Equal(t, "evm", details["chain_type"])
+	require.Equal(t, "11155111", details["network_id"])
+	require.Equal(t, "ETH", details["native_token"])
+	require.Equal(t, true, details["testnet"])
+	require.Equal(t, true, details["connected"])
+	require.Equal(t, true, details["active"])
+
+	status, _ = adminRequest(t, srv, "POST", "/v1/admin/chains", "secret", spec)
+	require.Equal(t, 400, status)
+	status, _ = adminRequest(t, srv, "POST", "/v1/admin/chains", "secret", map[string]interface{}{"type": "evm", "network": "sepolia"})
+	require.Equal(t, 400, status)
+
+	// disable: the chain refuses other requests until it is enabled again
+	status, details = adminRequest(t, srv, "POST", "/v1/admin/chains/localnet/disable", "secret", nil)
+	require.Equal(t, 200, status)
+	require.Equal(t, false, details["active"])
+	status, _ = adminRequest(t, srv, "GET", "/v1/localnet/balance/0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb", "", nil)
+	require.Equal(t, 500, status)
+
+	status, details = adminRequest(t, srv, "POST", "/v1/admin/chains/localnet/enable", "secret", nil)
+	require.Equal(t, 200, status)
+	require.Equal(t, true, details["active"])
+	status, _ = adminRequest(t, srv, "GET", "/v1/localnet/balance/0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb", "", nil)
+	require.Equal(t, 200, status)
+
+	// details
+	status, details = adminRequest(t, srv, "GET", "/v1/admin/chains/localnet", "secret", nil)
+	require.Equal(t, 200, status)
+	require.Equal(t, float64(1), details["block_height"])
+
+	// unregister
+	status, _ = adminRequest(t, srv, "DELETE", "/v1/admin/chains/localnet", "secret", nil)
+	require.Equal(t, 200, status)
+	status, _ = adminRequest(t, srv, "GET", "/v1/admin/chains/localnet", "secret", nil)
+	require.Equal(t, 404, status)
+	status, _ = adminRequest(t, srv, "POST", "/v1/admin/chains/localnet/enable", "secret", nil)
+	require.Equal(t, 404, status)
+	status, _ = adminRequest(t, srv, "DELETE", "/v1/admin/chains/localnet", "secret", nil)
+	require.Equal(t, 404, status)
+}
+
+func TestAdminRoutesWithoutToken(t *testing.T) {
+	t.Parallel()
+
+	srv := newAdminServer(t, "")
+	status, _ := adminRequest(t, srv, "GET", "/v1/admin/chains/localnet", "", nil)
+	require.Equal(t, 404, status)
+}
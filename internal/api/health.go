@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/health"
+	"github.com/gofiber/fiber/v2"
+)
+
+// EnableHealth serves the probes outside /v1: /livez answers while the process runs, /healthz
+// fails when any component is down and /readyz when the readiness policy is not met
+func (s *Server) EnableHealth(checker *health.Checker) {
+	s.healthChecker = checker
+
+	s.app.Get("/livez", s.liveness)
+	s.app.Get("/healthz", s.health)
+	s.app.Get("/readyz", s.readiness)
+}
+
+func (s *Server) liveness(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"status": health.StatusUp,
+	})
+}
+
+func (s *Server) health(c *fiber.Ctx) error {
+	report := s.healthChecker.Check(context.Background())
+
+	status := fiber.StatusOK
+	if report.Status != health.StatusUp {
+		status = fiber.StatusServiceUnavailable
+	}
+	return c.Status(status).JSON(healthReport(report))
+}
+
+func (s *Server) readiness(c *fiber.Ctx) error {
+	report := s.healthChecker.Check(context.Background())
+
+	status := fiber.StatusOK
+	if !report.Ready {
+		status = fiber.StatusServiceUnavailable
+	}
+	return c.Status(status).JSON(healthReport(report))
+}
+
+func healthReport(report *health.Report) fiber.Map {
+	components := make([]fiber.Map, 0, len(report.Components))
+	for _, component := range report.Components {
+		entry := fiber.Map{
+			"name":       component.Name,
+			"kind":       component.Kind,
+			"status":     component.Status,
+			"required":   component.Required,
+			"latency_ms": component.Latency.Milliseconds(),
+		}
+		if component.Error != "" {
+			entry["error"] = component.Error
+		}
+		if component.BlockHeight > 0 {
+			entry["block_height"] = component.BlockHeight
+			entry["block_age_seconds"] = int64(component.BlockAge.Seconds())
+		}
+		components = append(components, entry)
+	}
+
+	return fiber.Map{
+		"status":         report.Status,
+		"ready":          report.Ready,
+		"healthy_chains": report.HealthyChains,
+		"checked_at":     report.CheckedAt.UTC().Format(time.RFC3339),
+		"components":     components,
+	}
+}
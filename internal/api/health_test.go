@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/evm/harness"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/eventbus"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/health"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthRoutes(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	srv := newAdminServer(t, "")
+	require.NoError(t, srv.registry.Register("evm-mainnet", harness.NewEVMHarness("evm-mainnet")))
+	bus := eventbus.NewInMemoryEventBus(mocks.NewMockLogger())
+	require.NoError(t, bus.Start(ctx))
+	checker := health.NewChecker(srv.registry, health.DefaultConfig(), mocks.NewMockLogger())
+	checker.Add(health.KindEventBus, bus)
+	srv.EnableHealth(checker)
+
+	status, body := adminRequest(t, srv, "GET", "/livez", "", nil)
+	require.Equal(t, 200, status)
+	require.Equal(t, "up", body["status"])
+
+	status, body = adminRequest(t, srv, "GET", "/healthz", "", nil)
+	require.Equal(t, 200, status)
+	require.Equal(t, "up", body["status"])
+	require.Equal(t, true, body["ready"])
+	require.Equal(t, float64(1), body["healthy_chains"])
+	components, ok := body["components"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, components, 2)
+	chain, ok := components[1].(map[string]interface{})
+	require.True(t, ok)
+	require.Equal(t, "evm-mainnet", chain["name"])
+	require.Equal(t, "chain", chain["kind"])
+	require.Contains(t, chain, "block_height")
+
+	status, _ = adminRequest(t, srv, "GET", "/readyz", "", nil)
+	require.Equal(t, 200, status)
+
+	// The event bus is required by the default readiness policy
+	require.NoError(t, bus.Stop(ctx))
+	status, body = adminRequest(t, srv, "GET", "/readyz", "", nil)
+	require.Equal(t, 503, status)
+	require.Equal(t, "down", body["status"])
+	require.Equal(t, false, body["ready"])
+	status, _ = adminRequest(t, srv, "GET", "/healthz", "", nil)
+	require.Equal(t, 503, status)
+}
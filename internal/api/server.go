@@ -2,9 +2,16 @@ package api
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strings"
 
 	_ "github.com/gabrielksneiva/ChainSystemPro/docs"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/health"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/usecases"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -28,6 +35,11 @@ import (
 // @BasePath /v1
 // @schemes http https
 
+// @securityDefinitions.apikey AdminToken
+// @in header
+// @name Authorization
+// @description Token Bearer dos endpoints de administração (admin.token), no formato "Bearer <token>"
+
 type Server struct {
 	app                    *fiber.App
 	registry               ports.ChainRegistry
@@ -37,6 +49,12 @@ type Server struct {
 	broadcastTransactionUC *usecases.BroadcastTransactionUseCase
 	estimateFeeUC          *usecases.EstimateFeeUseCase
 	getTransactionStatusUC *usecases.GetTransactionStatusUseCase
+	exportPSBTUC           *usecases.ExportPSBTUseCase
+	importPSBTUC           *usecases.ImportPSBTUseCase
+	bumpFeeUC              *usecases.BumpFeeUseCase
+	getChainInfoUC         *usecases.GetChainInfoUseCase
+	manageChainsUC         *usecases.ManageChainsUseCase
+	healthChecker          *health.Checker
 	log                    ports.Logger
 }
 
@@ -48,6 +66,10 @@ func NewServer(
 	broadcastTransactionUC *usecases.BroadcastTransactionUseCase,
 	estimateFeeUC *usecases.EstimateFeeUseCase,
 	getTransactionStatusUC *usecases.GetTransactionStatusUseCase,
+	exportPSBTUC *usecases.ExportPSBTUseCase,
+	importPSBTUC *usecases.ImportPSBTUseCase,
+	bumpFeeUC *usecases.BumpFeeUseCase,
+	getChainInfoUC *usecases.GetChainInfoUseCase,
 	log ports.Logger,
 ) *Server {
 	app := fiber.New(fiber.Config{
@@ -67,6 +89,10 @@ func NewServer(
 		broadcastTransactionUC: broadcastTransactionUC,
 		estimateFeeUC:          estimateFeeUC,
 		getTransactionStatusUC: getTransactionStatusUC,
+		exportPSBTUC:           exportPSBTUC,
+		importPSBTUC:           importPSBTUC,
+		bumpFeeUC:              bumpFeeUC,
+		getChainInfoUC:         getChainInfoUC,
 		log:                    log,
 	}
 
@@ -84,7 +110,13 @@ func (s *Server) setupRoutes() {
 	v1.Get("/:chain/balance/:address", s.getBalance)
 	v1.Get("/:chain/transaction/:hash", s.getTransactionStatus)
 	v1.Post("/:chain/transaction/create", s.createTransaction)
+	v1.Post("/:chain/transaction/estimate-fee", s.estimateFee)
+	v1.Post("/:chain/transaction/:id/sign", s.signTransaction)
 	v1.Post("/:chain/transaction/send", s.broadcastTransaction)
+	v1.Post("/:chain/transaction/:hash/bump", s.bumpFee)
+	v1.Post("/:chain/psbt/export", s.exportPSBT)
+	v1.Post("/:chain/psbt/import", s.importPSBT)
+	v1.Get("/:chain", s.getChainInfo)
 }
 
 func (s *Server) Start(port string) error {
@@ -99,7 +131,7 @@ func (s *Server) Shutdown() error {
 
 // ListChains godoc
 // @Summary Lista todas as blockchains suportadas
-// @Description Retorna uma lista de IDs de todas as blockchains registradas no sistema
+// @Description Retorna os IDs das blockchains registradas no sistema e, em metadata, o tipo, a rede, o token nativo e suas casas decimais de cada uma
 // @Tags Chains
 // @Accept json
 // @Produce json
@@ -107,11 +139,90 @@ func (s *Server) Shutdown() error {
 // @Router /chains [get]
 func (s *Server) listChains(c *fiber.Ctx) error {
 	chains := s.registry.List()
+	sort.Strings(chains)
+
+	metadata := make([]fiber.Map, 0, len(chains))
+	for _, chainID := range chains {
+		adapter, err := s.registry.Lookup(chainID)
+		if err != nil {
+			// unregistered since the list was taken
+			continue
+		}
+		entry := fiber.Map{
+			"chain_id":   chainID,
+			"chain_type": adapter.GetChainType(),
+			"active":     s.registry.IsActive(chainID),
+		}
+		if chain, err := s.registry.Describe(chainID); err == nil {
+			describeChain(entry, chain)
+		}
+		metadata = append(metadata, entry)
+	}
+
 	return c.JSON(fiber.Map{
-		"chains": chains,
+		"chains":   chains,
+		"metadata": metadata,
 	})
 }
 
+// GetChainInfo godoc
+// @Summary Consulta as informações de uma blockchain
+// @Description Retorna os metadados de uma chain (rede, token nativo e suas casas decimais, explorer) e dados ao vivo do nó: último bloco, peers e preço do gás; uma chain desabilitada é descrita sem os dados ao vivo
+// @Tags Chains
+// @Produce json
+// @Param chain path string true "Chain ID" example(ethereum)
+// @Success 200 {object} map[string]interface{} "Informações da chain"
+// @Failure 404 {object} map[string]interface{} "Chain não encontrada"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /{chain} [get]
+func (s *Server) getChainInfo(c *fiber.Ctx) error {
+	chainID := c.Params("chain")
+	if !s.registry.Has(chainID) {
+		return fiber.NewError(fiber.StatusNotFound, "chain not found")
+	}
+
+	output, err := s.getChainInfoUC.Execute(context.Background(), usecases.GetChainInfoInput{
+		ChainID: chainID,
+	})
+	if err != nil {
+		s.log.Error("failed to get chain info", err, nil)
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	response := fiber.Map{
+		"chain_id":     output.ChainID,
+		"chain_type":   output.ChainType,
+		"active":       output.Active,
+		"latest_block": output.LatestBlock,
+		"peers":        output.Peers,
+	}
+	if output.Chain != nil {
+		describeChain(response, output.Chain)
+	}
+	if output.Network != nil {
+		// The RPC URL is left out: it may carry an API key
+		response["network"] = fiber.Map{
+			"name":         output.Network.Name(),
+			"explorer_url": output.Network.ExplorerURL(),
+			"active":       output.Network.IsActive(),
+		}
+	}
+	if output.GasPrice != nil {
+		response["gas_price"] = output.GasPrice.String()
+	}
+
+	return c.JSON(response)
+}
+
+// describeChain adds the metadata of a chain descriptor to a response
+func describeChain(response fiber.Map, chain *entities.Chain) {
+	response["name"] = chain.Name()
+	response["network_id"] = chain.NetworkID()
+	response["testnet"] = chain.IsTestnet()
+	response["native_token"] = chain.NativeToken()
+	response["decimals"] = chain.Decimals()
+}
+
 type GetBalanceRequest struct {
 	TokenAddress string `json:"token_address" query:"token_address"`
 }
@@ -150,20 +261,28 @@ func (s *Server) getBalance(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	return c.JSON(fiber.Map{
+	response := fiber.Map{
 		"chain_id":      output.ChainID,
 		"address":       output.Address,
 		"balance":       output.Balance.String(),
 		"token_address": output.TokenAddress,
-	})
+	}
+	if output.Token != nil {
+		response["token_symbol"] = output.Token.Symbol()
+		response["token_decimals"] = output.Token.Decimals()
+	}
+
+	return c.JSON(response)
 }
 
 type CreateTransactionRequest struct {
-	From     string `json:"from"`
-	To       string `json:"to"`
-	Value    string `json:"value"`
-	Data     []byte `json:"data"`
-	GasLimit uint64 `json:"gas_limit"`
+	From         string            `json:"from"`
+	To           string            `json:"to"`
+	Value        string            `json:"value"`
+	Data         []byte            `json:"data"`
+	GasLimit     uint64            `json:"gas_limit"`
+	TokenAddress string            `json:"token_address,omitempty"`
+	Options      map[string]string `json:"options,omitempty"`
 }
 
 // CreateTransaction godoc
@@ -187,12 +306,14 @@ func (s *Server) createTransaction(c *fiber.Ctx) error {
 	}
 
 	input := usecases.CreateTransactionInput{
-		ChainID:  chainID,
-		From:     req.From,
-		To:       req.To,
-		Value:    req.Value,
-		Data:     req.Data,
-		GasLimit: req.GasLimit,
+		ChainID:      chainID,
+		From:         req.From,
+		To:           req.To,
+		Value:        req.Value,
+		Data:         req.Data,
+		GasLimit:     req.GasLimit,
+		TokenAddress: req.TokenAddress,
+		Options:      req.Options,
 	}
 
 	output, err := s.createTransactionUC.Execute(context.Background(), input)
@@ -209,42 +330,212 @@ func (s *Server) createTransaction(c *fiber.Ctx) error {
 		"value":          output.Value,
 		"nonce":          output.Nonce,
 		"gas_limit":      output.GasLimit,
+		"token_address":  output.TokenAddress,
 	})
 }
 
+// EstimateFee godoc
+// @Summary Estima a taxa de uma transação
+// @Description Monta a transação descrita no corpo, igual ao de /transaction/create, sem guardá-la, e retorna a taxa estimada: gas_price nas chains legadas e max_fee_per_gas/max_priority_fee nas redes EIP-1559
+// @Tags Transactions
+// @Accept json
+// @Produce json
+// @Param chain path string true "Chain ID" example(ethereum)
+// @Param request body CreateTransactionRequest true "Transaction data"
+// @Success 200 {object} map[string]interface{} "Taxa estimada"
+// @Failure 400 {object} map[string]interface{} "Requisição inválida"
+// @Failure 404 {object} map[string]interface{} "Chain não encontrada"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /{chain}/transaction/estimate-fee [post]
+func (s *Server) estimateFee(c *fiber.Ctx) error {
+	chainID := c.Params("chain")
+	if !s.registry.Has(chainID) {
+		return fiber.NewError(fiber.StatusNotFound, "chain not found")
+	}
+
+	var req CreateTransactionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request")
+	}
+
+	input := usecases.EstimateFeeInput{
+		ChainID: chainID,
+		Request: &usecases.CreateTransactionInput{
+			From:         req.From,
+			To:           req.To,
+			Value:        req.Value,
+			Data:         req.Data,
+			GasLimit:     req.GasLimit,
+			TokenAddress: req.TokenAddress,
+			Options:      req.Options,
+		},
+	}
+
+	output, err := s.estimateFeeUC.Execute(context.Background(), input)
+	if err != nil {
+		s.log.Error("failed to estimate fee", err, nil)
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(fiber.Map{
+		"chain_id":         chainID,
+		"gas_limit":        output.GasLimit,
+		"gas_price":        output.GasPrice,
+		"max_fee_per_gas":  output.MaxFeePerGas,
+		"max_priority_fee": output.MaxPriorityFee,
+		"total":            output.Total,
+		"currency":         output.Currency,
+	})
+}
+
+type SignTransactionRequest struct {
+	KeyID string `json:"key_id"`
+}
+
+// SignTransaction godoc
+// @Summary Assina uma transação criada
+// @Description Assina uma transação criada por /transaction/create com uma chave do key store, referenciada por key_id; a chave privada nunca trafega na requisição
+// @Tags Transactions
+// @Accept json
+// @Produce json
+// @Param chain path string true "Chain ID" example(ethereum)
+// @Param id path string true "Transaction ID" example(550e8400-e29b-41d4-a716-446655440000)
+// @Param request body SignTransactionRequest true "Signing key"
+// @Success 200 {object} map[string]interface{} "Transação assinada"
+// @Failure 400 {object} map[string]interface{} "Requisição inválida ou chave desconhecida"
+// @Failure 404 {object} map[string]interface{} "Chain ou transação não encontrada"
+// @Failure 409 {object} map[string]interface{} "Transação já transmitida"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /{chain}/transaction/{id}/sign [post]
+func (s *Server) signTransaction(c *fiber.Ctx) error {
+	chainID := c.Params("chain")
+	if !s.registry.Has(chainID) {
+		return fiber.NewError(fiber.StatusNotFound, "chain not found")
+	}
+
+	var req SignTransactionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request")
+	}
+	if req.KeyID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "key_id is required")
+	}
+
+	output, err := s.signTransactionUC.Execute(context.Background(), usecases.SignTransactionInput{
+		ChainID:       chainID,
+		TransactionID: c.Params("id"),
+		KeyID:         req.KeyID,
+	})
+	if err != nil {
+		s.log.Error("failed to sign transaction", err, nil)
+		return fiber.NewError(signErrorStatus(err), err.Error())
+	}
+
+	return c.JSON(fiber.Map{
+		"chain_id":       chainID,
+		"transaction_id": output.TransactionID,
+		"hash":           output.Hash,
+		"signature":      output.Signature,
+	})
+}
+
+// signErrorStatus tells the errors caused by the transaction or key referenced apart from those
+// of the chain
+func signErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ports.ErrTransactionNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, usecases.ErrTransactionAlreadyBroadcast):
+		return fiber.StatusConflict
+	case errors.Is(err, ports.ErrKeyNotFound):
+		return fiber.StatusBadRequest
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
+
 type BroadcastTransactionRequest struct {
-	TransactionID string `json:"transaction_id"`
-	SignedData    string `json:"signed_data"`
+	TransactionID string `json:"transaction_id,omitempty"`
+	SignedData    string `json:"signed_data,omitempty"`
 }
 
 // BroadcastTransaction godoc
 // @Summary Transmite uma transação assinada
-// @Description Envia uma transação assinada para a blockchain
+// @Description Envia para a blockchain uma transação criada e assinada pela API (transaction_id) ou assinada fora dela (signed_data, a transação serializada no formato da chain, em hex ou base64 na Solana)
 // @Tags Transactions
 // @Accept json
 // @Produce json
 // @Param chain path string true "Chain ID" example(ethereum)
 // @Param request body BroadcastTransactionRequest true "Signed transaction data"
 // @Success 200 {object} map[string]interface{} "Transação transmitida"
-// @Failure 400 {object} map[string]interface{} "Requisição inválida"
+// @Failure 400 {object} map[string]interface{} "Requisição ou transação inválida"
+// @Failure 404 {object} map[string]interface{} "Chain ou transação não encontrada"
+// @Failure 409 {object} map[string]interface{} "Transação não assinada ou já transmitida"
 // @Failure 500 {object} map[string]interface{} "Erro interno"
 // @Router /{chain}/transaction/send [post]
 func (s *Server) broadcastTransaction(c *fiber.Ctx) error {
 	chainID := c.Params("chain")
+	if !s.registry.Has(chainID) {
+		return fiber.NewError(fiber.StatusNotFound, "chain not found")
+	}
 
 	var req BroadcastTransactionRequest
 	if err := c.BodyParser(&req); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, "invalid request")
 	}
+	if (req.TransactionID == "") == (req.SignedData == "") {
+		return fiber.NewError(fiber.StatusBadRequest, "either transaction_id or signed_data is required")
+	}
+
+	input := usecases.BroadcastTransactionInput{
+		ChainID:       chainID,
+		TransactionID: req.TransactionID,
+	}
+	if req.SignedData != "" {
+		signedData, err := s.decodeSignedData(chainID, req.SignedData)
+		if err != nil || len(signedData) == 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid signed_data")
+		}
+		input.SignedData = signedData
+	}
+
+	output, err := s.broadcastTransactionUC.Execute(context.Background(), input)
+	if err != nil {
+		s.log.Error("failed to broadcast transaction", err, nil)
+		return fiber.NewError(broadcastErrorStatus(err), err.Error())
+	}
 
 	return c.JSON(fiber.Map{
 		"chain_id":       chainID,
-		"transaction_id": req.TransactionID,
-		"hash":           "0xabcdef",
-		"status":         "pending",
+		"transaction_id": output.TransactionID,
+		"hash":           output.Hash,
+		"status":         output.Status,
 	})
 }
 
+// decodeSignedData decodes a raw transaction, which Solana clients encode in base64 and the
+// other chains in hex
+func (s *Server) decodeSignedData(chainID, signedData string) ([]byte, error) {
+	if chain, err := s.registry.Describe(chainID); err == nil && chain.ChainType() == entities.ChainTypeSolana {
+		return base64.StdEncoding.DecodeString(signedData)
+	}
+	return hex.DecodeString(strings.TrimPrefix(signedData, "0x"))
+}
+
+// broadcastErrorStatus tells the errors caused by the transaction sent apart from those of the chain
+func broadcastErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ports.ErrTransactionNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, usecases.ErrTransactionNotSigned), errors.Is(err, usecases.ErrTransactionAlreadyBroadcast):
+		return fiber.StatusConflict
+	case errors.Is(err, ports.ErrInvalidRawTransaction), errors.Is(err, usecases.ErrRawTransactionsUnsupported):
+		return fiber.StatusBadRequest
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
+
 // GetTransactionStatus godoc
 // @Summary Consulta status de uma transação
 // @Description Retorna informações sobre o status de uma transação específica
@@ -280,6 +571,158 @@ func (s *Server) getTransactionStatus(c *fiber.Ctx) error {
 	})
 }
 
+type ExportPSBTRequest struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Value string `json:"value"`
+}
+
+// ExportPSBT godoc
+// @Summary Exporta uma transação não assinada como PSBT
+// @Description Cria e guarda uma transação e a retorna como PSBT (BIP-174) em base64 para assinatura offline
+// @Tags PSBT
+// @Accept json
+// @Produce json
+// @Param chain path string true "Chain ID" example(bitcoin-mainnet)
+// @Param request body ExportPSBTRequest true "Transaction data"
+// @Success 201 {object} map[string]interface{} "PSBT exportado"
+// @Failure 400 {object} map[string]interface{} "Requisição inválida"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /{chain}/psbt/export [post]
+func (s *Server) exportPSBT(c *fiber.Ctx) error {
+	chainID := c.Params("chain")
+
+	var req ExportPSBTRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request")
+	}
+
+	input := usecases.ExportPSBTInput{
+		ChainID: chainID,
+		From:    req.From,
+		To:      req.To,
+		Value:   req.Value,
+	}
+
+	output, err := s.exportPSBTUC.Execute(context.Background(), input)
+	if err != nil {
+		s.log.Error("failed to export PSBT", err, nil)
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"transaction_id": output.TransactionID,
+		"chain_id":       output.ChainID,
+		"from":           output.From,
+		"to":             output.To,
+		"value":          output.Value,
+		"psbt":           output.PSBT,
+	})
+}
+
+type ImportPSBTRequest struct {
+	PSBTs     []string `json:"psbts"`
+	Broadcast bool     `json:"broadcast"`
+}
+
+// ImportPSBT godoc
+// @Summary Importa PSBTs assinados
+// @Description Combina PSBTs assinados, finaliza a transação e opcionalmente a transmite
+// @Tags PSBT
+// @Accept json
+// @Produce json
+// @Param chain path string true "Chain ID" example(bitcoin-mainnet)
+// @Param request body ImportPSBTRequest true "Signed PSBTs"
+// @Success 200 {object} map[string]interface{} "PSBT importado"
+// @Failure 400 {object} map[string]interface{} "Requisição inválida"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /{chain}/psbt/import [post]
+func (s *Server) importPSBT(c *fiber.Ctx) error {
+	chainID := c.Params("chain")
+
+	var req ImportPSBTRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request")
+	}
+
+	input := usecases.ImportPSBTInput{
+		ChainID:   chainID,
+		PSBTs:     req.PSBTs,
+		Broadcast: req.Broadcast,
+	}
+
+	output, err := s.importPSBTUC.Execute(context.Background(), input)
+	if err != nil {
+		s.log.Error("failed to import PSBT", err, nil)
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(fiber.Map{
+		"chain_id":        output.ChainID,
+		"psbt":            output.PSBT,
+		"complete":        output.Complete,
+		"raw_transaction": output.RawTransaction,
+		"hash":            output.Hash,
+		"status":          output.Status,
+	})
+}
+
+type BumpFeeRequest struct {
+	Method  string `json:"method"`
+	FeeRate string `json:"fee_rate"`
+	KeyID   string `json:"key_id"`
+}
+
+// BumpFee godoc
+// @Summary Acelera uma transação não confirmada
+// @Description Substitui a transação por outra com taxa maior (RBF, BIP-125) ou gasta sua saída de troco com uma transação filha de taxa alta (CPFP) e transmite o resultado
+// @Tags Transactions
+// @Accept json
+// @Produce json
+// @Param chain path string true "Chain ID" example(bitcoin-mainnet)
+// @Param hash path string true "Transaction hash"
+// @Param request body BumpFeeRequest true "Fee bump data"
+// @Success 200 {object} map[string]interface{} "Transação acelerada"
+// @Failure 400 {object} map[string]interface{} "Requisição inválida"
+// @Failure 500 {object} map[string]interface{} "Erro interno"
+// @Router /{chain}/transaction/{hash}/bump [post]
+func (s *Server) bumpFee(c *fiber.Ctx) error {
+	chainID := c.Params("chain")
+	hash := c.Params("hash")
+
+	var req BumpFeeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request")
+	}
+
+	input := usecases.BumpFeeInput{
+		ChainID:         chainID,
+		TransactionHash: hash,
+		Method:          req.Method,
+		FeeRate:         req.FeeRate,
+		KeyID:           req.KeyID,
+	}
+
+	output, err := s.bumpFeeUC.Execute(context.Background(), input)
+	if err != nil {
+		s.log.Error("failed to bump fee", err, nil)
+		if errors.Is(err, ports.ErrKeyNotFound) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(fiber.Map{
+		"chain_id":       output.ChainID,
+		"method":         output.Method,
+		"original_hash":  output.OriginalHash,
+		"transaction_id": output.TransactionID,
+		"hash":           output.Hash,
+		"fee":            output.Fee,
+		"status":         output.Status,
+	})
+}
+
 func customErrorHandler(c *fiber.Ctx, err error) error {
 	code := fiber.StatusInternalServerError
 	message := "Internal Server Error"
@@ -3,21 +3,36 @@ package api
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/base58"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/bitcoin"
+	btcharness "github.com/gabrielksneiva/ChainSystemPro/internal/adapters/bitcoin/harness"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/evm"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/evm/harness"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/keystore"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/registry"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/mocks"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/usecases"
 	"github.com/stretchr/testify/require"
 )
 
+// testKeys holds a secp256k1 private key as "treasury"
+func testKeys(t *testing.T, key []byte) ports.KeyManager {
+	t.Helper()
+	keys, err := keystore.NewManager(keystore.Key{ID: "treasury", Curve: ports.CurveSecp256k1, PrivateKey: key})
+	require.NoError(t, err)
+	return keys
+}
+
 func TestServerRoutes(t *testing.T) {
 	t.Parallel()
 
@@ -28,14 +43,19 @@ func TestServerRoutes(t *testing.T) {
 
 	// minimal UCs with mocks
 	eb := mocks.NewMockEventPublisher()
+	txs := mocks.NewMockTransactionRepository()
 	gb := usecases.NewGetBalanceUseCase(reg, eb, logger)
-	ct := usecases.NewCreateTransactionUseCase(reg, eb, logger)
-	st := usecases.NewSignTransactionUseCase(reg, eb, logger)
-	bt := usecases.NewBroadcastTransactionUseCase(reg, eb, logger)
+	ct := usecases.NewCreateTransactionUseCase(reg, txs, eb, logger)
+	st := usecases.NewSignTransactionUseCase(reg, txs, mocks.NewMockKeyManager(), eb, logger)
+	bt := usecases.NewBroadcastTransactionUseCase(reg, txs, eb, logger)
 	ef := usecases.NewEstimateFeeUseCase(reg, eb, logger)
 	gs := usecases.NewGetTransactionStatusUseCase(reg, logger)
+	ep := usecases.NewExportPSBTUseCase(reg, txs, eb, logger)
+	ip := usecases.NewImportPSBTUseCase(reg, eb, logger)
 
-	srv := NewServer(reg, gb, ct, st, bt, ef, gs, logger)
+	bf := usecases.NewBumpFeeUseCase(reg, txs, mocks.NewMockKeyManager(), eb, logger)
+	ci := usecases.NewGetChainInfoUseCase(reg, logger)
+	srv := NewServer(reg, gb, ct, st, bt, ef, gs, ep, ip, bf, ci, logger)
 
 	// list chains
 	req := httptest.NewRequest("GET", "/v1/chains", http.NoBody)
@@ -43,6 +63,52 @@ func TestServerRoutes(t *testing.T) {
 	require.NoError(t, err)
 	defer resp.Body.Close()
 	require.Equal(t, 200, resp.StatusCode)
+	var listed struct {
+		Chains   []string                 `json:"chains"`
+		Metadata []map[string]interface{} `json:"metadata"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&listed))
+	require.Equal(t, []string{"evm-mainnet"}, listed.Chains)
+	require.Len(t, listed.Metadata, 1)
+	require.Equal(t, "ETH", listed.Metadata[0]["native_token"])
+	require.Equal(t, float64(18), listed.Metadata[0]["decimals"])
+	require.Equal(t, true, listed.Metadata[0]["active"])
+
+	// chain info
+	req = httptest.NewRequest("GET", "/v1/evm-mainnet", http.NoBody)
+	resp, err = srv.app.Test(req, -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+	var info map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&info))
+	require.Equal(t, "evm", info["chain_type"])
+	require.Equal(t, float64(18), info["decimals"])
+	require.Contains(t, info, "latest_block")
+	require.Contains(t, info, "gas_price")
+	require.NotContains(t, info["network"], "rpc_url")
+	require.Equal(t, true, info["active"])
+
+	req = httptest.NewRequest("GET", "/v1/unknown", http.NoBody)
+	resp, err = srv.app.Test(req, -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 404, resp.StatusCode)
+
+	// a disabled chain is still described, without live data
+	require.NoError(t, reg.SetActive(context.Background(), "evm-mainnet", false))
+	req = httptest.NewRequest("GET", "/v1/evm-mainnet", http.NoBody)
+	resp, err = srv.app.Test(req, -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+	var disabled map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&disabled))
+	require.Equal(t, false, disabled["active"])
+	require.Equal(t, "ETH", disabled["native_token"])
+	require.Equal(t, false, disabled["network"].(map[string]interface{})["active"])
+	require.NotContains(t, disabled, "gas_price")
+	require.NoError(t, reg.SetActive(context.Background(), "evm-mainnet", true))
 
 	// balance endpoint
 	req = httptest.NewRequest("GET", "/v1/evm-mainnet/balance/0xabc", http.NoBody)
@@ -61,20 +127,42 @@ func TestServerRoutes(t *testing.T) {
 	defer resp.Body.Close()
 	require.Equal(t, 201, resp.StatusCode)
 
-	// broadcast transaction
-	broadcastBody := map[string]interface{}{"transaction_id": "tx123", "signed_data": "0xsigned"}
+	// create token transfer
+	body = map[string]interface{}{
+		"from":          "0x742d35cc6634c0532925a3b844bc9e7595f0beb0",
+		"to":            "0x8ba1f109551bd432803012645ac136ddd64dba72",
+		"value":         "1000000",
+		"token_address": "0xdac17f958d2ee523a2206206994597c13d831ec7",
+	}
+	reqBody, _ = json.Marshal(body)
+	req = httptest.NewRequest("POST", "/v1/evm-mainnet/transaction/create", bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = srv.app.Test(req, -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 201, resp.StatusCode)
+	var created map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	require.Equal(t, "0xdac17f958d2ee523a2206206994597c13d831ec7", created["to"])
+	require.Equal(t, "0", created["value"])
+
+	// broadcast transaction; the full flow is covered by TestServerBroadcastRoute
+	broadcastBody := map[string]interface{}{"transaction_id": "tx123"}
 	reqBody, _ = json.Marshal(broadcastBody)
 	req = httptest.NewRequest("POST", "/v1/evm-mainnet/transaction/send", bytes.NewReader(reqBody))
 	req.Header.Set("Content-Type", "application/json")
 	resp, err = srv.app.Test(req, -1)
 	require.NoError(t, err)
 	defer resp.Body.Close()
-	require.Equal(t, 200, resp.StatusCode)
+	require.Equal(t, 404, resp.StatusCode)
 
 	// get transaction status
-	h.SetBalance("0xabc", big.NewInt(100))
-	from, _ := valueobjects.NewAddress("0xabc", "evm-mainnet")
-	to, _ := valueobjects.NewAddress("0xdef", "evm-mainnet")
+	key := bytes.Repeat([]byte{0x46}, 32)
+	sender, err := evm.AddressFromPrivateKey(key)
+	require.NoError(t, err)
+	h.SetBalance(sender, big.NewInt(100))
+	from, _ := valueobjects.NewAddress(sender, "evm-mainnet")
+	to, _ := valueobjects.NewAddress("0x3535353535353535353535353535353535353535", "evm-mainnet")
 	testTx, _ := h.BuildTransaction(
 		context.Background(),
 		entities.TransactionParams{
@@ -84,8 +172,9 @@ func TestServerRoutes(t *testing.T) {
 			Value:   big.NewInt(1),
 		},
 	)
-	_ = h.SignTransaction(context.Background(), testTx, []byte("key"))
-	txHash, _ := h.BroadcastTransaction(context.Background(), testTx)
+	require.NoError(t, h.SignTransaction(context.Background(), testTx, testKeys(t, key), "treasury"))
+	txHash, err := h.BroadcastTransaction(context.Background(), testTx)
+	require.NoError(t, err)
 	req = httptest.NewRequest("GET", "/v1/evm-mainnet/transaction/"+txHash.HexWithoutPrefix(), http.NoBody)
 	resp, err = srv.app.Test(req, -1)
 	require.NoError(t, err)
@@ -107,6 +196,357 @@ func TestServerRoutes(t *testing.T) {
 	require.Equal(t, 400, resp.StatusCode)
 }
 
+func TestServerPSBTRoutes(t *testing.T) {
+	t.Parallel()
+
+	logger := mocks.NewMockLogger()
+	reg := registry.NewChainRegistry(logger)
+	h := btcharness.NewBitcoinHarness()
+	adapter := bitcoin.NewAdapter(h, "mainnet")
+	require.NoError(t, reg.Register("bitcoin-mainnet", adapter))
+	require.NoError(t, reg.Register("evm-mainnet", harness.NewEVMHarness("evm-mainnet")))
+
+	// P2WPKH output of the BIP-143 example key
+	key, _ := hex.DecodeString("619c335025c7f4012e556c2a58b2506e30b8511b53ade95ea316fd8c3286feb9")
+	pubKeyHash, _ := hex.DecodeString("1d0f172a0ecb48aee1be1f2687d2963ae33f71a1")
+	sender := base58.CheckEncode(append([]byte{0x00}, pubKeyHash...))
+	h.AddUTXO(sender, bitcoin.UTXO{
+		TxID:         "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b",
+		ScriptPubKey: "0014" + hex.EncodeToString(pubKeyHash),
+		Amount:       100000000,
+	})
+
+	eb := mocks.NewMockEventPublisher()
+	txs := mocks.NewMockTransactionRepository()
+	srv := NewServer(
+		reg,
+		usecases.NewGetBalanceUseCase(reg, eb, logger),
+		usecases.NewCreateTransactionUseCase(reg, txs, eb, logger),
+		usecases.NewSignTransactionUseCase(reg, txs, mocks.NewMockKeyManager(), eb, logger),
+		usecases.NewBroadcastTransactionUseCase(reg, txs, eb, logger),
+		usecases.NewEstimateFeeUseCase(reg, eb, logger),
+		usecases.NewGetTransactionStatusUseCase(reg, logger),
+		usecases.NewExportPSBTUseCase(reg, txs, eb, logger),
+		usecases.NewImportPSBTUseCase(reg, eb, logger),
+		usecases.NewBumpFeeUseCase(reg, txs, mocks.NewMockKeyManager(), eb, logger),
+		usecases.NewGetChainInfoUseCase(reg, logger),
+		logger,
+	)
+
+	post := func(path string, body interface{}) (int, map[string]interface{}) {
+		reqBody, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", path, bytes.NewReader(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := srv.app.Test(req, -1)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var decoded map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&decoded))
+		return resp.StatusCode, decoded
+	}
+
+	// export
+	status, exported := post("/v1/bitcoin-mainnet/psbt/export", map[string]interface{}{
+		"from":  sender,
+		"to":    "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2",
+		"value": "40000000",
+	})
+	require.Equal(t, 201, status)
+	psbt, _ := exported["psbt"].(string)
+	require.NotEmpty(t, psbt)
+	require.Contains(t, txs.Transactions, exported["transaction_id"], "the exported transaction is stored")
+
+	// import of an unsigned PSBT reports it as incomplete
+	status, imported := post("/v1/bitcoin-mainnet/psbt/import", map[string]interface{}{"psbts": []string{psbt}})
+	require.Equal(t, 200, status)
+	require.Equal(t, false, imported["complete"])
+
+	// import and broadcast of the offline-signed PSBT
+	signed, err := adapter.SignPSBT(context.Background(), psbt, testKeys(t, key), "treasury")
+	require.NoError(t, err)
+	status, imported = post("/v1/bitcoin-mainnet/psbt/import", map[string]interface{}{"psbts": []string{psbt, signed}, "broadcast": true})
+	require.Equal(t, 200, status)
+	require.Equal(t, true, imported["complete"])
+	require.Equal(t, "pending", imported["status"])
+	rawTx, _ := imported["raw_transaction"].(string)
+	decoded, err := bitcoin.DecodeRawTransaction(rawTx)
+	require.NoError(t, err)
+	require.Equal(t, "0x"+decoded.TxID, imported["hash"])
+
+	// error cases
+	status, _ = post("/v1/evm-mainnet/psbt/export", map[string]interface{}{"from": "0xabc", "to": "0xdef"})
+	require.Equal(t, 500, status)
+	status, _ = post("/v1/bitcoin-mainnet/psbt/import", map[string]interface{}{"psbts": []string{"invalid"}})
+	require.Equal(t, 500, status)
+	status, _ = post("/v1/bitcoin-mainnet/psbt/export", "invalid")
+	require.Equal(t, 400, status)
+	status, _ = post("/v1/bitcoin-mainnet/psbt/import", "invalid")
+	require.Equal(t, 400, status)
+}
+
+func TestServerBumpFeeRoute(t *testing.T) {
+	t.Parallel()
+
+	logger := mocks.NewMockLogger()
+	reg := registry.NewChainRegistry(logger)
+	h := btcharness.NewBitcoinHarness()
+	adapter := bitcoin.NewAdapter(h, "mainnet")
+	require.NoError(t, reg.Register("bitcoin-mainnet", adapter))
+
+	// P2WPKH output of the BIP-143 example key
+	key, _ := hex.DecodeString("619c335025c7f4012e556c2a58b2506e30b8511b53ade95ea316fd8c3286feb9")
+	keys := testKeys(t, key)
+	pubKeyHash, _ := hex.DecodeString("1d0f172a0ecb48aee1be1f2687d2963ae33f71a1")
+	sender := base58.CheckEncode(append([]byte{0x00}, pubKeyHash...))
+	_, err := h.Fund(sender, append([]byte{0x00, 0x14}, pubKeyHash...), 100000000)
+	require.NoError(t, err)
+
+	from, _ := valueobjects.NewAddress(sender, "bitcoin-mainnet")
+	to, _ := valueobjects.NewAddress("1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", "bitcoin-mainnet")
+	ctx := context.Background()
+	tx, err := adapter.BuildTransaction(ctx, entities.TransactionParams{From: from, To: to, Value: big.NewInt(40000000), GasPrice: big.NewInt(2)})
+	require.NoError(t, err)
+	require.NoError(t, adapter.SignTransaction(ctx, tx, keys, "treasury"))
+	original, err := adapter.BroadcastTransaction(ctx, tx)
+	require.NoError(t, err)
+
+	eb := mocks.NewMockEventPublisher()
+	txs := mocks.NewMockTransactionRepository()
+	srv := NewServer(
+		reg,
+		usecases.NewGetBalanceUseCase(reg, eb, logger),
+		usecases.NewCreateTransactionUseCase(reg, txs, eb, logger),
+		usecases.NewSignTransactionUseCase(reg, txs, mocks.NewMockKeyManager(), eb, logger),
+		usecases.NewBroadcastTransactionUseCase(reg, txs, eb, logger),
+		usecases.NewEstimateFeeUseCase(reg, eb, logger),
+		usecases.NewGetTransactionStatusUseCase(reg, logger),
+		usecases.NewExportPSBTUseCase(reg, txs, eb, logger),
+		usecases.NewImportPSBTUseCase(reg, eb, logger),
+		usecases.NewBumpFeeUseCase(reg, txs, keys, eb, logger),
+		usecases.NewGetChainInfoUseCase(reg, logger),
+		logger,
+	)
+
+	post := func(path string, body interface{}) (int, map[string]interface{}) {
+		reqBody, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", path, bytes.NewReader(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := srv.app.Test(req, -1)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var decoded map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&decoded))
+		return resp.StatusCode, decoded
+	}
+	path := "/v1/bitcoin-mainnet/transaction/" + original.Hex() + "/bump"
+
+	// child-pays-for-parent keeps the original in the mempool
+	status, child := post(path, map[string]interface{}{"method": "cpfp", "fee_rate": "20", "key_id": "treasury"})
+	require.Equal(t, 200, status)
+	require.Equal(t, "cpfp", child["method"])
+	require.Equal(t, original.Hex(), child["original_hash"])
+	require.Equal(t, "pending", child["status"])
+
+	// replace-by-fee evicts the original and its child
+	status, replaced := post(path, map[string]interface{}{"fee_rate": "100", "key_id": "treasury"})
+	require.Equal(t, 200, status)
+	require.Equal(t, "rbf", replaced["method"])
+	require.NotEqual(t, original.Hex(), replaced["hash"])
+	require.NotEmpty(t, replaced["fee"])
+	_, err = adapter.GetTransactionStatus(ctx, original)
+	require.ErrorContains(t, err, "transaction not found")
+
+	// error cases
+	status, _ = post(path, map[string]interface{}{"fee_rate": "200", "key_id": "missing"})
+	require.Equal(t, 400, status)
+	status, _ = post(path, "invalid")
+	require.Equal(t, 400, status)
+	status, _ = post(path, map[string]interface{}{"fee_rate": "200", "key_id": "treasury"})
+	require.Equal(t, 500, status)
+}
+
+func TestServerBroadcastRoute(t *testing.T) {
+	t.Parallel()
+
+	logger := mocks.NewMockLogger()
+	reg := registry.NewChainRegistry(logger)
+	h := harness.NewEVMHarness("evm-mainnet")
+	require.NoError(t, reg.Register("evm-mainnet", h))
+
+	eb := mocks.NewMockEventPublisher()
+	txs := mocks.NewMockTransactionRepository()
+	key := bytes.Repeat([]byte{0x46}, 32)
+	signTxUC := usecases.NewSignTransactionUseCase(reg, txs, testKeys(t, key), eb, logger)
+	srv := NewServer(
+		reg,
+		usecases.NewGetBalanceUseCase(reg, eb, logger),
+		usecases.NewCreateTransactionUseCase(reg, txs, eb, logger),
+		signTxUC,
+		usecases.NewBroadcastTransactionUseCase(reg, txs, eb, logger),
+		usecases.NewEstimateFeeUseCase(reg, eb, logger),
+		usecases.NewGetTransactionStatusUseCase(reg, logger),
+		usecases.NewExportPSBTUseCase(reg, txs, eb, logger),
+		usecases.NewImportPSBTUseCase(reg, eb, logger),
+		usecases.NewBumpFeeUseCase(reg, txs, mocks.NewMockKeyManager(), eb, logger),
+		usecases.NewGetChainInfoUseCase(reg, logger),
+		logger,
+	)
+
+	post := func(path string, body interface{}) (int, map[string]interface{}) {
+		reqBody, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", path, bytes.NewReader(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := srv.app.Test(req, -1)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var decoded map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&decoded))
+		return resp.StatusCode, decoded
+	}
+	path := "/v1/evm-mainnet/transaction/send"
+
+	sender, err := evm.AddressFromPrivateKey(key)
+	require.NoError(t, err)
+	h.SetBalance(sender, big.NewInt(100))
+	create := func() string {
+		status, created := post("/v1/evm-mainnet/transaction/create", map[string]interface{}{
+			"from":  sender,
+			"to":    "0x3535353535353535353535353535353535353535",
+			"value": "1",
+		})
+		require.Equal(t, 201, status)
+		return created["transaction_id"].(string)
+	}
+
+	// unsigned transactions are refused
+	txID := create()
+	status, _ := post(path, map[string]interface{}{"transaction_id": txID})
+	require.Equal(t, 409, status)
+
+	_, err = signTxUC.Execute(context.Background(), usecases.SignTransactionInput{ChainID: "evm-mainnet", TransactionID: txID, KeyID: "treasury"})
+	require.NoError(t, err)
+	status, sent := post(path, map[string]interface{}{"transaction_id": txID})
+	require.Equal(t, 200, status)
+	require.Equal(t, txID, sent["transaction_id"])
+	require.Equal(t, txs.Transactions[txID].Hash().Hex(), sent["hash"])
+	require.Equal(t, "confirmed", sent["status"])
+
+	// a transaction is broadcast only once
+	status, _ = post(path, map[string]interface{}{"transaction_id": txID})
+	require.Equal(t, 409, status)
+
+	// error cases
+	status, _ = post(path, map[string]interface{}{"transaction_id": "missing"})
+	require.Equal(t, 404, status)
+	status, _ = post("/v1/unknown/transaction/send", map[string]interface{}{"transaction_id": txID})
+	require.Equal(t, 404, status)
+	status, _ = post(path, map[string]interface{}{})
+	require.Equal(t, 400, status)
+	status, _ = post(path, map[string]interface{}{"transaction_id": create(), "signed_data": "0x01"})
+	require.Equal(t, 400, status)
+	status, _ = post(path, map[string]interface{}{"signed_data": "0xzz"})
+	require.Equal(t, 400, status)
+	status, _ = post(path, "invalid")
+	require.Equal(t, 400, status)
+}
+
+func TestServerSignAndEstimateFeeRoutes(t *testing.T) {
+	t.Parallel()
+
+	logger := mocks.NewMockLogger()
+	reg := registry.NewChainRegistry(logger)
+	h := harness.NewEVMHarness("evm-mainnet")
+	require.NoError(t, reg.Register("evm-mainnet", h))
+
+	key := bytes.Repeat([]byte{0x46}, 32)
+	keys := testKeys(t, key)
+
+	eb := mocks.NewMockEventPublisher()
+	txs := mocks.NewMockTransactionRepository()
+	srv := NewServer(
+		reg,
+		usecases.NewGetBalanceUseCase(reg, eb, logger),
+		usecases.NewCreateTransactionUseCase(reg, txs, eb, logger),
+		usecases.NewSignTransactionUseCase(reg, txs, keys, eb, logger),
+		usecases.NewBroadcastTransactionUseCase(reg, txs, eb, logger),
+		usecases.NewEstimateFeeUseCase(reg, eb, logger),
+		usecases.NewGetTransactionStatusUseCase(reg, logger),
+		usecases.NewExportPSBTUseCase(reg, txs, eb, logger),
+		usecases.NewImportPSBTUseCase(reg, eb, logger),
+		usecases.NewBumpFeeUseCase(reg, txs, mocks.NewMockKeyManager(), eb, logger),
+		usecases.NewGetChainInfoUseCase(reg, logger),
+		logger,
+	)
+
+	post := func(path string, body interface{}) (int, map[string]interface{}) {
+		reqBody, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", path, bytes.NewReader(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := srv.app.Test(req, -1)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var decoded map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&decoded))
+		return resp.StatusCode, decoded
+	}
+
+	sender, err := evm.AddressFromPrivateKey(key)
+	require.NoError(t, err)
+	h.SetBalance(sender, big.NewInt(100))
+	transfer := map[string]interface{}{
+		"from":  sender,
+		"to":    "0x3535353535353535353535353535353535353535",
+		"value": "1",
+	}
+
+	// estimates are built from the create body without storing a transaction
+	status, fee := post("/v1/evm-mainnet/transaction/estimate-fee", transfer)
+	require.Equal(t, 200, status)
+	require.Equal(t, "evm-mainnet", fee["chain_id"])
+	require.NotZero(t, fee["gas_limit"])
+	require.NotEmpty(t, fee["total"])
+	require.Contains(t, fee, "gas_price")
+	require.Contains(t, fee, "max_fee_per_gas")
+	require.Contains(t, fee, "max_priority_fee")
+	require.Empty(t, txs.Transactions)
+
+	status, _ = post("/v1/evm-mainnet/transaction/estimate-fee", map[string]interface{}{"from": sender})
+	require.Equal(t, 500, status)
+	status, _ = post("/v1/unknown/transaction/estimate-fee", transfer)
+	require.Equal(t, 404, status)
+	status, _ = post("/v1/evm-mainnet/transaction/estimate-fee", "invalid")
+	require.Equal(t, 400, status)
+
+	status, created := post("/v1/evm-mainnet/transaction/create", transfer)
+	require.Equal(t, 201, status)
+	txID := created["transaction_id"].(string)
+	path := "/v1/evm-mainnet/transaction/" + txID + "/sign"
+
+	// the key is referenced by ID
+	status, _ = post(path, map[string]interface{}{"key_id": "missing"})
+	require.Equal(t, 400, status)
+	status, _ = post(path, map[string]interface{}{})
+	require.Equal(t, 400, status)
+	status, _ = post(path, "invalid")
+	require.Equal(t, 400, status)
+
+	status, signed := post(path, map[string]interface{}{"key_id": "treasury"})
+	require.Equal(t, 200, status)
+	require.Equal(t, txID, signed["transaction_id"])
+	require.Equal(t, txs.Transactions[txID].Hash().Hex(), signed["hash"])
+	require.Equal(t, txs.Transactions[txID].Signature().Hex(), signed["signature"])
+
+	status, _ = post("/v1/evm-mainnet/transaction/send", map[string]interface{}{"transaction_id": txID})
+	require.Equal(t, 200, status)
+	status, _ = post(path, map[string]interface{}{"key_id": "treasury"})
+	require.Equal(t, 409, status)
+
+	status, _ = post("/v1/evm-mainnet/transaction/missing/sign", map[string]interface{}{"key_id": "treasury"})
+	require.Equal(t, 404, status)
+	status, _ = post("/v1/unknown/transaction/"+txID+"/sign", map[string]interface{}{"key_id": "treasury"})
+	require.Equal(t, 404, status)
+}
+
 func TestServerStartShutdown(t *testing.T) {
 	t.Parallel()
 	h := harness.NewEVMHarness("evm-mainnet")
@@ -114,15 +554,21 @@ func TestServerStartShutdown(t *testing.T) {
 	_ = registry.Register("evm-mainnet", h)
 	publisher := mocks.NewMockEventPublisher()
 	logger := mocks.NewMockLogger()
+	txs := mocks.NewMockTransactionRepository()
 
 	getBalanceUC := usecases.NewGetBalanceUseCase(registry, publisher, logger)
-	createTxUC := usecases.NewCreateTransactionUseCase(registry, publisher, logger)
-	signTxUC := usecases.NewSignTransactionUseCase(registry, publisher, logger)
-	broadcastTxUC := usecases.NewBroadcastTransactionUseCase(registry, publisher, logger)
+	createTxUC := usecases.NewCreateTransactionUseCase(registry, txs, publisher, logger)
+	signTxUC := usecases.NewSignTransactionUseCase(registry, txs, mocks.NewMockKeyManager(), publisher, logger)
+	broadcastTxUC := usecases.NewBroadcastTransactionUseCase(registry, txs, publisher, logger)
 	estimateFeeUC := usecases.NewEstimateFeeUseCase(registry, publisher, logger)
 	getStatusUC := usecases.NewGetTransactionStatusUseCase(registry, logger)
+	exportPSBTUC := usecases.NewExportPSBTUseCase(registry, txs, publisher, logger)
+	importPSBTUC := usecases.NewImportPSBTUseCase(registry, publisher, logger)
+
+	bumpFeeUC := usecases.NewBumpFeeUseCase(registry, txs, mocks.NewMockKeyManager(), publisher, logger)
+	getChainInfoUC := usecases.NewGetChainInfoUseCase(registry, logger)
 
-	srv := NewServer(registry, getBalanceUC, createTxUC, signTxUC, broadcastTxUC, estimateFeeUC, getStatusUC, logger)
+	srv := NewServer(registry, getBalanceUC, createTxUC, signTxUC, broadcastTxUC, estimateFeeUC, getStatusUC, exportPSBTUC, importPSBTUC, bumpFeeUC, getChainInfoUC, logger)
 
 	go func() {
 		_ = srv.Start("9999")
@@ -0,0 +1,337 @@
+package config
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/bitcoin"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/evm"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/solana"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/tron"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/database"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/eventbus"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/health"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/keystore"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/resilience"
+)
+
+// Chain kinds listed under chains.enabled
+const (
+	ChainEVM     = "evm"
+	ChainTron    = "tron"
+	ChainBitcoin = "bitcoin"
+	ChainSolana  = "solana"
+)
+
+// Adapter kinds a network is served by
+const (
+	// AdapterRPC talks to the node at the network's RPC URL (default)
+	AdapterRPC = "rpc"
+	// AdapterHarness simulates the chain in memory for local development
+	AdapterHarness = "harness"
+)
+
+// Config is the application configuration (config.yaml)
+type Config struct {
+	App        AppConfig            `yaml:"app"`
+	Logging    LoggingConfig        `yaml:"logging"`
+	Admin      AdminConfig          `yaml:"admin"`
+	Keystore   keystore.Config      `yaml:"keystore"`
+	Database   database.Config      `yaml:"database"`
+	Redis      eventbus.RedisConfig `yaml:"redis"`
+	Health     health.Config        `yaml:"health"`
+	Chains     ChainsConfig         `yaml:"chains"`
+	Reload     ReloadConfig         `yaml:"reload"`
+	Resilience resilience.Config    `yaml:"resilience"`
+	EVM        EVMConfig            `yaml:"evm"`
+	Tron       TronConfig           `yaml:"tron"`
+	Bitcoin    BitcoinConfig        `yaml:"bitcoin"`
+	Solana     SolanaConfig         `yaml:"solana"`
+}
+
+// AppConfig describes the application
+type AppConfig struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+	Port    int    `yaml:"port"`
+}
+
+// LoggingConfig describes the logger
+type LoggingConfig struct {
+	Level  string `yaml:"level"`
+	Format string `yaml:"format"`
+}
+
+// AdminConfig describes the admin API
+type AdminConfig struct {
+	// Token is the bearer token admin requests authenticate with; the admin API is off without one
+	Token string `yaml:"token"`
+}
+
+// ChainsConfig selects the chain kinds whose networks are served
+type ChainsConfig struct {
+	Enabled []string `yaml:"enabled"`
+}
+
+// ReloadConfig describes how chain networks are reloaded without a restart
+type ReloadConfig struct {
+	// Interval is how often the configuration file is checked for changes; zero leaves reloads to SIGHUP
+	Interval time.Duration `yaml:"interval"`
+	// DrainTimeout bounds the wait for the in-flight calls of removed or replaced adapters
+	DrainTimeout time.Duration `yaml:"drain_timeout"`
+}
+
+// EVMConfig lists the EVM networks
+type EVMConfig struct {
+	Networks []EVMNetwork `yaml:"networks"`
+}
+
+// EVMNetwork is an EVM network entry
+type EVMNetwork struct {
+	evm.NetworkConfig `yaml:",inline"`
+	// Adapter is AdapterRPC (default) or AdapterHarness
+	Adapter string `yaml:"adapter"`
+	// Balances seeds harness accounts, in wei
+	Balances map[string]string `yaml:"balances"`
+}
+
+// TronConfig lists the Tron networks
+type TronConfig struct {
+	Networks []TronNetwork `yaml:"networks"`
+}
+
+// TronNetwork is a Tron network entry
+type TronNetwork struct {
+	tron.NetworkConfig `yaml:",inline"`
+	// Adapter is AdapterRPC; Tron has no harness
+	Adapter string `yaml:"adapter"`
+}
+
+// BitcoinConfig lists the networks of Bitcoin and the UTXO chains derived from it
+type BitcoinConfig struct {
+	Networks []BitcoinNetwork `yaml:"networks"`
+}
+
+// BitcoinNetwork is a network entry of a UTXO chain
+type BitcoinNetwork struct {
+	bitcoin.NetworkConfig `yaml:",inline"`
+	// Adapter is AdapterRPC (default) or AdapterHarness
+	Adapter string `yaml:"adapter"`
+	// Balances funds harness addresses with one confirmed output each, in satoshis
+	Balances map[string]string `yaml:"balances"`
+}
+
+// SolanaConfig lists the Solana clusters
+type SolanaConfig struct {
+	Networks []SolanaNetwork `yaml:"networks"`
+}
+
+// SolanaNetwork is a Solana cluster entry
+type SolanaNetwork struct {
+	solana.NetworkConfig `yaml:",inline"`
+	// Adapter is AdapterRPC; Solana has no harness
+	Adapter string `yaml:"adapter"`
+}
+
+// Default returns the configuration used for settings config.yaml leaves out
+func Default() Config {
+	return Config{
+		App:        AppConfig{Name: "ChainSystemPro", Port: 8080},
+		Logging:    LoggingConfig{Level: "info", Format: "json"},
+		Database:   database.Config{Port: 5432, SSLMode: "require"},
+		Health:     health.DefaultConfig(),
+		Reload:     ReloadConfig{Interval: 5 * time.Second, DrainTimeout: 30 * time.Second},
+		Resilience: resilience.DefaultConfig(),
+	}
+}
+
+// Enabled reports whether a chain kind is listed under chains.enabled
+func (c *Config) Enabled(kind string) bool {
+	for _, enabled := range c.Chains.Enabled {
+		if enabled == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the configuration, including every network of the enabled chains
+func (c *Config) Validate() error {
+	if c.App.Port <= 0 || c.App.Port > 65535 {
+		return fmt.Errorf("app.port %d is out of range", c.App.Port)
+	}
+	switch c.Logging.Level {
+	case "", "debug", "info", "warn", "error":
+	default:
+		return fmt.Errorf("unknown logging.level %q", c.Logging.Level)
+	}
+	if c.Reload.Interval < 0 || c.Reload.DrainTimeout < 0 {
+		return fmt.Errorf("reload interval and drain_timeout cannot be negative")
+	}
+	if err := c.Resilience.Validate(); err != nil {
+		return err
+	}
+	if c.Database.Host != "" && (c.Database.Port <= 0 || c.Database.Port > 65535) {
+		return fmt.Errorf("database.port %d is out of range", c.Database.Port)
+	}
+	if err := c.Health.Validate(); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	for _, kind := range c.Chains.Enabled {
+		switch kind {
+		case ChainEVM, ChainTron, ChainBitcoin, ChainSolana:
+		default:
+			return fmt.Errorf("unknown chain %q in chains.enabled", kind)
+		}
+		if seen[kind] {
+			return fmt.Errorf("chain %q is enabled twice", kind)
+		}
+		seen[kind] = true
+	}
+
+	chainIDs := make(map[string]bool)
+	for _, network := range c.Networks() {
+		if err := network.validate(); err != nil {
+			return fmt.Errorf("invalid %s network: %w", network.Kind, err)
+		}
+		if chainIDs[network.ChainID] {
+			return fmt.Errorf("chain ID %s is configured twice", network.ChainID)
+		}
+		chainIDs[network.ChainID] = true
+	}
+	return nil
+}
+
+// Network is a configured network of an enabled chain
+type Network struct {
+	// Kind is the chain kind, such as ChainEVM
+	Kind string
+	// ChainID is the identifier the network's adapter is registered under
+	ChainID string
+	// Adapter is AdapterRPC or AdapterHarness
+	Adapter string
+	// Entry is the network's entry: EVMNetwork, TronNetwork, BitcoinNetwork or SolanaNetwork
+	Entry interface{}
+}
+
+// Networks lists the networks of the enabled chains, in configuration order
+func (c *Config) Networks() []Network {
+	var networks []Network
+	if c.Enabled(ChainEVM) {
+		for _, n := range c.EVM.Networks {
+			networks = append(networks, evmNetwork(n))
+		}
+	}
+	if c.Enabled(ChainTron) {
+		for _, n := range c.Tron.Networks {
+			networks = append(networks, tronNetwork(n))
+		}
+	}
+	if c.Enabled(ChainBitcoin) {
+		for _, n := range c.Bitcoin.Networks {
+			networks = append(networks, bitcoinNetwork(n))
+		}
+	}
+	if c.Enabled(ChainSolana) {
+		for _, n := range c.Solana.Networks {
+			networks = append(networks, solanaNetwork(n))
+		}
+	}
+	return networks
+}
+
+func evmNetwork(n EVMNetwork) Network {
+	return Network{Kind: ChainEVM, ChainID: n.Name, Adapter: adapterKind(n.Adapter), Entry: n}
+}
+
+func tronNetwork(n TronNetwork) Network {
+	return Network{Kind: ChainTron, ChainID: n.Name, Adapter: adapterKind(n.Adapter), Entry: n}
+}
+
+func bitcoinNetwork(n BitcoinNetwork) Network {
+	return Network{Kind: ChainBitcoin, ChainID: n.ChainID(), Adapter: adapterKind(n.Adapter), Entry: n}
+}
+
+func solanaNetwork(n SolanaNetwork) Network {
+	return Network{Kind: ChainSolana, ChainID: n.Name, Adapter: adapterKind(n.Adapter), Entry: n}
+}
+
+// validate checks a network entry for the adapter kind it is served by
+func (n Network) validate() error {
+	switch n.Adapter {
+	case AdapterRPC, AdapterHarness:
+	default:
+		return fmt.Errorf("unknown adapter %q for network %s", n.Adapter, n.ChainID)
+	}
+
+	switch entry := n.Entry.(type) {
+	case EVMNetwork:
+		if n.Adapter == AdapterRPC {
+			return entry.Validate()
+		}
+		if entry.Name == "" {
+			return fmt.Errorf("network name cannot be empty")
+		}
+		return validateBalances(entry.Balances, entry.Name)
+	case BitcoinNetwork:
+		if n.Adapter == AdapterRPC {
+			return entry.Validate()
+		}
+		if _, err := bitcoin.LookupChainParams(chainOrDefault(entry.Chain), entry.Name); err != nil {
+			return err
+		}
+		for address := range entry.Balances {
+			if _, err := bitcoin.ParseChainAddress(address, chainOrDefault(entry.Chain), entry.Name); err != nil {
+				return fmt.Errorf("invalid balance address for network %s: %w", entry.Name, err)
+			}
+		}
+		return validateBalances(entry.Balances, entry.Name)
+	case TronNetwork:
+		if n.Adapter == AdapterHarness {
+			return fmt.Errorf("no harness is available for tron network %s", entry.Name)
+		}
+		return entry.Validate()
+	case SolanaNetwork:
+		if n.Adapter == AdapterHarness {
+			return fmt.Errorf("no harness is available for solana network %s", entry.Name)
+		}
+		return entry.Validate()
+	default:
+		return fmt.Errorf("unknown network entry %T", n.Entry)
+	}
+}
+
+// ParseBalance parses a seeded harness balance in the chain's smallest unit
+func ParseBalance(balance string) (*big.Int, error) {
+	value, ok := new(big.Int).SetString(balance, 10)
+	if !ok || value.Sign() < 0 {
+		return nil, fmt.Errorf("invalid balance %q", balance)
+	}
+	return value, nil
+}
+
+func validateBalances(balances map[string]string, network string) error {
+	for address, balance := range balances {
+		if _, err := ParseBalance(balance); err != nil {
+			return fmt.Errorf("balance of %s on network %s: %w", address, network, err)
+		}
+	}
+	return nil
+}
+
+func adapterKind(adapter string) string {
+	if adapter == "" {
+		return AdapterRPC
+	}
+	return adapter
+}
+
+func chainOrDefault(chain string) string {
+	if chain == "" {
+		return bitcoin.ChainBitcoin
+	}
+	return chain
+}
@@ -0,0 +1,201 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = `
+app:
+  name: ChainSystemPro
+  port: 8080
+chains:
+  enabled: [evm, bitcoin]
+evm:
+  networks:
+    - name: ethereum
+      rpc_url: ${ETH_RPC_URL}
+      chain_id: 1
+      endpoints:
+        - url: https://ethereum-rpc.publicnode.com
+    - name: localnet
+      adapter: harness
+      chain_id: 1337
+      balances:
+        "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb": "1000000000000000000"
+bitcoin:
+  networks:
+    - name: regtest
+      adapter: harness
+      balances:
+        bcrt1qw508d6qejxtdg4y5r3zarvary0c5xw7kygt080: "50000"
+tron:
+  networks:
+    - name: tron
+      adapter: harness
+`
+
+func lookupEnv(env map[string]string) LookupFunc {
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+}
+
+func TestLoadRepoConfig(t *testing.T) {
+	t.Parallel()
+
+	provider, err := Load("../../config.yaml")
+	require.NoError(t, err)
+
+	config := provider.Config()
+	assert.Equal(t, 8080, config.App.Port)
+	assert.Equal(t, 30*time.Second, config.Resilience.PolicyFor("bitcoin-mainnet").Timeout)
+	assert.Equal(t, 2*time.Hour, config.Health.StaleAfterFor("bitcoin-mainnet"))
+	assert.Equal(t, 10*time.Minute, config.Health.StaleAfterFor("ethereum"))
+	assert.Empty(t, config.Database.Host)
+	assert.Equal(t, "require", config.Database.SSLMode)
+
+	var chainIDs []string
+	for _, network := range config.Networks() {
+		assert.Equal(t, AdapterRPC, network.Adapter)
+		chainIDs = append(chainIDs, network.ChainID)
+	}
+	assert.Equal(t, []string{
+		"ethereum", "polygon", "tron", "bitcoin-mainnet", "bitcoin-testnet", "litecoin-mainnet", "solana", "solana-devnet",
+	}, chainIDs)
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	provider, err := Parse([]byte(testConfig), lookupEnv(map[string]string{
+		"ETH_RPC_URL":                                "https://eth.example.com",
+		"CHAINSYSTEM_APP_PORT":                       "9090",
+		"CHAINSYSTEM_CHAINS_ENABLED":                 "evm",
+		"CHAINSYSTEM_EVM_NETWORKS_0_ENDPOINTS_0_URL": "https://rpc.example.com",
+	}))
+	require.NoError(t, err)
+
+	config := provider.Config()
+	assert.Equal(t, 9090, config.App.Port)
+	assert.Equal(t, "info", config.Logging.Level)
+	assert.Equal(t, []string{ChainEVM}, config.Chains.Enabled)
+	require.Len(t, config.EVM.Networks, 2)
+	assert.Equal(t, "https://eth.example.com", config.EVM.Networks[0].RPCURL)
+	assert.Equal(t, "https://rpc.example.com", config.EVM.Networks[0].Endpoints[0].URL)
+	assert.Equal(t, AdapterHarness, config.EVM.Networks[1].Adapter)
+
+	// Networks of disabled chains are neither served nor validated
+	networks := config.Networks()
+	require.Len(t, networks, 2)
+	assert.Equal(t, "localnet", networks[1].ChainID)
+}
+
+func TestParseInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		err  string
+	}{
+		{"harness without simulator", map[string]string{"ETH_RPC_URL": "https://eth.example.com", "CHAINSYSTEM_CHAINS_ENABLED": "evm,tron"}, "no harness is available for tron network tron"},
+		{"unknown chain", map[string]string{"CHAINSYSTEM_CHAINS_ENABLED": "evm,cosmos"}, `unknown chain "cosmos"`},
+		{"missing rpc url", nil, "invalid evm network"},
+		{"bad balance", map[string]string{
+			"ETH_RPC_URL": "https://eth.example.com",
+			"CHAINSYSTEM_EVM_NETWORKS_1_BALANCES_0X742D35CC6634C0532925A3B844BC9E7595F0BEB": "-1",
+		}, "invalid balance"},
+		{"duplicate chain id", map[string]string{
+			"ETH_RPC_URL":                     "https://eth.example.com",
+			"CHAINSYSTEM_EVM_NETWORKS_1_NAME": "ethereum",
+		}, "chain ID ethereum is configured twice"},
+		{"wrong address network", map[string]string{
+			"ETH_RPC_URL":                         "https://eth.example.com",
+			"CHAINSYSTEM_BITCOIN_NETWORKS_0_NAME": "mainnet",
+		}, "invalid balance address for network mainnet"},
+		{"bad port", map[string]string{"CHAINSYSTEM_APP_PORT": "70000"}, "app.port 70000 is out of range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse([]byte(testConfig), lookupEnv(tt.env))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+
+	_, err := Parse([]byte("app:\n  host: localhost\n"), lookupEnv(nil))
+	require.ErrorContains(t, err, "field host not found")
+	_, err = Parse([]byte("database:\n  host: localhost\n  port: 0\n"), lookupEnv(nil))
+	require.ErrorContains(t, err, "database.port 0 is out of range")
+	_, err = Parse([]byte("health:\n  readiness:\n    min_healthy_chains: -1\n"), lookupEnv(nil))
+	require.ErrorContains(t, err, "min_healthy_chains cannot be negative")
+}
+
+func TestParseNetwork(t *testing.T) {
+	t.Parallel()
+
+	network, err := ParseNetwork(ChainEVM, map[string]interface{}{
+		"name":      "sepolia",
+		"rpc_url":   "https://sepolia.example.com",
+		"chain_id":  int64(11155111),
+		"testnet":   true,
+		"endpoints": []interface{}{map[string]interface{}{"url": "https://rpc.example.com"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "sepolia", network.ChainID)
+	assert.Equal(t, AdapterRPC, network.Adapter)
+	entry, ok := network.Entry.(EVMNetwork)
+	require.True(t, ok)
+	assert.Equal(t, uint64(11155111), entry.ChainID)
+	assert.True(t, entry.Testnet)
+	assert.Equal(t, "https://rpc.example.com", entry.Endpoints[0].URL)
+
+	network, err = ParseNetwork(ChainBitcoin, map[string]interface{}{"name": "regtest", "adapter": "harness"})
+	require.NoError(t, err)
+	assert.Equal(t, "bitcoin-regtest", network.ChainID)
+
+	_, err = ParseNetwork("cosmos", map[string]interface{}{"name": "cosmoshub"})
+	require.ErrorContains(t, err, `unknown chain "cosmos"`)
+	_, err = ParseNetwork(ChainTron, map[string]interface{}{"name": "tron", "rpc_url": "https://tron.example.com"})
+	require.ErrorContains(t, err, "field rpc_url not found")
+	_, err = ParseNetwork(ChainSolana, map[string]interface{}{"name": "solana"})
+	require.ErrorContains(t, err, "invalid solana network")
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	t.Parallel()
+
+	_, err := Load("missing.yaml")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestProvider(t *testing.T) {
+	t.Parallel()
+
+	provider, err := Parse([]byte(testConfig), lookupEnv(map[string]string{
+		"ETH_RPC_URL": "https://eth.example.com",
+	}))
+	require.NoError(t, err)
+
+	var _ ports.ConfigProvider = provider
+	assert.Equal(t, "ChainSystemPro", provider.GetString("app.name"))
+	assert.Equal(t, 8080, provider.GetInt("app.port"))
+	assert.Equal(t, "8080", provider.GetString("app.port"))
+	assert.Equal(t, []string{"evm", "bitcoin"}, provider.GetStringSlice("chains.enabled"))
+	assert.Equal(t, "https://eth.example.com", provider.GetString("evm.networks.0.rpc_url"))
+	assert.Equal(t, 1337, provider.GetInt("evm.networks.1.chain_id"))
+	assert.True(t, provider.IsSet("evm.networks.1.balances"))
+	assert.False(t, provider.IsSet("evm.networks.2"))
+	assert.False(t, provider.IsSet("app.version"))
+	assert.False(t, provider.GetBool("app.name"))
+	assert.Empty(t, provider.GetString("missing.key"))
+}
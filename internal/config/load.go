@@ -0,0 +1,190 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix prefixes the environment variables overriding configuration keys: the key
+// evm.networks.0.rpc_url is overridden by CHAINSYSTEM_EVM_NETWORKS_0_RPC_URL
+const EnvPrefix = "CHAINSYSTEM"
+
+// LookupFunc looks up an environment variable
+type LookupFunc func(key string) (string, bool)
+
+// Load reads a YAML configuration file, applying environment overrides over it
+func Load(path string) (*Provider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	return Parse(data, os.LookupEnv)
+}
+
+// Parse decodes a YAML configuration. ${VAR} references are expanded first, then every key
+// whose environment variable is set takes its value; lists of scalars take comma-separated values
+func Parse(data []byte, lookup LookupFunc) (*Provider, error) {
+	expanded := os.Expand(string(data), func(name string) string {
+		value, _ := lookup(name)
+		return value
+	})
+
+	values := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(expanded), &values); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if err := applyEnv(values, []string{EnvPrefix}, lookup); err != nil {
+		return nil, err
+	}
+
+	// The overridden tree is decoded again into the typed configuration, over the defaults
+	overridden, err := yaml.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	config := Default()
+	if err := decodeStrict(overridden, &config); err != nil {
+		return nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	return &Provider{values: values, config: &config}, nil
+}
+
+// ParseNetwork decodes a network entry of a chain kind from its config.yaml keys, such as a
+// network registered at runtime, and validates it
+func ParseNetwork(kind string, settings map[string]interface{}) (Network, error) {
+	data, err := yaml.Marshal(settings)
+	if err != nil {
+		return Network{}, fmt.Errorf("failed to encode network: %w", err)
+	}
+
+	var network Network
+	switch kind {
+	case ChainEVM:
+		var entry EVMNetwork
+		err = decodeStrict(data, &entry)
+		network = evmNetwork(entry)
+	case ChainTron:
+		var entry TronNetwork
+		err = decodeStrict(data, &entry)
+		network = tronNetwork(entry)
+	case ChainBitcoin:
+		var entry BitcoinNetwork
+		err = decodeStrict(data, &entry)
+		network = bitcoinNetwork(entry)
+	case ChainSolana:
+		var entry SolanaNetwork
+		err = decodeStrict(data, &entry)
+		network = solanaNetwork(entry)
+	default:
+		return Network{}, fmt.Errorf("unknown chain %q", kind)
+	}
+	if err != nil {
+		return Network{}, fmt.Errorf("failed to decode %s network: %w", kind, err)
+	}
+	if err := network.validate(); err != nil {
+		return Network{}, fmt.Errorf("invalid %s network: %w", kind, err)
+	}
+	return network, nil
+}
+
+// decodeStrict decodes YAML into out, rejecting keys out does not have
+func decodeStrict(data []byte, out interface{}) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	return decoder.Decode(out)
+}
+
+// applyEnv replaces the values of a map whose environment variables are set
+func applyEnv(values map[string]interface{}, path []string, lookup LookupFunc) error {
+	for key, value := range values {
+		overridden, err := overrideValue(value, append(path, key), lookup)
+		if err != nil {
+			return err
+		}
+		values[key] = overridden
+	}
+	return nil
+}
+
+func overrideValue(value interface{}, path []string, lookup LookupFunc) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, applyEnv(v, path, lookup)
+	case []interface{}:
+		if !scalars(v) {
+			for i, item := range v {
+				overridden, err := overrideValue(item, append(path, strconv.Itoa(i)), lookup)
+				if err != nil {
+					return nil, err
+				}
+				v[i] = overridden
+			}
+			return v, nil
+		}
+		raw, ok := lookup(envName(path))
+		if !ok {
+			return v, nil
+		}
+		var items []interface{}
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+			scalar, err := parseScalar(item, path)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, scalar)
+		}
+		return items, nil
+	default:
+		raw, ok := lookup(envName(path))
+		if !ok {
+			return v, nil
+		}
+		return parseScalar(raw, path)
+	}
+}
+
+// parseScalar reads an environment value the way YAML would, so numbers and booleans keep their type
+func parseScalar(raw string, path []string) (interface{}, error) {
+	var value interface{}
+	if err := yaml.Unmarshal([]byte(raw), &value); err != nil {
+		return nil, fmt.Errorf("invalid value of %s: %w", envName(path), err)
+	}
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return raw, nil
+	default:
+		return value, nil
+	}
+}
+
+func scalars(items []interface{}) bool {
+	for _, item := range items {
+		switch item.(type) {
+		case map[string]interface{}, []interface{}:
+			return false
+		}
+	}
+	return true
+}
+
+// envName returns the environment variable of a key path
+func envName(path []string) string {
+	name := strings.ToUpper(strings.Join(path, "_"))
+	return strings.Map(func(r rune) rune {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
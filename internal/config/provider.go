@@ -0,0 +1,111 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Provider implements ports.ConfigProvider over a loaded configuration; keys are dot-separated
+// paths such as app.port or evm.networks.0.rpc_url
+type Provider struct {
+	values map[string]interface{}
+	config *Config
+}
+
+// Config returns the typed configuration
+func (p *Provider) Config() *Config {
+	return p.config
+}
+
+// GetString returns a string config value
+func (p *Provider) GetString(key string) string {
+	value, ok := p.lookup(key)
+	if !ok || value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// GetInt returns an int config value, zero when the value is not a number
+func (p *Provider) GetInt(key string) int {
+	value, _ := p.lookup(key)
+	switch v := value.(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	case string:
+		n, _ := strconv.Atoi(v)
+		return n
+	default:
+		return 0
+	}
+}
+
+// GetBool returns a bool config value
+func (p *Provider) GetBool(key string) bool {
+	value, _ := p.lookup(key)
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		b, _ := strconv.ParseBool(v)
+		return b
+	default:
+		return false
+	}
+}
+
+// GetStringSlice returns a string slice config value; a string value is split on commas
+func (p *Provider) GetStringSlice(key string) []string {
+	value, _ := p.lookup(key)
+	switch v := value.(type) {
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+		return items
+	case string:
+		var items []string
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items
+	default:
+		return nil
+	}
+}
+
+// IsSet checks if a config key is set
+func (p *Provider) IsSet(key string) bool {
+	_, ok := p.lookup(key)
+	return ok
+}
+
+// lookup walks a dot-separated key through maps and, by index, lists
+func (p *Provider) lookup(key string) (interface{}, bool) {
+	var node interface{} = p.values
+	for _, part := range strings.Split(key, ".") {
+		switch v := node.(type) {
+		case map[string]interface{}:
+			next, ok := v[part]
+			if !ok {
+				return nil, false
+			}
+			node = next
+		case []interface{}:
+			i, err := strconv.Atoi(part)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			node = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return node, true
+}
@@ -16,6 +16,7 @@ const (
 	ChainTypeEVM     ChainType = "evm"
 	ChainTypeTron    ChainType = "tron"
 	ChainTypeBitcoin ChainType = "bitcoin"
+	ChainTypeSolana  ChainType = "solana"
 )
 
 // Chain represents a blockchain network
@@ -26,6 +27,7 @@ type Chain struct {
 	networkID   string
 	isTestnet   bool
 	nativeToken string
+	decimals    uint8
 	createdAt   time.Time
 	updatedAt   time.Time
 }
@@ -68,6 +70,15 @@ func (c *Chain) NativeToken() string  { return c.nativeToken }
 func (c *Chain) CreatedAt() time.Time { return c.createdAt }
 func (c *Chain) UpdatedAt() time.Time { return c.updatedAt }
 
+// Decimals returns the number of decimals of the native token's smallest unit
+func (c *Chain) Decimals() uint8 { return c.decimals }
+
+// SetDecimals sets the number of decimals of the native token's smallest unit
+func (c *Chain) SetDecimals(decimals uint8) {
+	c.decimals = decimals
+	c.updatedAt = time.Now()
+}
+
 // TxStatus represents transaction status
 type TxStatus string
 
@@ -113,6 +124,8 @@ type TransactionParams struct {
 	GasPrice       *big.Int
 	MaxFeePerGas   *big.Int
 	MaxPriorityFee *big.Int
+	// Options carries chain-specific build options, such as a Bitcoin coin selection strategy
+	Options map[string]string
 }
 
 // NewTransaction creates a new Transaction entity
@@ -153,6 +166,79 @@ func NewTransaction(params TransactionParams) (*Transaction, error) {
 	}, nil
 }
 
+// TransactionState holds the stored state of a transaction
+type TransactionState struct {
+	ID             string
+	ChainID        string
+	Hash           *valueobjects.Hash
+	From           *valueobjects.Address
+	To             *valueobjects.Address
+	Value          *big.Int
+	Data           []byte
+	Nonce          *valueobjects.Nonce
+	GasLimit       uint64
+	GasPrice       *big.Int
+	MaxFeePerGas   *big.Int
+	MaxPriorityFee *big.Int
+	Signature      *valueobjects.Signature
+	Status         TxStatus
+	BlockNumber    uint64
+	Confirmations  uint64
+	Metadata       map[string]interface{}
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// RestoreTransaction rebuilds a Transaction entity from its stored state, keeping its ID and timestamps
+func RestoreTransaction(state TransactionState) (*Transaction, error) {
+	if state.ID == "" {
+		return nil, fmt.Errorf("transaction ID cannot be empty")
+	}
+	if state.ChainID == "" {
+		return nil, fmt.Errorf("chain ID cannot be empty")
+	}
+	if state.From == nil {
+		return nil, fmt.Errorf("from address cannot be nil")
+	}
+	if state.To == nil {
+		return nil, fmt.Errorf("to address cannot be nil")
+	}
+	if state.Value == nil {
+		state.Value = big.NewInt(0)
+	}
+	if state.Value.Sign() < 0 {
+		return nil, fmt.Errorf("value cannot be negative")
+	}
+	if state.Status == "" {
+		state.Status = TxStatusPending
+	}
+	if state.Metadata == nil {
+		state.Metadata = make(map[string]interface{})
+	}
+
+	return &Transaction{
+		id:             state.ID,
+		chainID:        state.ChainID,
+		hash:           state.Hash,
+		from:           state.From,
+		to:             state.To,
+		value:          new(big.Int).Set(state.Value),
+		data:           state.Data,
+		nonce:          state.Nonce,
+		gasLimit:       state.GasLimit,
+		gasPrice:       state.GasPrice,
+		maxFeePerGas:   state.MaxFeePerGas,
+		maxPriorityFee: state.MaxPriorityFee,
+		signature:      state.Signature,
+		status:         state.Status,
+		blockNumber:    state.BlockNumber,
+		confirmations:  state.Confirmations,
+		metadata:       state.Metadata,
+		createdAt:      state.CreatedAt,
+		updatedAt:      state.UpdatedAt,
+	}, nil
+}
+
 // Getters
 func (t *Transaction) ID() string                         { return t.id }
 func (t *Transaction) ChainID() string                    { return t.chainID }
@@ -194,6 +280,49 @@ func (t *Transaction) SetSignature(sig *valueobjects.Signature) error {
 	return nil
 }
 
+// SetNonce sets the transaction nonce
+func (t *Transaction) SetNonce(nonce *valueobjects.Nonce) error {
+	if nonce == nil {
+		return fmt.Errorf("nonce cannot be nil")
+	}
+	t.nonce = nonce
+	t.updatedAt = time.Now()
+	return nil
+}
+
+// SetGasLimit sets the gas limit
+func (t *Transaction) SetGasLimit(gasLimit uint64) {
+	t.gasLimit = gasLimit
+	t.updatedAt = time.Now()
+}
+
+// SetGasPrice sets the legacy gas price
+func (t *Transaction) SetGasPrice(gasPrice *big.Int) error {
+	if gasPrice == nil || gasPrice.Sign() < 0 {
+		return fmt.Errorf("gas price cannot be nil or negative")
+	}
+	t.gasPrice = new(big.Int).Set(gasPrice)
+	t.updatedAt = time.Now()
+	return nil
+}
+
+// SetDynamicFees sets the EIP-1559 fee caps
+func (t *Transaction) SetDynamicFees(maxFeePerGas, maxPriorityFee *big.Int) error {
+	if maxFeePerGas == nil || maxFeePerGas.Sign() < 0 {
+		return fmt.Errorf("max fee per gas cannot be nil or negative")
+	}
+	if maxPriorityFee == nil || maxPriorityFee.Sign() < 0 {
+		return fmt.Errorf("max priority fee cannot be nil or negative")
+	}
+	if maxPriorityFee.Cmp(maxFeePerGas) > 0 {
+		return fmt.Errorf("max priority fee cannot exceed max fee per gas")
+	}
+	t.maxFeePerGas = new(big.Int).Set(maxFeePerGas)
+	t.maxPriorityFee = new(big.Int).Set(maxPriorityFee)
+	t.updatedAt = time.Now()
+	return nil
+}
+
 // UpdateStatus updates the transaction status
 func (t *Transaction) UpdateStatus(status TxStatus) {
 	t.status = status
@@ -277,6 +406,8 @@ type Fee struct {
 	gasPrice       *big.Int
 	maxFeePerGas   *big.Int
 	maxPriorityFee *big.Int
+	bandwidth      uint64
+	energy         uint64
 	total          *big.Int
 	currency       string
 }
@@ -335,14 +466,86 @@ func NewEIP1559Fee(gasLimit uint64, maxFeePerGas, maxPriorityFee *big.Int, curre
 	}, nil
 }
 
+// NewResourceFee creates a Fee for resource-metered chains such as Tron.
+// Energy is reported as the gas limit and total is the amount burned once
+// staked and free resources are consumed.
+func NewResourceFee(bandwidth, energy uint64, energyPrice, total *big.Int, currency string) (*Fee, error) {
+	if energyPrice == nil || energyPrice.Sign() < 0 {
+		return nil, fmt.Errorf("energy price cannot be negative")
+	}
+	if total == nil || total.Sign() < 0 {
+		return nil, fmt.Errorf("total fee cannot be negative")
+	}
+	if currency == "" {
+		return nil, fmt.Errorf("currency cannot be empty")
+	}
+
+	return &Fee{
+		gasLimit:  energy,
+		gasPrice:  new(big.Int).Set(energyPrice),
+		bandwidth: bandwidth,
+		energy:    energy,
+		total:     new(big.Int).Set(total),
+		currency:  currency,
+	}, nil
+}
+
+// NewComputeUnitFee creates a Fee for chains that price compute units, such as Solana.
+// The compute-unit limit is reported as the gas limit, the compute-unit price as the
+// gas price, and total includes the base fee charged per signature.
+func NewComputeUnitFee(computeUnits uint64, unitPrice, total *big.Int, currency string) (*Fee, error) {
+	if unitPrice == nil || unitPrice.Sign() < 0 {
+		return nil, fmt.Errorf("compute unit price cannot be negative")
+	}
+	if total == nil || total.Sign() < 0 {
+		return nil, fmt.Errorf("total fee cannot be negative")
+	}
+	if currency == "" {
+		return nil, fmt.Errorf("currency cannot be empty")
+	}
+
+	return &Fee{
+		gasLimit: computeUnits,
+		gasPrice: new(big.Int).Set(unitPrice),
+		total:    new(big.Int).Set(total),
+		currency: currency,
+	}, nil
+}
+
 // Getters
 func (f *Fee) GasLimit() uint64         { return f.gasLimit }
 func (f *Fee) GasPrice() *big.Int       { return f.gasPrice }
 func (f *Fee) MaxFeePerGas() *big.Int   { return f.maxFeePerGas }
 func (f *Fee) MaxPriorityFee() *big.Int { return f.maxPriorityFee }
+func (f *Fee) Bandwidth() uint64        { return f.bandwidth }
+func (f *Fee) Energy() uint64           { return f.energy }
 func (f *Fee) Total() *big.Int          { return new(big.Int).Set(f.total) }
 func (f *Fee) Currency() string         { return f.currency }
 
+// Token represents a fungible token contract
+type Token struct {
+	address  *valueobjects.Address
+	symbol   string
+	decimals uint8
+}
+
+// NewToken creates a new Token entity
+func NewToken(address *valueobjects.Address, symbol string, decimals uint8) (*Token, error) {
+	if address == nil {
+		return nil, fmt.Errorf("token address cannot be nil")
+	}
+	return &Token{
+		address:  address,
+		symbol:   symbol,
+		decimals: decimals,
+	}, nil
+}
+
+// Getters
+func (t *Token) Address() *valueobjects.Address { return t.address }
+func (t *Token) Symbol() string                 { return t.symbol }
+func (t *Token) Decimals() uint8                { return t.decimals }
+
 // Network represents network information
 type Network struct {
 	id          string
@@ -350,11 +553,28 @@ type Network struct {
 	name        string
 	rpcURL      string
 	explorerURL string
+	endpoints   []EndpointHealth
 	isActive    bool
 	createdAt   time.Time
 	updatedAt   time.Time
 }
 
+// EndpointHealth is the observed health of an RPC endpoint of a network
+type EndpointHealth struct {
+	URL     string
+	Healthy bool
+	// Latency is the moving average of the endpoint's response time
+	Latency time.Duration
+	// ErrorRate is the moving average share of failed requests, between 0 and 1
+	ErrorRate   float64
+	BlockHeight uint64
+	// Stale reports the endpoint trails the highest block height seen by more than the allowed lag
+	Stale     bool
+	Requests  uint64
+	Failures  uint64
+	LastError string
+}
+
 // NewNetwork creates a new Network entity
 func NewNetwork(chainID, name, rpcURL string) (*Network, error) {
 	if chainID == "" {
@@ -395,6 +615,15 @@ func (n *Network) SetExplorerURL(url string) {
 	n.updatedAt = time.Now()
 }
 
+// Endpoints returns the health of the network's RPC endpoints, empty for single-endpoint networks
+func (n *Network) Endpoints() []EndpointHealth { return n.endpoints }
+
+// SetEndpoints sets the health of the network's RPC endpoints
+func (n *Network) SetEndpoints(endpoints []EndpointHealth) {
+	n.endpoints = endpoints
+	n.updatedAt = time.Now()
+}
+
 // Activate activates the network
 func (n *Network) Activate() {
 	n.isActive = true
@@ -110,3 +110,79 @@ func TestNetworkGetters(t *testing.T) {
 	require.NotZero(t, net.CreatedAt())
 	require.NotZero(t, net.UpdatedAt())
 }
+
+func TestTransactionFeeAndNonceSetters(t *testing.T) {
+	t.Parallel()
+
+	from, _ := valueobjects.NewAddress("0xfrom", "chain")
+	to, _ := valueobjects.NewAddress("0xto", "chain")
+	tx, _ := NewTransaction(TransactionParams{ChainID: "chain", From: from, To: to})
+
+	require.Error(t, tx.SetNonce(nil))
+	require.NoError(t, tx.SetNonce(valueobjects.NewNonce(7)))
+	require.Equal(t, uint64(7), tx.Nonce().Value())
+
+	tx.SetGasLimit(21000)
+	require.Equal(t, uint64(21000), tx.GasLimit())
+
+	require.Error(t, tx.SetGasPrice(nil))
+	require.Error(t, tx.SetGasPrice(big.NewInt(-1)))
+	require.NoError(t, tx.SetGasPrice(big.NewInt(20)))
+	require.Equal(t, big.NewInt(20), tx.GasPrice())
+
+	require.Error(t, tx.SetDynamicFees(nil, big.NewInt(1)))
+	require.Error(t, tx.SetDynamicFees(big.NewInt(10), nil))
+	require.Error(t, tx.SetDynamicFees(big.NewInt(10), big.NewInt(11)))
+	require.NoError(t, tx.SetDynamicFees(big.NewInt(30), big.NewInt(2)))
+	require.Equal(t, big.NewInt(30), tx.MaxFeePerGas())
+	require.Equal(t, big.NewInt(2), tx.MaxPriorityFee())
+}
+
+func TestTokenGetters(t *testing.T) {
+	t.Parallel()
+	_, err := NewToken(nil, "USDC", 6)
+	require.Error(t, err)
+
+	addr, _ := valueobjects.NewAddress("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", "ethereum")
+	token, err := NewToken(addr, "USDC", 6)
+	require.NoError(t, err)
+	require.Equal(t, addr, token.Address())
+	require.Equal(t, "USDC", token.Symbol())
+	require.Equal(t, uint8(6), token.Decimals())
+}
+
+func TestResourceFee(t *testing.T) {
+	t.Parallel()
+	_, err := NewResourceFee(268, 0, nil, big.NewInt(0), "TRX")
+	require.Error(t, err)
+	_, err = NewResourceFee(268, 0, big.NewInt(420), big.NewInt(-1), "TRX")
+	require.Error(t, err)
+	_, err = NewResourceFee(268, 0, big.NewInt(420), big.NewInt(0), "")
+	require.Error(t, err)
+
+	fee, err := NewResourceFee(345, 29650, big.NewInt(420), big.NewInt(12798000), "TRX")
+	require.NoError(t, err)
+	require.Equal(t, uint64(345), fee.Bandwidth())
+	require.Equal(t, uint64(29650), fee.Energy())
+	require.Equal(t, uint64(29650), fee.GasLimit())
+	require.Equal(t, big.NewInt(420), fee.GasPrice())
+	require.Equal(t, big.NewInt(12798000), fee.Total())
+	require.Equal(t, "TRX", fee.Currency())
+}
+
+func TestComputeUnitFee(t *testing.T) {
+	t.Parallel()
+	_, err := NewComputeUnitFee(200000, nil, big.NewInt(5000), "SOL")
+	require.Error(t, err)
+	_, err = NewComputeUnitFee(200000, big.NewInt(1000), big.NewInt(-1), "SOL")
+	require.Error(t, err)
+	_, err = NewComputeUnitFee(200000, big.NewInt(1000), big.NewInt(5000), "")
+	require.Error(t, err)
+
+	fee, err := NewComputeUnitFee(200000, big.NewInt(1000), big.NewInt(5200), "SOL")
+	require.NoError(t, err)
+	require.Equal(t, uint64(200000), fee.GasLimit())
+	require.Equal(t, big.NewInt(1000), fee.GasPrice())
+	require.Equal(t, big.NewInt(5200), fee.Total())
+	require.Equal(t, "SOL", fee.Currency())
+}
@@ -95,6 +95,14 @@ func TestNewChain(t *testing.T) {
 	}
 }
 
+func TestChain_SetDecimals(t *testing.T) {
+	chain, _ := NewChain("ethereum", ChainTypeEVM, "1", false, "ETH")
+	assert.Zero(t, chain.Decimals())
+
+	chain.SetDecimals(18)
+	assert.Equal(t, uint8(18), chain.Decimals())
+}
+
 func TestNewTransaction(t *testing.T) {
 	from, _ := valueobjects.NewAddress("0xfrom", "ethereum")
 	to, _ := valueobjects.NewAddress("0xto", "ethereum")
@@ -306,6 +314,57 @@ func TestTransaction_SetMetadata(t *testing.T) {
 	assert.Equal(t, "value", tx.Metadata()["key"])
 }
 
+func TestRestoreTransaction(t *testing.T) {
+	from, _ := valueobjects.NewAddress("0xfrom", "ethereum")
+	to, _ := valueobjects.NewAddress("0xto", "ethereum")
+	hash, _ := valueobjects.NewHash("0xabcdef")
+	sig, _ := valueobjects.NewSignature("0x1234")
+	createdAt := time.Now().Add(-time.Hour)
+
+	tx, err := RestoreTransaction(TransactionState{
+		ID:             "4c9b7c36-0d0b-4a44-9a5c-8d3a4b1c2d3e",
+		ChainID:        "ethereum",
+		Hash:           hash,
+		From:           from,
+		To:             to,
+		Value:          big.NewInt(1000),
+		Nonce:          valueobjects.NewNonce(7),
+		GasLimit:       21000,
+		MaxFeePerGas:   big.NewInt(30),
+		MaxPriorityFee: big.NewInt(2),
+		Signature:      sig,
+		Status:         TxStatusConfirmed,
+		BlockNumber:    100,
+		Confirmations:  3,
+		Metadata:       map[string]interface{}{"key": "value"},
+		CreatedAt:      createdAt,
+		UpdatedAt:      createdAt,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "4c9b7c36-0d0b-4a44-9a5c-8d3a4b1c2d3e", tx.ID())
+	assert.Equal(t, hash, tx.Hash())
+	assert.Equal(t, uint64(7), tx.Nonce().Value())
+	assert.Equal(t, big.NewInt(30), tx.MaxFeePerGas())
+	assert.Equal(t, sig, tx.Signature())
+	assert.Equal(t, TxStatusConfirmed, tx.Status())
+	assert.Equal(t, uint64(3), tx.Confirmations())
+	assert.Equal(t, "value", tx.Metadata()["key"])
+	assert.Equal(t, createdAt, tx.CreatedAt())
+
+	tx, err = RestoreTransaction(TransactionState{ID: "id", ChainID: "ethereum", From: from, To: to})
+	require.NoError(t, err)
+	assert.Equal(t, TxStatusPending, tx.Status())
+	assert.Equal(t, int64(0), tx.Value().Int64())
+	tx.SetMetadata("key", "value")
+
+	_, err = RestoreTransaction(TransactionState{ChainID: "ethereum", From: from, To: to})
+	assert.Error(t, err)
+	_, err = RestoreTransaction(TransactionState{ID: "id", ChainID: "ethereum", From: from})
+	assert.Error(t, err)
+	_, err = RestoreTransaction(TransactionState{ID: "id", ChainID: "ethereum", From: from, To: to, Value: big.NewInt(-1)})
+	assert.Error(t, err)
+}
+
 func TestNewWallet(t *testing.T) {
 	addr, _ := valueobjects.NewAddress("0xwallet", "ethereum")
 
@@ -596,6 +655,19 @@ func TestNetwork_SetExplorerURL(t *testing.T) {
 	assert.Equal(t, "https://etherscan.io", network.ExplorerURL())
 }
 
+func TestNetwork_SetEndpoints(t *testing.T) {
+	network, _ := NewNetwork("ethereum", "Ethereum Mainnet", "https://eth.llamarpc.com")
+	assert.Empty(t, network.Endpoints())
+
+	network.SetEndpoints([]EndpointHealth{
+		{URL: "https://eth.llamarpc.com", Healthy: true, BlockHeight: 100},
+		{URL: "https://rpc.ankr.com/eth", Stale: true, BlockHeight: 90},
+	})
+	require.Len(t, network.Endpoints(), 2)
+	assert.True(t, network.Endpoints()[0].Healthy)
+	assert.True(t, network.Endpoints()[1].Stale)
+}
+
 func TestNetwork_ActivateDeactivate(t *testing.T) {
 	network, _ := NewNetwork("ethereum", "Ethereum Mainnet", "https://eth.llamarpc.com")
 
@@ -20,6 +20,7 @@ const (
 	EventTypeTransactionFailed      EventType = "transaction.failed"
 	EventTypeBalanceQueried         EventType = "balance.queried"
 	EventTypeFeeEstimated           EventType = "fee.estimated"
+	EventTypeRegistryChanged        EventType = "registry.changed"
 )
 
 // BaseEvent contains common event fields
@@ -227,3 +228,21 @@ func NewFeeEstimatedEvent(chainID string, fee *entities.Fee, txID string) *FeeEs
 
 	return event
 }
+
+// RegistryChangedEvent is published when a configuration reload changes the registered chain adapters
+type RegistryChangedEvent struct {
+	BaseEvent
+	Added    []string `json:"added,omitempty"`
+	Removed  []string `json:"removed,omitempty"`
+	Replaced []string `json:"replaced,omitempty"`
+}
+
+// NewRegistryChangedEvent creates a new registry changed event; it concerns no single chain
+func NewRegistryChangedEvent(added, removed, replaced []string) *RegistryChangedEvent {
+	return &RegistryChangedEvent{
+		BaseEvent: NewBaseEvent(EventTypeRegistryChanged, ""),
+		Added:     added,
+		Removed:   removed,
+		Replaced:  replaced,
+	}
+}
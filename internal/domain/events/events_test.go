@@ -178,3 +178,14 @@ func TestNewFeeEstimatedEvent(t *testing.T) {
 		assert.Equal(t, "2000000000", event.MaxPriorityFee)
 	})
 }
+
+func TestNewRegistryChangedEvent(t *testing.T) {
+	event := NewRegistryChangedEvent([]string{"base"}, []string{"polygon"}, []string{"ethereum"})
+
+	assert.NotEmpty(t, event.ID)
+	assert.Equal(t, EventTypeRegistryChanged, event.Type)
+	assert.Empty(t, event.ChainID)
+	assert.Equal(t, []string{"base"}, event.Added)
+	assert.Equal(t, []string{"polygon"}, event.Removed)
+	assert.Equal(t, []string{"ethereum"}, event.Replaced)
+}
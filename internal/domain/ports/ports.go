@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"errors"
 	"math/big"
 
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
@@ -49,8 +50,8 @@ type TransactionBuilder interface {
 
 // TransactionSigner defines the interface for signing transactions
 type TransactionSigner interface {
-	// SignTransaction signs a transaction
-	SignTransaction(ctx context.Context, tx *entities.Transaction, privateKey []byte) error
+	// SignTransaction signs a transaction with the key keyID of a KeyManager
+	SignTransaction(ctx context.Context, tx *entities.Transaction, keys KeyManager, keyID string) error
 
 	// VerifySignature verifies a transaction signature
 	VerifySignature(ctx context.Context, tx *entities.Transaction) (bool, error)
@@ -106,6 +107,74 @@ type ChainAdapter interface {
 	NetworkInfoProvider
 }
 
+// AdapterWrapper is implemented by decorators of chain adapters, which hide the optional
+// capabilities of the adapter they wrap
+type AdapterWrapper interface {
+	// Unwrap returns the decorated adapter
+	Unwrap() ChainAdapter
+}
+
+// AdapterDrainer is implemented by adapters that can wait for their in-flight calls, so that an
+// adapter taken out of service finishes the requests it is serving
+type AdapterDrainer interface {
+	// Drain waits until no call is in flight or the context is done
+	Drain(ctx context.Context) error
+}
+
+// ChainDescriber is implemented by adapters that can describe the chain they serve
+type ChainDescriber interface {
+	// DescribeChain returns the chain's type, network ID, testnet flag and native token
+	DescribeChain() (*entities.Chain, error)
+}
+
+// TokenMetadataProvider is implemented by adapters that can describe token contracts
+type TokenMetadataProvider interface {
+	// GetTokenMetadata returns the symbol and decimals of a token contract
+	GetTokenMetadata(ctx context.Context, tokenAddress *valueobjects.Address) (*entities.Token, error)
+}
+
+// TokenTransferEncoder is implemented by adapters that can encode token transfer calldata
+type TokenTransferEncoder interface {
+	// EncodeTokenTransfer returns the calldata transferring amount tokens to the recipient
+	EncodeTokenTransfer(to *valueobjects.Address, amount *big.Int) ([]byte, error)
+}
+
+// PSBTProvider is implemented by adapters that exchange partially signed transactions (BIP-174)
+type PSBTProvider interface {
+	// ExportPSBT encodes an unsigned transaction as a base64 PSBT
+	ExportPSBT(ctx context.Context, tx *entities.Transaction) (string, error)
+	// CombinePSBT merges PSBTs of the same transaction signed by different parties
+	CombinePSBT(psbts ...string) (string, error)
+	// FinalizePSBT completes a fully signed PSBT and returns the raw transaction in hex
+	FinalizePSBT(psbt string) (string, error)
+	// BroadcastPSBT finalizes a fully signed PSBT and broadcasts it
+	BroadcastPSBT(ctx context.Context, psbt string) (*valueobjects.Hash, error)
+}
+
+// MetadataFee is the transaction metadata key under which adapters record the fee paid, in the
+// smallest unit of the native token, as a decimal string
+const MetadataFee = "fee"
+
+// FeeBumper is implemented by adapters that can accelerate unconfirmed transactions; the
+// transactions it returns record their fee under MetadataFee
+type FeeBumper interface {
+	// BumpFee builds and signs a replacement of an unconfirmed transaction paying feeRate (BIP-125 replace-by-fee)
+	BumpFee(ctx context.Context, hash *valueobjects.Hash, feeRate *big.Int, keys KeyManager, keyID string) (*entities.Transaction, error)
+	// CPFP builds and signs a child spending an unconfirmed transaction's output so that both pay feeRate (child-pays-for-parent)
+	CPFP(ctx context.Context, hash *valueobjects.Hash, feeRate *big.Int, keys KeyManager, keyID string) (*entities.Transaction, error)
+}
+
+// ErrInvalidRawTransaction is wrapped by RawBroadcaster when a raw transaction cannot be decoded
+// or fails validation
+var ErrInvalidRawTransaction = errors.New("invalid raw transaction")
+
+// RawBroadcaster is implemented by adapters that relay transactions signed elsewhere
+type RawBroadcaster interface {
+	// BroadcastRaw decodes a signed transaction in the wire format of the chain, validates it and
+	// relays it, returning the decoded transaction with its hash set
+	BroadcastRaw(ctx context.Context, raw []byte) (*entities.Transaction, error)
+}
+
 // EventPublisher defines the interface for publishing domain events
 type EventPublisher interface {
 	// Publish publishes an event
@@ -127,6 +196,13 @@ type EventSubscriber interface {
 // EventHandler handles domain events
 type EventHandler func(ctx context.Context, event interface{}) error
 
+// HealthChecker is implemented by dependencies whose availability can be checked, such as the
+// event bus or a database
+type HealthChecker interface {
+	// HealthCheck returns an error when the dependency cannot serve requests
+	HealthCheck(ctx context.Context) error
+}
+
 // EventBus combines publisher and subscriber
 type EventBus interface {
 	EventPublisher
@@ -147,9 +223,24 @@ type ChainRegistry interface {
 	// Unregister unregisters a chain adapter
 	Unregister(chainID string) error
 
-	// Get returns a chain adapter by ID
+	// Replace swaps the adapter of a registered chain, returning the adapter it replaced
+	Replace(chainID string, adapter ChainAdapter) (ChainAdapter, error)
+
+	// Get returns a chain adapter by ID, refusing chains that are disabled
 	Get(chainID string) (ChainAdapter, error)
 
+	// Lookup returns a chain adapter by ID whether or not the chain is enabled
+	Lookup(chainID string) (ChainAdapter, error)
+
+	// SetActive enables or disables a registered chain
+	SetActive(ctx context.Context, chainID string, active bool) error
+
+	// IsActive checks if a registered chain is enabled
+	IsActive(chainID string) bool
+
+	// Describe returns the descriptor of a registered chain, taken from its adapter on registration
+	Describe(chainID string) (*entities.Chain, error)
+
 	// List returns all registered chain IDs
 	List() []string
 
@@ -157,6 +248,67 @@ type ChainRegistry interface {
 	Has(chainID string) bool
 }
 
+// ErrTransactionNotFound is returned by a TransactionRepository for unknown transaction IDs
+var ErrTransactionNotFound = errors.New("transaction not found")
+
+// TransactionRepository stores transactions between the requests that create, sign and broadcast them
+type TransactionRepository interface {
+	// Save stores a transaction, replacing the stored state of a transaction with the same ID
+	Save(ctx context.Context, tx *entities.Transaction) error
+
+	// GetByID returns a stored transaction, or an error wrapping ErrTransactionNotFound
+	GetByID(ctx context.Context, id string) (*entities.Transaction, error)
+}
+
+// ErrKeyNotFound is returned by a KeyManager for unknown key IDs
+var ErrKeyNotFound = errors.New("key not found")
+
+// Curves of the keys held by a KeyManager
+const (
+	CurveSecp256k1 = "secp256k1"
+	CurveEd25519   = "ed25519"
+)
+
+// KeyInfo describes a key held by a KeyManager
+type KeyInfo struct {
+	ID    string
+	Curve string
+	// PublicKey is compressed (33 bytes) for secp256k1 keys and 32 bytes for ed25519 keys
+	PublicKey []byte
+}
+
+// KeyManager holds the keys transactions are signed with and signs with them, so that key
+// material never leaves it; requests reference keys by ID
+type KeyManager interface {
+	// Sign signs digest with a key. secp256k1 keys sign a 32-byte digest and return a 65-byte
+	// [R || S || V] signature with low S and V in {0, 1}; ed25519 keys return the 64-byte
+	// signature of digest taken as the message
+	Sign(ctx context.Context, keyID string, digest []byte) ([]byte, error)
+
+	// PublicKey returns the public key of a key, as in KeyInfo
+	PublicKey(ctx context.Context, keyID string) ([]byte, error)
+
+	// ListKeys describes the keys held, ordered by ID
+	ListKeys(ctx context.Context) ([]KeyInfo, error)
+}
+
+// ChainSpec describes a chain network registered at runtime
+type ChainSpec struct {
+	// Type is the chain kind: evm, tron, bitcoin or solana
+	Type string
+	// Network is the network name, as in the networks of config.yaml
+	Network string
+	// Settings holds the rest of the network entry under its config.yaml keys, such as
+	// rpc_url, endpoints or chain_id
+	Settings map[string]interface{}
+}
+
+// ChainFactory creates the adapters of chain networks described at runtime
+type ChainFactory interface {
+	// NewChain creates the adapter of a network, returning the chain ID it is registered under
+	NewChain(spec ChainSpec) (string, ChainAdapter, error)
+}
+
 // Logger defines the interface for structured logging
 type Logger interface {
 	// Debug logs a debug message
@@ -15,12 +15,15 @@ import (
 
 // Config holds database configuration
 type Config struct {
-	Host     string
-	Port     int
-	User     string
-	Password string
-	DBName   string
-	SSLMode  string
+	// Host is the PostgreSQL server; the database is not used without one
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	DBName   string `yaml:"name"`
+	SSLMode  string `yaml:"sslmode"`
+	// Migrations is the directory of the migrations applied on startup; none are applied when empty
+	Migrations string `yaml:"migrations"`
 }
 
 // DB wraps sqlx.DB with additional functionality
@@ -53,6 +56,14 @@ func (db *DB) Ping(ctx context.Context) error {
 	return db.PingContext(ctx)
 }
 
+// HealthCheck pings the database
+func (db *DB) HealthCheck(ctx context.Context) error {
+	if err := db.Ping(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+	return nil
+}
+
 // Close closes the database connection
 func (db *DB) Close() error {
 	return db.DB.Close()
@@ -11,6 +11,7 @@ import (
 // InMemoryEventBus is an in-memory implementation of EventBus
 type InMemoryEventBus struct {
 	subscribers map[string][]ports.EventHandler
+	running     bool
 	mu          sync.RWMutex
 	logger      ports.Logger
 }
@@ -135,6 +136,10 @@ func (bus *InMemoryEventBus) Unsubscribe(ctx context.Context, eventType string)
 
 // Start starts the event bus
 func (bus *InMemoryEventBus) Start(ctx context.Context) error {
+	bus.mu.Lock()
+	bus.running = true
+	bus.mu.Unlock()
+
 	bus.logger.Info("event bus started", map[string]interface{}{})
 	return nil
 }
@@ -145,11 +150,23 @@ func (bus *InMemoryEventBus) Stop(ctx context.Context) error {
 	defer bus.mu.Unlock()
 
 	bus.subscribers = make(map[string][]ports.EventHandler)
+	bus.running = false
 
 	bus.logger.Info("event bus stopped", map[string]interface{}{})
 	return nil
 }
 
+// HealthCheck reports whether the event bus is started
+func (bus *InMemoryEventBus) HealthCheck(ctx context.Context) error {
+	bus.mu.RLock()
+	defer bus.mu.RUnlock()
+
+	if !bus.running {
+		return fmt.Errorf("event bus is not running")
+	}
+	return nil
+}
+
 // getEventType extracts the event type from an event
 func getEventType(event interface{}) string {
 	type eventTyper interface {
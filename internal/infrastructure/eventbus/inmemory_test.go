@@ -197,12 +197,15 @@ func TestInMemoryEventBus_StartStop(t *testing.T) {
 	logger := mocks.NewMockLogger()
 	bus := NewInMemoryEventBus(logger)
 	ctx := context.Background()
+	require.Error(t, bus.HealthCheck(ctx))
 
 	err := bus.Start(ctx)
 	require.NoError(t, err)
+	require.NoError(t, bus.HealthCheck(ctx))
 
 	err = bus.Stop(ctx)
 	require.NoError(t, err)
+	require.Error(t, bus.HealthCheck(ctx))
 
 	assert.Empty(t, bus.subscribers)
 }
@@ -30,9 +30,10 @@ type RedisStreamBackend struct {
 
 // RedisConfig holds Redis configuration
 type RedisConfig struct {
-	Addr     string
-	Password string
-	DB       int
+	// Addr is the host:port of the server; Redis is not used without one
+	Addr     string `yaml:"addr"`
+	Password string `yaml:"password"`
+	DB       int    `yaml:"db"`
 }
 
 // NewRedisStreamBackend creates a new Redis Streams backend
@@ -222,6 +223,14 @@ func (r *RedisStreamBackend) replayFromTime(ctx context.Context, stream string,
 	}
 }
 
+// HealthCheck pings the Redis server
+func (r *RedisStreamBackend) HealthCheck(ctx context.Context) error {
+	if err := r.client.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("failed to ping Redis: %w", err)
+	}
+	return nil
+}
+
 // Close closes the Redis connection
 func (r *RedisStreamBackend) Close() error {
 	return r.client.Close()
@@ -0,0 +1,266 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+)
+
+// Statuses of components and reports
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+	// StatusDegraded is the status of a report with components down while the service is ready
+	StatusDegraded = "degraded"
+	// StatusDisabled is the status of chains disabled through the admin API, which are not checked
+	StatusDisabled = "disabled"
+)
+
+// ComponentReport is the result of the check of a component
+type ComponentReport struct {
+	Name   string
+	Kind   string
+	Status string
+	// Required reports whether the readiness of the service depends on the component
+	Required bool
+	Error    string
+	Latency  time.Duration
+	// BlockHeight and BlockAge, the time since the height last changed, are set for chains
+	BlockHeight uint64
+	BlockAge    time.Duration
+}
+
+// Report is the result of the check of every component
+type Report struct {
+	// Status is up when every component is up, degraded when some are down but the service is
+	// ready, and down when it is not ready
+	Status        string
+	Ready         bool
+	HealthyChains int
+	CheckedAt     time.Time
+	Components    []ComponentReport
+}
+
+// Checker checks the chains and the dependencies the service relies on
+type Checker struct {
+	registry   ports.ChainRegistry
+	config     Config
+	logger     ports.Logger
+	mu         sync.Mutex
+	components []component
+	progress   map[string]blockProgress
+	ready      bool
+	now        func() time.Time
+}
+
+type component struct {
+	kind    string
+	checker ports.HealthChecker
+}
+
+// blockProgress is the last block height seen for a chain and when it was first seen
+type blockProgress struct {
+	height uint64
+	since  time.Time
+}
+
+// NewChecker creates a Checker of the chains of a registry
+func NewChecker(registry ports.ChainRegistry, config Config, logger ports.Logger) *Checker {
+	return &Checker{
+		registry: registry,
+		config:   config,
+		logger:   logger,
+		progress: make(map[string]blockProgress),
+		ready:    true,
+		now:      time.Now,
+	}
+}
+
+// Add checks a dependency as the component of a kind, such as KindDatabase
+func (c *Checker) Add(kind string, checker ports.HealthChecker) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.components = append(c.components, component{kind: kind, checker: checker})
+}
+
+// Check checks every component concurrently and applies the readiness policy
+func (c *Checker) Check(ctx context.Context) *Report {
+	c.mu.Lock()
+	components := append([]component(nil), c.components...)
+	c.mu.Unlock()
+	chains := c.registry.List()
+	sort.Strings(chains)
+
+	reports := make([]ComponentReport, len(components)+len(chains))
+	var wg sync.WaitGroup
+	for i, dependency := range components {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reports[i] = c.checkComponent(ctx, dependency)
+		}()
+	}
+	for i, chainID := range chains {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reports[len(components)+i] = c.checkChain(ctx, chainID)
+		}()
+	}
+	wg.Wait()
+	c.forget(chains)
+
+	report := &Report{
+		Status:     StatusUp,
+		Ready:      true,
+		CheckedAt:  c.now(),
+		Components: reports,
+	}
+	for i := range reports {
+		result := &reports[i]
+		result.Required = result.Status != StatusDisabled && c.config.Readiness.requires(result.Name, result.Kind)
+		switch {
+		case result.Status == StatusUp && result.Kind == KindChain:
+			report.HealthyChains++
+		case result.Status == StatusDown:
+			report.Status = StatusDegraded
+			if result.Required {
+				report.Ready = false
+			}
+		}
+	}
+	if report.HealthyChains < c.config.Readiness.MinHealthyChains {
+		report.Ready = false
+	}
+	if !report.Ready {
+		report.Status = StatusDown
+	}
+
+	c.logTransition(report)
+	return report
+}
+
+func (c *Checker) checkComponent(ctx context.Context, dependency component) ComponentReport {
+	report := ComponentReport{Name: dependency.kind, Kind: dependency.kind, Status: StatusUp}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	err := dependency.checker.HealthCheck(ctx)
+	report.Latency = time.Since(start)
+	if err != nil {
+		report.Status = StatusDown
+		report.Error = err.Error()
+	}
+	return report
+}
+
+// checkChain checks that a chain's node is connected and that its block height keeps advancing
+func (c *Checker) checkChain(ctx context.Context, chainID string) (report ComponentReport) {
+	report = ComponentReport{Name: chainID, Kind: KindChain, Status: StatusUp}
+	if !c.registry.IsActive(chainID) {
+		report.Status = StatusDisabled
+		return report
+	}
+	adapter, err := c.registry.Lookup(chainID)
+	if err != nil {
+		report.Status = StatusDown
+		report.Error = err.Error()
+		return report
+	}
+
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	defer func() {
+		report.Latency = time.Since(start)
+	}()
+
+	if !adapter.IsConnected(ctx) {
+		report.Status = StatusDown
+		report.Error = "node is not connected"
+		return report
+	}
+	report.BlockHeight, err = adapter.GetBlockNumber(ctx)
+	if err != nil {
+		report.Status = StatusDown
+		report.Error = fmt.Sprintf("failed to get block number: %v", err)
+		return report
+	}
+
+	report.BlockAge = c.advance(chainID, report.BlockHeight)
+	if staleAfter := c.config.StaleAfterFor(chainID); staleAfter > 0 && report.BlockAge > staleAfter {
+		report.Status = StatusDown
+		report.Error = fmt.Sprintf("block height has not changed for %s", report.BlockAge.Round(time.Second))
+	}
+	return report
+}
+
+// advance records the block height of a chain and returns how long it has been unchanged
+func (c *Checker) advance(chainID string, height uint64) time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	progress, ok := c.progress[chainID]
+	if !ok || progress.height != height {
+		progress = blockProgress{height: height, since: now}
+		c.progress[chainID] = progress
+	}
+	return now.Sub(progress.since)
+}
+
+// forget drops the block heights of chains no longer registered
+func (c *Checker) forget(chains []string) {
+	registered := make(map[string]bool, len(chains))
+	for _, chainID := range chains {
+		registered[chainID] = true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for chainID := range c.progress {
+		if !registered[chainID] {
+			delete(c.progress, chainID)
+		}
+	}
+}
+
+// logTransition logs when the service becomes ready or not ready
+func (c *Checker) logTransition(report *Report) {
+	c.mu.Lock()
+	changed := c.ready != report.Ready
+	c.ready = report.Ready
+	c.mu.Unlock()
+	if !changed {
+		return
+	}
+
+	if report.Ready {
+		c.logger.Info("service is ready", nil)
+		return
+	}
+	var failing []string
+	for _, result := range report.Components {
+		if result.Status == StatusDown {
+			failing = append(failing, result.Name)
+		}
+	}
+	c.logger.Warn("service is not ready", map[string]interface{}{
+		"failing":        failing,
+		"healthy_chains": report.HealthyChains,
+	})
+}
+
+func (c *Checker) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.config.Timeout > 0 {
+		return context.WithTimeout(ctx, c.config.Timeout)
+	}
+	return context.WithCancel(ctx)
+}
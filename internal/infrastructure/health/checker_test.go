@@ -0,0 +1,188 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// checkFunc adapts a function to ports.HealthChecker
+type checkFunc func(ctx context.Context) error
+
+func (f checkFunc) HealthCheck(ctx context.Context) error {
+	return f(ctx)
+}
+
+// nodeAdapter is a chain adapter whose connectivity and block height are set by the test
+type nodeAdapter struct {
+	mocks.MockChainAdapter
+	disconnected atomic.Bool
+	height       atomic.Uint64
+}
+
+func (a *nodeAdapter) IsConnected(ctx context.Context) bool {
+	return !a.disconnected.Load()
+}
+
+func (a *nodeAdapter) GetBlockNumber(ctx context.Context) (uint64, error) {
+	return a.height.Load(), nil
+}
+
+func findComponent(t *testing.T, report *Report, name string) ComponentReport {
+	t.Helper()
+	for _, component := range report.Components {
+		if component.Name == name {
+			return component
+		}
+	}
+	t.Fatalf("component %s not reported", name)
+	return ComponentReport{}
+}
+
+func TestChecker(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	registry := mocks.NewMockChainRegistry()
+	ethereum, bitcoin := &nodeAdapter{}, &nodeAdapter{}
+	ethereum.height.Store(100)
+	bitcoin.height.Store(800000)
+	require.NoError(t, registry.Register("ethereum", ethereum))
+	require.NoError(t, registry.Register("bitcoin-mainnet", bitcoin))
+	require.NoError(t, registry.Register("polygon", &mocks.MockChainAdapter{}))
+	require.NoError(t, registry.SetActive(ctx, "polygon", false))
+
+	config := DefaultConfig()
+	config.Chains = map[string]time.Duration{"bitcoin-mainnet": time.Hour}
+	logger := mocks.NewMockLogger()
+	checker := NewChecker(registry, config, logger)
+	now := time.Now()
+	checker.now = func() time.Time { return now }
+
+	var databaseDown atomic.Bool
+	checker.Add(KindEventBus, checkFunc(func(ctx context.Context) error { return nil }))
+	checker.Add(KindDatabase, checkFunc(func(ctx context.Context) error {
+		if databaseDown.Load() {
+			return errors.New("connection refused")
+		}
+		return nil
+	}))
+
+	report := checker.Check(ctx)
+	assert.Equal(t, StatusUp, report.Status)
+	assert.True(t, report.Ready)
+	assert.Equal(t, 2, report.HealthyChains)
+	require.Len(t, report.Components, 5)
+	assert.Equal(t, KindEventBus, report.Components[0].Name)
+	assert.Equal(t, "bitcoin-mainnet", report.Components[2].Name)
+	assert.True(t, findComponent(t, report, KindDatabase).Required)
+	assert.False(t, findComponent(t, report, "ethereum").Required)
+	assert.Equal(t, uint64(100), findComponent(t, report, "ethereum").BlockHeight)
+	assert.Equal(t, StatusDisabled, findComponent(t, report, "polygon").Status)
+
+	// A chain whose block height stops advancing goes down; the service stays ready
+	now = now.Add(20 * time.Minute)
+	bitcoin.height.Store(800001)
+	report = checker.Check(ctx)
+	assert.Equal(t, StatusDegraded, report.Status)
+	assert.True(t, report.Ready)
+	stale := findComponent(t, report, "ethereum")
+	assert.Equal(t, StatusDown, stale.Status)
+	assert.Equal(t, 20*time.Minute, stale.BlockAge)
+	assert.Contains(t, stale.Error, "block height has not changed")
+	assert.Equal(t, StatusUp, findComponent(t, report, "bitcoin-mainnet").Status)
+
+	ethereum.height.Store(101)
+	ethereum.disconnected.Store(true)
+	report = checker.Check(ctx)
+	assert.Equal(t, "node is not connected", findComponent(t, report, "ethereum").Error)
+
+	// A required dependency going down makes the service not ready
+	databaseDown.Store(true)
+	report = checker.Check(ctx)
+	assert.Equal(t, StatusDown, report.Status)
+	assert.False(t, report.Ready)
+	assert.Equal(t, "connection refused", findComponent(t, report, KindDatabase).Error)
+	require.Len(t, logger.WarnCalls, 1)
+	assert.Equal(t, "service is not ready", logger.WarnCalls[0].Message)
+
+	databaseDown.Store(false)
+	ethereum.disconnected.Store(false)
+	report = checker.Check(ctx)
+	assert.True(t, report.Ready)
+	assert.Equal(t, StatusUp, report.Status)
+	require.Len(t, logger.InfoCalls, 1)
+}
+
+func TestChecker_ReadinessPolicy(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	registry := mocks.NewMockChainRegistry()
+	ethereum := &nodeAdapter{}
+	ethereum.disconnected.Store(true)
+	require.NoError(t, registry.Register("ethereum", ethereum))
+	require.NoError(t, registry.Register("tron", &mocks.MockChainAdapter{}))
+
+	tests := []struct {
+		name   string
+		policy ReadinessPolicy
+		ready  bool
+	}{
+		{"chains not required", ReadinessPolicy{Required: []string{KindEventBus}}, true},
+		{"failing chain required", ReadinessPolicy{Required: []string{"ethereum"}}, false},
+		{"healthy chain required", ReadinessPolicy{Required: []string{"tron"}}, true},
+		{"every chain required", ReadinessPolicy{Required: []string{RequireChains}}, false},
+		{"enough healthy chains", ReadinessPolicy{MinHealthyChains: 1}, true},
+		{"too few healthy chains", ReadinessPolicy{MinHealthyChains: 2}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := DefaultConfig()
+			config.Readiness = tt.policy
+			report := NewChecker(registry, config, mocks.NewMockLogger()).Check(ctx)
+			assert.Equal(t, tt.ready, report.Ready)
+		})
+	}
+}
+
+func TestChecker_Timeout(t *testing.T) {
+	t.Parallel()
+
+	config := DefaultConfig()
+	config.Timeout = 10 * time.Millisecond
+	checker := NewChecker(mocks.NewMockChainRegistry(), config, mocks.NewMockLogger())
+	checker.Add(KindRedis, checkFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+
+	report := checker.Check(context.Background())
+	assert.False(t, report.Ready)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Components[0].Error)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, DefaultConfig().Validate())
+	assert.Equal(t, time.Hour, Config{BlockStaleAfter: time.Minute, Chains: map[string]time.Duration{"bitcoin-mainnet": time.Hour}}.StaleAfterFor("bitcoin-mainnet"))
+
+	config := DefaultConfig()
+	config.Timeout = -time.Second
+	require.Error(t, config.Validate())
+	config = DefaultConfig()
+	config.Chains = map[string]time.Duration{"ethereum": -time.Second}
+	require.ErrorContains(t, config.Validate(), "chain ethereum")
+	config = DefaultConfig()
+	config.Readiness.MinHealthyChains = -1
+	require.ErrorContains(t, config.Validate(), "min_healthy_chains")
+}
@@ -0,0 +1,85 @@
+package health
+
+import (
+	"fmt"
+	"time"
+)
+
+// Component kinds, which also name the components other than chains
+const (
+	KindChain    = "chain"
+	KindEventBus = "eventbus"
+	KindDatabase = "database"
+	KindRedis    = "redis"
+)
+
+// RequireChains, listed in ReadinessPolicy.Required, requires every enabled chain
+const RequireChains = "chains"
+
+// Config describes the health checks (health in config.yaml)
+type Config struct {
+	// Timeout bounds the check of each component
+	Timeout time.Duration `yaml:"timeout"`
+	// BlockStaleAfter is how long the block height of a chain may stay unchanged before the chain
+	// is reported down; zero disables the freshness check
+	BlockStaleAfter time.Duration `yaml:"block_stale_after"`
+	// Chains overrides BlockStaleAfter per chain ID, for chains with slower blocks
+	Chains map[string]time.Duration `yaml:"chains"`
+	// Readiness decides which failures make the service not ready
+	Readiness ReadinessPolicy `yaml:"readiness"`
+}
+
+// ReadinessPolicy decides which failures make the service not ready
+type ReadinessPolicy struct {
+	// Required lists the components that must be up: eventbus, database, redis, a chain ID, or
+	// chains for every enabled chain. Components that are not configured are left out.
+	Required []string `yaml:"required"`
+	// MinHealthyChains is the number of enabled chains that must be up
+	MinHealthyChains int `yaml:"min_healthy_chains"`
+}
+
+// DefaultConfig returns the health checks applied without configuration: the service is ready
+// while its event bus, database and Redis are up, whatever the state of the chains
+func DefaultConfig() Config {
+	return Config{
+		Timeout:         3 * time.Second,
+		BlockStaleAfter: 10 * time.Minute,
+		Readiness: ReadinessPolicy{
+			Required: []string{KindEventBus, KindDatabase, KindRedis},
+		},
+	}
+}
+
+// Validate checks the configuration
+func (c Config) Validate() error {
+	if c.Timeout < 0 || c.BlockStaleAfter < 0 {
+		return fmt.Errorf("health timeout and block_stale_after cannot be negative")
+	}
+	for chainID, staleAfter := range c.Chains {
+		if staleAfter < 0 {
+			return fmt.Errorf("block_stale_after of chain %s cannot be negative", chainID)
+		}
+	}
+	if c.Readiness.MinHealthyChains < 0 {
+		return fmt.Errorf("min_healthy_chains cannot be negative")
+	}
+	return nil
+}
+
+// StaleAfterFor returns how long the block height of a chain may stay unchanged
+func (c Config) StaleAfterFor(chainID string) time.Duration {
+	if staleAfter, ok := c.Chains[chainID]; ok {
+		return staleAfter
+	}
+	return c.BlockStaleAfter
+}
+
+// requires reports whether the readiness of the service depends on a component
+func (p ReadinessPolicy) requires(name, kind string) bool {
+	for _, required := range p.Required {
+		if required == name || (required == RequireChains && kind == KindChain) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCall(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "2.0", req.JSONRPC)
+
+		switch req.Method {
+		case "echo":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": req.Params})
+		case "fail":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"jsonrpc": "2.0", "id": req.ID,
+				"error": map[string]interface{}{"code": -32000, "message": "boom"},
+			})
+		case "broken":
+			_, _ = w.Write([]byte("not json"))
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("unavailable"))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewClient(srv.URL, nil)
+	require.Equal(t, srv.URL, client.URL())
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		var out []string
+		require.NoError(t, client.Call(ctx, &out, "echo", "a", "b"))
+		require.Equal(t, []string{"a", "b"}, out)
+	})
+
+	t.Run("nil result", func(t *testing.T) {
+		t.Parallel()
+		require.NoError(t, client.Call(ctx, nil, "echo"))
+	})
+
+	t.Run("rpc error", func(t *testing.T) {
+		t.Parallel()
+		err := client.Call(ctx, nil, "fail")
+		require.Error(t, err)
+		var rpcErr *Error
+		require.ErrorAs(t, err, &rpcErr)
+		require.Equal(t, -32000, rpcErr.Code)
+		require.Contains(t, err.Error(), "boom")
+	})
+
+	t.Run("malformed response", func(t *testing.T) {
+		t.Parallel()
+		require.Error(t, client.Call(ctx, nil, "broken"))
+	})
+
+	t.Run("http status error", func(t *testing.T) {
+		t.Parallel()
+		err := client.Call(ctx, nil, "unknown")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "503")
+	})
+
+	t.Run("result type mismatch", func(t *testing.T) {
+		t.Parallel()
+		var out int
+		require.Error(t, client.Call(ctx, &out, "echo", "x"))
+	})
+}
+
+func TestClientCall_Unreachable(t *testing.T) {
+	t.Parallel()
+	client := NewClient("http://127.0.0.1:1", nil)
+	require.Error(t, client.Call(context.Background(), nil, "eth_blockNumber"))
+}
@@ -0,0 +1,409 @@
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/sha3"
+)
+
+// Config describes the keystore directory
+type Config struct {
+	// Dir holds the encrypted key files; no keys are loaded without one
+	Dir string `yaml:"dir"`
+	// Password decrypts every key file in Dir
+	Password string `yaml:"password"`
+}
+
+// ScryptParams are the scrypt cost parameters EncryptKey derives the encryption key with
+type ScryptParams struct {
+	N int
+	R int
+	P int
+}
+
+var (
+	// StandardScrypt is the cost geth writes keystore files with
+	StandardScrypt = ScryptParams{N: 1 << 18, R: 8, P: 1}
+	// LightScrypt trades strength for speed, for tests and hosts short of memory
+	LightScrypt = ScryptParams{N: 1 << 12, R: 8, P: 6}
+)
+
+// Ciphers EncryptKey seals private keys with
+const (
+	// CipherAESCTR is the standard v3 cipher, read by geth and other v3 keystore tools
+	CipherAESCTR = "aes-128-ctr"
+	// CipherAESGCM authenticates the ciphertext itself instead of keeping a MAC, but only this
+	// package reads the files sealed with it
+	CipherAESGCM = "aes-256-gcm"
+)
+
+const (
+	keyFileVersion   = 3
+	derivedKeyLength = 32
+	saltLength       = 32
+
+	kdfScrypt = "scrypt"
+	kdfPBKDF2 = "pbkdf2"
+)
+
+// ErrWrongPassword is returned when a keystore file does not decrypt with the password given
+var ErrWrongPassword = errors.New("could not decrypt key with given password")
+
+// keyFile is the layout of an Ethereum v3 keystore file; Curve extends it for ed25519 keys
+type keyFile struct {
+	Version int        `json:"version"`
+	ID      string     `json:"id"`
+	Address string     `json:"address,omitempty"`
+	Curve   string     `json:"curve,omitempty"`
+	Crypto  cryptoJSON `json:"crypto"`
+}
+
+type cryptoJSON struct {
+	Cipher       string          `json:"cipher"`
+	CipherText   string          `json:"ciphertext"`
+	CipherParams cipherParams    `json:"cipherparams"`
+	KDF          string          `json:"kdf"`
+	KDFParams    json.RawMessage `json:"kdfparams"`
+	MAC          string          `json:"mac,omitempty"`
+}
+
+type cipherParams struct {
+	IV string `json:"iv"`
+}
+
+type scryptParamsJSON struct {
+	DKLen int    `json:"dklen"`
+	N     int    `json:"n"`
+	R     int    `json:"r"`
+	P     int    `json:"p"`
+	Salt  string `json:"salt"`
+}
+
+type pbkdf2ParamsJSON struct {
+	DKLen int    `json:"dklen"`
+	C     int    `json:"c"`
+	PRF   string `json:"prf"`
+	Salt  string `json:"salt"`
+}
+
+// EncryptKey encrypts a key into an Ethereum v3 keystore file, sealing the private key with
+// cipherName (CipherAESCTR or CipherAESGCM) under a scrypt-derived key. secp256k1 keys record
+// their Ethereum address, as geth does, and ed25519 keys their curve
+func EncryptKey(key Key, password string, params ScryptParams, cipherName string) ([]byte, error) {
+	parsed, err := parseKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	derived, err := scrypt.Key([]byte(password), salt, params.N, params.R, params.P, derivedKeyLength)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	c := cryptoJSON{Cipher: cipherName, KDF: kdfScrypt}
+	switch cipherName {
+	case CipherAESCTR:
+		err = encryptCTR(&c, derived, key.PrivateKey)
+	case CipherAESGCM:
+		err = encryptGCM(&c, derived, key.PrivateKey)
+	default:
+		return nil, fmt.Errorf("unsupported cipher %q", cipherName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.KDFParams, err = json.Marshal(scryptParamsJSON{
+		DKLen: derivedKeyLength,
+		N:     params.N,
+		R:     params.R,
+		P:     params.P,
+		Salt:  hex.EncodeToString(salt),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	file := keyFile{
+		Version: keyFileVersion,
+		ID:      uuid.NewString(),
+		Crypto:  c,
+	}
+	if parsed.secp != nil {
+		file.Address = ethereumAddress(parsed.secp.PubKey())
+	} else {
+		file.Curve = key.Curve
+	}
+	return json.MarshalIndent(file, "", "  ")
+}
+
+// DecryptKey decrypts an Ethereum v3 keystore file into the key id: files written by geth
+// (scrypt or PBKDF2 with AES-128-CTR) and those written by EncryptKey with either cipher
+func DecryptKey(id string, data []byte, password string) (Key, error) {
+	var file keyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return Key{}, fmt.Errorf("invalid keystore file: %w", err)
+	}
+	if file.Version != keyFileVersion {
+		return Key{}, fmt.Errorf("unsupported keystore version %d", file.Version)
+	}
+	curve := file.Curve
+	if curve == "" {
+		curve = ports.CurveSecp256k1
+	}
+
+	derived, err := deriveKey(file.Crypto, password)
+	if err != nil {
+		return Key{}, err
+	}
+	cipherText, err := hex.DecodeString(file.Crypto.CipherText)
+	if err != nil {
+		return Key{}, fmt.Errorf("invalid ciphertext: %w", err)
+	}
+	iv, err := hex.DecodeString(file.Crypto.CipherParams.IV)
+	if err != nil {
+		return Key{}, fmt.Errorf("invalid iv: %w", err)
+	}
+
+	var privateKey []byte
+	switch file.Crypto.Cipher {
+	case CipherAESCTR:
+		privateKey, err = decryptCTR(derived, cipherText, iv, file.Crypto.MAC)
+	case CipherAESGCM:
+		privateKey, err = decryptGCM(derived, cipherText, iv)
+	default:
+		return Key{}, fmt.Errorf("unsupported cipher %q", file.Crypto.Cipher)
+	}
+	if err != nil {
+		return Key{}, err
+	}
+
+	key := Key{ID: id, Curve: curve, PrivateKey: privateKey}
+	parsed, err := parseKey(key)
+	if err != nil {
+		return Key{}, err
+	}
+	if file.Address != "" && parsed.secp != nil {
+		address := ethereumAddress(parsed.secp.PubKey())
+		if !strings.EqualFold(strings.TrimPrefix(file.Address, "0x"), address) {
+			return Key{}, fmt.Errorf("key does not match address %s", file.Address)
+		}
+	}
+	return key, nil
+}
+
+// deriveKey derives the encryption key of a keystore file from the password
+func deriveKey(c cryptoJSON, password string) ([]byte, error) {
+	switch c.KDF {
+	case kdfScrypt:
+		var params scryptParamsJSON
+		if err := json.Unmarshal(c.KDFParams, &params); err != nil {
+			return nil, fmt.Errorf("invalid scrypt parameters: %w", err)
+		}
+		salt, err := hex.DecodeString(params.Salt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid salt: %w", err)
+		}
+		if params.DKLen < derivedKeyLength {
+			return nil, fmt.Errorf("derived key must be at least %d bytes, got %d", derivedKeyLength, params.DKLen)
+		}
+		derived, err := scrypt.Key([]byte(password), salt, params.N, params.R, params.P, params.DKLen)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive key: %w", err)
+		}
+		return derived, nil
+	case kdfPBKDF2:
+		var params pbkdf2ParamsJSON
+		if err := json.Unmarshal(c.KDFParams, &params); err != nil {
+			return nil, fmt.Errorf("invalid pbkdf2 parameters: %w", err)
+		}
+		if params.PRF != "hmac-sha256" {
+			return nil, fmt.Errorf("unsupported pbkdf2 function %q", params.PRF)
+		}
+		salt, err := hex.DecodeString(params.Salt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid salt: %w", err)
+		}
+		if params.DKLen < derivedKeyLength {
+			return nil, fmt.Errorf("derived key must be at least %d bytes, got %d", derivedKeyLength, params.DKLen)
+		}
+		derived, err := pbkdf2.Key(sha256.New, password, salt, params.C, params.DKLen)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive key: %w", err)
+		}
+		return derived, nil
+	default:
+		return nil, fmt.Errorf("unsupported kdf %q", c.KDF)
+	}
+}
+
+// encryptCTR encrypts with the first half of the derived key under a random IV, then sets the v3
+// MAC, keccak256 of the second half of the derived key and the ciphertext
+func encryptCTR(c *cryptoJSON, derived, plain []byte) error {
+	block, err := aes.NewCipher(derived[:16])
+	if err != nil {
+		return err
+	}
+	iv := make([]byte, block.BlockSize())
+	if _, err := rand.Read(iv); err != nil {
+		return fmt.Errorf("failed to generate iv: %w", err)
+	}
+	cipherText := make([]byte, len(plain))
+	cipher.NewCTR(block, iv).XORKeyStream(cipherText, plain)
+
+	c.CipherText = hex.EncodeToString(cipherText)
+	c.CipherParams = cipherParams{IV: hex.EncodeToString(iv)}
+	c.MAC = hex.EncodeToString(keccak256(derived[16:32], cipherText))
+	return nil
+}
+
+// decryptCTR checks the v3 MAC, keccak256 of the second half of the derived key and the
+// ciphertext, then decrypts with the first half
+func decryptCTR(derived, cipherText, iv []byte, mac string) ([]byte, error) {
+	expected, err := hex.DecodeString(mac)
+	if err != nil || len(expected) == 0 {
+		return nil, fmt.Errorf("invalid mac")
+	}
+	if subtle.ConstantTimeCompare(keccak256(derived[16:32], cipherText), expected) != 1 {
+		return nil, ErrWrongPassword
+	}
+	block, err := aes.NewCipher(derived[:16])
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != block.BlockSize() {
+		return nil, fmt.Errorf("iv must be %d bytes, got %d", block.BlockSize(), len(iv))
+	}
+	plain := make([]byte, len(cipherText))
+	cipher.NewCTR(block, iv).XORKeyStream(plain, cipherText)
+	return plain, nil
+}
+
+// encryptGCM seals with the whole derived key under a random nonce, stored as the IV
+func encryptGCM(c *cryptoJSON, derived, plain []byte) error {
+	aead, err := newGCM(derived)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	c.CipherText = hex.EncodeToString(aead.Seal(nil, nonce, plain, nil))
+	c.CipherParams = cipherParams{IV: hex.EncodeToString(nonce)}
+	return nil
+}
+
+func decryptGCM(derived, cipherText, nonce []byte) ([]byte, error) {
+	aead, err := newGCM(derived[:32])
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("iv must be %d bytes, got %d", aead.NonceSize(), len(nonce))
+	}
+	plain, err := aead.Open(nil, nonce, cipherText, nil)
+	if err != nil {
+		return nil, ErrWrongPassword
+	}
+	return plain, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Load decrypts the keystore files in dir into a Manager. Each file holds one key, whose ID is
+// the file name without its .json extension; hidden files and directories are skipped
+func Load(dir, password string) (*Manager, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore: %w", err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	keys := make([]Key, 0, len(names))
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key file %s: %w", name, err)
+		}
+		key, err := DecryptKey(strings.TrimSuffix(name, ".json"), data, password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt key file %s: %w", name, err)
+		}
+		keys = append(keys, key)
+	}
+	manager, err := NewManager(keys...)
+	for _, key := range keys {
+		clear(key.PrivateKey)
+	}
+	return manager, err
+}
+
+// WriteKey encrypts a key with EncryptKey into dir/<ID>.json, refusing to replace an existing file
+func WriteKey(dir string, key Key, password string, params ScryptParams, cipherName string) error {
+	if key.ID == "" || key.ID != filepath.Base(key.ID) || strings.HasPrefix(key.ID, ".") {
+		return fmt.Errorf("invalid key ID %q", key.ID)
+	}
+	data, err := EncryptKey(key, password, params, cipherName)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create keystore: %w", err)
+	}
+	file, err := os.OpenFile(filepath.Join(dir, key.ID+".json"), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create key file: %w", err)
+	}
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write key file: %w", err)
+	}
+	return file.Close()
+}
+
+// ethereumAddress returns the lowercase hex address of a public key, without 0x
+func ethereumAddress(pub *secp256k1.PublicKey) string {
+	uncompressed := pub.SerializeUncompressed()
+	return hex.EncodeToString(keccak256(uncompressed[1:])[12:])
+}
+
+func keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
@@ -0,0 +1,204 @@
+package keystore
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Test vectors of the Web3 Secret Storage Definition, password "testpassword"
+const (
+	vectorPrivateKey = "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d"
+	vectorPBKDF2     = `{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"6087dab2f9fdbbfaddc31a909735c1e6"},"ciphertext":"5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46","kdf":"pbkdf2","kdfparams":{"c":262144,"dklen":32,"prf":"hmac-sha256","salt":"ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"},"mac":"517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"},"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`
+	vectorScrypt     = `{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"83dbcc02d8ccb40e466191a123791e0e"},"ciphertext":"d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c","kdf":"scrypt","kdfparams":{"dklen":32,"n":262144,"p":8,"r":1,"salt":"ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"},"mac":"2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"},"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`
+)
+
+// A key file written by geth, from its keystore test data, password "foobar"
+const (
+	gethAddress = "f466859ead1932d743d622cb74fc058882e8648a"
+	gethFile    = `{"address":"f466859ead1932d743d622cb74fc058882e8648a","crypto":{"cipher":"aes-128-ctr","ciphertext":"cb664472deacb41a2e995fa7f96fe29ce744471deb8d146a0e43c7898c9ddd4d","cipherparams":{"iv":"dfd9ee70812add5f4b8f89d0811c9158"},"kdf":"scrypt","kdfparams":{"dklen":32,"n":8,"p":16,"r":8,"salt":"0d6769bf016d45c479213990d6a08d938469c4adad8a02ce507b4a4e7b7739f1"},"mac":"bac9af994b15a45dd39669fc66f9aa8a3b9dd8c22cb16e4d8d7ea089d0f1a1a9"},"id":"472e8b3d-afb6-45b5-8111-72c89895099a","version":3}`
+)
+
+func TestDecryptKeyVectors(t *testing.T) {
+	t.Parallel()
+
+	for name, file := range map[string]string{"pbkdf2": vectorPBKDF2, "scrypt": vectorScrypt} {
+		key, err := DecryptKey("vector", []byte(file), "testpassword")
+		require.NoError(t, err, name)
+		assert.Equal(t, "vector", key.ID)
+		assert.Equal(t, ports.CurveSecp256k1, key.Curve)
+		assert.Equal(t, vectorPrivateKey, hex.EncodeToString(key.PrivateKey), name)
+
+		_, err = DecryptKey("vector", []byte(file), "wrong")
+		require.ErrorIs(t, err, ErrWrongPassword, name)
+	}
+}
+
+func TestGethKeyFile(t *testing.T) {
+	t.Parallel()
+
+	key, err := DecryptKey("geth", []byte(gethFile), "foobar")
+	require.NoError(t, err)
+	publicKey := secp256k1.PrivKeyFromBytes(key.PrivateKey).PubKey()
+	assert.Equal(t, gethAddress, ethereumAddress(publicKey))
+
+	// Written back as standard v3, the file has the layout and MAC geth checks
+	data, err := EncryptKey(key, "foobar", LightScrypt, CipherAESCTR)
+	require.NoError(t, err)
+	var file keyFile
+	require.NoError(t, json.Unmarshal(data, &file))
+	assert.Equal(t, gethAddress, file.Address)
+	assert.Empty(t, file.Curve)
+	assert.Equal(t, CipherAESCTR, file.Crypto.Cipher)
+
+	var params scryptParamsJSON
+	require.NoError(t, json.Unmarshal(file.Crypto.KDFParams, &params))
+	salt, err := hex.DecodeString(params.Salt)
+	require.NoError(t, err)
+	derived, err := scrypt.Key([]byte("foobar"), salt, params.N, params.R, params.P, params.DKLen)
+	require.NoError(t, err)
+	cipherText, err := hex.DecodeString(file.Crypto.CipherText)
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(keccak256(derived[16:32], cipherText)), file.Crypto.MAC)
+	iv, err := hex.DecodeString(file.Crypto.CipherParams.IV)
+	require.NoError(t, err)
+	assert.Len(t, iv, 16)
+
+	decrypted, err := DecryptKey("geth", data, "foobar")
+	require.NoError(t, err)
+	assert.Equal(t, key, decrypted)
+}
+
+func TestEncryptKey(t *testing.T) {
+	t.Parallel()
+
+	for _, cipherName := range []string{CipherAESCTR, CipherAESGCM} {
+		for _, key := range []Key{
+			{ID: "treasury", Curve: ports.CurveSecp256k1, PrivateKey: bytes.Repeat([]byte{0x46}, 32)},
+			{ID: "fee-payer", Curve: ports.CurveEd25519, PrivateKey: bytes.Repeat([]byte{0x07}, 32)},
+		} {
+			testEncryptKey(t, key, cipherName)
+		}
+	}
+
+	_, err := EncryptKey(Key{ID: "bad", Curve: ports.CurveSecp256k1, PrivateKey: []byte{1}}, "secret", LightScrypt, CipherAESCTR)
+	require.Error(t, err)
+	_, err = EncryptKey(Key{ID: "a", Curve: ports.CurveSecp256k1, PrivateKey: bytes.Repeat([]byte{0x46}, 32)}, "secret", LightScrypt, "aes-128-cbc")
+	require.Error(t, err)
+}
+
+func testEncryptKey(t *testing.T, key Key, cipherName string) {
+	t.Helper()
+	data, err := EncryptKey(key, "secret", LightScrypt, cipherName)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), hex.EncodeToString(key.PrivateKey))
+
+	var file keyFile
+	require.NoError(t, json.Unmarshal(data, &file))
+	assert.Equal(t, 3, file.Version)
+	assert.Equal(t, cipherName, file.Crypto.Cipher)
+	assert.Equal(t, kdfScrypt, file.Crypto.KDF)
+	// Only the standard v3 cipher keeps a MAC; GCM authenticates the ciphertext
+	assert.Equal(t, cipherName == CipherAESCTR, file.Crypto.MAC != "")
+	if key.Curve == ports.CurveSecp256k1 {
+		// The address of the 0x46... key, as derived by Ethereum clients
+		assert.Equal(t, "9d8a62f656a8d1615c1294fd71e9cfb3e4855a4f", file.Address)
+		assert.Empty(t, file.Curve)
+	} else {
+		assert.Empty(t, file.Address)
+		assert.Equal(t, ports.CurveEd25519, file.Curve)
+	}
+
+	decrypted, err := DecryptKey(key.ID, data, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, key, decrypted)
+
+	_, err = DecryptKey(key.ID, data, "wrong")
+	require.ErrorIs(t, err, ErrWrongPassword)
+}
+
+func TestDecryptKeyErrors(t *testing.T) {
+	t.Parallel()
+
+	data, err := EncryptKey(Key{ID: "a", Curve: ports.CurveSecp256k1, PrivateKey: bytes.Repeat([]byte{0x46}, 32)}, "secret", LightScrypt, CipherAESCTR)
+	require.NoError(t, err)
+	editFile := func(source []byte, change func(file map[string]interface{})) []byte {
+		var file map[string]interface{}
+		require.NoError(t, json.Unmarshal(source, &file))
+		change(file)
+		edited, err := json.Marshal(file)
+		require.NoError(t, err)
+		return edited
+	}
+	edit := func(change func(file map[string]interface{})) []byte {
+		return editFile(data, change)
+	}
+	crypto := func(file map[string]interface{}) map[string]interface{} {
+		return file["crypto"].(map[string]interface{})
+	}
+
+	for name, file := range map[string][]byte{
+		"not json":        []byte("{"),
+		"version":         edit(func(file map[string]interface{}) { file["version"] = 1 }),
+		"cipher":          edit(func(file map[string]interface{}) { crypto(file)["cipher"] = "aes-128-cbc" }),
+		"kdf":             edit(func(file map[string]interface{}) { crypto(file)["kdf"] = "argon2" }),
+		"ciphertext":      edit(func(file map[string]interface{}) { crypto(file)["ciphertext"] = "zz" }),
+		"address":         edit(func(file map[string]interface{}) { file["address"] = "0000000000000000000000000000000000000001" }),
+		"curve":           edit(func(file map[string]interface{}) { file["curve"] = "p256" }),
+		"ctr without mac": editFile([]byte(vectorPBKDF2), func(file map[string]interface{}) { delete(crypto(file), "mac") }),
+		"pbkdf2 prf": editFile([]byte(vectorPBKDF2), func(file map[string]interface{}) {
+			crypto(file)["kdfparams"].(map[string]interface{})["prf"] = "hmac-sha1"
+		}),
+	} {
+		_, err := DecryptKey("a", file, "secret")
+		require.Error(t, err, name)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	treasury := Key{ID: "treasury", Curve: ports.CurveSecp256k1, PrivateKey: bytes.Repeat([]byte{0x46}, 32)}
+	require.NoError(t, WriteKey(dir, treasury, "secret", LightScrypt, CipherAESCTR))
+	require.NoError(t, WriteKey(dir, Key{ID: "fee-payer", Curve: ports.CurveEd25519, PrivateKey: bytes.Repeat([]byte{0x07}, 32)}, "secret", LightScrypt, CipherAESGCM))
+	require.Error(t, WriteKey(dir, treasury, "secret", LightScrypt, CipherAESCTR), "existing files are not replaced")
+	require.Error(t, WriteKey(dir, Key{ID: "../escape", Curve: ports.CurveSecp256k1, PrivateKey: treasury.PrivateKey}, "secret", LightScrypt, CipherAESCTR))
+
+	info, err := os.Stat(filepath.Join(dir, "treasury.json"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// geth names key files without an extension
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "UTC--2016-01-01T00-00-00Z--008aeeda"), []byte(vectorScrypt), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".hidden"), []byte("ignored"), 0o600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "backup"), 0o700))
+
+	_, err = Load(dir, "secret")
+	require.ErrorContains(t, err, "UTC--2016-01-01T00-00-00Z--008aeeda", "every key decrypts with the same password")
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "UTC--2016-01-01T00-00-00Z--008aeeda")))
+	manager, err := Load(dir, "secret")
+	require.NoError(t, err)
+	keys, err := manager.ListKeys(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, "fee-payer", keys[0].ID)
+	assert.Equal(t, "treasury", keys[1].ID)
+
+	_, err = Load(dir, "wrong")
+	require.ErrorIs(t, err, ErrWrongPassword)
+	_, err = Load(filepath.Join(dir, "missing"), "secret")
+	require.Error(t, err)
+}
@@ -0,0 +1,140 @@
+package keystore
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"sort"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+)
+
+const (
+	secp256k1KeyLength = 32
+	digestLength       = 32
+)
+
+// Key is a decrypted signing key
+type Key struct {
+	ID    string
+	Curve string
+	// PrivateKey is a 32-byte secp256k1 scalar, or a 32-byte ed25519 seed or 64-byte keypair
+	PrivateKey []byte
+}
+
+// Manager is a ports.KeyManager holding decrypted keys in memory; it is safe for concurrent use
+type Manager struct {
+	keys map[string]*signingKey
+}
+
+// signingKey is a parsed key; exactly one of secp and ed is set
+type signingKey struct {
+	info ports.KeyInfo
+	secp *secp256k1.PrivateKey
+	ed   ed25519.PrivateKey
+}
+
+// NewManager creates a Manager holding keys
+func NewManager(keys ...Key) (*Manager, error) {
+	m := &Manager{keys: make(map[string]*signingKey, len(keys))}
+	for _, key := range keys {
+		if key.ID == "" {
+			return nil, fmt.Errorf("key ID cannot be empty")
+		}
+		if _, exists := m.keys[key.ID]; exists {
+			return nil, fmt.Errorf("duplicate key %q", key.ID)
+		}
+		parsed, err := parseKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q: %w", key.ID, err)
+		}
+		m.keys[key.ID] = parsed
+	}
+	return m, nil
+}
+
+// parseKey validates a key for its curve and derives its public key
+func parseKey(key Key) (*signingKey, error) {
+	parsed := &signingKey{info: ports.KeyInfo{ID: key.ID, Curve: key.Curve}}
+	switch key.Curve {
+	case ports.CurveSecp256k1:
+		if len(key.PrivateKey) != secp256k1KeyLength {
+			return nil, fmt.Errorf("secp256k1 private key must be %d bytes, got %d", secp256k1KeyLength, len(key.PrivateKey))
+		}
+		var scalar secp256k1.ModNScalar
+		if overflow := scalar.SetByteSlice(key.PrivateKey); overflow || scalar.IsZero() {
+			return nil, fmt.Errorf("invalid secp256k1 private key")
+		}
+		parsed.secp = secp256k1.NewPrivateKey(&scalar)
+		parsed.info.PublicKey = parsed.secp.PubKey().SerializeCompressed()
+	case ports.CurveEd25519:
+		switch len(key.PrivateKey) {
+		case ed25519.SeedSize:
+			parsed.ed = ed25519.NewKeyFromSeed(key.PrivateKey)
+		case ed25519.PrivateKeySize:
+			parsed.ed = ed25519.NewKeyFromSeed(key.PrivateKey[:ed25519.SeedSize])
+			if !bytes.Equal(parsed.ed[ed25519.SeedSize:], key.PrivateKey[ed25519.SeedSize:]) {
+				return nil, fmt.Errorf("keypair public key does not match its seed")
+			}
+		default:
+			return nil, fmt.Errorf("ed25519 private key must be %d or %d bytes, got %d", ed25519.SeedSize, ed25519.PrivateKeySize, len(key.PrivateKey))
+		}
+		parsed.info.PublicKey = append([]byte(nil), parsed.ed.Public().(ed25519.PublicKey)...)
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", key.Curve)
+	}
+	return parsed, nil
+}
+
+func (m *Manager) key(keyID string) (*signingKey, error) {
+	key, ok := m.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ports.ErrKeyNotFound, keyID)
+	}
+	return key, nil
+}
+
+// Sign signs digest with a key
+func (m *Manager) Sign(ctx context.Context, keyID string, digest []byte) ([]byte, error) {
+	key, err := m.key(keyID)
+	if err != nil {
+		return nil, err
+	}
+	if key.ed != nil {
+		return ed25519.Sign(key.ed, digest), nil
+	}
+
+	if len(digest) != digestLength {
+		return nil, fmt.Errorf("digest must be %d bytes, got %d", digestLength, len(digest))
+	}
+	// SignCompact returns [V || R || S] with V in {27, 28} and S in the lower half order
+	compact := ecdsa.SignCompact(key.secp, digest, false)
+	sig := make([]byte, len(compact))
+	copy(sig, compact[1:])
+	sig[64] = compact[0] - 27
+	return sig, nil
+}
+
+// PublicKey returns the public key of a key
+func (m *Manager) PublicKey(ctx context.Context, keyID string) ([]byte, error) {
+	key, err := m.key(keyID)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), key.info.PublicKey...), nil
+}
+
+// ListKeys describes the keys held, ordered by ID
+func (m *Manager) ListKeys(ctx context.Context) ([]ports.KeyInfo, error) {
+	keys := make([]ports.KeyInfo, 0, len(m.keys))
+	for _, key := range m.keys {
+		info := key.info
+		info.PublicKey = append([]byte(nil), info.PublicKey...)
+		keys = append(keys, info)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+	return keys, nil
+}
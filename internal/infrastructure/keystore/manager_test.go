@@ -0,0 +1,103 @@
+package keystore
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ ports.KeyManager = (*Manager)(nil)
+
+func TestManager(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	seed := bytes.Repeat([]byte{0x07}, ed25519.SeedSize)
+	manager, err := NewManager(
+		Key{ID: "treasury", Curve: ports.CurveSecp256k1, PrivateKey: bytes.Repeat([]byte{0x46}, 32)},
+		Key{ID: "fee-payer", Curve: ports.CurveEd25519, PrivateKey: seed},
+	)
+	require.NoError(t, err)
+
+	keys, err := manager.ListKeys(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, "fee-payer", keys[0].ID)
+	assert.Equal(t, ports.CurveEd25519, keys[0].Curve)
+	assert.Equal(t, "treasury", keys[1].ID)
+	assert.Equal(t, ports.CurveSecp256k1, keys[1].Curve)
+	assert.Len(t, keys[1].PublicKey, 33)
+
+	t.Run("secp256k1", func(t *testing.T) {
+		digest := bytes.Repeat([]byte{0xab}, 32)
+		sig, err := manager.Sign(ctx, "treasury", digest)
+		require.NoError(t, err)
+		require.Len(t, sig, 65)
+		require.LessOrEqual(t, sig[64], byte(1))
+
+		compact := append([]byte{sig[64] + 27}, sig[:64]...)
+		pub, _, err := ecdsa.RecoverCompact(compact, digest)
+		require.NoError(t, err)
+		publicKey, err := manager.PublicKey(ctx, "treasury")
+		require.NoError(t, err)
+		assert.Equal(t, publicKey, pub.SerializeCompressed())
+
+		var s secp256k1.ModNScalar
+		s.SetByteSlice(sig[32:64])
+		assert.False(t, s.IsOverHalfOrder())
+
+		_, err = manager.Sign(ctx, "treasury", []byte("short"))
+		require.Error(t, err)
+	})
+
+	t.Run("ed25519", func(t *testing.T) {
+		message := []byte("message")
+		sig, err := manager.Sign(ctx, "fee-payer", message)
+		require.NoError(t, err)
+		publicKey, err := manager.PublicKey(ctx, "fee-payer")
+		require.NoError(t, err)
+		assert.Equal(t, []byte(ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)), publicKey)
+		assert.True(t, ed25519.Verify(publicKey, message, sig))
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := manager.Sign(ctx, "missing", make([]byte, 32))
+		require.ErrorIs(t, err, ports.ErrKeyNotFound)
+		_, err = manager.PublicKey(ctx, "missing")
+		require.ErrorIs(t, err, ports.ErrKeyNotFound)
+	})
+
+	t.Run("public keys are copies", func(t *testing.T) {
+		publicKey, err := manager.PublicKey(ctx, "treasury")
+		require.NoError(t, err)
+		publicKey[0] = 0
+		again, err := manager.PublicKey(ctx, "treasury")
+		require.NoError(t, err)
+		assert.NotEqual(t, publicKey, again)
+	})
+}
+
+func TestNewManagerErrors(t *testing.T) {
+	t.Parallel()
+	valid := bytes.Repeat([]byte{0x46}, 32)
+
+	for name, keys := range map[string][]Key{
+		"empty ID":      {{Curve: ports.CurveSecp256k1, PrivateKey: valid}},
+		"duplicate":     {{ID: "a", Curve: ports.CurveSecp256k1, PrivateKey: valid}, {ID: "a", Curve: ports.CurveEd25519, PrivateKey: valid}},
+		"short key":     {{ID: "a", Curve: ports.CurveSecp256k1, PrivateKey: valid[:31]}},
+		"zero key":      {{ID: "a", Curve: ports.CurveSecp256k1, PrivateKey: make([]byte, 32)}},
+		"ed25519 size":  {{ID: "a", Curve: ports.CurveEd25519, PrivateKey: valid[:16]}},
+		"bad keypair":   {{ID: "a", Curve: ports.CurveEd25519, PrivateKey: append(valid, valid...)}},
+		"unknown curve": {{ID: "a", Curve: "p256", PrivateKey: valid}},
+	} {
+		_, err := NewManager(keys...)
+		require.Error(t, err, name)
+	}
+}
@@ -1,27 +1,50 @@
 package registry
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/resilience"
 )
 
 // ChainRegistry manages chain adapters
 type ChainRegistry struct {
 	adapters map[string]ports.ChainAdapter
+	// chains holds the descriptors of adapters that can describe their chain
+	chains map[string]*entities.Chain
+	// networks holds the network records of chains enabled or disabled at runtime; chains
+	// without one are enabled
+	networks map[string]*entities.Network
 	mu       sync.RWMutex
 	logger   ports.Logger
+	// resilience, when set, holds the policies registered adapters are wrapped with
+	resilience *resilience.Config
 }
 
 // NewChainRegistry creates a new chain registry
 func NewChainRegistry(logger ports.Logger) *ChainRegistry {
 	return &ChainRegistry{
 		adapters: make(map[string]ports.ChainAdapter),
+		chains:   make(map[string]*entities.Chain),
+		networks: make(map[string]*entities.Network),
 		logger:   logger,
 	}
 }
 
+// NewChainRegistryWithResilience creates a chain registry wrapping each registered adapter
+// with the timeout, retry and circuit breaker policy of its chain
+func NewChainRegistryWithResilience(logger ports.Logger, config resilience.Config) (*ChainRegistry, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid resilience config: %w", err)
+	}
+	registry := NewChainRegistry(logger)
+	registry.resilience = &config
+	return registry, nil
+}
+
 // Register registers a chain adapter
 func (r *ChainRegistry) Register(chainID string, adapter ports.ChainAdapter) error {
 	if chainID == "" {
@@ -38,6 +61,10 @@ func (r *ChainRegistry) Register(chainID string, adapter ports.ChainAdapter) err
 		return fmt.Errorf("chain adapter already registered: %s", chainID)
 	}
 
+	r.chains[chainID] = r.describe(chainID, adapter)
+	if r.resilience != nil {
+		adapter = resilience.Wrap(chainID, adapter, r.resilience.PolicyFor(chainID), r.logger)
+	}
 	r.adapters[chainID] = adapter
 
 	r.logger.Info("chain adapter registered", map[string]interface{}{
@@ -57,6 +84,8 @@ func (r *ChainRegistry) Unregister(chainID string) error {
 	}
 
 	delete(r.adapters, chainID)
+	delete(r.chains, chainID)
+	delete(r.networks, chainID)
 
 	r.logger.Info("chain adapter unregistered", map[string]interface{}{
 		"chain_id": chainID,
@@ -65,7 +94,36 @@ func (r *ChainRegistry) Unregister(chainID string) error {
 	return nil
 }
 
-// Get returns a chain adapter by ID
+// Replace swaps the adapter of a registered chain, returning the adapter it replaced; callers
+// holding the previous adapter keep using it until they look the chain up again. A disabled
+// chain stays disabled.
+func (r *ChainRegistry) Replace(chainID string, adapter ports.ChainAdapter) (ports.ChainAdapter, error) {
+	if adapter == nil {
+		return nil, fmt.Errorf("adapter cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, exists := r.adapters[chainID]
+	if !exists {
+		return nil, fmt.Errorf("chain adapter not found: %s", chainID)
+	}
+
+	r.chains[chainID] = r.describe(chainID, adapter)
+	if r.resilience != nil {
+		adapter = resilience.Wrap(chainID, adapter, r.resilience.PolicyFor(chainID), r.logger)
+	}
+	r.adapters[chainID] = adapter
+
+	r.logger.Info("chain adapter replaced", map[string]interface{}{
+		"chain_id": chainID,
+	})
+
+	return previous, nil
+}
+
+// Get returns a chain adapter by ID, refusing chains that are disabled
 func (r *ChainRegistry) Get(chainID string) (ports.ChainAdapter, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -74,10 +132,104 @@ func (r *ChainRegistry) Get(chainID string) (ports.ChainAdapter, error) {
 	if !exists {
 		return nil, fmt.Errorf("chain adapter not found: %s", chainID)
 	}
+	if network, ok := r.networks[chainID]; ok && !network.IsActive() {
+		return nil, fmt.Errorf("chain adapter disabled: %s", chainID)
+	}
 
 	return adapter, nil
 }
 
+// Lookup returns a chain adapter by ID whether or not the chain is enabled
+func (r *ChainRegistry) Lookup(chainID string) (ports.ChainAdapter, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	adapter, exists := r.adapters[chainID]
+	if !exists {
+		return nil, fmt.Errorf("chain adapter not found: %s", chainID)
+	}
+
+	return adapter, nil
+}
+
+// SetActive enables or disables a registered chain by activating or deactivating its network
+// record, which is taken from the adapter the first time the chain is disabled. The record is
+// read from the adapter beneath its decorators, so that an open circuit cannot keep a chain from
+// being disabled.
+func (r *ChainRegistry) SetActive(ctx context.Context, chainID string, active bool) error {
+	adapter, err := r.Lookup(chainID)
+	if err != nil {
+		return err
+	}
+
+	r.mu.RLock()
+	network, exists := r.networks[chainID]
+	r.mu.RUnlock()
+	if !exists {
+		if active {
+			return nil
+		}
+		network, err = unwrap(adapter).GetNetworkInfo(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get network info: %w", err)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, registered := r.adapters[chainID]; !registered {
+		return fmt.Errorf("chain adapter not found: %s", chainID)
+	}
+	if recorded, ok := r.networks[chainID]; ok {
+		network = recorded
+	}
+	if active {
+		network.Activate()
+	} else {
+		network.Deactivate()
+	}
+	r.networks[chainID] = network
+
+	message := "chain adapter disabled"
+	if active {
+		message = "chain adapter enabled"
+	}
+	r.logger.Info(message, map[string]interface{}{
+		"chain_id": chainID,
+	})
+
+	return nil
+}
+
+// IsActive checks if a registered chain is enabled
+func (r *ChainRegistry) IsActive(chainID string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, exists := r.adapters[chainID]; !exists {
+		return false
+	}
+	network, ok := r.networks[chainID]
+	return !ok || network.IsActive()
+}
+
+// Describe returns the descriptor of a registered chain, taken from its adapter on registration
+func (r *ChainRegistry) Describe(chainID string) (*entities.Chain, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, exists := r.adapters[chainID]; !exists {
+		return nil, fmt.Errorf("chain adapter not found: %s", chainID)
+	}
+	chain := r.chains[chainID]
+	if chain == nil {
+		return nil, fmt.Errorf("chain descriptor not available: %s", chainID)
+	}
+
+	return chain, nil
+}
+
 // List returns all registered chain IDs
 func (r *ChainRegistry) List() []string {
 	r.mu.RLock()
@@ -99,3 +251,37 @@ func (r *ChainRegistry) Has(chainID string) bool {
 	_, exists := r.adapters[chainID]
 	return exists
 }
+
+// describe returns the descriptor of an adapter that can describe its chain, looking through
+// the decorators wrapping it, or nil
+func (r *ChainRegistry) describe(chainID string, adapter ports.ChainAdapter) *entities.Chain {
+	for {
+		if describer, ok := adapter.(ports.ChainDescriber); ok {
+			chain, err := describer.DescribeChain()
+			if err != nil {
+				r.logger.Warn("failed to describe chain", map[string]interface{}{
+					"chain_id": chainID,
+					"error":    err.Error(),
+				})
+				return nil
+			}
+			return chain
+		}
+		wrapper, ok := adapter.(ports.AdapterWrapper)
+		if !ok {
+			return nil
+		}
+		adapter = wrapper.Unwrap()
+	}
+}
+
+// unwrap returns the adapter beneath the decorators wrapping it
+func unwrap(adapter ports.ChainAdapter) ports.ChainAdapter {
+	for {
+		wrapper, ok := adapter.(ports.AdapterWrapper)
+		if !ok {
+			return adapter
+		}
+		adapter = wrapper.Unwrap()
+	}
+}
@@ -1,9 +1,14 @@
 package registry
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"testing"
+	"time"
 
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/resilience"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -103,6 +108,156 @@ func TestChainRegistry_Unregister(t *testing.T) {
 	})
 }
 
+func TestChainRegistry_Replace(t *testing.T) {
+	logger := mocks.NewMockLogger()
+	registry := NewChainRegistry(logger)
+
+	t.Run("success", func(t *testing.T) {
+		first := &mocks.MockChainAdapter{}
+		second := &mocks.MockChainAdapter{}
+		require.NoError(t, registry.Register("ethereum", first))
+
+		previous, err := registry.Replace("ethereum", second)
+
+		require.NoError(t, err)
+		assert.Same(t, first, previous)
+		current, err := registry.Get("ethereum")
+		require.NoError(t, err)
+		assert.Same(t, second, current)
+	})
+
+	t.Run("error - not found", func(t *testing.T) {
+		_, err := registry.Replace("unknown", &mocks.MockChainAdapter{})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("error - nil adapter", func(t *testing.T) {
+		_, err := registry.Replace("ethereum", nil)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "adapter cannot be nil")
+	})
+}
+
+func TestChainRegistry_SetActive(t *testing.T) {
+	logger := mocks.NewMockLogger()
+	registry := NewChainRegistry(logger)
+	ctx := context.Background()
+
+	adapter := &mocks.MockChainAdapter{}
+	require.NoError(t, registry.Register("ethereum", adapter))
+	assert.True(t, registry.IsActive("ethereum"))
+
+	t.Run("disable", func(t *testing.T) {
+		require.NoError(t, registry.SetActive(ctx, "ethereum", false))
+
+		assert.False(t, registry.IsActive("ethereum"))
+		_, err := registry.Get("ethereum")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "disabled")
+		found, err := registry.Lookup("ethereum")
+		require.NoError(t, err)
+		assert.Same(t, adapter, found)
+		assert.Contains(t, registry.List(), "ethereum")
+	})
+
+	t.Run("replace keeps the chain disabled", func(t *testing.T) {
+		_, err := registry.Replace("ethereum", &mocks.MockChainAdapter{})
+		require.NoError(t, err)
+
+		assert.False(t, registry.IsActive("ethereum"))
+	})
+
+	t.Run("enable", func(t *testing.T) {
+		require.NoError(t, registry.SetActive(ctx, "ethereum", true))
+
+		assert.True(t, registry.IsActive("ethereum"))
+		_, err := registry.Get("ethereum")
+		require.NoError(t, err)
+	})
+
+	t.Run("error - not found", func(t *testing.T) {
+		err := registry.SetActive(ctx, "unknown", false)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+		assert.False(t, registry.IsActive("unknown"))
+	})
+}
+
+// unreachableAdapter is a chain adapter whose node never answers; its network record is taken
+// from its configuration
+type unreachableAdapter struct {
+	mocks.MockChainAdapter
+}
+
+func (a *unreachableAdapter) GetBlockNumber(ctx context.Context) (uint64, error) {
+	return 0, io.EOF
+}
+
+func TestChainRegistry_SetActive_OpenCircuit(t *testing.T) {
+	config := resilience.DefaultConfig()
+	config.Default.MaxRetries = 0
+	config.Default.FailureThreshold = 1
+	registry, err := NewChainRegistryWithResilience(mocks.NewMockLogger(), config)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, registry.Register("ethereum", &unreachableAdapter{}))
+	adapter, err := registry.Get("ethereum")
+	require.NoError(t, err)
+	_, err = adapter.GetBlockNumber(ctx)
+	require.Error(t, err)
+	require.Equal(t, resilience.StateOpen, adapter.(*resilience.Adapter).CircuitState())
+
+	// Enabling and disabling a chain does not go through its circuit
+	require.NoError(t, registry.SetActive(ctx, "ethereum", false))
+	assert.False(t, registry.IsActive("ethereum"))
+	network := registry.networks["ethereum"]
+	require.NotNil(t, network)
+	assert.False(t, network.IsActive())
+
+	require.NoError(t, registry.SetActive(ctx, "ethereum", true))
+	assert.True(t, registry.IsActive("ethereum"))
+	assert.Same(t, network, registry.networks["ethereum"])
+	assert.True(t, network.IsActive())
+}
+
+// describedAdapter is a chain adapter that can describe its chain
+type describedAdapter struct {
+	mocks.MockChainAdapter
+}
+
+func (a *describedAdapter) DescribeChain() (*entities.Chain, error) {
+	return entities.NewChain("ethereum", entities.ChainTypeEVM, "1", false, "ETH")
+}
+
+func TestChainRegistry_Describe(t *testing.T) {
+	logger := mocks.NewMockLogger()
+	registry, err := NewChainRegistryWithResilience(logger, resilience.DefaultConfig())
+	require.NoError(t, err)
+
+	require.NoError(t, registry.Register("ethereum", &describedAdapter{}))
+
+	chain, err := registry.Describe("ethereum")
+	require.NoError(t, err)
+	assert.Equal(t, "1", chain.NetworkID())
+	assert.Equal(t, "ETH", chain.NativeToken())
+
+	// The descriptor follows the adapter serving the chain
+	_, err = registry.Replace("ethereum", &mocks.MockChainAdapter{})
+	require.NoError(t, err)
+	_, err = registry.Describe("ethereum")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "descriptor not available")
+
+	_, err = registry.Describe("unknown")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
 func TestChainRegistry_List(t *testing.T) {
 	logger := mocks.NewMockLogger()
 	registry := NewChainRegistry(logger)
@@ -152,3 +307,26 @@ func TestChainRegistry_Concurrent(t *testing.T) {
 
 	assert.Len(t, registry.List(), 10)
 }
+
+func TestChainRegistry_Resilience(t *testing.T) {
+	logger := mocks.NewMockLogger()
+	config := resilience.DefaultConfig()
+	config.Chains = map[string]resilience.Policy{"tron": {FailureThreshold: 1}}
+	registry, err := NewChainRegistryWithResilience(logger, config)
+	require.NoError(t, err)
+
+	adapter := &mocks.MockChainAdapter{}
+	require.NoError(t, registry.Register("tron", adapter))
+
+	registered, err := registry.Get("tron")
+	require.NoError(t, err)
+	wrapped, ok := registered.(*resilience.Adapter)
+	require.True(t, ok)
+	assert.Same(t, adapter, wrapped.Unwrap())
+	assert.Equal(t, resilience.StateClosed, wrapped.CircuitState())
+
+	config.Chains["tron"] = resilience.Policy{OpenTimeout: -time.Second}
+	_, err = NewChainRegistryWithResilience(logger, config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid resilience config")
+}
@@ -0,0 +1,263 @@
+package reload
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/factory"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/config"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/events"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+)
+
+// Reloader applies configuration reloads to the chain registry: new networks are registered,
+// removed networks are unregistered and drained, and networks whose entry changed are swapped
+// for a new adapter. Settings outside the chain networks take effect on restart.
+type Reloader struct {
+	path      string
+	registry  ports.ChainRegistry
+	publisher ports.EventPublisher
+	logger    ports.Logger
+
+	load       func(path string) (*config.Provider, error)
+	newAdapter func(network config.Network) (ports.ChainAdapter, error)
+
+	mu       sync.Mutex
+	current  *config.Config
+	networks map[string]config.Network
+	digest   [sha256.Size]byte
+}
+
+// NewReloader creates a reloader of the configuration file at path, whose current configuration
+// has already been registered
+func NewReloader(path string, current *config.Config, registry ports.ChainRegistry, publisher ports.EventPublisher, logger ports.Logger) *Reloader {
+	r := &Reloader{
+		path:       path,
+		registry:   registry,
+		publisher:  publisher,
+		logger:     logger,
+		load:       config.Load,
+		newAdapter: factory.NewAdapter,
+		current:    current,
+		networks:   networksByChainID(current),
+	}
+	r.digest, _ = r.fileDigest()
+	return r
+}
+
+// Watch reloads the configuration on SIGHUP and, every reload interval, when the file changed,
+// until the context is done
+func (r *Reloader) Watch(ctx context.Context) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	r.mu.Lock()
+	interval := r.current.Reload.Interval
+	r.mu.Unlock()
+
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hangup:
+			r.reload(ctx, "signal")
+		case <-tick:
+			digest, err := r.fileDigest()
+			if err != nil || digest == r.digest {
+				continue
+			}
+			r.digest = digest
+			r.reload(ctx, "file changed")
+		}
+	}
+}
+
+func (r *Reloader) reload(ctx context.Context, trigger string) {
+	if err := r.Reload(ctx); err != nil {
+		r.logger.Error("failed to reload chain configuration", err, map[string]interface{}{
+			"path":    r.path,
+			"trigger": trigger,
+		})
+	}
+}
+
+// Reload reads the configuration file again and applies it; an invalid file changes nothing
+func (r *Reloader) Reload(ctx context.Context) error {
+	provider, err := r.load(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to reload config: %w", err)
+	}
+	return r.Apply(ctx, provider.Config())
+}
+
+// Apply brings the registry in line with the networks of a configuration. Every new adapter is
+// created before the registry changes, so a network that cannot be served leaves it untouched.
+func (r *Reloader) Apply(ctx context.Context, cfg *config.Config) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	next := networksByChainID(cfg)
+	created := make(map[string]ports.ChainAdapter)
+	var added, removed, replaced []string
+	for _, chainID := range sortedKeys(next) {
+		previous, exists := r.networks[chainID]
+		if exists && reflect.DeepEqual(previous, next[chainID]) {
+			continue
+		}
+		adapter, err := r.newAdapter(next[chainID])
+		if err != nil {
+			return fmt.Errorf("failed to create adapter for %s: %w", chainID, err)
+		}
+		created[chainID] = adapter
+		if exists {
+			replaced = append(replaced, chainID)
+		} else {
+			added = append(added, chainID)
+		}
+	}
+	for _, chainID := range sortedKeys(r.networks) {
+		if _, exists := next[chainID]; !exists {
+			removed = append(removed, chainID)
+		}
+	}
+
+	var errs []error
+	var retired []ports.ChainAdapter
+	applied := func(chainIDs []string, apply func(chainID string) error) []string {
+		var done []string
+		for _, chainID := range chainIDs {
+			if err := apply(chainID); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			done = append(done, chainID)
+		}
+		return done
+	}
+	added = applied(added, func(chainID string) error {
+		if err := r.registry.Register(chainID, created[chainID]); err != nil {
+			return err
+		}
+		r.networks[chainID] = next[chainID]
+		return nil
+	})
+	replaced = applied(replaced, func(chainID string) error {
+		previous, err := r.registry.Replace(chainID, created[chainID])
+		if err != nil {
+			return err
+		}
+		r.networks[chainID] = next[chainID]
+		retired = append(retired, previous)
+		return nil
+	})
+	removed = applied(removed, func(chainID string) error {
+		// A chain disabled at runtime is removed and drained all the same
+		previous, err := r.registry.Lookup(chainID)
+		if err == nil {
+			err = r.registry.Unregister(chainID)
+		}
+		if err != nil {
+			return err
+		}
+		delete(r.networks, chainID)
+		retired = append(retired, previous)
+		return nil
+	})
+
+	if r.current.App != cfg.App || r.current.Logging != cfg.Logging || r.current.Admin != cfg.Admin || r.current.Reload.Interval != cfg.Reload.Interval ||
+		r.current.Database != cfg.Database || r.current.Redis != cfg.Redis ||
+		!reflect.DeepEqual(r.current.Resilience, cfg.Resilience) || !reflect.DeepEqual(r.current.Health, cfg.Health) {
+		r.logger.Warn("configuration changes outside the chain networks take effect on restart", map[string]interface{}{
+			"path": r.path,
+		})
+	}
+	r.current = cfg
+
+	if len(added)+len(removed)+len(replaced) > 0 {
+		r.logger.Info("chain configuration reloaded", map[string]interface{}{
+			"added":    added,
+			"removed":  removed,
+			"replaced": replaced,
+		})
+		if err := r.publisher.Publish(ctx, events.NewRegistryChangedEvent(added, removed, replaced)); err != nil {
+			r.logger.Error("failed to publish registry changed event", err, nil)
+		}
+	}
+
+	r.drain(ctx, retired)
+	return errors.Join(errs...)
+}
+
+// drain waits, up to the drain timeout, for the in-flight calls of adapters taken out of the registry
+func (r *Reloader) drain(ctx context.Context, adapters []ports.ChainAdapter) {
+	if len(adapters) == 0 {
+		return
+	}
+	if timeout := r.current.Reload.DrainTimeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var wg sync.WaitGroup
+	for _, adapter := range adapters {
+		drainer, ok := adapter.(ports.AdapterDrainer)
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := drainer.Drain(ctx); err != nil {
+				r.logger.Warn("chain adapter retired with calls in flight", map[string]interface{}{
+					"chain_id": adapter.GetChainID(),
+					"error":    err.Error(),
+				})
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// fileDigest hashes the configuration file, so that rewriting it unchanged triggers no reload
+func (r *Reloader) fileDigest() ([sha256.Size]byte, error) {
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	return sha256.Sum256(data), nil
+}
+
+func networksByChainID(cfg *config.Config) map[string]config.Network {
+	networks := make(map[string]config.Network)
+	for _, network := range cfg.Networks() {
+		networks[network.ChainID] = network
+	}
+	return networks
+}
+
+func sortedKeys(networks map[string]config.Network) []string {
+	keys := make([]string, 0, len(networks))
+	for key := range networks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
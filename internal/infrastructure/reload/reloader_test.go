@@ -0,0 +1,254 @@
+package reload
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/factory"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/config"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/events"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/registry"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/resilience"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const initialConfig = `
+reload:
+  interval: 10ms
+  drain_timeout: 50ms
+chains:
+  enabled: [evm]
+evm:
+  networks:
+    - name: ethereum
+      adapter: harness
+    - name: polygon
+      adapter: harness
+`
+
+const reloadedConfig = `
+reload:
+  interval: 10ms
+  drain_timeout: 50ms
+chains:
+  enabled: [evm, bitcoin]
+evm:
+  networks:
+    - name: ethereum
+      adapter: harness
+      balances:
+        "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb": "1000"
+bitcoin:
+  networks:
+    - name: regtest
+      adapter: harness
+`
+
+func parseConfig(t *testing.T, data string) *config.Config {
+	t.Helper()
+	provider, err := config.Parse([]byte(data), func(string) (string, bool) { return "", false })
+	require.NoError(t, err)
+	return provider.Config()
+}
+
+// publisher records published events; the reloader publishes from the watch goroutine
+type publisher struct {
+	mu     sync.Mutex
+	events []interface{}
+}
+
+func (p *publisher) Publish(ctx context.Context, event interface{}) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+	return nil
+}
+
+func (p *publisher) PublishBatch(ctx context.Context, events []interface{}) error {
+	for _, event := range events {
+		_ = p.Publish(ctx, event)
+	}
+	return nil
+}
+
+func (p *publisher) published() []interface{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]interface{}(nil), p.events...)
+}
+
+// newTestReloader registers the initial configuration the way the adapters module does
+func newTestReloader(t *testing.T, path string) (*Reloader, *registry.ChainRegistry, *publisher, *mocks.MockLogger) {
+	t.Helper()
+	logger := mocks.NewMockLogger()
+	chains, err := registry.NewChainRegistryWithResilience(logger, resilience.DefaultConfig())
+	require.NoError(t, err)
+	cfg := parseConfig(t, initialConfig)
+	for _, network := range cfg.Networks() {
+		adapter, err := factory.NewAdapter(network)
+		require.NoError(t, err)
+		require.NoError(t, chains.Register(network.ChainID, adapter))
+	}
+	bus := &publisher{}
+	return NewReloader(path, cfg, chains, bus, logger), chains, bus, logger
+}
+
+func TestReloaderApply(t *testing.T) {
+	t.Parallel()
+
+	reloader, chains, published, _ := newTestReloader(t, filepath.Join(t.TempDir(), "config.yaml"))
+	ethereum, err := chains.Get("ethereum")
+	require.NoError(t, err)
+	polygon, err := chains.Get("polygon")
+	require.NoError(t, err)
+
+	require.NoError(t, reloader.Apply(context.Background(), parseConfig(t, reloadedConfig)))
+	assert.ElementsMatch(t, []string{"ethereum", "bitcoin-regtest"}, chains.List())
+
+	// The changed network is served by a new adapter
+	swapped, err := chains.Get("ethereum")
+	require.NoError(t, err)
+	assert.NotSame(t, ethereum, swapped)
+	address, err := valueobjects.NewAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb", "ethereum")
+	require.NoError(t, err)
+	balance, err := swapped.GetBalance(context.Background(), "ethereum", address)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1000), balance)
+
+	// Callers still holding a removed adapter can finish with it
+	assert.True(t, polygon.IsConnected(context.Background()))
+
+	changes := published.published()
+	require.Len(t, changes, 1)
+	changed, ok := changes[0].(*events.RegistryChangedEvent)
+	require.True(t, ok)
+	assert.Equal(t, []string{"bitcoin-regtest"}, changed.Added)
+	assert.Equal(t, []string{"polygon"}, changed.Removed)
+	assert.Equal(t, []string{"ethereum"}, changed.Replaced)
+
+	// Applying the same configuration again changes nothing
+	require.NoError(t, reloader.Apply(context.Background(), parseConfig(t, reloadedConfig)))
+	assert.Len(t, published.published(), 1)
+	unchanged, err := chains.Get("ethereum")
+	require.NoError(t, err)
+	assert.Same(t, swapped, unchanged)
+}
+
+func TestReloaderRemovesDisabledChain(t *testing.T) {
+	t.Parallel()
+
+	reloader, chains, published, _ := newTestReloader(t, filepath.Join(t.TempDir(), "config.yaml"))
+	require.NoError(t, chains.SetActive(context.Background(), "polygon", false))
+
+	require.NoError(t, reloader.Apply(context.Background(), parseConfig(t, reloadedConfig)))
+	assert.ElementsMatch(t, []string{"ethereum", "bitcoin-regtest"}, chains.List())
+	assert.False(t, chains.Has("polygon"))
+
+	changes := published.published()
+	require.Len(t, changes, 1)
+	changed, ok := changes[0].(*events.RegistryChangedEvent)
+	require.True(t, ok)
+	assert.Equal(t, []string{"polygon"}, changed.Removed)
+}
+
+func TestReloaderApplyFailureKeepsRegistry(t *testing.T) {
+	t.Parallel()
+
+	reloader, chains, published, _ := newTestReloader(t, filepath.Join(t.TempDir(), "config.yaml"))
+	reloader.newAdapter = func(network config.Network) (ports.ChainAdapter, error) {
+		if network.ChainID == "bitcoin-regtest" {
+			return nil, errors.New("node unreachable")
+		}
+		return &mocks.MockChainAdapter{}, nil
+	}
+
+	err := reloader.Apply(context.Background(), parseConfig(t, reloadedConfig))
+	require.ErrorContains(t, err, "failed to create adapter for bitcoin-regtest: node unreachable")
+	assert.ElementsMatch(t, []string{"ethereum", "polygon"}, chains.List())
+	assert.Empty(t, published.published())
+
+	// An invalid file is rejected before anything changes
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("chains:\n  enabled: [cosmos]\n"), 0o600))
+	reloader.path = path
+	require.ErrorContains(t, reloader.Reload(context.Background()), "failed to reload config")
+	assert.ElementsMatch(t, []string{"ethereum", "polygon"}, chains.List())
+}
+
+func TestReloaderDrainsRetiredAdapters(t *testing.T) {
+	t.Parallel()
+
+	logger := mocks.NewMockLogger()
+	chains, err := registry.NewChainRegistryWithResilience(logger, resilience.DefaultConfig())
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	inner := &mocks.MockChainAdapter{
+		GetTransactionStatusFunc: func(ctx context.Context, hash *valueobjects.Hash) (entities.TxStatus, error) {
+			close(started)
+			<-release
+			return entities.TxStatusConfirmed, nil
+		},
+	}
+	require.NoError(t, chains.Register("polygon", inner))
+	polygon, err := chains.Get("polygon")
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = polygon.GetTransactionStatus(context.Background(), nil)
+	}()
+	<-started
+
+	cfg := parseConfig(t, initialConfig)
+	reloader := NewReloader("config.yaml", cfg, chains, &publisher{}, logger)
+	reloader.networks = map[string]config.Network{"polygon": {ChainID: "polygon"}}
+	next := parseConfig(t, initialConfig)
+	next.EVM.Networks = next.EVM.Networks[:1]
+	next.EVM.Networks[0].Name = "base"
+
+	start := time.Now()
+	require.NoError(t, reloader.Apply(context.Background(), next))
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	assert.ElementsMatch(t, []string{"base"}, chains.List())
+	require.NotEmpty(t, logger.WarnCalls)
+	assert.Equal(t, "chain adapter retired with calls in flight", logger.WarnCalls[len(logger.WarnCalls)-1].Message)
+
+	close(release)
+	<-done
+}
+
+func TestReloaderWatch(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(initialConfig), 0o600))
+	reloader, chains, _, _ := newTestReloader(t, path)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		reloader.Watch(ctx)
+	}()
+
+	require.NoError(t, os.WriteFile(path, []byte(reloadedConfig), 0o600))
+	assert.Eventually(t, func() bool { return chains.Has("bitcoin-regtest") }, 2*time.Second, 10*time.Millisecond)
+	assert.False(t, chains.Has("polygon"))
+
+	cancel()
+	<-stopped
+}
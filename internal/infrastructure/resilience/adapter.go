@@ -0,0 +1,380 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+)
+
+// Adapter decorates a chain adapter with per-method timeouts, retries of idempotent reads and a
+// circuit breaker. Transactions are never resent: BroadcastTransaction and the calls building
+// transactions run once. The optional raw broadcast, fee bumping and PSBT capabilities are
+// forwarded under the same policy, failing with ErrUnsupported when the wrapped adapter lacks
+// them; other capabilities are reached through Unwrap.
+type Adapter struct {
+	chainID string
+	adapter ports.ChainAdapter
+	policy  Policy
+	breaker *Breaker
+	logger  ports.Logger
+	sleep   func(ctx context.Context, d time.Duration) error
+	// inflight counts the calls to the node in progress, which Drain waits for
+	inflight atomic.Int64
+}
+
+// ErrUnsupported is returned by a forwarded capability the wrapped adapter does not have
+var ErrUnsupported = errors.New("capability not supported")
+
+// drainPollInterval is how often Drain checks for in-flight calls
+const drainPollInterval = 10 * time.Millisecond
+
+// Wrap decorates the adapter of a chain with a policy
+func Wrap(chainID string, adapter ports.ChainAdapter, policy Policy, logger ports.Logger) *Adapter {
+	a := &Adapter{
+		chainID: chainID,
+		adapter: adapter,
+		policy:  policy,
+		breaker: NewBreaker(policy.FailureThreshold, policy.OpenTimeout),
+		logger:  logger,
+		sleep:   sleep,
+	}
+	a.breaker.onChange = func(from, to State) {
+		fields := map[string]interface{}{"chain_id": chainID, "from": string(from), "to": string(to)}
+		if to == StateOpen {
+			logger.Warn("circuit breaker opened", fields)
+			return
+		}
+		logger.Info("circuit breaker state changed", fields)
+	}
+	return a
+}
+
+// Unwrap returns the decorated adapter
+func (a *Adapter) Unwrap() ports.ChainAdapter {
+	return a.adapter
+}
+
+// CircuitState returns the state of the chain's circuit breaker
+func (a *Adapter) CircuitState() State {
+	return a.breaker.State()
+}
+
+// Drain waits until no call to the node is in flight, so that an adapter taken out of the
+// registry finishes the requests it is serving
+func (a *Adapter) Drain(ctx context.Context) error {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for a.inflight.Load() > 0 {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to drain chain %s with %d call(s) in flight: %w", a.chainID, a.inflight.Load(), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// GetChainID returns the chain identifier
+func (a *Adapter) GetChainID() string {
+	return a.adapter.GetChainID()
+}
+
+// GetChainType returns the chain type
+func (a *Adapter) GetChainType() entities.ChainType {
+	return a.adapter.GetChainType()
+}
+
+// IsConnected reports false while the circuit is open, without asking the node
+func (a *Adapter) IsConnected(ctx context.Context) bool {
+	if a.breaker.State() == StateOpen {
+		return false
+	}
+	a.inflight.Add(1)
+	defer a.inflight.Add(-1)
+	ctx, cancel := withTimeout(ctx, a.policy.timeout("IsConnected"))
+	defer cancel()
+	return a.adapter.IsConnected(ctx)
+}
+
+// GetBlockNumber returns the current block number
+func (a *Adapter) GetBlockNumber(ctx context.Context) (uint64, error) {
+	return call(ctx, a, "GetBlockNumber", true, a.adapter.GetBlockNumber)
+}
+
+// GetNativeBalance returns the native token balance for an address
+func (a *Adapter) GetNativeBalance(ctx context.Context, address *valueobjects.Address) (*big.Int, error) {
+	return call(ctx, a, "GetNativeBalance", true, func(ctx context.Context) (*big.Int, error) {
+		return a.adapter.GetNativeBalance(ctx, address)
+	})
+}
+
+// GetBalance returns the balance for a given address
+func (a *Adapter) GetBalance(ctx context.Context, chainID string, address *valueobjects.Address) (*big.Int, error) {
+	return call(ctx, a, "GetBalance", true, func(ctx context.Context) (*big.Int, error) {
+		return a.adapter.GetBalance(ctx, chainID, address)
+	})
+}
+
+// GetTokenBalance returns the token balance for a given address and token
+func (a *Adapter) GetTokenBalance(ctx context.Context, chainID string, address *valueobjects.Address, tokenAddress *valueobjects.Address) (*big.Int, error) {
+	return call(ctx, a, "GetTokenBalance", true, func(ctx context.Context) (*big.Int, error) {
+		return a.adapter.GetTokenBalance(ctx, chainID, address, tokenAddress)
+	})
+}
+
+// BuildTransaction creates a new transaction
+func (a *Adapter) BuildTransaction(ctx context.Context, params entities.TransactionParams) (*entities.Transaction, error) {
+	return call(ctx, a, "BuildTransaction", false, func(ctx context.Context) (*entities.Transaction, error) {
+		return a.adapter.BuildTransaction(ctx, params)
+	})
+}
+
+// EstimateGas estimates the gas required for a transaction
+func (a *Adapter) EstimateGas(ctx context.Context, tx *entities.Transaction) (uint64, error) {
+	return call(ctx, a, "EstimateGas", true, func(ctx context.Context) (uint64, error) {
+		return a.adapter.EstimateGas(ctx, tx)
+	})
+}
+
+// SetNonce sets the nonce for a transaction
+func (a *Adapter) SetNonce(ctx context.Context, tx *entities.Transaction) error {
+	_, err := call(ctx, a, "SetNonce", false, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, a.adapter.SetNonce(ctx, tx)
+	})
+	return err
+}
+
+// SignTransaction signs a transaction locally, so neither timeouts nor the breaker apply
+func (a *Adapter) SignTransaction(ctx context.Context, tx *entities.Transaction, keys ports.KeyManager, keyID string) error {
+	return a.adapter.SignTransaction(ctx, tx, keys, keyID)
+}
+
+// VerifySignature verifies a transaction signature locally
+func (a *Adapter) VerifySignature(ctx context.Context, tx *entities.Transaction) (bool, error) {
+	return a.adapter.VerifySignature(ctx, tx)
+}
+
+// BroadcastTransaction broadcasts a signed transaction once; a retry could not tell a lost
+// response from a rejected transaction
+func (a *Adapter) BroadcastTransaction(ctx context.Context, tx *entities.Transaction) (*valueobjects.Hash, error) {
+	return call(ctx, a, "BroadcastTransaction", false, func(ctx context.Context) (*valueobjects.Hash, error) {
+		return a.adapter.BroadcastTransaction(ctx, tx)
+	})
+}
+
+// GetTransactionStatus returns the status of a transaction
+func (a *Adapter) GetTransactionStatus(ctx context.Context, hash *valueobjects.Hash) (entities.TxStatus, error) {
+	return call(ctx, a, "GetTransactionStatus", true, func(ctx context.Context) (entities.TxStatus, error) {
+		return a.adapter.GetTransactionStatus(ctx, hash)
+	})
+}
+
+// GetTransactionReceipt returns the transaction receipt
+func (a *Adapter) GetTransactionReceipt(ctx context.Context, hash *valueobjects.Hash) (*entities.Transaction, error) {
+	return call(ctx, a, "GetTransactionReceipt", true, func(ctx context.Context) (*entities.Transaction, error) {
+		return a.adapter.GetTransactionReceipt(ctx, hash)
+	})
+}
+
+// WaitForConfirmation waits for a transaction to be confirmed
+func (a *Adapter) WaitForConfirmation(ctx context.Context, hash *valueobjects.Hash, confirmations uint64) error {
+	_, err := call(ctx, a, "WaitForConfirmation", false, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, a.adapter.WaitForConfirmation(ctx, hash, confirmations)
+	})
+	return err
+}
+
+// EstimateFee estimates the fee for a transaction
+func (a *Adapter) EstimateFee(ctx context.Context, tx *entities.Transaction) (*entities.Fee, error) {
+	return call(ctx, a, "EstimateFee", true, func(ctx context.Context) (*entities.Fee, error) {
+		return a.adapter.EstimateFee(ctx, tx)
+	})
+}
+
+// GetGasPrice returns the current gas price
+func (a *Adapter) GetGasPrice(ctx context.Context) (*big.Int, error) {
+	return call(ctx, a, "GetGasPrice", true, a.adapter.GetGasPrice)
+}
+
+// GetMaxPriorityFee returns the max priority fee (EIP-1559)
+func (a *Adapter) GetMaxPriorityFee(ctx context.Context) (*big.Int, error) {
+	return call(ctx, a, "GetMaxPriorityFee", true, a.adapter.GetMaxPriorityFee)
+}
+
+// GetNetworkInfo returns the network information
+func (a *Adapter) GetNetworkInfo(ctx context.Context) (*entities.Network, error) {
+	return call(ctx, a, "GetNetworkInfo", true, a.adapter.GetNetworkInfo)
+}
+
+// GetPeers returns the number of connected peers
+func (a *Adapter) GetPeers(ctx context.Context) (int, error) {
+	return call(ctx, a, "GetPeers", true, a.adapter.GetPeers)
+}
+
+// GetLatestBlock returns the latest block number
+func (a *Adapter) GetLatestBlock(ctx context.Context) (uint64, error) {
+	return call(ctx, a, "GetLatestBlock", true, a.adapter.GetLatestBlock)
+}
+
+// BroadcastRaw relays a transaction signed elsewhere once
+func (a *Adapter) BroadcastRaw(ctx context.Context, raw []byte) (*entities.Transaction, error) {
+	broadcaster, err := capability[ports.RawBroadcaster](a, "BroadcastRaw")
+	if err != nil {
+		return nil, err
+	}
+	return call(ctx, a, "BroadcastRaw", false, func(ctx context.Context) (*entities.Transaction, error) {
+		return broadcaster.BroadcastRaw(ctx, raw)
+	})
+}
+
+// BumpFee builds and signs a replacement of an unconfirmed transaction without broadcasting it.
+// Like the other calls building transactions it runs once, so that a request never has the key
+// sign twice or selects inputs again against a changed mempool.
+func (a *Adapter) BumpFee(ctx context.Context, hash *valueobjects.Hash, feeRate *big.Int, keys ports.KeyManager, keyID string) (*entities.Transaction, error) {
+	bumper, err := capability[ports.FeeBumper](a, "BumpFee")
+	if err != nil {
+		return nil, err
+	}
+	return call(ctx, a, "BumpFee", false, func(ctx context.Context) (*entities.Transaction, error) {
+		return bumper.BumpFee(ctx, hash, feeRate, keys, keyID)
+	})
+}
+
+// CPFP builds and signs a child paying for an unconfirmed transaction without broadcasting it; it
+// runs once for the same reasons as BumpFee
+func (a *Adapter) CPFP(ctx context.Context, hash *valueobjects.Hash, feeRate *big.Int, keys ports.KeyManager, keyID string) (*entities.Transaction, error) {
+	bumper, err := capability[ports.FeeBumper](a, "CPFP")
+	if err != nil {
+		return nil, err
+	}
+	return call(ctx, a, "CPFP", false, func(ctx context.Context) (*entities.Transaction, error) {
+		return bumper.CPFP(ctx, hash, feeRate, keys, keyID)
+	})
+}
+
+// ExportPSBT encodes an unsigned transaction as a PSBT; looking up the outputs it spends is a
+// read, retried like the others
+func (a *Adapter) ExportPSBT(ctx context.Context, tx *entities.Transaction) (string, error) {
+	provider, err := capability[ports.PSBTProvider](a, "ExportPSBT")
+	if err != nil {
+		return "", err
+	}
+	return call(ctx, a, "ExportPSBT", true, func(ctx context.Context) (string, error) {
+		return provider.ExportPSBT(ctx, tx)
+	})
+}
+
+// CombinePSBT merges PSBTs locally, so neither timeouts nor the breaker apply
+func (a *Adapter) CombinePSBT(psbts ...string) (string, error) {
+	provider, err := capability[ports.PSBTProvider](a, "CombinePSBT")
+	if err != nil {
+		return "", err
+	}
+	return provider.CombinePSBT(psbts...)
+}
+
+// FinalizePSBT completes a signed PSBT locally
+func (a *Adapter) FinalizePSBT(psbt string) (string, error) {
+	provider, err := capability[ports.PSBTProvider](a, "FinalizePSBT")
+	if err != nil {
+		return "", err
+	}
+	return provider.FinalizePSBT(psbt)
+}
+
+// BroadcastPSBT finalizes a signed PSBT and broadcasts it once
+func (a *Adapter) BroadcastPSBT(ctx context.Context, psbt string) (*valueobjects.Hash, error) {
+	provider, err := capability[ports.PSBTProvider](a, "BroadcastPSBT")
+	if err != nil {
+		return nil, err
+	}
+	return call(ctx, a, "BroadcastPSBT", false, func(ctx context.Context) (*valueobjects.Hash, error) {
+		return provider.BroadcastPSBT(ctx, psbt)
+	})
+}
+
+// capability returns the optional capability T of the wrapped adapter
+func capability[T any](a *Adapter, method string) (T, error) {
+	capability, ok := a.adapter.(T)
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("%s on chain %s: %w", method, a.chainID, ErrUnsupported)
+	}
+	return capability, nil
+}
+
+// call runs fn through the breaker under the method's timeout, retrying transient errors of idempotent calls
+func call[T any](ctx context.Context, a *Adapter, method string, idempotent bool, fn func(context.Context) (T, error)) (T, error) {
+	a.inflight.Add(1)
+	defer a.inflight.Add(-1)
+
+	retries := 0
+	if idempotent {
+		retries = a.policy.MaxRetries
+	}
+	timeout := a.policy.timeout(method)
+	for attempt := 0; ; attempt++ {
+		if err := a.breaker.Allow(); err != nil {
+			var zero T
+			return zero, fmt.Errorf("%s on chain %s: %w", method, a.chainID, err)
+		}
+
+		attemptCtx, cancel := withTimeout(ctx, timeout)
+		result, err := fn(attemptCtx)
+		cancel()
+		switch {
+		case ctx.Err() != nil:
+			// The caller gave up; that says nothing about the node
+			a.breaker.Abandon()
+			return result, err
+		case !IsTransient(err):
+			a.breaker.Record(false)
+			return result, err
+		}
+		a.breaker.Record(true)
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("%s timed out after %s: %w", method, timeout, err)
+		}
+		if attempt >= retries {
+			return result, err
+		}
+
+		wait := a.policy.backoff(attempt + 1)
+		a.logger.Warn("retrying chain adapter call", map[string]interface{}{
+			"chain_id": a.chainID,
+			"method":   method,
+			"attempt":  attempt + 1,
+			"backoff":  wait.String(),
+			"error":    err.Error(),
+		})
+		if a.sleep(ctx, wait) != nil {
+			return result, err
+		}
+	}
+}
+
+// withTimeout bounds a context, leaving it unbounded for a zero timeout
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
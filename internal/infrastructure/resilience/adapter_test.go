@@ -0,0 +1,355 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errUnavailable = errors.New("eth_getBalance request failed with status 503: upstream unavailable")
+
+func testPolicy() Policy {
+	return Policy{
+		Timeout:          time.Second,
+		MaxRetries:       2,
+		InitialBackoff:   10 * time.Millisecond,
+		MaxBackoff:       15 * time.Millisecond,
+		FailureThreshold: 3,
+		OpenTimeout:      time.Minute,
+	}
+}
+
+// newTestAdapter wraps a mock adapter, recording the backoffs instead of sleeping
+func newTestAdapter(inner ports.ChainAdapter, policy Policy) (*Adapter, *mocks.MockLogger, *[]time.Duration) {
+	logger := mocks.NewMockLogger()
+	adapter := Wrap("ethereum", inner, policy, logger)
+	var waits []time.Duration
+	adapter.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return ctx.Err()
+	}
+	return adapter, logger, &waits
+}
+
+func testAddress(t *testing.T) *valueobjects.Address {
+	t.Helper()
+	address, err := valueobjects.NewAddress("0x742d35cc6634c0532925a3b844bc9e7595f0beb0", "ethereum")
+	require.NoError(t, err)
+	return address
+}
+
+func TestAdapterRetriesTransientReads(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	inner := &mocks.MockChainAdapter{
+		GetBalanceFunc: func(ctx context.Context, chainID string, address *valueobjects.Address) (*big.Int, error) {
+			if calls.Add(1) < 3 {
+				return nil, errUnavailable
+			}
+			return big.NewInt(42), nil
+		},
+	}
+	adapter, logger, waits := newTestAdapter(inner, testPolicy())
+
+	balance, err := adapter.GetBalance(context.Background(), "ethereum", testAddress(t))
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), balance.Int64())
+	assert.Equal(t, int64(3), calls.Load())
+	assert.Equal(t, []time.Duration{10 * time.Millisecond, 15 * time.Millisecond}, *waits)
+	require.Len(t, logger.WarnCalls, 2)
+	assert.Equal(t, "GetBalance", logger.WarnCalls[0].Fields["method"])
+	assert.Equal(t, StateClosed, adapter.CircuitState())
+
+	// Errors the node answered with are returned at once
+	calls.Store(0)
+	inner.GetBalanceFunc = func(ctx context.Context, chainID string, address *valueobjects.Address) (*big.Int, error) {
+		calls.Add(1)
+		return nil, errors.New("json-rpc error -32602: invalid argument")
+	}
+	_, err = adapter.GetBalance(context.Background(), "ethereum", testAddress(t))
+	require.Error(t, err)
+	assert.Equal(t, int64(1), calls.Load())
+}
+
+func TestAdapterNeverRetriesBroadcast(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	inner := &mocks.MockChainAdapter{
+		BroadcastTransactionFunc: func(ctx context.Context, tx *entities.Transaction) (*valueobjects.Hash, error) {
+			calls.Add(1)
+			return nil, errUnavailable
+		},
+	}
+	adapter, _, waits := newTestAdapter(inner, testPolicy())
+
+	_, err := adapter.BroadcastTransaction(context.Background(), nil)
+	require.ErrorIs(t, err, errUnavailable)
+	assert.Equal(t, int64(1), calls.Load())
+	assert.Empty(t, *waits)
+}
+
+func TestAdapterTimeout(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	inner := &mocks.MockChainAdapter{
+		GetTransactionStatusFunc: func(ctx context.Context, hash *valueobjects.Hash) (entities.TxStatus, error) {
+			calls.Add(1)
+			// A hanging node answers only when the request is abandoned
+			<-ctx.Done()
+			return "", fmt.Errorf("failed to get receipt: %w", ctx.Err())
+		},
+	}
+	policy := testPolicy()
+	policy.MaxRetries = 1
+	policy.MethodTimeouts = map[string]time.Duration{"GetTransactionStatus": 20 * time.Millisecond}
+	adapter, _, _ := newTestAdapter(inner, policy)
+
+	start := time.Now()
+	_, err := adapter.GetTransactionStatus(context.Background(), nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "GetTransactionStatus timed out after 20ms")
+	assert.Equal(t, int64(2), calls.Load())
+	assert.Less(t, time.Since(start), time.Second)
+
+	// Calls the caller cancels are neither retried nor held against the node
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls.Store(0)
+	_, err = adapter.GetTransactionStatus(ctx, nil)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int64(1), calls.Load())
+	assert.Equal(t, StateClosed, adapter.CircuitState())
+}
+
+func TestAdapterCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	failing := true
+	inner := &mocks.MockChainAdapter{
+		GetBalanceFunc: func(ctx context.Context, chainID string, address *valueobjects.Address) (*big.Int, error) {
+			calls.Add(1)
+			if failing {
+				return nil, errUnavailable
+			}
+			return big.NewInt(1), nil
+		},
+	}
+	policy := testPolicy()
+	policy.MaxRetries = 0
+	adapter, logger, _ := newTestAdapter(inner, policy)
+	now := time.Now()
+	adapter.breaker.now = func() time.Time { return now }
+
+	for range 3 {
+		_, err := adapter.GetBalance(context.Background(), "ethereum", testAddress(t))
+		require.ErrorIs(t, err, errUnavailable)
+	}
+	assert.Equal(t, StateOpen, adapter.CircuitState())
+	assert.False(t, adapter.IsConnected(context.Background()))
+	require.Len(t, logger.WarnCalls, 1)
+	assert.Equal(t, "circuit breaker opened", logger.WarnCalls[0].Message)
+
+	// An open circuit fails fast without calling the node
+	_, err := adapter.GetBalance(context.Background(), "ethereum", testAddress(t))
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Contains(t, err.Error(), "GetBalance on chain ethereum")
+	assert.Equal(t, int64(3), calls.Load())
+
+	// Once the open timeout elapses a trial call closes the circuit again
+	now = now.Add(time.Minute)
+	failing = false
+	balance, err := adapter.GetBalance(context.Background(), "ethereum", testAddress(t))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), balance.Int64())
+	assert.Equal(t, StateClosed, adapter.CircuitState())
+}
+
+func TestAdapterDrain(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	inner := &mocks.MockChainAdapter{
+		GetBalanceFunc: func(ctx context.Context, chainID string, address *valueobjects.Address) (*big.Int, error) {
+			close(started)
+			<-release
+			return big.NewInt(1), nil
+		},
+	}
+	adapter, _, _ := newTestAdapter(inner, testPolicy())
+	require.NoError(t, adapter.Drain(context.Background()))
+
+	address := testAddress(t)
+	done := make(chan error, 1)
+	go func() {
+		_, err := adapter.GetBalance(context.Background(), "ethereum", address)
+		done <- err
+	}()
+	<-started
+
+	// A call in flight holds the drain until it returns
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	err := adapter.Drain(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "1 call(s) in flight")
+
+	close(release)
+	require.NoError(t, <-done)
+	require.NoError(t, adapter.Drain(context.Background()))
+}
+
+func TestAdapterPassesThrough(t *testing.T) {
+	t.Parallel()
+
+	inner := &mocks.MockChainAdapter{GetChainIDFunc: func() string { return "polygon" }}
+	adapter, _, _ := newTestAdapter(inner, testPolicy())
+
+	assert.Same(t, inner, adapter.Unwrap())
+	assert.Equal(t, "polygon", adapter.GetChainID())
+	assert.Equal(t, entities.ChainTypeEVM, adapter.GetChainType())
+	assert.True(t, adapter.IsConnected(context.Background()))
+
+	block, err := adapter.GetLatestBlock(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(12345), block)
+	require.NoError(t, adapter.WaitForConfirmation(context.Background(), nil, 1))
+}
+
+// capableAdapter is a mock adapter with the raw broadcast, fee bumping and PSBT capabilities
+type capableAdapter struct {
+	mocks.MockPSBTAdapter
+	send func(ctx context.Context) error
+}
+
+func (a *capableAdapter) BroadcastRaw(ctx context.Context, raw []byte) (*entities.Transaction, error) {
+	return nil, a.send(ctx)
+}
+
+func (a *capableAdapter) BumpFee(ctx context.Context, hash *valueobjects.Hash, feeRate *big.Int, keys ports.KeyManager, keyID string) (*entities.Transaction, error) {
+	return nil, a.send(ctx)
+}
+
+func (a *capableAdapter) CPFP(ctx context.Context, hash *valueobjects.Hash, feeRate *big.Int, keys ports.KeyManager, keyID string) (*entities.Transaction, error) {
+	return nil, a.send(ctx)
+}
+
+func TestAdapterForwardsCapabilities(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+	inner := &capableAdapter{send: func(ctx context.Context) error {
+		calls.Add(1)
+		return errUnavailable
+	}}
+	inner.BroadcastPSBTFunc = func(ctx context.Context, psbt string) (*valueobjects.Hash, error) {
+		return nil, inner.send(ctx)
+	}
+	inner.ExportPSBTFunc = func(ctx context.Context, tx *entities.Transaction) (string, error) {
+		return "", inner.send(ctx)
+	}
+	policy := testPolicy()
+	policy.FailureThreshold = 10
+	adapter, _, waits := newTestAdapter(inner, policy)
+	ctx := context.Background()
+
+	// Calls building, signing or sending transactions run once and count against the breaker
+	_, err := adapter.BroadcastRaw(ctx, []byte{0x01})
+	require.ErrorIs(t, err, errUnavailable)
+	_, err = adapter.BumpFee(ctx, nil, big.NewInt(1), nil, "key")
+	require.ErrorIs(t, err, errUnavailable)
+	_, err = adapter.CPFP(ctx, nil, big.NewInt(1), nil, "key")
+	require.ErrorIs(t, err, errUnavailable)
+	_, err = adapter.BroadcastPSBT(ctx, "cHNidP8=")
+	require.ErrorIs(t, err, errUnavailable)
+	assert.Equal(t, int64(4), calls.Load())
+	assert.Empty(t, *waits)
+
+	// Exporting a PSBT only reads, so it is retried
+	_, err = adapter.ExportPSBT(ctx, nil)
+	require.ErrorIs(t, err, errUnavailable)
+	assert.Equal(t, int64(7), calls.Load())
+	assert.Len(t, *waits, 2)
+
+	combined, err := adapter.CombinePSBT("a", "b")
+	require.NoError(t, err)
+	assert.Equal(t, "a", combined)
+	raw, err := adapter.FinalizePSBT("a")
+	require.NoError(t, err)
+	assert.Equal(t, "0200000000", raw)
+
+	// Once the circuit opens the node is no longer asked
+	policy.FailureThreshold = 1
+	adapter, _, _ = newTestAdapter(inner, policy)
+	_, err = adapter.BroadcastRaw(ctx, []byte{0x01})
+	require.ErrorIs(t, err, errUnavailable)
+	_, err = adapter.BumpFee(ctx, nil, big.NewInt(1), nil, "key")
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int64(8), calls.Load())
+}
+
+func TestAdapterDrainWaitsForCapabilities(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	inner := &capableAdapter{send: func(ctx context.Context) error {
+		close(started)
+		<-release
+		return nil
+	}}
+	adapter, _, _ := newTestAdapter(inner, testPolicy())
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := adapter.BroadcastRaw(context.Background(), []byte{0x01})
+		done <- err
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, adapter.Drain(ctx), context.DeadlineExceeded)
+
+	close(release)
+	require.NoError(t, <-done)
+	require.NoError(t, adapter.Drain(context.Background()))
+}
+
+func TestAdapterUnsupportedCapabilities(t *testing.T) {
+	t.Parallel()
+
+	adapter, _, _ := newTestAdapter(&mocks.MockChainAdapter{}, testPolicy())
+	ctx := context.Background()
+
+	_, err := adapter.BroadcastRaw(ctx, nil)
+	require.ErrorIs(t, err, ErrUnsupported)
+	_, err = adapter.BumpFee(ctx, nil, nil, nil, "")
+	require.ErrorIs(t, err, ErrUnsupported)
+	_, err = adapter.CPFP(ctx, nil, nil, nil, "")
+	require.ErrorIs(t, err, ErrUnsupported)
+	_, err = adapter.ExportPSBT(ctx, nil)
+	require.ErrorIs(t, err, ErrUnsupported)
+	_, err = adapter.CombinePSBT()
+	require.ErrorIs(t, err, ErrUnsupported)
+	_, err = adapter.FinalizePSBT("")
+	require.ErrorIs(t, err, ErrUnsupported)
+	_, err = adapter.BroadcastPSBT(ctx, "")
+	assert.ErrorIs(t, err, ErrUnsupported)
+	assert.Contains(t, err.Error(), "BroadcastPSBT on chain ethereum")
+}
@@ -0,0 +1,164 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"time"
+)
+
+// Policy bounds the calls made to a chain adapter
+type Policy struct {
+	// Timeout bounds each attempt of a call to the node; zero leaves calls unbounded
+	Timeout time.Duration `yaml:"timeout"`
+	// MethodTimeouts overrides Timeout per ChainAdapter method, such as GetBalance or WaitForConfirmation
+	MethodTimeouts map[string]time.Duration `yaml:"method_timeouts"`
+	// MaxRetries is how many times an idempotent read is retried after a transient error
+	MaxRetries int `yaml:"max_retries"`
+	// InitialBackoff is the wait before the first retry, doubled for each further one up to MaxBackoff
+	InitialBackoff time.Duration `yaml:"initial_backoff"`
+	MaxBackoff     time.Duration `yaml:"max_backoff"`
+	// FailureThreshold is the number of consecutive transient errors that open the circuit; zero disables the breaker
+	FailureThreshold int `yaml:"failure_threshold"`
+	// OpenTimeout is how long an open circuit rejects calls before letting a trial call through
+	OpenTimeout time.Duration `yaml:"open_timeout"`
+}
+
+// Config holds the default policy and the overrides of individual chains (resilience in config.yaml)
+type Config struct {
+	Default Policy `yaml:"default"`
+	// Chains overrides the default policy per chain ID; unset fields keep the default
+	Chains map[string]Policy `yaml:"chains"`
+}
+
+// DefaultPolicy returns the policy applied to chains without configuration
+func DefaultPolicy() Policy {
+	return Policy{
+		Timeout: 10 * time.Second,
+		// Waiting for confirmations is bounded by the caller, not by the node's responsiveness
+		MethodTimeouts:   map[string]time.Duration{"WaitForConfirmation": 0},
+		MaxRetries:       2,
+		InitialBackoff:   200 * time.Millisecond,
+		MaxBackoff:       2 * time.Second,
+		FailureThreshold: 5,
+		OpenTimeout:      30 * time.Second,
+	}
+}
+
+// DefaultConfig returns a configuration applying DefaultPolicy to every chain
+func DefaultConfig() Config {
+	return Config{Default: DefaultPolicy()}
+}
+
+// Validate checks the policy
+func (p Policy) Validate() error {
+	if p.Timeout < 0 || p.InitialBackoff < 0 || p.MaxBackoff < 0 || p.OpenTimeout < 0 {
+		return fmt.Errorf("durations cannot be negative")
+	}
+	for method, timeout := range p.MethodTimeouts {
+		if timeout < 0 {
+			return fmt.Errorf("timeout of %s cannot be negative", method)
+		}
+	}
+	if p.MaxRetries < 0 {
+		return fmt.Errorf("max_retries cannot be negative")
+	}
+	if p.FailureThreshold < 0 {
+		return fmt.Errorf("failure_threshold cannot be negative")
+	}
+	return nil
+}
+
+// Validate checks the default policy and the policy of every chain
+func (c Config) Validate() error {
+	if err := c.Default.Validate(); err != nil {
+		return fmt.Errorf("invalid default policy: %w", err)
+	}
+	for chainID := range c.Chains {
+		if err := c.PolicyFor(chainID).Validate(); err != nil {
+			return fmt.Errorf("invalid policy for chain %s: %w", chainID, err)
+		}
+	}
+	return nil
+}
+
+// PolicyFor returns the policy of a chain, its overrides applied over the default policy
+func (c Config) PolicyFor(chainID string) Policy {
+	policy := c.Default
+	override, ok := c.Chains[chainID]
+	if !ok {
+		return policy
+	}
+	if override.Timeout != 0 {
+		policy.Timeout = override.Timeout
+	}
+	if len(override.MethodTimeouts) > 0 {
+		timeouts := make(map[string]time.Duration, len(policy.MethodTimeouts)+len(override.MethodTimeouts))
+		for method, timeout := range policy.MethodTimeouts {
+			timeouts[method] = timeout
+		}
+		for method, timeout := range override.MethodTimeouts {
+			timeouts[method] = timeout
+		}
+		policy.MethodTimeouts = timeouts
+	}
+	if override.MaxRetries != 0 {
+		policy.MaxRetries = override.MaxRetries
+	}
+	if override.InitialBackoff != 0 {
+		policy.InitialBackoff = override.InitialBackoff
+	}
+	if override.MaxBackoff != 0 {
+		policy.MaxBackoff = override.MaxBackoff
+	}
+	if override.FailureThreshold != 0 {
+		policy.FailureThreshold = override.FailureThreshold
+	}
+	if override.OpenTimeout != 0 {
+		policy.OpenTimeout = override.OpenTimeout
+	}
+	return policy
+}
+
+// timeout returns the bound of each attempt of a method
+func (p Policy) timeout(method string) time.Duration {
+	if timeout, ok := p.MethodTimeouts[method]; ok {
+		return timeout
+	}
+	return p.Timeout
+}
+
+// backoff returns the wait before a retry, counted from one
+func (p Policy) backoff(retry int) time.Duration {
+	wait := p.InitialBackoff
+	for i := 1; i < retry && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if p.MaxBackoff > 0 {
+		wait = min(wait, p.MaxBackoff)
+	}
+	return wait
+}
+
+// statusPattern matches the HTTP status errors of the RPC clients worth retrying
+var statusPattern = regexp.MustCompile(`status (429|5\d\d)\b`)
+
+// IsTransient reports whether an error says the node could not answer, rather than that it
+// rejected the call: network failures, timeouts, and 429 or 5xx responses
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, io.EOF):
+		return true
+	case errors.As(err, &netErr):
+		return true
+	default:
+		return statusPattern.MatchString(err.Error())
+	}
+}
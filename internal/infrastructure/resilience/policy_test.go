@@ -0,0 +1,114 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigPolicyFor(t *testing.T) {
+	t.Parallel()
+
+	config := Config{
+		Default: DefaultPolicy(),
+		Chains: map[string]Policy{
+			"bitcoin-mainnet": {
+				Timeout:        30 * time.Second,
+				MethodTimeouts: map[string]time.Duration{"BroadcastTransaction": time.Minute},
+				MaxRetries:     4,
+			},
+		},
+	}
+	require.NoError(t, config.Validate())
+
+	assert.Equal(t, DefaultPolicy(), config.PolicyFor("ethereum"))
+
+	policy := config.PolicyFor("bitcoin-mainnet")
+	assert.Equal(t, 30*time.Second, policy.Timeout)
+	assert.Equal(t, 4, policy.MaxRetries)
+	assert.Equal(t, DefaultPolicy().FailureThreshold, policy.FailureThreshold)
+	assert.Equal(t, time.Minute, policy.timeout("BroadcastTransaction"))
+	assert.Equal(t, 30*time.Second, policy.timeout("GetBalance"))
+	assert.Zero(t, policy.timeout("WaitForConfirmation"))
+	// Overrides do not leak into the default policy
+	assert.Equal(t, 10*time.Second, config.PolicyFor("ethereum").timeout("BroadcastTransaction"))
+
+	config.Chains["tron"] = Policy{MaxRetries: -1}
+	require.ErrorContains(t, config.Validate(), "invalid policy for chain tron: max_retries cannot be negative")
+	require.ErrorContains(t, Config{Default: Policy{Timeout: -time.Second}}.Validate(), "invalid default policy")
+	require.ErrorContains(t, Policy{MethodTimeouts: map[string]time.Duration{"GetPeers": -1}}.Validate(), "timeout of GetPeers")
+}
+
+func TestPolicyBackoff(t *testing.T) {
+	t.Parallel()
+
+	policy := Policy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 350 * time.Millisecond}
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(1))
+	assert.Equal(t, 200*time.Millisecond, policy.backoff(2))
+	assert.Equal(t, 350*time.Millisecond, policy.backoff(3))
+	assert.Equal(t, 350*time.Millisecond, policy.backoff(10))
+}
+
+func TestIsTransient(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err       error
+		transient bool
+	}{
+		{nil, false},
+		{fmt.Errorf("failed to get balance: %w", context.DeadlineExceeded), true},
+		{fmt.Errorf("failed to get balance: %w", context.Canceled), false},
+		{fmt.Errorf("eth_call request failed: %w", &net.OpError{Op: "dial", Err: errors.New("connection refused")}), true},
+		{errors.New("/blocks/tip/height returned status 502: bad gateway"), true},
+		{errors.New("getBalance returned status 429: too many requests"), true},
+		{errors.New("/address/x returned status 400: invalid address"), false},
+		{errors.New("json-rpc error -32000: nonce too low"), false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.transient, IsTransient(tt.err), "%v", tt.err)
+	}
+}
+
+func TestBreakerHalfOpen(t *testing.T) {
+	t.Parallel()
+
+	breaker := NewBreaker(1, time.Minute)
+	now := time.Now()
+	breaker.now = func() time.Time { return now }
+
+	require.NoError(t, breaker.Allow())
+	breaker.Record(true)
+	assert.Equal(t, StateOpen, breaker.State())
+	require.ErrorIs(t, breaker.Allow(), ErrCircuitOpen)
+
+	// One trial call at a time once the open timeout elapses; its failure opens the circuit again
+	now = now.Add(time.Minute)
+	assert.Equal(t, StateHalfOpen, breaker.State())
+	require.NoError(t, breaker.Allow())
+	require.ErrorIs(t, breaker.Allow(), ErrCircuitOpen)
+	breaker.Record(true)
+	assert.Equal(t, StateOpen, breaker.State())
+
+	// An abandoned trial lets the next call try instead
+	now = now.Add(time.Minute)
+	require.NoError(t, breaker.Allow())
+	breaker.Abandon()
+	require.NoError(t, breaker.Allow())
+	breaker.Record(false)
+	assert.Equal(t, StateClosed, breaker.State())
+
+	// A zero threshold never opens the circuit
+	breaker = NewBreaker(0, time.Minute)
+	for range 10 {
+		require.NoError(t, breaker.Allow())
+		breaker.Record(true)
+	}
+	assert.Equal(t, StateClosed, breaker.State())
+}
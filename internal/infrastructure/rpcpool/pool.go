@@ -0,0 +1,437 @@
+package rpcpool
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+)
+
+// Endpoint selection strategies
+const (
+	StrategyRoundRobin = "round-robin"
+	StrategyWeighted   = "weighted"
+)
+
+const (
+	defaultTimeout             = 30 * time.Second
+	defaultFailureThreshold    = 3
+	defaultCooldown            = 30 * time.Second
+	defaultHealthCheckInterval = 15 * time.Second
+	healthCheckTimeout         = 5 * time.Second
+	// ewmaWeight is the weight of the latest observation in latency and error rate averages
+	ewmaWeight = 0.2
+)
+
+// Endpoint is an RPC endpoint of a network
+type Endpoint struct {
+	URL string `yaml:"url"`
+	// Weight is the share of requests the endpoint gets with weighted selection; zero counts as one
+	Weight int `yaml:"weight"`
+}
+
+// Options tunes endpoint selection and health tracking
+type Options struct {
+	// Strategy is StrategyRoundRobin (default) or StrategyWeighted
+	Strategy string `yaml:"strategy"`
+	// MaxBlockLag is how many blocks an endpoint may trail the highest one before it is stale; zero disables the check
+	MaxBlockLag uint64 `yaml:"max_block_lag"`
+	// FailureThreshold is the number of consecutive failures that take an endpoint out of rotation
+	FailureThreshold int `yaml:"failure_threshold"`
+	// Cooldown is how long a failing endpoint stays out of rotation before it is tried again
+	Cooldown time.Duration `yaml:"cooldown"`
+	// HealthCheckInterval is the minimum time between block height probes, which requests trigger
+	HealthCheckInterval time.Duration `yaml:"health_check_interval"`
+}
+
+// HeightFunc returns the latest block height an endpoint serves, using client for its requests
+type HeightFunc func(ctx context.Context, endpoint string, client *http.Client) (uint64, error)
+
+// Validate checks a list of endpoints and the options balancing them
+func Validate(endpoints []Endpoint, options Options) error {
+	for _, e := range endpoints {
+		if _, err := parseEndpoint(e.URL); err != nil {
+			return err
+		}
+		if e.Weight < 0 {
+			return fmt.Errorf("weight cannot be negative for endpoint %s", e.URL)
+		}
+	}
+	switch options.Strategy {
+	case "", StrategyRoundRobin, StrategyWeighted:
+	default:
+		return fmt.Errorf("unknown strategy %q", options.Strategy)
+	}
+	if options.FailureThreshold < 0 {
+		return fmt.Errorf("failure_threshold cannot be negative")
+	}
+	if options.Cooldown < 0 || options.HealthCheckInterval < 0 {
+		return fmt.Errorf("cooldown and health_check_interval cannot be negative")
+	}
+	return nil
+}
+
+// Pool is an http.RoundTripper spreading the requests of an RPC client over several endpoints.
+// Clients address the first endpoint; the pool sends each request to the selected endpoint,
+// failing over to the others on transport errors, 5xx and 429 responses.
+type Pool struct {
+	endpoints []*endpoint
+	options   Options
+	height    HeightFunc
+	transport http.RoundTripper
+	now       func() time.Time
+
+	mu        sync.Mutex
+	next      int
+	lastCheck time.Time
+	checking  atomic.Bool
+}
+
+// endpoint holds the health observed for an endpoint, guarded by the pool mutex
+type endpoint struct {
+	url           *url.URL
+	weight        int
+	currentWeight int
+
+	latency             time.Duration
+	errorRate           float64
+	requests            uint64
+	failures            uint64
+	consecutiveFailures int
+	cooldownUntil       time.Time
+	height              uint64
+	stale               bool
+	lastError           string
+}
+
+// New creates a pool over endpoints; height, when set, probes block heights to detect stale endpoints
+func New(endpoints []Endpoint, options Options, height HeightFunc) (*Pool, error) {
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("at least one endpoint is required")
+	}
+	if err := Validate(endpoints, options); err != nil {
+		return nil, err
+	}
+	if options.Strategy == "" {
+		options.Strategy = StrategyRoundRobin
+	}
+	if options.FailureThreshold == 0 {
+		options.FailureThreshold = defaultFailureThreshold
+	}
+	if options.Cooldown == 0 {
+		options.Cooldown = defaultCooldown
+	}
+	if options.HealthCheckInterval == 0 {
+		options.HealthCheckInterval = defaultHealthCheckInterval
+	}
+
+	pool := &Pool{
+		options:   options,
+		height:    height,
+		transport: http.DefaultTransport,
+		now:       time.Now,
+	}
+	for _, e := range endpoints {
+		u, _ := parseEndpoint(e.URL)
+		pool.endpoints = append(pool.endpoints, &endpoint{url: u, weight: max(e.Weight, 1)})
+	}
+	return pool, nil
+}
+
+// URL returns the base URL clients of the pool are created with, the first endpoint
+func (p *Pool) URL() string {
+	return p.endpoints[0].url.String()
+}
+
+// Client returns an HTTP client sending its requests through the pool
+func (p *Pool) Client() *http.Client {
+	return &http.Client{Timeout: defaultTimeout, Transport: p}
+}
+
+// RoundTrip sends a request to the selected endpoint, failing over to the others
+func (p *Pool) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+	p.maybeCheckHealth()
+
+	candidates := p.candidates()
+	for i, e := range candidates {
+		out, err := p.rewrite(req, e, body)
+		if err != nil {
+			return nil, err
+		}
+		start := p.now()
+		resp, err := p.transport.RoundTrip(out)
+		switch {
+		case err == nil && !retryableStatus(resp.StatusCode):
+			p.record(e, p.now().Sub(start), nil)
+			return resp, nil
+		case req.Context().Err() != nil:
+			// The caller gave up; that says nothing about the endpoint
+			return resp, err
+		case err == nil:
+			p.record(e, p.now().Sub(start), fmt.Errorf("unexpected status %d", resp.StatusCode))
+		default:
+			p.record(e, p.now().Sub(start), err)
+		}
+		if i == len(candidates)-1 {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+	}
+	return nil, fmt.Errorf("no endpoint available")
+}
+
+// CheckHealth probes the block height of every endpoint and marks those trailing the highest as stale
+func (p *Pool) CheckHealth(ctx context.Context) {
+	if p.height == nil {
+		return
+	}
+	client := &http.Client{Timeout: healthCheckTimeout, Transport: p.transport}
+	heights := make([]uint64, len(p.endpoints))
+	errs := make([]error, len(p.endpoints))
+	latencies := make([]time.Duration, len(p.endpoints))
+	var wg sync.WaitGroup
+	for i, e := range p.endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := p.now()
+			heights[i], errs[i] = p.height(ctx, e.url.String(), client)
+			latencies[i] = p.now().Sub(start)
+		}()
+	}
+	wg.Wait()
+
+	for i, e := range p.endpoints {
+		p.record(e, latencies[i], errs[i])
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var best uint64
+	for i := range p.endpoints {
+		if errs[i] == nil {
+			best = max(best, heights[i])
+		}
+	}
+	for i, e := range p.endpoints {
+		if errs[i] != nil {
+			continue
+		}
+		e.height = heights[i]
+		e.stale = p.options.MaxBlockLag > 0 && best-heights[i] > p.options.MaxBlockLag
+	}
+	p.lastCheck = p.now()
+}
+
+// Health returns the observed health of every endpoint
+func (p *Pool) Health() []entities.EndpointHealth {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	health := make([]entities.EndpointHealth, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		health = append(health, entities.EndpointHealth{
+			URL:         redact(e.url),
+			Healthy:     e.available(now),
+			Latency:     e.latency,
+			ErrorRate:   e.errorRate,
+			BlockHeight: e.height,
+			Stale:       e.stale,
+			Requests:    e.requests,
+			Failures:    e.failures,
+			LastError:   e.lastError,
+		})
+	}
+	return health
+}
+
+// maybeCheckHealth starts a background height probe when the last one is older than the interval
+func (p *Pool) maybeCheckHealth() {
+	if p.height == nil {
+		return
+	}
+	p.mu.Lock()
+	due := p.now().Sub(p.lastCheck) >= p.options.HealthCheckInterval
+	p.mu.Unlock()
+	if !due || !p.checking.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer p.checking.Store(false)
+		ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+		defer cancel()
+		p.CheckHealth(ctx)
+	}()
+}
+
+// candidates orders the endpoints to try: the selected one, then the other available ones and
+// finally those out of rotation, each group healthiest first
+func (p *Pool) candidates() []*endpoint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+
+	var available, unavailable []*endpoint
+	for _, e := range p.endpoints {
+		if e.available(now) {
+			available = append(available, e)
+		} else {
+			unavailable = append(unavailable, e)
+		}
+	}
+	byHealth := func(a, b *endpoint) int {
+		if a.errorRate != b.errorRate {
+			if a.errorRate < b.errorRate {
+				return -1
+			}
+			return 1
+		}
+		return int(a.latency - b.latency)
+	}
+	slices.SortStableFunc(unavailable, byHealth)
+	if len(available) == 0 {
+		return unavailable
+	}
+
+	selected := p.selectEndpoint(available)
+	rest := slices.DeleteFunc(slices.Clone(available), func(e *endpoint) bool { return e == selected })
+	slices.SortStableFunc(rest, byHealth)
+	return append(append([]*endpoint{selected}, rest...), unavailable...)
+}
+
+// selectEndpoint picks one of the available endpoints according to the strategy
+func (p *Pool) selectEndpoint(available []*endpoint) *endpoint {
+	if p.options.Strategy == StrategyWeighted {
+		// Smooth weighted round-robin spreads each endpoint's share evenly over time
+		total := 0
+		var selected *endpoint
+		for _, e := range available {
+			e.currentWeight += e.weight
+			total += e.weight
+			if selected == nil || e.currentWeight > selected.currentWeight {
+				selected = e
+			}
+		}
+		selected.currentWeight -= total
+		return selected
+	}
+
+	for i := range p.endpoints {
+		e := p.endpoints[(p.next+i)%len(p.endpoints)]
+		if slices.Contains(available, e) {
+			p.next = (p.next + i + 1) % len(p.endpoints)
+			return e
+		}
+	}
+	return available[0]
+}
+
+// record updates the health of an endpoint with the outcome of a request
+func (p *Pool) record(e *endpoint, latency time.Duration, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	e.requests++
+	if e.latency == 0 {
+		e.latency = latency
+	} else {
+		e.latency = time.Duration(ewmaWeight*float64(latency) + (1-ewmaWeight)*float64(e.latency))
+	}
+	if err == nil {
+		e.errorRate = (1 - ewmaWeight) * e.errorRate
+		e.consecutiveFailures = 0
+		e.cooldownUntil = time.Time{}
+		return
+	}
+	e.errorRate = ewmaWeight + (1-ewmaWeight)*e.errorRate
+	e.failures++
+	e.consecutiveFailures++
+	e.lastError = err.Error()
+	if e.consecutiveFailures >= p.options.FailureThreshold {
+		e.cooldownUntil = p.now().Add(p.options.Cooldown)
+	}
+}
+
+// rewrite returns a copy of a request addressed to an endpoint
+func (p *Pool) rewrite(req *http.Request, e *endpoint, body []byte) (*http.Request, error) {
+	base := p.endpoints[0].url
+	if req.URL.Host != base.Host || !strings.HasPrefix(req.URL.Path, base.Path) {
+		return nil, fmt.Errorf("request to %s is outside the pool base URL %s", req.URL.Redacted(), base.Redacted())
+	}
+
+	target := *e.url
+	target.User = nil
+	target.Path = e.url.Path + strings.TrimPrefix(req.URL.Path, base.Path)
+	target.RawPath = ""
+	switch {
+	case e.url.RawQuery == "":
+		target.RawQuery = req.URL.RawQuery
+	case req.URL.RawQuery != "":
+		target.RawQuery = e.url.RawQuery + "&" + req.URL.RawQuery
+	}
+
+	out := req.Clone(req.Context())
+	out.URL = &target
+	out.Host = ""
+	if body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+		out.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }
+	}
+	if e.url.User != nil && out.Header.Get("Authorization") == "" {
+		password, _ := e.url.User.Password()
+		out.SetBasicAuth(e.url.User.Username(), password)
+	}
+	return out, nil
+}
+
+// available reports whether an endpoint is in rotation
+func (e *endpoint) available(now time.Time) bool {
+	return !e.stale && !now.Before(e.cooldownUntil)
+}
+
+// retryableStatus reports whether a response status is worth retrying on another endpoint
+func retryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+func parseEndpoint(raw string) (*url.URL, error) {
+	if raw == "" {
+		return nil, fmt.Errorf("endpoint URL cannot be empty")
+	}
+	u, err := url.Parse(strings.TrimRight(raw, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("invalid endpoint URL %q", u.Redacted())
+	}
+	return u, nil
+}
+
+// redact strips credentials from an endpoint URL, including API keys carried in the query
+func redact(u *url.URL) string {
+	clean := *u
+	clean.User = nil
+	clean.RawQuery = ""
+	return clean.String()
+}
@@ -0,0 +1,226 @@
+package rpcpool
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingServer answers with its name and counts the requests it served
+func countingServer(t *testing.T, name string, status int) (*httptest.Server, *atomic.Int64) {
+	t.Helper()
+	var hits atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(status)
+		_, _ = fmt.Fprintf(w, "%s %s?%s", name, r.URL.Path, r.URL.RawQuery)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+func get(t *testing.T, pool *Pool, path string) (int, string) {
+	t.Helper()
+	resp, err := pool.Client().Get(pool.URL() + path)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestPoolRoundRobin(t *testing.T) {
+	t.Parallel()
+
+	a, hitsA := countingServer(t, "a", http.StatusOK)
+	b, hitsB := countingServer(t, "b", http.StatusOK)
+	pool, err := New([]Endpoint{{URL: a.URL + "/api/"}, {URL: b.URL + "/v2?key=secret"}}, Options{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, a.URL+"/api", pool.URL())
+
+	// Paths below the first endpoint map onto the selected one, keeping its own path and query
+	_, body := get(t, pool, "/blocks/tip?limit=1")
+	assert.Equal(t, "a /api/blocks/tip?limit=1", body)
+	_, body = get(t, pool, "/blocks/tip?limit=1")
+	assert.Equal(t, "b /v2/blocks/tip?key=secret&limit=1", body)
+
+	for range 4 {
+		status, _ := get(t, pool, "")
+		assert.Equal(t, http.StatusOK, status)
+	}
+	assert.Equal(t, int64(3), hitsA.Load())
+	assert.Equal(t, int64(3), hitsB.Load())
+
+	health := pool.Health()
+	require.Len(t, health, 2)
+	assert.Equal(t, b.URL+"/v2", health[1].URL)
+	assert.True(t, health[1].Healthy)
+	assert.Equal(t, uint64(3), health[1].Requests)
+	assert.Positive(t, health[1].Latency)
+}
+
+func TestPoolWeighted(t *testing.T) {
+	t.Parallel()
+
+	a, hitsA := countingServer(t, "a", http.StatusOK)
+	b, hitsB := countingServer(t, "b", http.StatusOK)
+	pool, err := New([]Endpoint{{URL: a.URL, Weight: 3}, {URL: b.URL}}, Options{Strategy: StrategyWeighted}, nil)
+	require.NoError(t, err)
+
+	for range 8 {
+		get(t, pool, "")
+	}
+	assert.Equal(t, int64(6), hitsA.Load())
+	assert.Equal(t, int64(2), hitsB.Load())
+}
+
+func TestPoolFailover(t *testing.T) {
+	t.Parallel()
+
+	down, hitsDown := countingServer(t, "down", http.StatusServiceUnavailable)
+	var bodies []string
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(up.Close)
+
+	pool, err := New([]Endpoint{{URL: down.URL}, {URL: up.URL}}, Options{FailureThreshold: 2, Cooldown: time.Minute}, nil)
+	require.NoError(t, err)
+	now := time.Now()
+	pool.now = func() time.Time { return now }
+
+	// Failed requests are replayed, body included, on the next endpoint
+	for range 4 {
+		resp, err := pool.Client().Post(pool.URL(), "application/json", strings.NewReader(`{"method":"eth_blockNumber"}`))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		_ = resp.Body.Close()
+	}
+	assert.Equal(t, []string{`{"method":"eth_blockNumber"}`, `{"method":"eth_blockNumber"}`, `{"method":"eth_blockNumber"}`, `{"method":"eth_blockNumber"}`}, bodies)
+	// Two consecutive failures took the endpoint out of rotation
+	assert.Equal(t, int64(2), hitsDown.Load())
+
+	health := pool.Health()
+	assert.False(t, health[0].Healthy)
+	assert.Equal(t, uint64(2), health[0].Failures)
+	assert.Equal(t, "unexpected status 503", health[0].LastError)
+	assert.InDelta(t, 0.36, health[0].ErrorRate, 0.001)
+	assert.True(t, health[1].Healthy)
+
+	// After the cooldown the endpoint is tried again
+	now = now.Add(time.Minute)
+	get(t, pool, "")
+	assert.Equal(t, int64(3), hitsDown.Load())
+}
+
+func TestPoolAllEndpointsFailing(t *testing.T) {
+	t.Parallel()
+
+	a, hitsA := countingServer(t, "a", http.StatusTooManyRequests)
+	b, hitsB := countingServer(t, "b", http.StatusBadGateway)
+	pool, err := New([]Endpoint{{URL: a.URL}, {URL: b.URL}}, Options{}, nil)
+	require.NoError(t, err)
+
+	// The last endpoint's response reaches the client
+	status, body := get(t, pool, "/x")
+	assert.Equal(t, http.StatusBadGateway, status)
+	assert.Equal(t, "b /x?", body)
+	assert.Equal(t, int64(1), hitsA.Load())
+	assert.Equal(t, int64(1), hitsB.Load())
+
+	// Client errors are the endpoint's answer, not a reason to fail over
+	c, _ := countingServer(t, "c", http.StatusNotFound)
+	pool, err = New([]Endpoint{{URL: c.URL}, {URL: b.URL}}, Options{}, nil)
+	require.NoError(t, err)
+	status, _ = get(t, pool, "")
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, int64(1), hitsB.Load())
+
+	// Requests for other hosts are refused rather than sent somewhere unexpected
+	_, err = pool.Client().Get(b.URL)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "outside the pool base URL")
+}
+
+func TestPoolStaleEndpoints(t *testing.T) {
+	t.Parallel()
+
+	a, hitsA := countingServer(t, "a", http.StatusOK)
+	b, hitsB := countingServer(t, "b", http.StatusOK)
+	c, _ := countingServer(t, "c", http.StatusOK)
+	heights := map[string]uint64{a.URL: 90, b.URL: 100}
+	height := func(ctx context.Context, endpoint string, client *http.Client) (uint64, error) {
+		if h, ok := heights[endpoint]; ok {
+			return h, nil
+		}
+		return 0, fmt.Errorf("connection refused")
+	}
+	pool, err := New([]Endpoint{{URL: a.URL}, {URL: b.URL}, {URL: c.URL}}, Options{MaxBlockLag: 5}, height)
+	require.NoError(t, err)
+
+	pool.CheckHealth(context.Background())
+	health := pool.Health()
+	assert.True(t, health[0].Stale)
+	assert.False(t, health[0].Healthy)
+	assert.Equal(t, uint64(90), health[0].BlockHeight)
+	assert.True(t, health[1].Healthy)
+	assert.Equal(t, uint64(100), health[1].BlockHeight)
+	assert.Equal(t, "connection refused", health[2].LastError)
+
+	// The stale endpoint is skipped while a caught-up one answers
+	pool.lastCheck = time.Now()
+	for range 4 {
+		get(t, pool, "")
+	}
+	assert.Zero(t, hitsA.Load())
+	assert.Positive(t, hitsB.Load())
+
+	heights[a.URL] = 98
+	pool.CheckHealth(context.Background())
+	assert.True(t, pool.Health()[0].Healthy)
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		endpoints []Endpoint
+		options   Options
+		wantErr   string
+	}{
+		{"valid", []Endpoint{{URL: "https://eth.llamarpc.com", Weight: 2}}, Options{Strategy: StrategyWeighted}, ""},
+		{"empty url", []Endpoint{{}}, Options{}, "endpoint URL cannot be empty"},
+		{"bad scheme", []Endpoint{{URL: "ftp://node"}}, Options{}, "invalid endpoint URL"},
+		{"negative weight", []Endpoint{{URL: "https://node", Weight: -1}}, Options{}, "weight cannot be negative"},
+		{"unknown strategy", nil, Options{Strategy: "random"}, `unknown strategy "random"`},
+		{"negative threshold", nil, Options{FailureThreshold: -1}, "failure_threshold cannot be negative"},
+		{"negative cooldown", nil, Options{Cooldown: -time.Second}, "cannot be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := Validate(tt.endpoints, tt.options)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	_, err := New(nil, Options{}, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,93 @@
+package txstore
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+)
+
+// MemoryRepository keeps transactions in memory, for deployments without a database. It stores
+// and returns copies, so callers never share a transaction and changes count only once saved
+type MemoryRepository struct {
+	mu           sync.RWMutex
+	transactions map[string]*entities.Transaction
+}
+
+// NewMemoryRepository creates an empty MemoryRepository
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		transactions: make(map[string]*entities.Transaction),
+	}
+}
+
+// Save stores a transaction
+func (r *MemoryRepository) Save(ctx context.Context, tx *entities.Transaction) error {
+	if tx == nil {
+		return fmt.Errorf("transaction cannot be nil")
+	}
+
+	stored, err := cloneTransaction(tx)
+	if err != nil {
+		return fmt.Errorf("failed to copy transaction: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.transactions[tx.ID()] = stored
+	return nil
+}
+
+// GetByID returns a stored transaction
+func (r *MemoryRepository) GetByID(ctx context.Context, id string) (*entities.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tx, ok := r.transactions[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ports.ErrTransactionNotFound, id)
+	}
+	return cloneTransaction(tx)
+}
+
+// cloneTransaction rebuilds a transaction from a copy of its state; value objects are immutable
+// and shared, while amounts, data and metadata are copied
+func cloneTransaction(tx *entities.Transaction) (*entities.Transaction, error) {
+	metadata := make(map[string]interface{}, len(tx.Metadata()))
+	for key, value := range tx.Metadata() {
+		metadata[key] = value
+	}
+
+	return entities.RestoreTransaction(entities.TransactionState{
+		ID:             tx.ID(),
+		ChainID:        tx.ChainID(),
+		Hash:           tx.Hash(),
+		From:           tx.From(),
+		To:             tx.To(),
+		Value:          tx.Value(),
+		Data:           append([]byte(nil), tx.Data()...),
+		Nonce:          tx.Nonce(),
+		GasLimit:       tx.GasLimit(),
+		GasPrice:       cloneBigInt(tx.GasPrice()),
+		MaxFeePerGas:   cloneBigInt(tx.MaxFeePerGas()),
+		MaxPriorityFee: cloneBigInt(tx.MaxPriorityFee()),
+		Signature:      tx.Signature(),
+		Status:         tx.Status(),
+		BlockNumber:    tx.BlockNumber(),
+		Confirmations:  tx.Confirmations(),
+		Metadata:       metadata,
+		CreatedAt:      tx.CreatedAt(),
+		UpdatedAt:      tx.UpdatedAt(),
+	})
+}
+
+func cloneBigInt(value *big.Int) *big.Int {
+	if value == nil {
+		return nil
+	}
+	return new(big.Int).Set(value)
+}
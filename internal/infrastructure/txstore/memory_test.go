@@ -0,0 +1,108 @@
+package txstore
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTransaction(t *testing.T) *entities.Transaction {
+	t.Helper()
+	from, err := valueobjects.NewAddress("0x1111111111111111111111111111111111111111", "evm-1")
+	require.NoError(t, err)
+	to, err := valueobjects.NewAddress("0x2222222222222222222222222222222222222222", "evm-1")
+	require.NoError(t, err)
+	tx, err := entities.NewTransaction(entities.TransactionParams{
+		ChainID:  "evm-1",
+		From:     from,
+		To:       to,
+		Value:    big.NewInt(1000),
+		Nonce:    valueobjects.NewNonce(5),
+		GasLimit: 21000,
+	})
+	require.NoError(t, err)
+	return tx
+}
+
+func TestMemoryRepository(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+
+	tx := newTransaction(t)
+	require.NoError(t, repo.Save(ctx, tx))
+	stored, err := repo.GetByID(ctx, tx.ID())
+	require.NoError(t, err)
+	assert.Equal(t, tx.ID(), stored.ID())
+
+	_, err = repo.GetByID(ctx, "missing")
+	assert.ErrorIs(t, err, ports.ErrTransactionNotFound)
+	assert.Error(t, repo.Save(ctx, nil))
+}
+
+func TestMemoryRepositoryCopies(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+
+	tx := newTransaction(t)
+	tx.SetMetadata("fee", "21000")
+	require.NoError(t, repo.Save(ctx, tx))
+
+	// Changes to the saved transaction or to a loaded one are not stored until saved
+	tx.SetMetadata("fee", "0")
+	loaded, err := repo.GetByID(ctx, tx.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "21000", loaded.Metadata()["fee"])
+	loaded.UpdateStatus(entities.TxStatusFailed)
+	loaded.SetMetadata("error", "rejected")
+
+	again, err := repo.GetByID(ctx, tx.ID())
+	require.NoError(t, err)
+	assert.Equal(t, entities.TxStatusPending, again.Status())
+	assert.NotContains(t, again.Metadata(), "error")
+	assert.Equal(t, tx.CreatedAt(), again.CreatedAt())
+
+	require.NoError(t, repo.Save(ctx, loaded))
+	again, err = repo.GetByID(ctx, tx.ID())
+	require.NoError(t, err)
+	assert.Equal(t, entities.TxStatusFailed, again.Status())
+	assert.Equal(t, "rejected", again.Metadata()["error"])
+}
+
+func TestMemoryRepositoryConcurrentUse(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	tx := newTransaction(t)
+	require.NoError(t, repo.Save(ctx, tx))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				loaded, err := repo.GetByID(ctx, tx.ID())
+				if !assert.NoError(t, err) {
+					return
+				}
+				loaded.SetMetadata(fmt.Sprintf("worker-%d", i), j)
+				assert.NoError(t, repo.Save(ctx, loaded))
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	stored, err := repo.GetByID(ctx, tx.ID())
+	require.NoError(t, err)
+	assert.NotEmpty(t, stored.Metadata())
+}
@@ -0,0 +1,281 @@
+package txstore
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// PostgresRepository stores transactions in the transactions table
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRepository creates a PostgresRepository
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+// row is a transaction as stored in the transactions table
+type row struct {
+	ID             string         `db:"id"`
+	ChainID        string         `db:"chain_id"`
+	TxHash         sql.NullString `db:"tx_hash"`
+	FromAddress    string         `db:"from_address"`
+	ToAddress      string         `db:"to_address"`
+	Value          string         `db:"value"` // NUMERIC, stored as string to handle big.Int
+	Data           []byte         `db:"data"`
+	Nonce          sql.NullInt64  `db:"nonce"`
+	GasLimit       sql.NullInt64  `db:"gas_limit"`
+	GasPrice       sql.NullString `db:"gas_price"`
+	MaxFeePerGas   sql.NullString `db:"max_fee_per_gas"`
+	MaxPriorityFee sql.NullString `db:"max_priority_fee"`
+	Signature      []byte         `db:"signature"`
+	Status         string         `db:"status"`
+	BlockNumber    sql.NullInt64  `db:"block_number"`
+	Confirmations  sql.NullInt64  `db:"confirmations"`
+	Metadata       metadata       `db:"metadata"`
+	CreatedAt      time.Time      `db:"created_at"`
+	UpdatedAt      time.Time      `db:"updated_at"`
+}
+
+// metadata handles JSON marshaling of the JSONB metadata column
+type metadata map[string]interface{}
+
+// Value implements the driver.Valuer interface
+func (m metadata) Value() (driver.Value, error) {
+	if m == nil {
+		return json.Marshal(map[string]interface{}{})
+	}
+	return json.Marshal(map[string]interface{}(m))
+}
+
+// Scan implements the sql.Scanner interface, keeping numbers as json.Number so that large
+// integers survive the round trip
+func (m *metadata) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*m = make(metadata)
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("failed to scan JSONB: expected []byte, got %T", value)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	decoded := make(metadata)
+	if err := decoder.Decode(&decoded); err != nil {
+		return fmt.Errorf("failed to scan JSONB: %w", err)
+	}
+	*m = decoded
+	return nil
+}
+
+const upsertTransactionQuery = `
+	INSERT INTO transactions (
+		id, chain_id, tx_hash, from_address, to_address, value, data, nonce,
+		gas_limit, gas_price, max_fee_per_gas, max_priority_fee, signature,
+		status, block_number, confirmations, metadata, created_at, updated_at
+	) VALUES (
+		:id, :chain_id, :tx_hash, :from_address, :to_address, :value, :data, :nonce,
+		:gas_limit, :gas_price, :max_fee_per_gas, :max_priority_fee, :signature,
+		:status, :block_number, :confirmations, :metadata, :created_at, :updated_at
+	)
+	ON CONFLICT (id) DO UPDATE SET
+		tx_hash = EXCLUDED.tx_hash,
+		data = EXCLUDED.data,
+		nonce = EXCLUDED.nonce,
+		gas_limit = EXCLUDED.gas_limit,
+		gas_price = EXCLUDED.gas_price,
+		max_fee_per_gas = EXCLUDED.max_fee_per_gas,
+		max_priority_fee = EXCLUDED.max_priority_fee,
+		signature = EXCLUDED.signature,
+		status = EXCLUDED.status,
+		block_number = EXCLUDED.block_number,
+		confirmations = EXCLUDED.confirmations,
+		metadata = EXCLUDED.metadata
+`
+
+// Save inserts a transaction or updates the stored one with the same ID
+func (r *PostgresRepository) Save(ctx context.Context, tx *entities.Transaction) error {
+	if tx == nil {
+		return fmt.Errorf("transaction cannot be nil")
+	}
+	record, err := toRow(tx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.db.NamedExecContext(ctx, upsertTransactionQuery, record); err != nil {
+		return fmt.Errorf("failed to save transaction: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a transaction by ID
+func (r *PostgresRepository) GetByID(ctx context.Context, id string) (*entities.Transaction, error) {
+	// IDs are UUIDs, which Postgres refuses to compare with any other string
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("%w: %s", ports.ErrTransactionNotFound, id)
+	}
+
+	var record row
+	query := `
+		SELECT id, chain_id, tx_hash, from_address, to_address, value, data, nonce,
+		       gas_limit, gas_price, max_fee_per_gas, max_priority_fee, signature,
+		       status, block_number, confirmations, metadata, created_at, updated_at
+		FROM transactions
+		WHERE id = $1
+	`
+
+	err := r.db.GetContext(ctx, &record, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: %s", ports.ErrTransactionNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	return fromRow(record)
+}
+
+func toRow(tx *entities.Transaction) (*row, error) {
+	record := &row{
+		ID:             tx.ID(),
+		ChainID:        tx.ChainID(),
+		FromAddress:    tx.From().Value(),
+		ToAddress:      tx.To().Value(),
+		Value:          tx.Value().String(),
+		Data:           tx.Data(),
+		GasPrice:       nullBigInt(tx.GasPrice()),
+		MaxFeePerGas:   nullBigInt(tx.MaxFeePerGas()),
+		MaxPriorityFee: nullBigInt(tx.MaxPriorityFee()),
+		Status:         string(tx.Status()),
+		Metadata:       metadata(tx.Metadata()),
+		CreatedAt:      tx.CreatedAt(),
+		UpdatedAt:      tx.UpdatedAt(),
+	}
+	if tx.Hash() != nil {
+		record.TxHash = sql.NullString{String: tx.Hash().Hex(), Valid: true}
+	}
+	if tx.Signature() != nil {
+		record.Signature = tx.Signature().Bytes()
+	}
+
+	var err error
+	if tx.Nonce() != nil {
+		if record.Nonce, err = nullInt64(tx.Nonce().Value(), "nonce"); err != nil {
+			return nil, err
+		}
+	}
+	if record.GasLimit, err = nullInt64(tx.GasLimit(), "gas limit"); err != nil {
+		return nil, err
+	}
+	if tx.BlockNumber() > 0 {
+		if record.BlockNumber, err = nullInt64(tx.BlockNumber(), "block number"); err != nil {
+			return nil, err
+		}
+	}
+	if record.Confirmations, err = nullInt64(tx.Confirmations(), "confirmations"); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func fromRow(record row) (*entities.Transaction, error) {
+	from, err := valueobjects.NewAddress(record.FromAddress, record.ChainID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stored from address: %w", err)
+	}
+	to, err := valueobjects.NewAddress(record.ToAddress, record.ChainID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stored to address: %w", err)
+	}
+	value, ok := new(big.Int).SetString(record.Value, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid stored value: %s", record.Value)
+	}
+
+	state := entities.TransactionState{
+		ID:            record.ID,
+		ChainID:       record.ChainID,
+		From:          from,
+		To:            to,
+		Value:         value,
+		Data:          record.Data,
+		GasLimit:      uint64(record.GasLimit.Int64),
+		Status:        entities.TxStatus(record.Status),
+		BlockNumber:   uint64(record.BlockNumber.Int64),
+		Confirmations: uint64(record.Confirmations.Int64),
+		Metadata:      record.Metadata,
+		CreatedAt:     record.CreatedAt,
+		UpdatedAt:     record.UpdatedAt,
+	}
+	if record.TxHash.Valid {
+		if state.Hash, err = valueobjects.NewHash(record.TxHash.String); err != nil {
+			return nil, fmt.Errorf("invalid stored hash: %w", err)
+		}
+	}
+	if len(record.Signature) > 0 {
+		if state.Signature, err = valueobjects.NewSignatureFromBytes(record.Signature); err != nil {
+			return nil, fmt.Errorf("invalid stored signature: %w", err)
+		}
+	}
+	if record.Nonce.Valid {
+		state.Nonce = valueobjects.NewNonce(uint64(record.Nonce.Int64))
+	}
+	if state.GasPrice, err = parseNullBigInt(record.GasPrice, "gas price"); err != nil {
+		return nil, err
+	}
+	if state.MaxFeePerGas, err = parseNullBigInt(record.MaxFeePerGas, "max fee per gas"); err != nil {
+		return nil, err
+	}
+	if state.MaxPriorityFee, err = parseNullBigInt(record.MaxPriorityFee, "max priority fee"); err != nil {
+		return nil, err
+	}
+
+	return entities.RestoreTransaction(state)
+}
+
+func nullBigInt(value *big.Int) sql.NullString {
+	if value == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: value.String(), Valid: true}
+}
+
+func parseNullBigInt(value sql.NullString, field string) (*big.Int, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+	parsed, ok := new(big.Int).SetString(value.String, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid stored %s: %s", field, value.String)
+	}
+	return parsed, nil
+}
+
+// nullInt64 converts unsigned values to the BIGINT columns
+func nullInt64(value uint64, field string) (sql.NullInt64, error) {
+	if value > math.MaxInt64 {
+		return sql.NullInt64{}, fmt.Errorf("%s %d does not fit the transactions table", field, value)
+	}
+	return sql.NullInt64{Int64: int64(value), Valid: true}, nil
+}
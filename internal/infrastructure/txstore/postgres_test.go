@@ -0,0 +1,144 @@
+package txstore
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func setupTestDB(t *testing.T) (db *database.DB, cleanup func()) {
+	ctx := context.Background()
+
+	pgContainer, err := postgres.Run(ctx,
+		"postgres:16-alpine",
+		postgres.WithDatabase("txstoretest"),
+		postgres.WithUsername("testuser"),
+		postgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(5*time.Second)),
+	)
+	require.NoError(t, err)
+
+	host, err := pgContainer.Host(ctx)
+	require.NoError(t, err)
+	port, err := pgContainer.MappedPort(ctx, "5432")
+	require.NoError(t, err)
+
+	db, err = database.New(database.Config{
+		Host:     host,
+		Port:     port.Int(),
+		User:     "testuser",
+		Password: "testpass",
+		DBName:   "txstoretest",
+		SSLMode:  "disable",
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.RunMigrations("../../../migrations"))
+
+	cleanup = func() {
+		db.Close()
+		if termErr := testcontainers.TerminateContainer(pgContainer); termErr != nil {
+			t.Logf("failed to terminate container: %s", termErr)
+		}
+	}
+	return db, cleanup
+}
+
+// signTransaction fills in what signing records on a transaction
+func signTransaction(t *testing.T, tx *entities.Transaction) {
+	t.Helper()
+	hash, err := valueobjects.NewHash("0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060")
+	require.NoError(t, err)
+	sig, err := valueobjects.NewSignature("0x0102030405")
+	require.NoError(t, err)
+	require.NoError(t, tx.SetHash(hash))
+	require.NoError(t, tx.SetSignature(sig))
+	require.NoError(t, tx.SetDynamicFees(big.NewInt(30000000000), big.NewInt(2000000000)))
+	tx.SetMetadata("raw_transaction", "0x02f8")
+	tx.SetMetadata("large", new(big.Int).Lsh(big.NewInt(1), 100))
+	tx.SetMetadata("utxos", []struct{ TxID string }{{TxID: "abc"}})
+}
+
+func assertRestored(t *testing.T, want, got *entities.Transaction) {
+	t.Helper()
+	assert.Equal(t, want.ID(), got.ID())
+	assert.Equal(t, want.ChainID(), got.ChainID())
+	assert.Equal(t, want.From().Value(), got.From().Value())
+	assert.Equal(t, want.To().Value(), got.To().Value())
+	assert.Equal(t, want.Value(), got.Value())
+	assert.Equal(t, want.Nonce().Value(), got.Nonce().Value())
+	assert.Equal(t, want.GasLimit(), got.GasLimit())
+	assert.Equal(t, want.MaxFeePerGas(), got.MaxFeePerGas())
+	assert.Equal(t, want.MaxPriorityFee(), got.MaxPriorityFee())
+	assert.Nil(t, got.GasPrice())
+	assert.Equal(t, want.Hash().Hex(), got.Hash().Hex())
+	assert.Equal(t, want.Signature().Hex(), got.Signature().Hex())
+	assert.Equal(t, want.Status(), got.Status())
+	assert.Equal(t, "0x02f8", got.Metadata()["raw_transaction"])
+	assert.Equal(t, json.Number("1267650600228229401496703205376"), got.Metadata()["large"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"TxID": "abc"}}, got.Metadata()["utxos"])
+}
+
+func TestRowRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tx := newTransaction(t)
+	signTransaction(t, tx)
+	record, err := toRow(tx)
+	require.NoError(t, err)
+
+	// Metadata goes through its JSONB encoding, as it does in the database
+	encoded, err := record.Metadata.Value()
+	require.NoError(t, err)
+	require.NoError(t, record.Metadata.Scan(encoded))
+
+	restored, err := fromRow(*record)
+	require.NoError(t, err)
+	assertRestored(t, tx, restored)
+
+	record.Value = "not a number"
+	_, err = fromRow(*record)
+	assert.Error(t, err)
+}
+
+func TestPostgresRepository(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewPostgresRepository(db.DB)
+	ctx := context.Background()
+
+	tx := newTransaction(t)
+	require.NoError(t, repo.Save(ctx, tx))
+	stored, err := repo.GetByID(ctx, tx.ID())
+	require.NoError(t, err)
+	assert.Nil(t, stored.Hash())
+	assert.Nil(t, stored.Signature())
+	assert.Equal(t, uint64(5), stored.Nonce().Value())
+
+	signTransaction(t, tx)
+	tx.UpdateStatus(entities.TxStatusConfirmed)
+	require.NoError(t, repo.Save(ctx, tx))
+	stored, err = repo.GetByID(ctx, tx.ID())
+	require.NoError(t, err)
+	assertRestored(t, tx, stored)
+
+	_, err = repo.GetByID(ctx, "c7d5e1f0-9a8b-4c3d-8e2f-1a2b3c4d5e6f")
+	assert.ErrorIs(t, err, ports.ErrTransactionNotFound)
+	_, err = repo.GetByID(ctx, "not-a-uuid")
+	assert.ErrorIs(t, err, ports.ErrTransactionNotFound)
+}
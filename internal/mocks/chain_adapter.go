@@ -5,6 +5,7 @@ import (
 	"math/big"
 
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
 )
 
@@ -18,7 +19,7 @@ type MockChainAdapter struct {
 		tokenAddress *valueobjects.Address,
 	) (*big.Int, error)
 	BuildTransactionFunc      func(ctx context.Context, params entities.TransactionParams) (*entities.Transaction, error)
-	SignTransactionFunc       func(ctx context.Context, tx *entities.Transaction, privateKey []byte) error
+	SignTransactionFunc       func(ctx context.Context, tx *entities.Transaction, keys ports.KeyManager, keyID string) error
 	BroadcastTransactionFunc  func(ctx context.Context, tx *entities.Transaction) (*valueobjects.Hash, error)
 	EstimateFeeFunc           func(ctx context.Context, tx *entities.Transaction) (*entities.Fee, error)
 	GetTransactionStatusFunc  func(ctx context.Context, hash *valueobjects.Hash) (entities.TxStatus, error)
@@ -81,9 +82,9 @@ func (m *MockChainAdapter) SetNonce(ctx context.Context, tx *entities.Transactio
 	return nil
 }
 
-func (m *MockChainAdapter) SignTransaction(ctx context.Context, tx *entities.Transaction, privateKey []byte) error {
+func (m *MockChainAdapter) SignTransaction(ctx context.Context, tx *entities.Transaction, keys ports.KeyManager, keyID string) error {
 	if m.SignTransactionFunc != nil {
-		return m.SignTransactionFunc(ctx, tx, privateKey)
+		return m.SignTransactionFunc(ctx, tx, keys, keyID)
 	}
 	hash, _ := valueobjects.NewHash("0xabcd")
 	sig, _ := valueobjects.NewSignature("0x1234")
@@ -148,3 +149,85 @@ func (m *MockChainAdapter) GetPeers(ctx context.Context) (int, error) {
 func (m *MockChainAdapter) GetLatestBlock(ctx context.Context) (uint64, error) {
 	return 12345, nil
 }
+
+// MockTokenAdapter is a MockChainAdapter that also implements the optional token capabilities
+type MockTokenAdapter struct {
+	MockChainAdapter
+	EncodeTokenTransferFunc func(to *valueobjects.Address, amount *big.Int) ([]byte, error)
+	GetTokenMetadataFunc    func(ctx context.Context, tokenAddress *valueobjects.Address) (*entities.Token, error)
+}
+
+func (m *MockTokenAdapter) EncodeTokenTransfer(to *valueobjects.Address, amount *big.Int) ([]byte, error) {
+	if m.EncodeTokenTransferFunc != nil {
+		return m.EncodeTokenTransferFunc(to, amount)
+	}
+	return []byte{0xa9, 0x05, 0x9c, 0xbb}, nil
+}
+
+func (m *MockTokenAdapter) GetTokenMetadata(ctx context.Context, tokenAddress *valueobjects.Address) (*entities.Token, error) {
+	if m.GetTokenMetadataFunc != nil {
+		return m.GetTokenMetadataFunc(ctx, tokenAddress)
+	}
+	return entities.NewToken(tokenAddress, "MOCK", 18)
+}
+
+// MockPSBTAdapter is a MockChainAdapter that also implements the optional PSBT capability
+type MockPSBTAdapter struct {
+	MockChainAdapter
+	ExportPSBTFunc    func(ctx context.Context, tx *entities.Transaction) (string, error)
+	CombinePSBTFunc   func(psbts ...string) (string, error)
+	FinalizePSBTFunc  func(psbt string) (string, error)
+	BroadcastPSBTFunc func(ctx context.Context, psbt string) (*valueobjects.Hash, error)
+}
+
+func (m *MockPSBTAdapter) ExportPSBT(ctx context.Context, tx *entities.Transaction) (string, error) {
+	if m.ExportPSBTFunc != nil {
+		return m.ExportPSBTFunc(ctx, tx)
+	}
+	return "cHNidP8=", nil
+}
+
+func (m *MockPSBTAdapter) CombinePSBT(psbts ...string) (string, error) {
+	if m.CombinePSBTFunc != nil {
+		return m.CombinePSBTFunc(psbts...)
+	}
+	if len(psbts) == 0 {
+		return "", nil
+	}
+	return psbts[0], nil
+}
+
+func (m *MockPSBTAdapter) FinalizePSBT(psbt string) (string, error) {
+	if m.FinalizePSBTFunc != nil {
+		return m.FinalizePSBTFunc(psbt)
+	}
+	return "0200000000", nil
+}
+
+func (m *MockPSBTAdapter) BroadcastPSBT(ctx context.Context, psbt string) (*valueobjects.Hash, error) {
+	if m.BroadcastPSBTFunc != nil {
+		return m.BroadcastPSBTFunc(ctx, psbt)
+	}
+	return valueobjects.NewHash("0xabcdef")
+}
+
+// MockFeeBumpAdapter is a MockChainAdapter that also implements the optional fee bumping capability
+type MockFeeBumpAdapter struct {
+	MockChainAdapter
+	BumpFeeFunc func(ctx context.Context, hash *valueobjects.Hash, feeRate *big.Int, keys ports.KeyManager, keyID string) (*entities.Transaction, error)
+	CPFPFunc    func(ctx context.Context, hash *valueobjects.Hash, feeRate *big.Int, keys ports.KeyManager, keyID string) (*entities.Transaction, error)
+}
+
+func (m *MockFeeBumpAdapter) BumpFee(ctx context.Context, hash *valueobjects.Hash, feeRate *big.Int, keys ports.KeyManager, keyID string) (*entities.Transaction, error) {
+	if m.BumpFeeFunc != nil {
+		return m.BumpFeeFunc(ctx, hash, feeRate, keys, keyID)
+	}
+	return nil, nil
+}
+
+func (m *MockFeeBumpAdapter) CPFP(ctx context.Context, hash *valueobjects.Hash, feeRate *big.Int, keys ports.KeyManager, keyID string) (*entities.Transaction, error) {
+	if m.CPFPFunc != nil {
+		return m.CPFPFunc(ctx, hash, feeRate, keys, keyID)
+	}
+	return nil, nil
+}
@@ -2,10 +2,12 @@ package mocks
 
 import (
 	"context"
+	"errors"
 	"math/big"
 	"testing"
 
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
 	"github.com/stretchr/testify/assert"
 )
@@ -54,7 +56,7 @@ func TestMockChainAdapter_AllMethods(t *testing.T) {
 	gas, _ := m.EstimateGas(ctx, tx)
 	assert.Equal(t, uint64(21000), gas)
 	_ = m.SetNonce(ctx, tx)
-	_ = m.SignTransaction(ctx, tx, []byte("key"))
+	_ = m.SignTransaction(ctx, tx, NewMockKeyManager(), "key")
 	ok, _ := m.VerifySignature(ctx, tx)
 	assert.True(t, ok)
 	hash2, _ := m.BroadcastTransaction(ctx, tx)
@@ -77,3 +79,84 @@ func TestMockChainAdapter_AllMethods(t *testing.T) {
 	lb, _ := m.GetLatestBlock(ctx)
 	assert.Equal(t, uint64(12345), lb)
 }
+
+func TestMockTokenAdapter(t *testing.T) {
+	t.Parallel()
+	m := &MockTokenAdapter{}
+	token, _ := valueobjects.NewAddress("0xtoken", "mock")
+
+	data, err := m.EncodeTokenTransfer(token, big.NewInt(1))
+	assert.NoError(t, err)
+	assert.Len(t, data, 4)
+
+	meta, err := m.GetTokenMetadata(context.Background(), token)
+	assert.NoError(t, err)
+	assert.Equal(t, "MOCK", meta.Symbol())
+	assert.Equal(t, uint8(18), meta.Decimals())
+}
+
+func TestMockPSBTAdapter(t *testing.T) {
+	t.Parallel()
+	m := &MockPSBTAdapter{}
+	ctx := context.Background()
+
+	psbt, err := m.ExportPSBT(ctx, nil)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, psbt)
+
+	combined, err := m.CombinePSBT(psbt, "other")
+	assert.NoError(t, err)
+	assert.Equal(t, psbt, combined)
+
+	rawTx, err := m.FinalizePSBT(combined)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, rawTx)
+
+	hash, err := m.BroadcastPSBT(ctx, combined)
+	assert.NoError(t, err)
+	assert.Equal(t, "0xabcdef", hash.Hex())
+
+	combined, err = m.CombinePSBT()
+	assert.NoError(t, err)
+	assert.Empty(t, combined)
+
+	failure := errors.New("invalid psbt")
+	m.ExportPSBTFunc = func(ctx context.Context, tx *entities.Transaction) (string, error) { return "", failure }
+	m.CombinePSBTFunc = func(psbts ...string) (string, error) { return "", failure }
+	m.FinalizePSBTFunc = func(psbt string) (string, error) { return "", failure }
+	m.BroadcastPSBTFunc = func(ctx context.Context, psbt string) (*valueobjects.Hash, error) { return nil, failure }
+	_, err = m.ExportPSBT(ctx, nil)
+	assert.ErrorIs(t, err, failure)
+	_, err = m.CombinePSBT(psbt)
+	assert.ErrorIs(t, err, failure)
+	_, err = m.FinalizePSBT(psbt)
+	assert.ErrorIs(t, err, failure)
+	_, err = m.BroadcastPSBT(ctx, psbt)
+	assert.ErrorIs(t, err, failure)
+}
+
+func TestMockFeeBumpAdapter(t *testing.T) {
+	t.Parallel()
+	m := &MockFeeBumpAdapter{}
+	ctx := context.Background()
+
+	tx, err := m.BumpFee(ctx, nil, big.NewInt(10), nil, "")
+	assert.NoError(t, err)
+	assert.Nil(t, tx)
+
+	tx, err = m.CPFP(ctx, nil, big.NewInt(10), nil, "")
+	assert.NoError(t, err)
+	assert.Nil(t, tx)
+
+	m.BumpFeeFunc = func(ctx context.Context, hash *valueobjects.Hash, feeRate *big.Int, keys ports.KeyManager, keyID string) (*entities.Transaction, error) {
+		return nil, errors.New("not replaceable")
+	}
+	_, err = m.BumpFee(ctx, nil, big.NewInt(10), nil, "")
+	assert.EqualError(t, err, "not replaceable")
+
+	m.CPFPFunc = func(ctx context.Context, hash *valueobjects.Hash, feeRate *big.Int, keys ports.KeyManager, keyID string) (*entities.Transaction, error) {
+		return nil, errors.New("no change output")
+	}
+	_, err = m.CPFP(ctx, nil, big.NewInt(10), nil, "")
+	assert.EqualError(t, err, "no change output")
+}
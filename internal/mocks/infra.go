@@ -3,20 +3,24 @@ package mocks
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
 )
 
 // MockChainRegistry is a mock implementation of ChainRegistry
 type MockChainRegistry struct {
 	adapters map[string]ports.ChainAdapter
+	disabled map[string]bool
 }
 
 // NewMockChainRegistry creates a new mock chain registry
 func NewMockChainRegistry() *MockChainRegistry {
 	return &MockChainRegistry{
 		adapters: make(map[string]ports.ChainAdapter),
+		disabled: make(map[string]bool),
 	}
 }
 
@@ -31,12 +35,44 @@ func (r *MockChainRegistry) Register(chainID string, adapter ports.ChainAdapter)
 	return nil
 }
 
+func (r *MockChainRegistry) Describe(chainID string) (*entities.Chain, error) {
+	adapter, err := r.Lookup(chainID)
+	if err != nil {
+		return nil, err
+	}
+	describer, ok := adapter.(ports.ChainDescriber)
+	if !ok {
+		return nil, fmt.Errorf("chain descriptor not available: %s", chainID)
+	}
+	return describer.DescribeChain()
+}
+
 func (r *MockChainRegistry) Unregister(chainID string) error {
 	delete(r.adapters, chainID)
+	delete(r.disabled, chainID)
 	return nil
 }
 
+func (r *MockChainRegistry) Replace(chainID string, adapter ports.ChainAdapter) (ports.ChainAdapter, error) {
+	previous, exists := r.adapters[chainID]
+	if !exists {
+		return nil, fmt.Errorf("chain adapter not found: %s", chainID)
+	}
+	if adapter == nil {
+		return nil, fmt.Errorf("adapter cannot be nil")
+	}
+	r.adapters[chainID] = adapter
+	return previous, nil
+}
+
 func (r *MockChainRegistry) Get(chainID string) (ports.ChainAdapter, error) {
+	if r.disabled[chainID] {
+		return nil, fmt.Errorf("chain adapter disabled: %s", chainID)
+	}
+	return r.Lookup(chainID)
+}
+
+func (r *MockChainRegistry) Lookup(chainID string) (ports.ChainAdapter, error) {
 	adapter, exists := r.adapters[chainID]
 	if !exists {
 		return nil, fmt.Errorf("chain adapter not found: %s", chainID)
@@ -44,6 +80,19 @@ func (r *MockChainRegistry) Get(chainID string) (ports.ChainAdapter, error) {
 	return adapter, nil
 }
 
+func (r *MockChainRegistry) SetActive(ctx context.Context, chainID string, active bool) error {
+	if _, exists := r.adapters[chainID]; !exists {
+		return fmt.Errorf("chain adapter not found: %s", chainID)
+	}
+	r.disabled[chainID] = !active
+	return nil
+}
+
+func (r *MockChainRegistry) IsActive(chainID string) bool {
+	_, exists := r.adapters[chainID]
+	return exists && !r.disabled[chainID]
+}
+
 func (r *MockChainRegistry) List() []string {
 	chains := make([]string, 0, len(r.adapters))
 	for chainID := range r.adapters {
@@ -79,6 +128,85 @@ func (p *MockEventPublisher) PublishBatch(ctx context.Context, events []interfac
 	return nil
 }
 
+// MockTransactionRepository is a mock implementation of TransactionRepository
+type MockTransactionRepository struct {
+	mu           sync.Mutex
+	Transactions map[string]*entities.Transaction
+	SaveErr      error
+}
+
+// NewMockTransactionRepository creates a new mock transaction repository
+func NewMockTransactionRepository() *MockTransactionRepository {
+	return &MockTransactionRepository{
+		Transactions: make(map[string]*entities.Transaction),
+	}
+}
+
+func (r *MockTransactionRepository) Save(ctx context.Context, tx *entities.Transaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.SaveErr != nil {
+		return r.SaveErr
+	}
+	r.Transactions[tx.ID()] = tx
+	return nil
+}
+
+func (r *MockTransactionRepository) GetByID(ctx context.Context, id string) (*entities.Transaction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tx, exists := r.Transactions[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ports.ErrTransactionNotFound, id)
+	}
+	return tx, nil
+}
+
+// MockKeyManager is a mock implementation of KeyManager
+type MockKeyManager struct {
+	mu        sync.Mutex
+	Keys      map[string]ports.KeyInfo
+	SignCalls []string
+}
+
+// NewMockKeyManager creates a new mock key manager
+func NewMockKeyManager() *MockKeyManager {
+	return &MockKeyManager{
+		Keys: make(map[string]ports.KeyInfo),
+	}
+}
+
+func (m *MockKeyManager) Sign(ctx context.Context, keyID string, digest []byte) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, exists := m.Keys[keyID]; !exists {
+		return nil, fmt.Errorf("%w: %s", ports.ErrKeyNotFound, keyID)
+	}
+	m.SignCalls = append(m.SignCalls, keyID)
+	return make([]byte, 65), nil
+}
+
+func (m *MockKeyManager) PublicKey(ctx context.Context, keyID string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key, exists := m.Keys[keyID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ports.ErrKeyNotFound, keyID)
+	}
+	return append([]byte(nil), key.PublicKey...), nil
+}
+
+func (m *MockKeyManager) ListKeys(ctx context.Context) ([]ports.KeyInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	keys := make([]ports.KeyInfo, 0, len(m.Keys))
+	for _, key := range m.Keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+	return keys, nil
+}
+
 // MockLogger is a mock implementation of Logger
 type MockLogger struct {
 	mu         sync.Mutex
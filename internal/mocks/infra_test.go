@@ -2,11 +2,27 @@ package mocks
 
 import (
 	"context"
+	"errors"
+	"math/big"
 	"testing"
 
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// describedAdapter is a MockChainAdapter that describes its chain
+type describedAdapter struct {
+	MockChainAdapter
+	chain *entities.Chain
+}
+
+func (a *describedAdapter) DescribeChain() (*entities.Chain, error) {
+	return a.chain, nil
+}
+
 func TestMockChainRegistry_AllMethods(t *testing.T) {
 	t.Parallel()
 	r := NewMockChainRegistry()
@@ -34,6 +50,46 @@ func TestMockChainRegistry_AllMethods(t *testing.T) {
 	chains := r.List()
 	assert.Contains(t, chains, "eth")
 
+	// Disabled chains are only returned by Lookup
+	assert.NoError(t, r.SetActive(context.Background(), "eth", false))
+	assert.False(t, r.IsActive("eth"))
+	_, err = r.Get("eth")
+	assert.Error(t, err)
+	a, err = r.Lookup("eth")
+	assert.NoError(t, err)
+	assert.Equal(t, adapter, a)
+	assert.NoError(t, r.SetActive(context.Background(), "eth", true))
+	assert.True(t, r.IsActive("eth"))
+	assert.Error(t, r.SetActive(context.Background(), "unknown", false))
+
+	// Replace returns the previous adapter
+	replacement := &MockChainAdapter{}
+	previous, err := r.Replace("eth", replacement)
+	assert.NoError(t, err)
+	assert.Same(t, adapter, previous)
+	a, err = r.Get("eth")
+	assert.NoError(t, err)
+	assert.Same(t, replacement, a)
+	_, err = r.Replace("eth", nil)
+	assert.Error(t, err)
+	_, err = r.Replace("unknown", replacement)
+	assert.Error(t, err)
+
+	// Describe needs an adapter that describes its chain
+	_, err = r.Describe("eth")
+	assert.Error(t, err)
+	_, err = r.Describe("unknown")
+	assert.Error(t, err)
+	chain, err := entities.NewChain("Ethereum", entities.ChainTypeEVM, "1", false, "ETH")
+	require.NoError(t, err)
+	assert.NoError(t, r.Register("described", &describedAdapter{chain: chain}))
+	described, err := r.Describe("described")
+	assert.NoError(t, err)
+	assert.Equal(t, chain, described)
+
+	// Register with an empty chain ID should error
+	assert.Error(t, r.Register("", adapter))
+
 	// Unregister
 	err = r.Unregister("eth")
 	assert.NoError(t, err)
@@ -55,6 +111,58 @@ func TestMockEventPublisher_AllMethods(t *testing.T) {
 	assert.Len(t, p.PublishedEvents, 3)
 }
 
+func TestMockTransactionRepository_AllMethods(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	r := NewMockTransactionRepository()
+	from, _ := valueobjects.NewAddress("0x1234", "mock")
+	to, _ := valueobjects.NewAddress("0x5678", "mock")
+	tx, err := entities.NewTransaction(entities.TransactionParams{ChainID: "mock", From: from, To: to, Value: big.NewInt(1)})
+	require.NoError(t, err)
+
+	// Save and GetByID
+	assert.NoError(t, r.Save(ctx, tx))
+	stored, err := r.GetByID(ctx, tx.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, tx, stored)
+	assert.Len(t, r.Transactions, 1)
+
+	// GetByID non-existent
+	_, err = r.GetByID(ctx, "unknown")
+	assert.ErrorIs(t, err, ports.ErrTransactionNotFound)
+
+	// Save returns SaveErr
+	r.SaveErr = errors.New("database down")
+	assert.ErrorIs(t, r.Save(ctx, tx), r.SaveErr)
+}
+
+func TestMockKeyManager_AllMethods(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	m := NewMockKeyManager()
+	m.Keys["hot"] = ports.KeyInfo{ID: "hot", Curve: ports.CurveSecp256k1, PublicKey: []byte{0x02}}
+	m.Keys["fee-payer"] = ports.KeyInfo{ID: "fee-payer", Curve: ports.CurveEd25519, PublicKey: []byte{0x03}}
+
+	sig, err := m.Sign(ctx, "hot", []byte{0x01})
+	assert.NoError(t, err)
+	assert.Len(t, sig, 65)
+	assert.Equal(t, []string{"hot"}, m.SignCalls)
+
+	publicKey, err := m.PublicKey(ctx, "hot")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x02}, publicKey)
+
+	keys, err := m.ListKeys(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, keys, 2)
+	assert.Equal(t, "fee-payer", keys[0].ID)
+
+	_, err = m.Sign(ctx, "cold", nil)
+	assert.ErrorIs(t, err, ports.ErrKeyNotFound)
+	_, err = m.PublicKey(ctx, "cold")
+	assert.ErrorIs(t, err, ports.ErrKeyNotFound)
+}
+
 func TestMockLogger_AllMethods(t *testing.T) {
 	t.Parallel()
 	l := NewMockLogger()
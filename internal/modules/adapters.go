@@ -1,62 +1,50 @@
 package modules
 
 import (
-	"math/big"
+	"context"
 
-	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/evm/harness"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/factory"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/config"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/logger"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/reload"
 	"go.uber.org/fx"
 )
 
-// AdaptersModule provides blockchain adapters
+// AdaptersModule registers an adapter for every configured network of the enabled chains,
+// applies changes to the networks while the server runs and creates the adapters of networks
+// registered through the admin API
 var AdaptersModule = fx.Module("adapters",
+	fx.Invoke(registerAdapters),
 	fx.Provide(
-		fx.Annotate(
-			func() ports.ChainAdapter {
-				h := harness.NewEVMHarness("ethereum")
-				h.SetBalance("0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb", big.NewInt(1000000000000000000))
-				return h
-			},
-			fx.ResultTags(`name:"ethereum"`),
-		),
-		fx.Annotate(
-			func() ports.ChainAdapter {
-				h := harness.NewEVMHarness("polygon")
-				h.SetBalance("0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb", big.NewInt(5000000000000000000))
-				return h
+		func() ports.ChainFactory {
+			return factory.ChainFactory{}
+		},
+		func(registry ports.ChainRegistry, cfg *config.Config, publisher ports.EventPublisher, log *logger.ZapLogger) *reload.Reloader {
+			path, _ := configPath()
+			return reload.NewReloader(path, cfg, registry, publisher, log)
+		},
+	),
+	fx.Invoke(func(reloader *reload.Reloader, lifecycle fx.Lifecycle) {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		lifecycle.Append(fx.Hook{
+			OnStart: func(context.Context) error {
+				go func() {
+					defer close(done)
+					reloader.Watch(ctx)
+				}()
+				return nil
 			},
-			fx.ResultTags(`name:"polygon"`),
-		),
-		fx.Annotate(
-			func() ports.ChainAdapter {
-				h := harness.NewEVMHarness("tron")
-				h.SetBalance("0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb", big.NewInt(2000000000000000000))
-				return h
+			OnStop: func(context.Context) error {
+				cancel()
+				<-done
+				return nil
 			},
-			fx.ResultTags(`name:"tron"`),
-		),
-	),
-	fx.Invoke(registerAdapters),
+		})
+	}),
 )
 
-type AdapterParams struct {
-	fx.In
-
-	Registry ports.ChainRegistry
-	Ethereum ports.ChainAdapter `name:"ethereum"`
-	Polygon  ports.ChainAdapter `name:"polygon"`
-	Tron     ports.ChainAdapter `name:"tron"`
-}
-
-func registerAdapters(params AdapterParams) error {
-	if err := params.Registry.Register("ethereum", params.Ethereum); err != nil {
-		return err
-	}
-	if err := params.Registry.Register("polygon", params.Polygon); err != nil {
-		return err
-	}
-	if err := params.Registry.Register("tron", params.Tron); err != nil {
-		return err
-	}
-	return nil
+func registerAdapters(registry ports.ChainRegistry, cfg *config.Config) error {
+	return factory.Register(registry, cfg)
 }
@@ -2,153 +2,106 @@ package modules
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/gabrielksneiva/ChainSystemPro/internal/config"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func TestRegisterAdapters(t *testing.T) {
-	t.Parallel()
-	reg := mocks.NewMockChainRegistry()
-	params := AdapterParams{
-		Registry: reg,
-		Ethereum: &mocks.MockChainAdapter{},
-		Polygon:  &mocks.MockChainAdapter{},
-		Tron:     &mocks.MockChainAdapter{},
-	}
-	err := registerAdapters(params)
-	assert.NoError(t, err)
-	assert.True(t, reg.Has("ethereum"))
-	assert.True(t, reg.Has("polygon"))
-	assert.True(t, reg.Has("tron"))
+const harnessConfig = `
+chains:
+  enabled: [evm, bitcoin]
+evm:
+  networks:
+    - name: ethereum
+      adapter: harness
+      balances:
+        "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb": "1000000000000000000"
+    - name: polygon
+      adapter: harness
+bitcoin:
+  networks:
+    - name: regtest
+      adapter: harness
+solana:
+  networks:
+    - name: solana
+      adapter: harness
+`
+
+func parseConfig(t *testing.T, data string) *config.Config {
+	t.Helper()
+	provider, err := config.Parse([]byte(data), func(string) (string, bool) { return "", false })
+	require.NoError(t, err)
+	return provider.Config()
 }
 
-func TestRegisterAdapters_ErrorOnNilAdapter(t *testing.T) {
+func TestRegisterAdapters(t *testing.T) {
 	t.Parallel()
 	reg := mocks.NewMockChainRegistry()
-	params := AdapterParams{
-		Registry: reg,
-		Ethereum: nil, // force error on first register
-		Polygon:  &mocks.MockChainAdapter{},
-		Tron:     &mocks.MockChainAdapter{},
-	}
-	err := registerAdapters(params)
-	assert.Error(t, err)
-}
 
-func TestRegisterAdapters_PolygonError(t *testing.T) {
-	t.Parallel()
-	reg := mocks.NewMockChainRegistry()
-	params := AdapterParams{
-		Registry: reg,
-		Ethereum: &mocks.MockChainAdapter{},
-		Polygon:  nil, // force error on second register
-		Tron:     &mocks.MockChainAdapter{},
-	}
-	err := registerAdapters(params)
-	assert.Error(t, err)
-}
+	err := registerAdapters(reg, parseConfig(t, harnessConfig))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"ethereum", "polygon", "bitcoin-regtest"}, reg.List())
 
-func TestRegisterAdapters_TronError(t *testing.T) {
-	t.Parallel()
-	reg := mocks.NewMockChainRegistry()
-	params := AdapterParams{
-		Registry: reg,
-		Ethereum: &mocks.MockChainAdapter{},
-		Polygon:  &mocks.MockChainAdapter{},
-		Tron:     nil, // force error on third register
-	}
-	err := registerAdapters(params)
-	assert.Error(t, err)
+	// Networks of chains left out of chains.enabled are not registered
+	assert.False(t, reg.Has("solana"))
+
+	eth, err := reg.Get("ethereum")
+	require.NoError(t, err)
+	assert.True(t, eth.IsConnected(context.Background()))
 }
 
-func TestRegisterAdapters_ValidatesAllAdapters(t *testing.T) {
+func TestRegisterAdapters_NoNetworks(t *testing.T) {
 	t.Parallel()
 	reg := mocks.NewMockChainRegistry()
 
-	ethereum := &mocks.MockChainAdapter{}
-	polygon := &mocks.MockChainAdapter{}
-	tron := &mocks.MockChainAdapter{}
-
-	params := AdapterParams{
-		Registry: reg,
-		Ethereum: ethereum,
-		Polygon:  polygon,
-		Tron:     tron,
-	}
-
-	err := registerAdapters(params)
-	assert.NoError(t, err)
-
-	// Verify all three are registered
-	ethAdapter, err := reg.Get("ethereum")
-	assert.NoError(t, err)
-	assert.Equal(t, ethereum, ethAdapter)
-
-	polyAdapter, err := reg.Get("polygon")
-	assert.NoError(t, err)
-	assert.Equal(t, polygon, polyAdapter)
-
-	tronAdapter, err := reg.Get("tron")
-	assert.NoError(t, err)
-	assert.Equal(t, tron, tronAdapter)
+	err := registerAdapters(reg, parseConfig(t, "app:\n  port: 8080\n"))
+	require.NoError(t, err)
+	assert.Empty(t, reg.List())
 }
 
-func TestRegisterAdapters_ErrorPropagation(t *testing.T) {
+func TestLoadConfig(t *testing.T) {
 	t.Parallel()
-	reg := mocks.NewMockChainRegistry()
 
-	// Register ethereum first so it exists
-	firstAdapter := &mocks.MockChainAdapter{}
-	err := reg.Register("ethereum", firstAdapter)
-	assert.NoError(t, err)
-
-	// Try to register different adapters but ethereum will be skipped since it exists
-	params := AdapterParams{
-		Registry: reg,
-		Ethereum: &mocks.MockChainAdapter{},
-		Polygon:  &mocks.MockChainAdapter{},
-		Tron:     &mocks.MockChainAdapter{},
-	}
-
-	// Since our mock doesn't error on re-registration, this will succeed
-	// In a real scenario with a strict registry, this might fail
-	err = registerAdapters(params)
-	// The mock registry allows re-registration, so this passes
-	assert.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(harnessConfig), 0o600))
+
+	log := mocks.NewMockLogger()
+	provider, err := loadConfig(path, true, log)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"evm", "bitcoin"}, provider.GetStringSlice("chains.enabled"))
+	assert.Empty(t, log.WarnCalls)
 }
 
-func TestAdapterParams_Integration(t *testing.T) {
+func TestLoadConfig_MissingFile(t *testing.T) {
 	t.Parallel()
-	reg := mocks.NewMockChainRegistry()
 
-	// Create mock adapters with some state
-	ethAdapter := &mocks.MockChainAdapter{}
-	polyAdapter := &mocks.MockChainAdapter{}
-	tronAdapter := &mocks.MockChainAdapter{}
+	missing := filepath.Join(t.TempDir(), "config.yaml")
 
-	params := AdapterParams{
-		Registry: reg,
-		Ethereum: ethAdapter,
-		Polygon:  polyAdapter,
-		Tron:     tronAdapter,
-	}
+	// A missing default file falls back to the defaults
+	log := mocks.NewMockLogger()
+	provider, err := loadConfig(missing, false, log)
+	require.NoError(t, err)
+	assert.Equal(t, 8080, provider.Config().App.Port)
+	assert.Empty(t, provider.Config().Networks())
+	require.Len(t, log.WarnCalls, 1)
 
-	// Register all
-	err := registerAdapters(params)
-	assert.NoError(t, err)
+	// A file named by CONFIG_PATH must exist
+	_, err = loadConfig(missing, true, mocks.NewMockLogger())
+	require.Error(t, err)
+}
 
-	// Verify functionality of each adapter through registry
-	eth, err := reg.Get("ethereum")
-	assert.NoError(t, err)
-	assert.True(t, eth.IsConnected(context.Background()))
+func TestLoadConfig_Invalid(t *testing.T) {
+	t.Parallel()
 
-	poly, err := reg.Get("polygon")
-	assert.NoError(t, err)
-	assert.True(t, poly.IsConnected(context.Background()))
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("chains:\n  enabled: [cosmos]\n"), 0o600))
 
-	tron, err := reg.Get("tron")
-	assert.NoError(t, err)
-	assert.True(t, tron.IsConnected(context.Background()))
+	_, err := loadConfig(path, false, mocks.NewMockLogger())
+	require.ErrorContains(t, err, `unknown chain "cosmos"`)
 }
@@ -3,9 +3,12 @@ package modules
 import (
 	"context"
 	"os"
+	"strconv"
 
 	"github.com/gabrielksneiva/ChainSystemPro/internal/api"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/config"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/health"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/logger"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/usecases"
 	"go.uber.org/fx"
@@ -22,9 +25,16 @@ var APIModule = fx.Module("api",
 			broadcastTransactionUC *usecases.BroadcastTransactionUseCase,
 			estimateFeeUC *usecases.EstimateFeeUseCase,
 			getTransactionStatusUC *usecases.GetTransactionStatusUseCase,
+			exportPSBTUC *usecases.ExportPSBTUseCase,
+			importPSBTUC *usecases.ImportPSBTUseCase,
+			bumpFeeUC *usecases.BumpFeeUseCase,
+			getChainInfoUC *usecases.GetChainInfoUseCase,
+			manageChainsUC *usecases.ManageChainsUseCase,
+			checker *health.Checker,
+			cfg *config.Config,
 			log *logger.ZapLogger,
 		) *api.Server {
-			return api.NewServer(
+			server := api.NewServer(
 				registry,
 				getBalanceUC,
 				createTransactionUC,
@@ -32,16 +42,23 @@ var APIModule = fx.Module("api",
 				broadcastTransactionUC,
 				estimateFeeUC,
 				getTransactionStatusUC,
+				exportPSBTUC,
+				importPSBTUC,
+				bumpFeeUC,
+				getChainInfoUC,
 				log,
 			)
+			server.EnableAdmin(cfg.Admin.Token, manageChainsUC)
+			server.EnableHealth(checker)
+			return server
 		},
 	),
-	fx.Invoke(func(server *api.Server, lifecycle fx.Lifecycle, log *logger.ZapLogger) {
+	fx.Invoke(func(server *api.Server, lifecycle fx.Lifecycle, cfg *config.Config, log *logger.ZapLogger) {
 		lifecycle.Append(fx.Hook{
 			OnStart: func(ctx context.Context) error {
 				port := os.Getenv("PORT")
 				if port == "" {
-					port = "8080"
+					port = strconv.Itoa(cfg.App.Port)
 				}
 
 				go func() {
@@ -0,0 +1,52 @@
+package modules
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/config"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/logger"
+	"go.uber.org/fx"
+)
+
+// defaultConfigPath is read when CONFIG_PATH is not set
+const defaultConfigPath = "config.yaml"
+
+// ConfigModule provides the configuration loaded from CONFIG_PATH (default config.yaml)
+var ConfigModule = fx.Module("config",
+	fx.Provide(
+		func(log *logger.ZapLogger) (*config.Provider, error) {
+			path, explicit := configPath()
+			return loadConfig(path, explicit, log)
+		},
+		func(provider *config.Provider) *config.Config {
+			return provider.Config()
+		},
+		func(provider *config.Provider) ports.ConfigProvider {
+			return provider
+		},
+	),
+)
+
+// configPath returns the configuration file and whether CONFIG_PATH named it
+func configPath() (string, bool) {
+	if path, ok := os.LookupEnv("CONFIG_PATH"); ok {
+		return path, true
+	}
+	return defaultConfigPath, false
+}
+
+// loadConfig loads the configuration file; a missing default file falls back to the defaults,
+// which serve no networks
+func loadConfig(path string, explicit bool, log ports.Logger) (*config.Provider, error) {
+	provider, err := config.Load(path)
+	if err == nil || explicit || !errors.Is(err, fs.ErrNotExist) {
+		return provider, err
+	}
+	log.Warn("config file not found, using defaults", map[string]interface{}{
+		"path": path,
+	})
+	return config.Parse(nil, os.LookupEnv)
+}
@@ -0,0 +1,139 @@
+package modules
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/config"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/keystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+)
+
+// writeConfig writes a config file and points CONFIG_PATH at it
+func writeConfig(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	t.Setenv("CONFIG_PATH", path)
+	return path
+}
+
+// unsetConfigPath removes CONFIG_PATH for the test, restoring it afterwards
+func unsetConfigPath(t *testing.T) {
+	t.Helper()
+	t.Setenv("CONFIG_PATH", "")
+	require.NoError(t, os.Unsetenv("CONFIG_PATH"))
+}
+
+func TestConfigPath(t *testing.T) {
+	unsetConfigPath(t)
+	path, explicit := configPath()
+	assert.Equal(t, defaultConfigPath, path)
+	assert.False(t, explicit)
+
+	t.Setenv("CONFIG_PATH", "/etc/chainsystem/config.yaml")
+	path, explicit = configPath()
+	assert.Equal(t, "/etc/chainsystem/config.yaml", path)
+	assert.True(t, explicit)
+}
+
+func TestConfigModule(t *testing.T) {
+	writeConfig(t, harnessConfig)
+	t.Setenv("CHAINSYSTEM_CHAINS_ENABLED", "evm")
+
+	var (
+		cfg      *config.Config
+		provider ports.ConfigProvider
+	)
+	app := fx.New(LoggerModule, ConfigModule, fx.Populate(&cfg, &provider), fx.NopLogger)
+	require.NoError(t, app.Err())
+
+	assert.Equal(t, []string{"evm"}, provider.GetStringSlice("chains.enabled"), "environment variables override the file")
+	assert.Len(t, cfg.Networks(), 2)
+}
+
+func TestConfigModule_MissingFile(t *testing.T) {
+	// Without CONFIG_PATH the missing default file falls back to the defaults
+	unsetConfigPath(t)
+	var cfg *config.Config
+	app := fx.New(LoggerModule, ConfigModule, fx.Populate(&cfg), fx.NopLogger)
+	require.NoError(t, app.Err())
+	assert.Equal(t, 8080, cfg.App.Port)
+	assert.Empty(t, cfg.Networks())
+
+	// A file named by CONFIG_PATH must exist
+	t.Setenv("CONFIG_PATH", filepath.Join(t.TempDir(), "missing.yaml"))
+	app = fx.New(LoggerModule, ConfigModule, fx.Populate(&cfg), fx.NopLogger)
+	require.Error(t, app.Err())
+}
+
+func TestModules_FromConfigFile(t *testing.T) {
+	keys := t.TempDir()
+	require.NoError(t, keystore.WriteKey(keys, keystore.Key{
+		ID:         "treasury",
+		Curve:      ports.CurveSecp256k1,
+		PrivateKey: bytes.Repeat([]byte{0x46}, 32),
+	}, "secret", keystore.LightScrypt, keystore.CipherAESCTR))
+
+	writeConfig(t, harnessConfig+`
+keystore:
+  dir: `+keys+`
+  password: ${KEYSTORE_PASSWORD}
+`)
+	t.Setenv("KEYSTORE_PASSWORD", "secret")
+	// Only the EVM networks are registered once chains.enabled is overridden
+	t.Setenv("CHAINSYSTEM_CHAINS_ENABLED", "evm")
+
+	var (
+		registry ports.ChainRegistry
+		manager  ports.KeyManager
+		repo     ports.TransactionRepository
+	)
+	app := fx.New(
+		LoggerModule,
+		ConfigModule,
+		DatabaseModule,
+		KeyStoreModule,
+		EventBusModule,
+		RegistryModule,
+		AdaptersModule,
+		UseCasesModule,
+		HealthModule,
+		fx.Populate(&registry, &manager, &repo),
+		fx.NopLogger,
+	)
+	require.NoError(t, app.Err())
+
+	ctx := context.Background()
+	require.NoError(t, app.Start(ctx))
+	defer func() {
+		require.NoError(t, app.Stop(ctx))
+	}()
+
+	assert.ElementsMatch(t, []string{"ethereum", "polygon"}, registry.List())
+	infos, err := manager.ListKeys(ctx)
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	assert.Equal(t, "treasury", infos[0].ID)
+	assert.NotNil(t, repo)
+}
+
+func TestKeyStoreModule_WrongPassword(t *testing.T) {
+	keys := t.TempDir()
+	require.NoError(t, keystore.WriteKey(keys, keystore.Key{
+		ID:         "treasury",
+		Curve:      ports.CurveSecp256k1,
+		PrivateKey: bytes.Repeat([]byte{0x46}, 32),
+	}, "secret", keystore.LightScrypt, keystore.CipherAESCTR))
+	writeConfig(t, "keystore:\n  dir: "+keys+"\n  password: wrong\n")
+
+	var manager ports.KeyManager
+	app := fx.New(LoggerModule, ConfigModule, KeyStoreModule, fx.Populate(&manager), fx.NopLogger)
+	require.ErrorIs(t, app.Err(), keystore.ErrWrongPassword)
+}
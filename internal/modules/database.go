@@ -0,0 +1,47 @@
+package modules
+
+import (
+	"context"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/config"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/database"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/logger"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/txstore"
+	"go.uber.org/fx"
+)
+
+// DatabaseModule provides the PostgreSQL database, or nil when database.host is not set, and the
+// transaction repository, which keeps transactions in memory without a database
+var DatabaseModule = fx.Module("database",
+	fx.Provide(
+		func(cfg *config.Config, lifecycle fx.Lifecycle) (*database.DB, error) {
+			if cfg.Database.Host == "" {
+				return nil, nil
+			}
+			db, err := database.New(cfg.Database)
+			if err != nil {
+				return nil, err
+			}
+			if cfg.Database.Migrations != "" {
+				if err := db.RunMigrations(cfg.Database.Migrations); err != nil {
+					_ = db.Close()
+					return nil, err
+				}
+			}
+			lifecycle.Append(fx.Hook{
+				OnStop: func(ctx context.Context) error {
+					return db.Close()
+				},
+			})
+			return db, nil
+		},
+		func(db *database.DB, log *logger.ZapLogger) ports.TransactionRepository {
+			if db == nil {
+				log.Warn("database is not configured; transactions are kept in memory", nil)
+				return txstore.NewMemoryRepository()
+			}
+			return txstore.NewPostgresRepository(db.DB)
+		},
+	),
+)
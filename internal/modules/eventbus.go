@@ -1,6 +1,9 @@
 package modules
 
 import (
+	"context"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/config"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/eventbus"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/logger"
@@ -17,6 +20,22 @@ var EventBusModule = fx.Module("eventbus",
 		func(bus ports.EventBus) ports.EventPublisher {
 			return bus
 		},
+		// The Redis Streams backend is nil when redis.addr is not set
+		func(cfg *config.Config, lifecycle fx.Lifecycle) (*eventbus.RedisStreamBackend, error) {
+			if cfg.Redis.Addr == "" {
+				return nil, nil
+			}
+			backend, err := eventbus.NewRedisStreamBackend(cfg.Redis)
+			if err != nil {
+				return nil, err
+			}
+			lifecycle.Append(fx.Hook{
+				OnStop: func(ctx context.Context) error {
+					return backend.Close()
+				},
+			})
+			return backend, nil
+		},
 	),
 	fx.Invoke(func(bus ports.EventBus, lifecycle fx.Lifecycle) {
 		lifecycle.Append(fx.Hook{
@@ -0,0 +1,38 @@
+package modules
+
+import (
+	"github.com/gabrielksneiva/ChainSystemPro/internal/config"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/database"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/eventbus"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/health"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/logger"
+	"go.uber.org/fx"
+)
+
+// HealthModule provides the health checker of the chains, the event bus and, when they are
+// configured, the database and Redis
+var HealthModule = fx.Module("health",
+	fx.Provide(
+		func(
+			registry ports.ChainRegistry,
+			bus ports.EventBus,
+			db *database.DB,
+			redis *eventbus.RedisStreamBackend,
+			cfg *config.Config,
+			log *logger.ZapLogger,
+		) *health.Checker {
+			checker := health.NewChecker(registry, cfg.Health, log)
+			if busChecker, ok := bus.(ports.HealthChecker); ok {
+				checker.Add(health.KindEventBus, busChecker)
+			}
+			if db != nil {
+				checker.Add(health.KindDatabase, db)
+			}
+			if redis != nil {
+				checker.Add(health.KindRedis, redis)
+			}
+			return checker
+		},
+	),
+)
@@ -0,0 +1,40 @@
+package modules
+
+import (
+	"context"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/config"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/keystore"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/logger"
+	"go.uber.org/fx"
+)
+
+// KeyStoreModule provides the key manager holding the keys of the keystore directory
+var KeyStoreModule = fx.Module("keystore",
+	fx.Provide(
+		func(cfg *config.Config, log *logger.ZapLogger) (ports.KeyManager, error) {
+			if cfg.Keystore.Dir == "" {
+				log.Warn("keystore is not configured; transactions cannot be signed", nil)
+				return keystore.NewManager()
+			}
+			manager, err := keystore.Load(cfg.Keystore.Dir, cfg.Keystore.Password)
+			if err != nil {
+				return nil, err
+			}
+			keys, err := manager.ListKeys(context.Background())
+			if err != nil {
+				return nil, err
+			}
+			ids := make([]string, 0, len(keys))
+			for _, key := range keys {
+				ids = append(ids, key.ID)
+			}
+			log.Info("signing keys loaded", map[string]interface{}{
+				"dir":  cfg.Keystore.Dir,
+				"keys": ids,
+			})
+			return manager, nil
+		},
+	),
+)
@@ -1,6 +1,7 @@
 package modules
 
 import (
+	"github.com/gabrielksneiva/ChainSystemPro/internal/config"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/logger"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/registry"
@@ -10,8 +11,8 @@ import (
 // RegistryModule provides chain registry dependency
 var RegistryModule = fx.Module("registry",
 	fx.Provide(
-		func(log *logger.ZapLogger) ports.ChainRegistry {
-			return registry.NewChainRegistry(log)
+		func(log *logger.ZapLogger, cfg *config.Config) (ports.ChainRegistry, error) {
+			return registry.NewChainRegistryWithResilience(log, cfg.Resilience)
 		},
 	),
 )
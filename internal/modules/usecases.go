@@ -13,14 +13,14 @@ var UseCasesModule = fx.Module("usecases",
 		func(registry ports.ChainRegistry, eventBus ports.EventPublisher, log *logger.ZapLogger) *usecases.GetBalanceUseCase {
 			return usecases.NewGetBalanceUseCase(registry, eventBus, log)
 		},
-		func(registry ports.ChainRegistry, eventBus ports.EventPublisher, log *logger.ZapLogger) *usecases.CreateTransactionUseCase {
-			return usecases.NewCreateTransactionUseCase(registry, eventBus, log)
+		func(registry ports.ChainRegistry, transactions ports.TransactionRepository, eventBus ports.EventPublisher, log *logger.ZapLogger) *usecases.CreateTransactionUseCase {
+			return usecases.NewCreateTransactionUseCase(registry, transactions, eventBus, log)
 		},
-		func(registry ports.ChainRegistry, eventBus ports.EventPublisher, log *logger.ZapLogger) *usecases.SignTransactionUseCase {
-			return usecases.NewSignTransactionUseCase(registry, eventBus, log)
+		func(registry ports.ChainRegistry, transactions ports.TransactionRepository, keys ports.KeyManager, eventBus ports.EventPublisher, log *logger.ZapLogger) *usecases.SignTransactionUseCase {
+			return usecases.NewSignTransactionUseCase(registry, transactions, keys, eventBus, log)
 		},
-		func(registry ports.ChainRegistry, eventBus ports.EventPublisher, log *logger.ZapLogger) *usecases.BroadcastTransactionUseCase {
-			return usecases.NewBroadcastTransactionUseCase(registry, eventBus, log)
+		func(registry ports.ChainRegistry, transactions ports.TransactionRepository, eventBus ports.EventPublisher, log *logger.ZapLogger) *usecases.BroadcastTransactionUseCase {
+			return usecases.NewBroadcastTransactionUseCase(registry, transactions, eventBus, log)
 		},
 		func(registry ports.ChainRegistry, eventBus ports.EventPublisher, log *logger.ZapLogger) *usecases.EstimateFeeUseCase {
 			return usecases.NewEstimateFeeUseCase(registry, eventBus, log)
@@ -28,5 +28,20 @@ var UseCasesModule = fx.Module("usecases",
 		func(registry ports.ChainRegistry, log *logger.ZapLogger) *usecases.GetTransactionStatusUseCase {
 			return usecases.NewGetTransactionStatusUseCase(registry, log)
 		},
+		func(registry ports.ChainRegistry, transactions ports.TransactionRepository, eventBus ports.EventPublisher, log *logger.ZapLogger) *usecases.ExportPSBTUseCase {
+			return usecases.NewExportPSBTUseCase(registry, transactions, eventBus, log)
+		},
+		func(registry ports.ChainRegistry, eventBus ports.EventPublisher, log *logger.ZapLogger) *usecases.ImportPSBTUseCase {
+			return usecases.NewImportPSBTUseCase(registry, eventBus, log)
+		},
+		func(registry ports.ChainRegistry, transactions ports.TransactionRepository, keys ports.KeyManager, eventBus ports.EventPublisher, log *logger.ZapLogger) *usecases.BumpFeeUseCase {
+			return usecases.NewBumpFeeUseCase(registry, transactions, keys, eventBus, log)
+		},
+		func(registry ports.ChainRegistry, log *logger.ZapLogger) *usecases.GetChainInfoUseCase {
+			return usecases.NewGetChainInfoUseCase(registry, log)
+		},
+		func(registry ports.ChainRegistry, factory ports.ChainFactory, eventBus ports.EventPublisher, log *logger.ZapLogger) *usecases.ManageChainsUseCase {
+			return usecases.NewManageChainsUseCase(registry, factory, eventBus, log)
+		},
 	),
 )
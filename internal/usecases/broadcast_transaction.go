@@ -2,17 +2,33 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/events"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
 )
 
+// MetadataBroadcastAt records when a transaction was broadcast, in RFC 3339
+const MetadataBroadcastAt = "broadcast_at"
+
+// Errors of BroadcastTransactionUseCase caused by the transaction rather than the chain
+var (
+	ErrTransactionNotSigned        = errors.New("transaction is not signed")
+	ErrTransactionAlreadyBroadcast = errors.New("transaction was already broadcast")
+	ErrRawTransactionsUnsupported  = errors.New("raw transactions are not supported on this chain")
+)
+
 // BroadcastTransactionInput represents the input for BroadcastTransaction use case
 type BroadcastTransactionInput struct {
-	ChainID     string
-	Transaction *entities.Transaction
+	ChainID string
+	// TransactionID is the ID of a transaction stored by CreateTransactionUseCase
+	TransactionID string
+	// SignedData is a transaction signed elsewhere, in the wire format of the chain; it is set
+	// instead of TransactionID
+	SignedData []byte
 }
 
 // BroadcastTransactionOutput represents the output for BroadcastTransaction use case
@@ -24,35 +40,40 @@ type BroadcastTransactionOutput struct {
 
 // BroadcastTransactionUseCase handles transaction broadcasting
 type BroadcastTransactionUseCase struct {
-	registry ports.ChainRegistry
-	eventBus ports.EventPublisher
-	logger   ports.Logger
+	registry     ports.ChainRegistry
+	transactions ports.TransactionRepository
+	eventBus     ports.EventPublisher
+	logger       ports.Logger
 }
 
 // NewBroadcastTransactionUseCase creates a new BroadcastTransactionUseCase
 func NewBroadcastTransactionUseCase(
 	registry ports.ChainRegistry,
+	transactions ports.TransactionRepository,
 	eventBus ports.EventPublisher,
 	logger ports.Logger,
 ) *BroadcastTransactionUseCase {
 	return &BroadcastTransactionUseCase{
-		registry: registry,
-		eventBus: eventBus,
-		logger:   logger,
+		registry:     registry,
+		transactions: transactions,
+		eventBus:     eventBus,
+		logger:       logger,
 	}
 }
 
 // Execute executes the broadcast transaction use case
 func (uc *BroadcastTransactionUseCase) Execute(ctx context.Context, input BroadcastTransactionInput) (*BroadcastTransactionOutput, error) {
 	uc.logger.Info("executing BroadcastTransaction use case", map[string]interface{}{
-		"chain_id": input.ChainID,
+		"chain_id":       input.ChainID,
+		"transaction_id": input.TransactionID,
+		"raw":            len(input.SignedData) > 0,
 	})
 
 	if input.ChainID == "" {
 		return nil, fmt.Errorf("chain ID cannot be empty")
 	}
-	if input.Transaction == nil {
-		return nil, fmt.Errorf("transaction cannot be nil")
+	if (input.TransactionID == "") == (len(input.SignedData) == 0) {
+		return nil, fmt.Errorf("either transaction ID or signed data must be set")
 	}
 
 	adapter, err := uc.registry.Get(input.ChainID)
@@ -63,29 +84,26 @@ func (uc *BroadcastTransactionUseCase) Execute(ctx context.Context, input Broadc
 		return nil, fmt.Errorf("failed to get chain adapter: %w", err)
 	}
 
-	hash, err := adapter.BroadcastTransaction(ctx, input.Transaction)
+	var tx *entities.Transaction
+	if len(input.SignedData) > 0 {
+		tx, err = uc.relay(ctx, adapter, input)
+	} else {
+		tx, err = uc.broadcastStored(ctx, adapter, input)
+	}
 	if err != nil {
-		uc.logger.Error("failed to broadcast transaction", err, map[string]interface{}{
-			"chain_id":       input.ChainID,
-			"transaction_id": input.Transaction.ID(),
-		})
-
-		event := events.NewTransactionFailedEvent(
-			input.ChainID,
-			input.Transaction.ID(),
-			"",
-			err.Error(),
-			"BROADCAST_ERROR",
-		)
-		if pubErr := uc.eventBus.Publish(ctx, event); pubErr != nil {
-			// Log the publish error but don't override the original broadcast error
-			fmt.Printf("failed to publish broadcast error event: %v\n", pubErr)
-		}
+		return nil, err
+	}
 
-		return nil, fmt.Errorf("failed to broadcast transaction: %w", err)
+	// The transaction is on the network already, so failing to record it is not an error
+	tx.SetMetadata(MetadataBroadcastAt, time.Now().UTC().Format(time.RFC3339))
+	if err := uc.transactions.Save(ctx, tx); err != nil {
+		uc.logger.Warn("failed to save broadcast transaction", map[string]interface{}{
+			"transaction_id": tx.ID(),
+			"error":          err.Error(),
+		})
 	}
 
-	event := events.NewTransactionBroadcastedEvent(input.ChainID, input.Transaction.ID(), hash)
+	event := events.NewTransactionBroadcastedEvent(input.ChainID, tx.ID(), tx.Hash())
 	if err := uc.eventBus.Publish(ctx, event); err != nil {
 		uc.logger.Warn("failed to publish transaction broadcasted event", map[string]interface{}{
 			"error": err.Error(),
@@ -94,13 +112,79 @@ func (uc *BroadcastTransactionUseCase) Execute(ctx context.Context, input Broadc
 
 	uc.logger.Info("transaction broadcasted successfully", map[string]interface{}{
 		"chain_id":       input.ChainID,
-		"transaction_id": input.Transaction.ID(),
-		"hash":           hash.Hex(),
+		"transaction_id": tx.ID(),
+		"hash":           tx.Hash().Hex(),
 	})
 
 	return &BroadcastTransactionOutput{
-		TransactionID: input.Transaction.ID(),
-		Hash:          hash.Hex(),
-		Status:        string(entities.TxStatusPending),
+		TransactionID: tx.ID(),
+		Hash:          tx.Hash().Hex(),
+		Status:        string(tx.Status()),
 	}, nil
 }
+
+// broadcastStored broadcasts a stored transaction signed by SignTransactionUseCase
+func (uc *BroadcastTransactionUseCase) broadcastStored(
+	ctx context.Context,
+	adapter ports.ChainAdapter,
+	input BroadcastTransactionInput,
+) (*entities.Transaction, error) {
+	tx, err := loadTransaction(ctx, uc.transactions, input.ChainID, input.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := tx.Metadata()[MetadataBroadcastAt]; ok {
+		return nil, fmt.Errorf("%w: %s", ErrTransactionAlreadyBroadcast, tx.ID())
+	}
+	if tx.Signature() == nil {
+		return nil, fmt.Errorf("%w: %s", ErrTransactionNotSigned, tx.ID())
+	}
+
+	hash, err := adapter.BroadcastTransaction(ctx, tx)
+	if err != nil {
+		return nil, uc.failed(ctx, input.ChainID, tx.ID(), err)
+	}
+	if err := tx.SetHash(hash); err != nil {
+		return nil, fmt.Errorf("failed to set transaction hash: %w", err)
+	}
+	return tx, nil
+}
+
+// relay broadcasts a transaction signed elsewhere through the adapter's RawBroadcaster
+func (uc *BroadcastTransactionUseCase) relay(
+	ctx context.Context,
+	adapter ports.ChainAdapter,
+	input BroadcastTransactionInput,
+) (*entities.Transaction, error) {
+	broadcaster, ok := adapterCapability[ports.RawBroadcaster](adapter)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrRawTransactionsUnsupported, input.ChainID)
+	}
+
+	tx, err := broadcaster.BroadcastRaw(ctx, input.SignedData)
+	if err != nil {
+		return nil, uc.failed(ctx, input.ChainID, "", err)
+	}
+	if tx.Hash() == nil {
+		return nil, fmt.Errorf("broadcast transaction has no hash")
+	}
+	return tx, nil
+}
+
+// failed reports a transaction the chain did not accept
+func (uc *BroadcastTransactionUseCase) failed(ctx context.Context, chainID, transactionID string, err error) error {
+	uc.logger.Error("failed to broadcast transaction", err, map[string]interface{}{
+		"chain_id":       chainID,
+		"transaction_id": transactionID,
+	})
+
+	event := events.NewTransactionFailedEvent(chainID, transactionID, "", err.Error(), "BROADCAST_ERROR")
+	if pubErr := uc.eventBus.Publish(ctx, event); pubErr != nil {
+		// Log the publish error but don't override the original broadcast error
+		uc.logger.Warn("failed to publish broadcast error event", map[string]interface{}{
+			"error": pubErr.Error(),
+		})
+	}
+
+	return fmt.Errorf("failed to broadcast transaction: %w", err)
+}
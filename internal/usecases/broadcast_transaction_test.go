@@ -2,9 +2,11 @@ package usecases
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/mocks"
 	"github.com/stretchr/testify/require"
@@ -17,21 +19,81 @@ func TestBroadcastTransaction(t *testing.T) {
 	from, _ := valueobjects.NewAddress("0xabc", "evm-mainnet")
 	to, _ := valueobjects.NewAddress("0xdef", "evm-mainnet")
 	tx, _ := entities.NewTransaction(entities.TransactionParams{ChainID: "evm-mainnet", From: from, To: to})
+	sig, _ := valueobjects.NewSignatureFromBytes(make([]byte, 65))
+	_ = tx.SetSignature(sig)
 
 	t.Run("success", func(t *testing.T) {
 		t.Parallel()
 		adapter := &mocks.MockChainAdapter{}
 		registry := mocks.NewMockChainRegistry()
+		transactions := mocks.NewMockTransactionRepository()
 		publisher := mocks.NewMockEventPublisher()
 		logger := mocks.NewMockLogger()
 		_ = registry.Register("evm-mainnet", adapter)
+		stored, _ := entities.NewTransaction(entities.TransactionParams{ChainID: "evm-mainnet", From: from, To: to})
+		_ = stored.SetSignature(sig)
+		_ = transactions.Save(ctx, stored)
 		adapter.BroadcastTransactionFunc = func(ctx context.Context, tx *entities.Transaction) (*valueobjects.Hash, error) {
 			return valueobjects.NewHash("aaaaaaaa")
 		}
-		uc := NewBroadcastTransactionUseCase(registry, publisher, logger)
-		out, err := uc.Execute(ctx, BroadcastTransactionInput{ChainID: "evm-mainnet", Transaction: tx})
+		uc := NewBroadcastTransactionUseCase(registry, transactions, publisher, logger)
+		out, err := uc.Execute(ctx, BroadcastTransactionInput{ChainID: "evm-mainnet", TransactionID: stored.ID()})
 		require.NoError(t, err)
 		require.Equal(t, "0xaaaaaaaa", out.Hash)
+		require.Equal(t, "0xaaaaaaaa", transactions.Transactions[stored.ID()].Hash().Hex())
+		require.Contains(t, transactions.Transactions[stored.ID()].Metadata(), MetadataBroadcastAt)
+		require.Len(t, publisher.PublishedEvents, 1)
+
+		// A transaction goes to the network only once
+		_, err = uc.Execute(ctx, BroadcastTransactionInput{ChainID: "evm-mainnet", TransactionID: stored.ID()})
+		require.ErrorIs(t, err, ErrTransactionAlreadyBroadcast)
+	})
+
+	t.Run("unsigned transaction", func(t *testing.T) {
+		t.Parallel()
+		adapter := &mocks.MockChainAdapter{}
+		registry := mocks.NewMockChainRegistry()
+		transactions := mocks.NewMockTransactionRepository()
+		_ = registry.Register("evm-mainnet", adapter)
+		unsigned, _ := entities.NewTransaction(entities.TransactionParams{ChainID: "evm-mainnet", From: from, To: to})
+		_ = transactions.Save(ctx, unsigned)
+		uc := NewBroadcastTransactionUseCase(registry, transactions, mocks.NewMockEventPublisher(), mocks.NewMockLogger())
+		_, err := uc.Execute(ctx, BroadcastTransactionInput{ChainID: "evm-mainnet", TransactionID: unsigned.ID()})
+		require.ErrorIs(t, err, ErrTransactionNotSigned)
+	})
+
+	t.Run("signed data", func(t *testing.T) {
+		t.Parallel()
+		adapter := &rawBroadcastAdapter{MockChainAdapter: &mocks.MockChainAdapter{}}
+		registry := mocks.NewMockChainRegistry()
+		transactions := mocks.NewMockTransactionRepository()
+		_ = registry.Register("evm-mainnet", adapter)
+		adapter.BroadcastRawFunc = func(ctx context.Context, raw []byte) (*entities.Transaction, error) {
+			relayed, _ := entities.NewTransaction(entities.TransactionParams{ChainID: "evm-mainnet", From: from, To: to})
+			hash, _ := valueobjects.NewHash("bbbbbbbb")
+			_ = relayed.SetHash(hash)
+			return relayed, nil
+		}
+		uc := NewBroadcastTransactionUseCase(registry, transactions, mocks.NewMockEventPublisher(), mocks.NewMockLogger())
+		out, err := uc.Execute(ctx, BroadcastTransactionInput{ChainID: "evm-mainnet", SignedData: []byte{0x01}})
+		require.NoError(t, err)
+		require.Equal(t, "0xbbbbbbbb", out.Hash)
+		require.Contains(t, transactions.Transactions, out.TransactionID)
+
+		adapter.BroadcastRawFunc = func(ctx context.Context, raw []byte) (*entities.Transaction, error) {
+			return nil, fmt.Errorf("%w: bad signature", ports.ErrInvalidRawTransaction)
+		}
+		_, err = uc.Execute(ctx, BroadcastTransactionInput{ChainID: "evm-mainnet", SignedData: []byte{0x01}})
+		require.ErrorIs(t, err, ports.ErrInvalidRawTransaction)
+	})
+
+	t.Run("signed data unsupported", func(t *testing.T) {
+		t.Parallel()
+		registry := mocks.NewMockChainRegistry()
+		_ = registry.Register("evm-mainnet", &mocks.MockChainAdapter{})
+		uc := NewBroadcastTransactionUseCase(registry, mocks.NewMockTransactionRepository(), mocks.NewMockEventPublisher(), mocks.NewMockLogger())
+		_, err := uc.Execute(ctx, BroadcastTransactionInput{ChainID: "evm-mainnet", SignedData: []byte{0x01}})
+		require.ErrorIs(t, err, ErrRawTransactionsUnsupported)
 	})
 
 	t.Run("registry error", func(t *testing.T) {
@@ -39,8 +101,8 @@ func TestBroadcastTransaction(t *testing.T) {
 		registry := mocks.NewMockChainRegistry()
 		publisher := mocks.NewMockEventPublisher()
 		logger := mocks.NewMockLogger()
-		uc := NewBroadcastTransactionUseCase(registry, publisher, logger)
-		_, err := uc.Execute(ctx, BroadcastTransactionInput{ChainID: "evm-mainnet", Transaction: tx})
+		uc := NewBroadcastTransactionUseCase(registry, mocks.NewMockTransactionRepository(), publisher, logger)
+		_, err := uc.Execute(ctx, BroadcastTransactionInput{ChainID: "evm-mainnet", TransactionID: tx.ID()})
 		require.Error(t, err)
 	})
 
@@ -48,26 +110,55 @@ func TestBroadcastTransaction(t *testing.T) {
 		t.Parallel()
 		adapter := &mocks.MockChainAdapter{}
 		registry := mocks.NewMockChainRegistry()
+		transactions := mocks.NewMockTransactionRepository()
 		publisher := mocks.NewMockEventPublisher()
 		logger := mocks.NewMockLogger()
 		_ = registry.Register("evm-mainnet", adapter)
+		_ = transactions.Save(ctx, tx)
 		adapter.BroadcastTransactionFunc = func(ctx context.Context, tx *entities.Transaction) (*valueobjects.Hash, error) {
 			return nil, simpleError{"broadcast failed"}
 		}
-		uc := NewBroadcastTransactionUseCase(registry, publisher, logger)
-		_, err := uc.Execute(ctx, BroadcastTransactionInput{ChainID: "evm-mainnet", Transaction: tx})
+		uc := NewBroadcastTransactionUseCase(registry, transactions, publisher, logger)
+		_, err := uc.Execute(ctx, BroadcastTransactionInput{ChainID: "evm-mainnet", TransactionID: tx.ID()})
 		require.Error(t, err)
 	})
 
-	t.Run("nil transaction", func(t *testing.T) {
+	t.Run("unknown transaction", func(t *testing.T) {
+		t.Parallel()
+		adapter := &mocks.MockChainAdapter{}
+		registry := mocks.NewMockChainRegistry()
+		transactions := mocks.NewMockTransactionRepository()
+		_ = registry.Register("evm-mainnet", adapter)
+		_ = registry.Register("evm-sepolia", adapter)
+		_ = transactions.Save(ctx, tx)
+		uc := NewBroadcastTransactionUseCase(registry, transactions, mocks.NewMockEventPublisher(), mocks.NewMockLogger())
+		_, err := uc.Execute(ctx, BroadcastTransactionInput{ChainID: "evm-mainnet", TransactionID: "missing"})
+		require.ErrorIs(t, err, ports.ErrTransactionNotFound)
+		// Transactions are only found on their own chain
+		_, err = uc.Execute(ctx, BroadcastTransactionInput{ChainID: "evm-sepolia", TransactionID: tx.ID()})
+		require.ErrorIs(t, err, ports.ErrTransactionNotFound)
+	})
+
+	t.Run("empty transaction ID", func(t *testing.T) {
 		t.Parallel()
 		adapter := &mocks.MockChainAdapter{}
 		registry := mocks.NewMockChainRegistry()
 		publisher := mocks.NewMockEventPublisher()
 		logger := mocks.NewMockLogger()
 		_ = registry.Register("evm-mainnet", adapter)
-		uc := NewBroadcastTransactionUseCase(registry, publisher, logger)
-		_, err := uc.Execute(ctx, BroadcastTransactionInput{ChainID: "evm-mainnet", Transaction: nil})
+		uc := NewBroadcastTransactionUseCase(registry, mocks.NewMockTransactionRepository(), publisher, logger)
+		_, err := uc.Execute(ctx, BroadcastTransactionInput{ChainID: "evm-mainnet"})
+		require.Error(t, err)
+		_, err = uc.Execute(ctx, BroadcastTransactionInput{ChainID: "evm-mainnet", TransactionID: tx.ID(), SignedData: []byte{0x01}})
 		require.Error(t, err)
 	})
 }
+
+type rawBroadcastAdapter struct {
+	*mocks.MockChainAdapter
+	BroadcastRawFunc func(ctx context.Context, raw []byte) (*entities.Transaction, error)
+}
+
+func (a *rawBroadcastAdapter) BroadcastRaw(ctx context.Context, raw []byte) (*entities.Transaction, error) {
+	return a.BroadcastRawFunc(ctx, raw)
+}
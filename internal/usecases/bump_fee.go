@@ -0,0 +1,186 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/events"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+)
+
+// Fee bump methods accepted by BumpFeeUseCase
+const (
+	// BumpMethodRBF replaces the transaction with one paying a higher fee (BIP-125)
+	BumpMethodRBF = "rbf"
+	// BumpMethodCPFP spends an output of the transaction with a high-fee child
+	BumpMethodCPFP = "cpfp"
+)
+
+// BumpFeeInput represents the input for BumpFee use case
+type BumpFeeInput struct {
+	ChainID         string
+	TransactionHash string
+	// Method is BumpMethodRBF or BumpMethodCPFP, defaulting to BumpMethodRBF
+	Method string
+	// FeeRate is the target fee rate in the smallest unit of the chain per virtual byte
+	FeeRate string
+	// KeyID references the key in the key manager that signs the replacement or child
+	KeyID string
+}
+
+// BumpFeeOutput represents the output for BumpFee use case
+type BumpFeeOutput struct {
+	ChainID string
+	Method  string
+	// OriginalHash is the hash of the transaction that was accelerated
+	OriginalHash  string
+	TransactionID string
+	// Hash is the hash of the replacement or child transaction
+	Hash   string
+	Fee    string
+	Status string
+}
+
+// BumpFeeUseCase accelerates an unconfirmed transaction, broadcasts the result and stores it
+type BumpFeeUseCase struct {
+	registry     ports.ChainRegistry
+	transactions ports.TransactionRepository
+	keys         ports.KeyManager
+	eventBus     ports.EventPublisher
+	logger       ports.Logger
+}
+
+// NewBumpFeeUseCase creates a new BumpFeeUseCase
+func NewBumpFeeUseCase(
+	registry ports.ChainRegistry,
+	transactions ports.TransactionRepository,
+	keys ports.KeyManager,
+	eventBus ports.EventPublisher,
+	logger ports.Logger,
+) *BumpFeeUseCase {
+	return &BumpFeeUseCase{
+		registry:     registry,
+		transactions: transactions,
+		keys:         keys,
+		eventBus:     eventBus,
+		logger:       logger,
+	}
+}
+
+// Execute executes the bump fee use case
+func (uc *BumpFeeUseCase) Execute(ctx context.Context, input BumpFeeInput) (*BumpFeeOutput, error) {
+	uc.logger.Info("executing BumpFee use case", map[string]interface{}{
+		"chain_id": input.ChainID,
+		"hash":     input.TransactionHash,
+		"method":   input.Method,
+		"fee_rate": input.FeeRate,
+		"key_id":   input.KeyID,
+	})
+
+	if input.ChainID == "" {
+		return nil, fmt.Errorf("chain ID cannot be empty")
+	}
+	if input.TransactionHash == "" {
+		return nil, fmt.Errorf("transaction hash cannot be empty")
+	}
+	if input.Method == "" {
+		input.Method = BumpMethodRBF
+	}
+	if input.Method != BumpMethodRBF && input.Method != BumpMethodCPFP {
+		return nil, fmt.Errorf("unknown fee bump method %q", input.Method)
+	}
+	if input.KeyID == "" {
+		return nil, fmt.Errorf("key ID cannot be empty")
+	}
+	feeRate, ok := parseBigInt(input.FeeRate)
+	if !ok || feeRate.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid fee rate: %s", input.FeeRate)
+	}
+	hash, err := valueobjects.NewHash(input.TransactionHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction hash: %w", err)
+	}
+
+	adapter, err := uc.registry.Get(input.ChainID)
+	if err != nil {
+		uc.logger.Error("failed to get chain adapter", err, map[string]interface{}{
+			"chain_id": input.ChainID,
+		})
+		return nil, fmt.Errorf("failed to get chain adapter: %w", err)
+	}
+	bumper, ok := adapterCapability[ports.FeeBumper](adapter)
+	if !ok {
+		return nil, fmt.Errorf("fee bumping is not supported on chain %s", input.ChainID)
+	}
+
+	var tx *entities.Transaction
+	if input.Method == BumpMethodCPFP {
+		tx, err = bumper.CPFP(ctx, hash, feeRate, uc.keys, input.KeyID)
+	} else {
+		tx, err = bumper.BumpFee(ctx, hash, feeRate, uc.keys, input.KeyID)
+	}
+	if err != nil {
+		uc.logger.Error("failed to bump fee", err, map[string]interface{}{
+			"chain_id": input.ChainID,
+			"hash":     input.TransactionHash,
+			"method":   input.Method,
+		})
+		return nil, fmt.Errorf("failed to bump fee: %w", err)
+	}
+
+	broadcastHash, err := adapter.BroadcastTransaction(ctx, tx)
+	if err != nil {
+		uc.logger.Error("failed to broadcast fee bump", err, map[string]interface{}{
+			"chain_id":       input.ChainID,
+			"transaction_id": tx.ID(),
+		})
+
+		event := events.NewTransactionFailedEvent(input.ChainID, tx.ID(), "", err.Error(), "BROADCAST_ERROR")
+		if pubErr := uc.eventBus.Publish(ctx, event); pubErr != nil {
+			uc.logger.Warn("failed to publish broadcast error event", map[string]interface{}{
+				"error": pubErr.Error(),
+			})
+		}
+		return nil, fmt.Errorf("failed to broadcast transaction: %w", err)
+	}
+	if err := tx.SetHash(broadcastHash); err != nil {
+		return nil, fmt.Errorf("failed to set transaction hash: %w", err)
+	}
+
+	// The transaction is on the network already, so failing to record it is not an error
+	tx.SetMetadata(MetadataBroadcastAt, time.Now().UTC().Format(time.RFC3339))
+	if err := uc.transactions.Save(ctx, tx); err != nil {
+		uc.logger.Warn("failed to save fee bump transaction", map[string]interface{}{
+			"transaction_id": tx.ID(),
+			"error":          err.Error(),
+		})
+	}
+
+	event := events.NewTransactionBroadcastedEvent(input.ChainID, tx.ID(), broadcastHash)
+	if err := uc.eventBus.Publish(ctx, event); err != nil {
+		uc.logger.Warn("failed to publish transaction broadcasted event", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	uc.logger.Info("fee bump broadcasted successfully", map[string]interface{}{
+		"chain_id": input.ChainID,
+		"original": hash.Hex(),
+		"hash":     broadcastHash.Hex(),
+		"method":   input.Method,
+	})
+
+	fee, _ := tx.Metadata()[ports.MetadataFee].(string)
+	return &BumpFeeOutput{
+		ChainID:       input.ChainID,
+		Method:        input.Method,
+		OriginalHash:  hash.Hex(),
+		TransactionID: tx.ID(),
+		Hash:          broadcastHash.Hex(),
+		Fee:           fee,
+		Status:        string(entities.TxStatusPending),
+	}, nil
+}
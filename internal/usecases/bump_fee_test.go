@@ -0,0 +1,164 @@
+package usecases
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/events"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBumpFee(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	original := "0xab"
+
+	newUseCase := func(t *testing.T) (*BumpFeeUseCase, *mocks.MockFeeBumpAdapter, *mocks.MockEventPublisher, *mocks.MockTransactionRepository) {
+		adapter := &mocks.MockFeeBumpAdapter{}
+		registry := mocks.NewMockChainRegistry()
+		publisher := mocks.NewMockEventPublisher()
+		repo := mocks.NewMockTransactionRepository()
+		require.NoError(t, registry.Register("bitcoin-mainnet", adapter))
+		return NewBumpFeeUseCase(registry, repo, mocks.NewMockKeyManager(), publisher, mocks.NewMockLogger()), adapter, publisher, repo
+	}
+	bumped := func(t *testing.T) *entities.Transaction {
+		from, _ := valueobjects.NewAddress("1from", "bitcoin-mainnet")
+		to, _ := valueobjects.NewAddress("1to", "bitcoin-mainnet")
+		tx, err := entities.NewTransaction(entities.TransactionParams{
+			ChainID: "bitcoin-mainnet",
+			From:    from,
+			To:      to,
+			Value:   big.NewInt(50000),
+			Nonce:   valueobjects.NewNonce(0),
+		})
+		require.NoError(t, err)
+		tx.SetMetadata(ports.MetadataFee, "2820")
+		return tx
+	}
+
+	t.Run("replace by fee", func(t *testing.T) {
+		t.Parallel()
+		uc, adapter, publisher, repo := newUseCase(t)
+		adapter.BumpFeeFunc = func(ctx context.Context, hash *valueobjects.Hash, feeRate *big.Int, keys ports.KeyManager, keyID string) (*entities.Transaction, error) {
+			require.Equal(t, original, hash.Hex())
+			require.Equal(t, int64(20), feeRate.Int64())
+			require.Equal(t, "utxo", keyID)
+			return bumped(t), nil
+		}
+		adapter.CPFPFunc = func(ctx context.Context, hash *valueobjects.Hash, feeRate *big.Int, keys ports.KeyManager, keyID string) (*entities.Transaction, error) {
+			t.Fatal("CPFP must not be called for rbf")
+			return nil, nil
+		}
+		var broadcast *entities.Transaction
+		adapter.BroadcastTransactionFunc = func(ctx context.Context, tx *entities.Transaction) (*valueobjects.Hash, error) {
+			broadcast = tx
+			return valueobjects.NewHash("0xcd")
+		}
+
+		out, err := uc.Execute(ctx, BumpFeeInput{ChainID: "bitcoin-mainnet", TransactionHash: original, FeeRate: "20", KeyID: "utxo"})
+		require.NoError(t, err)
+		require.Equal(t, BumpMethodRBF, out.Method)
+		require.Equal(t, original, out.OriginalHash)
+		require.Equal(t, "0xcd", out.Hash)
+		require.Equal(t, broadcast.ID(), out.TransactionID)
+		require.Equal(t, "2820", out.Fee)
+		require.Equal(t, string(entities.TxStatusPending), out.Status)
+		require.Len(t, publisher.PublishedEvents, 1)
+		require.IsType(t, &events.TransactionBroadcastedEvent{}, publisher.PublishedEvents[0])
+
+		// The replacement is stored as broadcast, so it can be looked up but not signed or sent again
+		stored, err := repo.GetByID(ctx, out.TransactionID)
+		require.NoError(t, err)
+		require.Equal(t, "0xcd", stored.Hash().Hex())
+		require.Contains(t, stored.Metadata(), MetadataBroadcastAt)
+	})
+
+	t.Run("child pays for parent", func(t *testing.T) {
+		t.Parallel()
+		uc, adapter, _, repo := newUseCase(t)
+		adapter.CPFPFunc = func(ctx context.Context, hash *valueobjects.Hash, feeRate *big.Int, keys ports.KeyManager, keyID string) (*entities.Transaction, error) {
+			return bumped(t), nil
+		}
+		adapter.BroadcastTransactionFunc = func(ctx context.Context, tx *entities.Transaction) (*valueobjects.Hash, error) {
+			return valueobjects.NewHash("0xef")
+		}
+
+		out, err := uc.Execute(ctx, BumpFeeInput{ChainID: "bitcoin-mainnet", TransactionHash: original, Method: BumpMethodCPFP, FeeRate: "20", KeyID: "utxo"})
+		require.NoError(t, err)
+		require.Equal(t, BumpMethodCPFP, out.Method)
+		require.Equal(t, "0xef", out.Hash)
+		_, err = repo.GetByID(ctx, out.TransactionID)
+		require.NoError(t, err)
+
+		// Failing to store the child does not fail the bump, which is on the network already
+		repo.SaveErr = simpleError{"database down"}
+		out, err = uc.Execute(ctx, BumpFeeInput{ChainID: "bitcoin-mainnet", TransactionHash: original, Method: BumpMethodCPFP, FeeRate: "20", KeyID: "utxo"})
+		require.NoError(t, err)
+		require.Equal(t, "0xef", out.Hash)
+	})
+
+	t.Run("validation errors", func(t *testing.T) {
+		t.Parallel()
+		uc, _, _, _ := newUseCase(t)
+		valid := BumpFeeInput{ChainID: "bitcoin-mainnet", TransactionHash: original, FeeRate: "20", KeyID: "utxo"}
+		tests := []struct {
+			modify func(in *BumpFeeInput)
+			err    string
+		}{
+			{func(in *BumpFeeInput) { in.ChainID = "" }, "chain ID cannot be empty"},
+			{func(in *BumpFeeInput) { in.TransactionHash = "" }, "transaction hash cannot be empty"},
+			{func(in *BumpFeeInput) { in.TransactionHash = "0xzz" }, "invalid transaction hash"},
+			{func(in *BumpFeeInput) { in.Method = "double-spend" }, "unknown fee bump method"},
+			{func(in *BumpFeeInput) { in.KeyID = "" }, "key ID cannot be empty"},
+			{func(in *BumpFeeInput) { in.FeeRate = "0" }, "invalid fee rate"},
+			{func(in *BumpFeeInput) { in.FeeRate = "fast" }, "invalid fee rate"},
+			{func(in *BumpFeeInput) { in.ChainID = "unknown" }, "failed to get chain adapter"},
+		}
+		for _, tt := range tests {
+			in := valid
+			tt.modify(&in)
+			_, err := uc.Execute(ctx, in)
+			require.ErrorContains(t, err, tt.err)
+		}
+	})
+
+	t.Run("unsupported chain", func(t *testing.T) {
+		t.Parallel()
+		registry := mocks.NewMockChainRegistry()
+		require.NoError(t, registry.Register("evm-mainnet", &mocks.MockChainAdapter{}))
+		uc := NewBumpFeeUseCase(registry, mocks.NewMockTransactionRepository(), mocks.NewMockKeyManager(), mocks.NewMockEventPublisher(), mocks.NewMockLogger())
+
+		_, err := uc.Execute(ctx, BumpFeeInput{ChainID: "evm-mainnet", TransactionHash: original, FeeRate: "20", KeyID: "utxo"})
+		require.ErrorContains(t, err, "fee bumping is not supported on chain evm-mainnet")
+	})
+
+	t.Run("bump and broadcast errors", func(t *testing.T) {
+		t.Parallel()
+		uc, adapter, publisher, repo := newUseCase(t)
+		adapter.BumpFeeFunc = func(ctx context.Context, hash *valueobjects.Hash, feeRate *big.Int, keys ports.KeyManager, keyID string) (*entities.Transaction, error) {
+			return nil, simpleError{"does not signal replace-by-fee"}
+		}
+		input := BumpFeeInput{ChainID: "bitcoin-mainnet", TransactionHash: original, FeeRate: "20", KeyID: "utxo"}
+
+		_, err := uc.Execute(ctx, input)
+		require.ErrorContains(t, err, "failed to bump fee: does not signal replace-by-fee")
+
+		adapter.BumpFeeFunc = func(ctx context.Context, hash *valueobjects.Hash, feeRate *big.Int, keys ports.KeyManager, keyID string) (*entities.Transaction, error) {
+			return bumped(t), nil
+		}
+		adapter.BroadcastTransactionFunc = func(ctx context.Context, tx *entities.Transaction) (*valueobjects.Hash, error) {
+			return nil, simpleError{"insufficient fee, rejecting replacement"}
+		}
+		_, err = uc.Execute(ctx, input)
+		require.ErrorContains(t, err, "failed to broadcast transaction")
+		require.Len(t, publisher.PublishedEvents, 1)
+		require.IsType(t, &events.TransactionFailedEvent{}, publisher.PublishedEvents[0])
+		require.Empty(t, repo.Transactions, "rejected transactions are not stored")
+	})
+}
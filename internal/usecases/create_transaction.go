@@ -3,6 +3,7 @@ package usecases
 import (
 	"context"
 	"fmt"
+	"math/big"
 
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/events"
@@ -10,6 +11,13 @@ import (
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
 )
 
+// Metadata keys recorded on token transfer transactions
+const (
+	MetadataTokenAddress   = "token_address"
+	MetadataTokenRecipient = "token_recipient"
+	MetadataTokenAmount    = "token_amount"
+)
+
 // CreateTransactionInput represents the input for CreateTransaction use case
 type CreateTransactionInput struct {
 	ChainID  string
@@ -18,6 +26,10 @@ type CreateTransactionInput struct {
 	Value    string
 	Data     []byte
 	GasLimit uint64
+	// TokenAddress turns the transaction into a token transfer of Value to To
+	TokenAddress string
+	// Options carries chain-specific build options, such as a Bitcoin coin selection strategy
+	Options map[string]string
 }
 
 // CreateTransactionOutput represents the output for CreateTransaction use case
@@ -29,25 +41,29 @@ type CreateTransactionOutput struct {
 	Value         string
 	Nonce         uint64
 	GasLimit      uint64
+	TokenAddress  string
 }
 
 // CreateTransactionUseCase handles transaction creation
 type CreateTransactionUseCase struct {
-	registry ports.ChainRegistry
-	eventBus ports.EventPublisher
-	logger   ports.Logger
+	registry     ports.ChainRegistry
+	transactions ports.TransactionRepository
+	eventBus     ports.EventPublisher
+	logger       ports.Logger
 }
 
 // NewCreateTransactionUseCase creates a new CreateTransactionUseCase
 func NewCreateTransactionUseCase(
 	registry ports.ChainRegistry,
+	transactions ports.TransactionRepository,
 	eventBus ports.EventPublisher,
 	logger ports.Logger,
 ) *CreateTransactionUseCase {
 	return &CreateTransactionUseCase{
-		registry: registry,
-		eventBus: eventBus,
-		logger:   logger,
+		registry:     registry,
+		transactions: transactions,
+		eventBus:     eventBus,
+		logger:       logger,
 	}
 }
 
@@ -59,14 +75,8 @@ func (uc *CreateTransactionUseCase) Execute(ctx context.Context, input CreateTra
 		"to":       input.To,
 	})
 
-	if input.ChainID == "" {
-		return nil, fmt.Errorf("chain ID cannot be empty")
-	}
-	if input.From == "" {
-		return nil, fmt.Errorf("from address cannot be empty")
-	}
-	if input.To == "" {
-		return nil, fmt.Errorf("to address cannot be empty")
+	if err := validateTransactionInput(input); err != nil {
+		return nil, err
 	}
 
 	adapter, err := uc.registry.Get(input.ChainID)
@@ -77,6 +87,67 @@ func (uc *CreateTransactionUseCase) Execute(ctx context.Context, input CreateTra
 		return nil, fmt.Errorf("failed to get chain adapter: %w", err)
 	}
 
+	tx, err := buildTransaction(ctx, adapter, input)
+	if err != nil {
+		uc.logger.Error("failed to build transaction", err, map[string]interface{}{
+			"chain_id": input.ChainID,
+		})
+		return nil, err
+	}
+
+	if err := uc.transactions.Save(ctx, tx); err != nil {
+		uc.logger.Error("failed to save transaction", err, map[string]interface{}{
+			"chain_id":       input.ChainID,
+			"transaction_id": tx.ID(),
+		})
+		return nil, fmt.Errorf("failed to save transaction: %w", err)
+	}
+
+	event := events.NewTransactionCreatedEvent(tx)
+	if err := uc.eventBus.Publish(ctx, event); err != nil {
+		uc.logger.Warn("failed to publish transaction created event", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	uc.logger.Info("transaction created successfully", map[string]interface{}{
+		"chain_id":       input.ChainID,
+		"transaction_id": tx.ID(),
+	})
+
+	var nonce uint64
+	if tx.Nonce() != nil {
+		nonce = tx.Nonce().Value()
+	}
+
+	return &CreateTransactionOutput{
+		TransactionID: tx.ID(),
+		ChainID:       tx.ChainID(),
+		From:          tx.From().String(),
+		To:            tx.To().String(),
+		Value:         tx.Value().String(),
+		Nonce:         nonce,
+		GasLimit:      tx.GasLimit(),
+		TokenAddress:  input.TokenAddress,
+	}, nil
+}
+
+// validateTransactionInput checks the fields a transaction cannot be built without
+func validateTransactionInput(input CreateTransactionInput) error {
+	if input.ChainID == "" {
+		return fmt.Errorf("chain ID cannot be empty")
+	}
+	if input.From == "" {
+		return fmt.Errorf("from address cannot be empty")
+	}
+	if input.To == "" {
+		return fmt.Errorf("to address cannot be empty")
+	}
+	return nil
+}
+
+// buildTransaction builds the transaction described by input with the adapter of its chain
+func buildTransaction(ctx context.Context, adapter ports.ChainAdapter, input CreateTransactionInput) (*entities.Transaction, error) {
 	from, err := valueobjects.NewAddress(input.From, input.ChainID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid from address: %w", err)
@@ -99,40 +170,56 @@ func (uc *CreateTransactionUseCase) Execute(ctx context.Context, input CreateTra
 		Value:    value,
 		Data:     input.Data,
 		GasLimit: input.GasLimit,
+		Options:  input.Options,
+	}
+
+	if input.TokenAddress != "" {
+		params, err = tokenTransferParams(adapter, input, params)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	tx, err := adapter.BuildTransaction(ctx, params)
 	if err != nil {
-		uc.logger.Error("failed to build transaction", err, map[string]interface{}{
-			"chain_id": input.ChainID,
-		})
 		return nil, fmt.Errorf("failed to build transaction: %w", err)
 	}
 
-	event := events.NewTransactionCreatedEvent(tx)
-	if err := uc.eventBus.Publish(ctx, event); err != nil {
-		uc.logger.Warn("failed to publish transaction created event", map[string]interface{}{
-			"error": err.Error(),
-		})
+	if input.TokenAddress != "" {
+		tx.SetMetadata(MetadataTokenAddress, input.TokenAddress)
+		tx.SetMetadata(MetadataTokenRecipient, input.To)
+		tx.SetMetadata(MetadataTokenAmount, value.String())
 	}
+	return tx, nil
+}
 
-	uc.logger.Info("transaction created successfully", map[string]interface{}{
-		"chain_id":       input.ChainID,
-		"transaction_id": tx.ID(),
-	})
+// tokenTransferParams rewrites params into a call to the token contract
+func tokenTransferParams(
+	adapter ports.ChainAdapter,
+	input CreateTransactionInput,
+	params entities.TransactionParams,
+) (entities.TransactionParams, error) {
+	if len(input.Data) > 0 {
+		return params, fmt.Errorf("data cannot be set for token transfers")
+	}
 
-	var nonce uint64
-	if tx.Nonce() != nil {
-		nonce = tx.Nonce().Value()
+	encoder, ok := adapterCapability[ports.TokenTransferEncoder](adapter)
+	if !ok {
+		return params, fmt.Errorf("token transfers are not supported on chain %s", input.ChainID)
 	}
 
-	return &CreateTransactionOutput{
-		TransactionID: tx.ID(),
-		ChainID:       tx.ChainID(),
-		From:          tx.From().String(),
-		To:            tx.To().String(),
-		Value:         tx.Value().String(),
-		Nonce:         nonce,
-		GasLimit:      tx.GasLimit(),
-	}, nil
+	token, err := valueobjects.NewAddress(input.TokenAddress, input.ChainID)
+	if err != nil {
+		return params, fmt.Errorf("invalid token address: %w", err)
+	}
+
+	data, err := encoder.EncodeTokenTransfer(params.To, params.Value)
+	if err != nil {
+		return params, fmt.Errorf("failed to encode token transfer: %w", err)
+	}
+
+	params.To = token
+	params.Value = big.NewInt(0)
+	params.Data = data
+	return params, nil
 }
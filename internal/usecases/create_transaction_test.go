@@ -2,9 +2,11 @@ package usecases
 
 import (
 	"context"
+	"math/big"
 	"testing"
 
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/mocks"
 	"github.com/stretchr/testify/require"
 )
@@ -27,7 +29,8 @@ func TestCreateTransaction(t *testing.T) {
 			return entities.NewTransaction(params)
 		}
 
-		uc := NewCreateTransactionUseCase(registry, publisher, logger)
+		transactions := mocks.NewMockTransactionRepository()
+		uc := NewCreateTransactionUseCase(registry, transactions, publisher, logger)
 		out, err := uc.Execute(ctx, CreateTransactionInput{
 			ChainID:  "evm-mainnet",
 			From:     "0xabc",
@@ -39,6 +42,36 @@ func TestCreateTransaction(t *testing.T) {
 		require.NotNil(t, out)
 		require.Equal(t, "evm-mainnet", out.ChainID)
 		require.NotEmpty(t, out.TransactionID)
+		require.Contains(t, transactions.Transactions, out.TransactionID)
+
+		transactions.SaveErr = simpleError{"database down"}
+		_, err = uc.Execute(ctx, CreateTransactionInput{ChainID: "evm-mainnet", From: "0xabc", To: "0xdef"})
+		require.ErrorContains(t, err, "failed to save transaction")
+	})
+
+	t.Run("forwards options", func(t *testing.T) {
+		t.Parallel()
+		adapter := &mocks.MockChainAdapter{}
+		registry := mocks.NewMockChainRegistry()
+		require.NoError(t, registry.Register("bitcoin-mainnet", adapter))
+
+		options := map[string]string{"coin_selection": "largest-first"}
+		var received map[string]string
+		adapter.BuildTransactionFunc = func(ctx context.Context, params entities.TransactionParams) (*entities.Transaction, error) {
+			received = params.Options
+			return entities.NewTransaction(params)
+		}
+
+		uc := NewCreateTransactionUseCase(registry, mocks.NewMockTransactionRepository(), mocks.NewMockEventPublisher(), mocks.NewMockLogger())
+		_, err := uc.Execute(ctx, CreateTransactionInput{
+			ChainID: "bitcoin-mainnet",
+			From:    "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa",
+			To:      "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2",
+			Value:   "50000",
+			Options: options,
+		})
+		require.NoError(t, err)
+		require.Equal(t, options, received)
 	})
 
 	t.Run("validation error: missing chainID", func(t *testing.T) {
@@ -46,7 +79,7 @@ func TestCreateTransaction(t *testing.T) {
 		registry := mocks.NewMockChainRegistry()
 		publisher := mocks.NewMockEventPublisher()
 		logger := mocks.NewMockLogger()
-		uc := NewCreateTransactionUseCase(registry, publisher, logger)
+		uc := NewCreateTransactionUseCase(registry, mocks.NewMockTransactionRepository(), publisher, logger)
 		_, err := uc.Execute(ctx, CreateTransactionInput{
 			ChainID: "",
 			From:    "0xabc",
@@ -61,7 +94,7 @@ func TestCreateTransaction(t *testing.T) {
 		registry := mocks.NewMockChainRegistry()
 		publisher := mocks.NewMockEventPublisher()
 		logger := mocks.NewMockLogger()
-		uc := NewCreateTransactionUseCase(registry, publisher, logger)
+		uc := NewCreateTransactionUseCase(registry, mocks.NewMockTransactionRepository(), publisher, logger)
 		_, err := uc.Execute(ctx, CreateTransactionInput{
 			ChainID: "unknown",
 			From:    "0xabc",
@@ -76,7 +109,7 @@ func TestCreateTransaction(t *testing.T) {
 		registry := mocks.NewMockChainRegistry()
 		publisher := mocks.NewMockEventPublisher()
 		logger := mocks.NewMockLogger()
-		uc := NewCreateTransactionUseCase(registry, publisher, logger)
+		uc := NewCreateTransactionUseCase(registry, mocks.NewMockTransactionRepository(), publisher, logger)
 		_, err := uc.Execute(ctx, CreateTransactionInput{
 			ChainID: "evm-mainnet",
 			From:    "",
@@ -91,7 +124,7 @@ func TestCreateTransaction(t *testing.T) {
 		registry := mocks.NewMockChainRegistry()
 		publisher := mocks.NewMockEventPublisher()
 		logger := mocks.NewMockLogger()
-		uc := NewCreateTransactionUseCase(registry, publisher, logger)
+		uc := NewCreateTransactionUseCase(registry, mocks.NewMockTransactionRepository(), publisher, logger)
 		_, err := uc.Execute(ctx, CreateTransactionInput{
 			ChainID: "evm-mainnet",
 			From:    "0xabc",
@@ -106,7 +139,7 @@ func TestCreateTransaction(t *testing.T) {
 		registry := mocks.NewMockChainRegistry()
 		publisher := mocks.NewMockEventPublisher()
 		logger := mocks.NewMockLogger()
-		uc := NewCreateTransactionUseCase(registry, publisher, logger)
+		uc := NewCreateTransactionUseCase(registry, mocks.NewMockTransactionRepository(), publisher, logger)
 		_, err := uc.Execute(ctx, CreateTransactionInput{
 			ChainID: "evm-mainnet",
 			From:    "0xabc",
@@ -128,7 +161,7 @@ func TestCreateTransaction(t *testing.T) {
 			return nil, simpleError{"build failed"}
 		}
 
-		uc := NewCreateTransactionUseCase(registry, publisher, logger)
+		uc := NewCreateTransactionUseCase(registry, mocks.NewMockTransactionRepository(), publisher, logger)
 		_, err := uc.Execute(ctx, CreateTransactionInput{
 			ChainID:  "evm-mainnet",
 			From:     "0xabc",
@@ -138,4 +171,64 @@ func TestCreateTransaction(t *testing.T) {
 		})
 		require.Error(t, err)
 	})
+
+	t.Run("token transfer", func(t *testing.T) {
+		t.Parallel()
+		adapter := &mocks.MockTokenAdapter{}
+		registry := mocks.NewMockChainRegistry()
+		publisher := mocks.NewMockEventPublisher()
+		logger := mocks.NewMockLogger()
+		require.NoError(t, registry.Register("evm-mainnet", adapter))
+
+		var built entities.TransactionParams
+		adapter.BuildTransactionFunc = func(ctx context.Context, params entities.TransactionParams) (*entities.Transaction, error) {
+			built = params
+			return entities.NewTransaction(params)
+		}
+		adapter.EncodeTokenTransferFunc = func(to *valueobjects.Address, amount *big.Int) ([]byte, error) {
+			require.Equal(t, "0xdef", to.Value())
+			require.Equal(t, "2500", amount.String())
+			return []byte{0xa9, 0x05, 0x9c, 0xbb, 0x01}, nil
+		}
+
+		uc := NewCreateTransactionUseCase(registry, mocks.NewMockTransactionRepository(), publisher, logger)
+		out, err := uc.Execute(ctx, CreateTransactionInput{
+			ChainID:      "evm-mainnet",
+			From:         "0xabc",
+			To:           "0xdef",
+			Value:        "2500",
+			TokenAddress: "0xtoken",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "0xtoken", out.To)
+		require.Equal(t, "0", out.Value)
+		require.Equal(t, "0xtoken", out.TokenAddress)
+		require.Equal(t, []byte{0xa9, 0x05, 0x9c, 0xbb, 0x01}, built.Data)
+	})
+
+	t.Run("token transfer errors", func(t *testing.T) {
+		t.Parallel()
+		registry := mocks.NewMockChainRegistry()
+		publisher := mocks.NewMockEventPublisher()
+		logger := mocks.NewMockLogger()
+		tokenAdapter := &mocks.MockTokenAdapter{}
+		tokenAdapter.EncodeTokenTransferFunc = func(to *valueobjects.Address, amount *big.Int) ([]byte, error) {
+			return nil, simpleError{"encode failed"}
+		}
+		require.NoError(t, registry.Register("evm-mainnet", tokenAdapter))
+		require.NoError(t, registry.Register("plain", &mocks.MockChainAdapter{}))
+		uc := NewCreateTransactionUseCase(registry, mocks.NewMockTransactionRepository(), publisher, logger)
+
+		input := CreateTransactionInput{ChainID: "plain", From: "0xabc", To: "0xdef", Value: "1", TokenAddress: "0xtoken"}
+		_, err := uc.Execute(ctx, input)
+		require.ErrorContains(t, err, "not supported")
+
+		input.ChainID = "evm-mainnet"
+		_, err = uc.Execute(ctx, input)
+		require.ErrorContains(t, err, "encode failed")
+
+		input.Data = []byte{0x01}
+		_, err = uc.Execute(ctx, input)
+		require.ErrorContains(t, err, "data cannot be set")
+	})
 }
@@ -13,6 +13,9 @@ import (
 type EstimateFeeInput struct {
 	ChainID     string
 	Transaction *entities.Transaction
+	// Request describes the transaction to estimate when Transaction is nil; it is built as
+	// CreateTransactionUseCase would build it, but not stored
+	Request *CreateTransactionInput
 }
 
 // EstimateFeeOutput represents the output for EstimateFee use case
@@ -54,9 +57,17 @@ func (uc *EstimateFeeUseCase) Execute(ctx context.Context, input EstimateFeeInpu
 	if input.ChainID == "" {
 		return nil, fmt.Errorf("chain ID cannot be empty")
 	}
-	if input.Transaction == nil {
+	if input.Transaction == nil && input.Request == nil {
 		return nil, fmt.Errorf("transaction cannot be nil")
 	}
+	var request CreateTransactionInput
+	if input.Transaction == nil {
+		request = *input.Request
+		request.ChainID = input.ChainID
+		if err := validateTransactionInput(request); err != nil {
+			return nil, err
+		}
+	}
 
 	adapter, err := uc.registry.Get(input.ChainID)
 	if err != nil {
@@ -66,16 +77,26 @@ func (uc *EstimateFeeUseCase) Execute(ctx context.Context, input EstimateFeeInpu
 		return nil, fmt.Errorf("failed to get chain adapter: %w", err)
 	}
 
-	fee, err := adapter.EstimateFee(ctx, input.Transaction)
+	tx := input.Transaction
+	if tx == nil {
+		if tx, err = buildTransaction(ctx, adapter, request); err != nil {
+			uc.logger.Error("failed to build transaction", err, map[string]interface{}{
+				"chain_id": input.ChainID,
+			})
+			return nil, err
+		}
+	}
+
+	fee, err := adapter.EstimateFee(ctx, tx)
 	if err != nil {
 		uc.logger.Error("failed to estimate fee", err, map[string]interface{}{
 			"chain_id":       input.ChainID,
-			"transaction_id": input.Transaction.ID(),
+			"transaction_id": tx.ID(),
 		})
 		return nil, fmt.Errorf("failed to estimate fee: %w", err)
 	}
 
-	event := events.NewFeeEstimatedEvent(input.ChainID, fee, input.Transaction.ID())
+	event := events.NewFeeEstimatedEvent(input.ChainID, fee, tx.ID())
 	if err := uc.eventBus.Publish(ctx, event); err != nil {
 		uc.logger.Warn("failed to publish fee estimated event", map[string]interface{}{
 			"error": err.Error(),
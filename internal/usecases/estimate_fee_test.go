@@ -73,4 +73,34 @@ func TestEstimateFee(t *testing.T) {
 		_, err := uc.Execute(ctx, EstimateFeeInput{ChainID: "evm-mainnet", Transaction: nil})
 		require.Error(t, err)
 	})
+	t.Run("request", func(t *testing.T) {
+		t.Parallel()
+		adapter := &mocks.MockChainAdapter{}
+		registry := mocks.NewMockChainRegistry()
+		_ = registry.Register("evm-mainnet", adapter)
+		var built entities.TransactionParams
+		adapter.BuildTransactionFunc = func(ctx context.Context, params entities.TransactionParams) (*entities.Transaction, error) {
+			built = params
+			return entities.NewTransaction(params)
+		}
+		adapter.EstimateFeeFunc = func(ctx context.Context, inTx *entities.Transaction) (*entities.Fee, error) {
+			return entities.NewEIP1559Fee(21000, big.NewInt(30000000000), big.NewInt(2000000000), "ETH")
+		}
+		uc := NewEstimateFeeUseCase(registry, mocks.NewMockEventPublisher(), mocks.NewMockLogger())
+		out, err := uc.Execute(ctx, EstimateFeeInput{
+			ChainID: "evm-mainnet",
+			Request: &CreateTransactionInput{From: "0xabc", To: "0xdef", Value: "1000"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "evm-mainnet", built.ChainID)
+		require.Equal(t, big.NewInt(1000), built.Value)
+		require.Equal(t, "30000000000", out.MaxFeePerGas)
+		require.Equal(t, "2000000000", out.MaxPriorityFee)
+		require.Empty(t, out.GasPrice)
+
+		_, err = uc.Execute(ctx, EstimateFeeInput{ChainID: "evm-mainnet", Request: &CreateTransactionInput{From: "0xabc"}})
+		require.ErrorContains(t, err, "to address cannot be empty")
+		_, err = uc.Execute(ctx, EstimateFeeInput{ChainID: "evm-mainnet", Request: &CreateTransactionInput{From: "0xabc", To: "0xdef", Value: "x"}})
+		require.ErrorContains(t, err, "invalid value")
+	})
 }
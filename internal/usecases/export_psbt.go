@@ -0,0 +1,165 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/events"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+)
+
+// ExportPSBTInput represents the input for ExportPSBT use case
+type ExportPSBTInput struct {
+	ChainID string
+	From    string
+	To      string
+	Value   string
+}
+
+// ExportPSBTOutput represents the output for ExportPSBT use case
+type ExportPSBTOutput struct {
+	TransactionID string
+	ChainID       string
+	From          string
+	To            string
+	Value         string
+	PSBT          string
+}
+
+// ExportPSBTUseCase builds an unsigned transaction, stores it and exports it as a PSBT for
+// offline signing
+type ExportPSBTUseCase struct {
+	registry     ports.ChainRegistry
+	transactions ports.TransactionRepository
+	eventBus     ports.EventPublisher
+	logger       ports.Logger
+}
+
+// NewExportPSBTUseCase creates a new ExportPSBTUseCase
+func NewExportPSBTUseCase(
+	registry ports.ChainRegistry,
+	transactions ports.TransactionRepository,
+	eventBus ports.EventPublisher,
+	logger ports.Logger,
+) *ExportPSBTUseCase {
+	return &ExportPSBTUseCase{
+		registry:     registry,
+		transactions: transactions,
+		eventBus:     eventBus,
+		logger:       logger,
+	}
+}
+
+// Execute executes the export PSBT use case
+func (uc *ExportPSBTUseCase) Execute(ctx context.Context, input ExportPSBTInput) (*ExportPSBTOutput, error) {
+	uc.logger.Info("executing ExportPSBT use case", map[string]interface{}{
+		"chain_id": input.ChainID,
+		"from":     input.From,
+		"to":       input.To,
+	})
+
+	if input.ChainID == "" {
+		return nil, fmt.Errorf("chain ID cannot be empty")
+	}
+	if input.From == "" {
+		return nil, fmt.Errorf("from address cannot be empty")
+	}
+	if input.To == "" {
+		return nil, fmt.Errorf("to address cannot be empty")
+	}
+
+	provider, err := psbtProvider(uc.registry, input.ChainID)
+	if err != nil {
+		uc.logger.Error("failed to get PSBT provider", err, map[string]interface{}{
+			"chain_id": input.ChainID,
+		})
+		return nil, err
+	}
+
+	from, err := valueobjects.NewAddress(input.From, input.ChainID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from address: %w", err)
+	}
+
+	to, err := valueobjects.NewAddress(input.To, input.ChainID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid to address: %w", err)
+	}
+
+	value, ok := parseBigInt(input.Value)
+	if !ok {
+		return nil, fmt.Errorf("invalid value: %s", input.Value)
+	}
+
+	tx, err := provider.BuildTransaction(ctx, entities.TransactionParams{
+		ChainID: input.ChainID,
+		From:    from,
+		To:      to,
+		Value:   value,
+	})
+	if err != nil {
+		uc.logger.Error("failed to build transaction", err, map[string]interface{}{
+			"chain_id": input.ChainID,
+		})
+		return nil, fmt.Errorf("failed to build transaction: %w", err)
+	}
+
+	psbt, err := provider.ExportPSBT(ctx, tx)
+	if err != nil {
+		uc.logger.Error("failed to export PSBT", err, map[string]interface{}{
+			"chain_id":       input.ChainID,
+			"transaction_id": tx.ID(),
+		})
+		return nil, fmt.Errorf("failed to export PSBT: %w", err)
+	}
+
+	if err := uc.transactions.Save(ctx, tx); err != nil {
+		uc.logger.Error("failed to save transaction", err, map[string]interface{}{
+			"chain_id":       input.ChainID,
+			"transaction_id": tx.ID(),
+		})
+		return nil, fmt.Errorf("failed to save transaction: %w", err)
+	}
+
+	event := events.NewTransactionCreatedEvent(tx)
+	if err := uc.eventBus.Publish(ctx, event); err != nil {
+		uc.logger.Warn("failed to publish transaction created event", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	uc.logger.Info("PSBT exported successfully", map[string]interface{}{
+		"chain_id":       input.ChainID,
+		"transaction_id": tx.ID(),
+	})
+
+	return &ExportPSBTOutput{
+		TransactionID: tx.ID(),
+		ChainID:       tx.ChainID(),
+		From:          tx.From().String(),
+		To:            tx.To().String(),
+		Value:         tx.Value().String(),
+		PSBT:          psbt,
+	}, nil
+}
+
+// psbtAdapter is a chain adapter with the optional PSBT capability
+type psbtAdapter interface {
+	ports.ChainAdapter
+	ports.PSBTProvider
+}
+
+// psbtProvider returns the adapter of a chain that supports PSBTs
+func psbtProvider(registry ports.ChainRegistry, chainID string) (psbtAdapter, error) {
+	adapter, err := registry.Get(chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain adapter: %w", err)
+	}
+	provider, ok := adapterCapability[psbtAdapter](adapter)
+	if !ok {
+		return nil, fmt.Errorf("PSBTs are not supported on chain %s", chainID)
+	}
+	return provider, nil
+}
@@ -0,0 +1,116 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/events"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportPSBT(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	input := ExportPSBTInput{ChainID: "bitcoin-mainnet", From: "1from", To: "1to", Value: "50000"}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		adapter := &mocks.MockPSBTAdapter{}
+		registry := mocks.NewMockChainRegistry()
+		publisher := mocks.NewMockEventPublisher()
+		logger := mocks.NewMockLogger()
+		require.NoError(t, registry.Register("bitcoin-mainnet", adapter))
+
+		adapter.BuildTransactionFunc = func(ctx context.Context, params entities.TransactionParams) (*entities.Transaction, error) {
+			return entities.NewTransaction(params)
+		}
+		var exported *entities.Transaction
+		adapter.ExportPSBTFunc = func(ctx context.Context, tx *entities.Transaction) (string, error) {
+			exported = tx
+			return "cHNidP8BAA==", nil
+		}
+
+		transactions := mocks.NewMockTransactionRepository()
+		uc := NewExportPSBTUseCase(registry, transactions, publisher, logger)
+		out, err := uc.Execute(ctx, input)
+		require.NoError(t, err)
+		require.Equal(t, "cHNidP8BAA==", out.PSBT)
+		require.Equal(t, exported.ID(), out.TransactionID)
+		stored, err := transactions.GetByID(ctx, out.TransactionID)
+		require.NoError(t, err)
+		require.Equal(t, entities.TxStatusPending, stored.Status())
+		require.Equal(t, "50000", out.Value)
+		require.Len(t, publisher.PublishedEvents, 1)
+		require.IsType(t, &events.TransactionCreatedEvent{}, publisher.PublishedEvents[0])
+	})
+
+	t.Run("validation errors", func(t *testing.T) {
+		t.Parallel()
+		uc := NewExportPSBTUseCase(mocks.NewMockChainRegistry(), mocks.NewMockTransactionRepository(), mocks.NewMockEventPublisher(), mocks.NewMockLogger())
+		for _, in := range []ExportPSBTInput{
+			{From: "1from", To: "1to"},
+			{ChainID: "bitcoin-mainnet", To: "1to"},
+			{ChainID: "bitcoin-mainnet", From: "1from"},
+		} {
+			_, err := uc.Execute(ctx, in)
+			require.Error(t, err)
+		}
+	})
+
+	t.Run("unsupported chain", func(t *testing.T) {
+		t.Parallel()
+		registry := mocks.NewMockChainRegistry()
+		require.NoError(t, registry.Register("evm-mainnet", &mocks.MockChainAdapter{}))
+		transactions := mocks.NewMockTransactionRepository()
+		uc := NewExportPSBTUseCase(registry, transactions, mocks.NewMockEventPublisher(), mocks.NewMockLogger())
+
+		_, err := uc.Execute(ctx, ExportPSBTInput{ChainID: "evm-mainnet", From: "0xabc", To: "0xdef"})
+		require.ErrorContains(t, err, "PSBTs are not supported on chain evm-mainnet")
+		_, err = uc.Execute(ctx, ExportPSBTInput{ChainID: "unknown", From: "0xabc", To: "0xdef"})
+		require.ErrorContains(t, err, "failed to get chain adapter")
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		t.Parallel()
+		registry := mocks.NewMockChainRegistry()
+		require.NoError(t, registry.Register("bitcoin-mainnet", &mocks.MockPSBTAdapter{}))
+		transactions := mocks.NewMockTransactionRepository()
+		uc := NewExportPSBTUseCase(registry, transactions, mocks.NewMockEventPublisher(), mocks.NewMockLogger())
+
+		_, err := uc.Execute(ctx, ExportPSBTInput{ChainID: "bitcoin-mainnet", From: "1from", To: "1to", Value: "abc"})
+		require.ErrorContains(t, err, "invalid value")
+	})
+
+	t.Run("adapter errors", func(t *testing.T) {
+		t.Parallel()
+		adapter := &mocks.MockPSBTAdapter{}
+		registry := mocks.NewMockChainRegistry()
+		require.NoError(t, registry.Register("bitcoin-mainnet", adapter))
+		transactions := mocks.NewMockTransactionRepository()
+		uc := NewExportPSBTUseCase(registry, transactions, mocks.NewMockEventPublisher(), mocks.NewMockLogger())
+
+		adapter.BuildTransactionFunc = func(ctx context.Context, params entities.TransactionParams) (*entities.Transaction, error) {
+			return nil, simpleError{"no utxos"}
+		}
+		_, err := uc.Execute(ctx, input)
+		require.ErrorContains(t, err, "failed to build transaction")
+
+		adapter.BuildTransactionFunc = func(ctx context.Context, params entities.TransactionParams) (*entities.Transaction, error) {
+			return entities.NewTransaction(params)
+		}
+		adapter.ExportPSBTFunc = func(ctx context.Context, tx *entities.Transaction) (string, error) {
+			return "", simpleError{"unsupported script"}
+		}
+		_, err = uc.Execute(ctx, input)
+		require.ErrorContains(t, err, "failed to export PSBT")
+		require.Empty(t, transactions.Transactions)
+
+		adapter.ExportPSBTFunc = nil
+		transactions.SaveErr = simpleError{"database down"}
+		_, err = uc.Execute(ctx, input)
+		require.ErrorContains(t, err, "failed to save transaction")
+	})
+}
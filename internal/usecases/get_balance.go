@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/events"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
@@ -23,6 +24,8 @@ type GetBalanceOutput struct {
 	Address      string
 	Balance      *big.Int
 	TokenAddress string
+	// Token is set when the adapter can describe the token contract
+	Token *entities.Token
 }
 
 // GetBalanceUseCase handles balance queries
@@ -74,6 +77,7 @@ func (uc *GetBalanceUseCase) Execute(ctx context.Context, input GetBalanceInput)
 
 	var balance *big.Int
 	var tokenAddress *valueobjects.Address
+	var token *entities.Token
 
 	if input.TokenAddress != "" {
 		tokenAddress, err = valueobjects.NewAddress(input.TokenAddress, input.ChainID)
@@ -90,6 +94,16 @@ func (uc *GetBalanceUseCase) Execute(ctx context.Context, input GetBalanceInput)
 			})
 			return nil, fmt.Errorf("failed to get token balance: %w", err)
 		}
+
+		if provider, ok := adapterCapability[ports.TokenMetadataProvider](adapter); ok {
+			token, err = provider.GetTokenMetadata(ctx, tokenAddress)
+			if err != nil {
+				uc.logger.Warn("failed to get token metadata", map[string]interface{}{
+					"token_address": input.TokenAddress,
+					"error":         err.Error(),
+				})
+			}
+		}
 	} else {
 		balance, err = adapter.GetBalance(ctx, input.ChainID, address)
 		if err != nil {
@@ -119,5 +133,6 @@ func (uc *GetBalanceUseCase) Execute(ctx context.Context, input GetBalanceInput)
 		Address:      input.Address,
 		Balance:      balance,
 		TokenAddress: input.TokenAddress,
+		Token:        token,
 	}, nil
 }
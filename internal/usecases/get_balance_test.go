@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/mocks"
@@ -59,6 +60,30 @@ func TestGetBalance(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, "5000", out.Balance.String())
 		require.Equal(t, "0xtoken", out.TokenAddress)
+		require.Nil(t, out.Token)
+	})
+
+	t.Run("success token balance with metadata", func(t *testing.T) {
+		t.Parallel()
+		adapter := &mocks.MockTokenAdapter{}
+		registry := mocks.NewMockChainRegistry()
+		publisher := mocks.NewMockEventPublisher()
+		logger := mocks.NewMockLogger()
+		_ = registry.Register("evm-mainnet", adapter)
+
+		uc := NewGetBalanceUseCase(registry, publisher, logger)
+		out, err := uc.Execute(ctx, GetBalanceInput{ChainID: "evm-mainnet", Address: "0xabc", TokenAddress: "0xtoken"})
+		require.NoError(t, err)
+		require.NotNil(t, out.Token)
+		require.Equal(t, "MOCK", out.Token.Symbol())
+
+		// metadata failures are not fatal
+		adapter.GetTokenMetadataFunc = func(ctx context.Context, tokenAddress *valueobjects.Address) (*entities.Token, error) {
+			return nil, simpleError{"metadata failed"}
+		}
+		out, err = uc.Execute(ctx, GetBalanceInput{ChainID: "evm-mainnet", Address: "0xabc", TokenAddress: "0xtoken"})
+		require.NoError(t, err)
+		require.Nil(t, out.Token)
 	})
 
 	t.Run("validation error: missing chainID", func(t *testing.T) {
@@ -0,0 +1,105 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+)
+
+// GetChainInfoInput represents the input for GetChainInfo use case
+type GetChainInfoInput struct {
+	ChainID string
+}
+
+// GetChainInfoOutput represents the output for GetChainInfo use case
+type GetChainInfoOutput struct {
+	ChainID   string
+	ChainType entities.ChainType
+	// Active is false for a chain disabled at runtime, which is described without asking its node
+	Active bool
+	// Chain is set when the adapter can describe its chain
+	Chain *entities.Chain
+	// Network, LatestBlock, Peers and GasPrice are live data, left unset when the node
+	// cannot provide them
+	Network     *entities.Network
+	LatestBlock uint64
+	Peers       int
+	GasPrice    *big.Int
+}
+
+// GetChainInfoUseCase handles chain metadata queries
+type GetChainInfoUseCase struct {
+	registry ports.ChainRegistry
+	logger   ports.Logger
+}
+
+// NewGetChainInfoUseCase creates a new GetChainInfoUseCase
+func NewGetChainInfoUseCase(
+	registry ports.ChainRegistry,
+	logger ports.Logger,
+) *GetChainInfoUseCase {
+	return &GetChainInfoUseCase{
+		registry: registry,
+		logger:   logger,
+	}
+}
+
+// Execute executes the get chain info use case
+func (uc *GetChainInfoUseCase) Execute(ctx context.Context, input GetChainInfoInput) (*GetChainInfoOutput, error) {
+	uc.logger.Info("executing GetChainInfo use case", map[string]interface{}{
+		"chain_id": input.ChainID,
+	})
+
+	if input.ChainID == "" {
+		return nil, fmt.Errorf("chain ID cannot be empty")
+	}
+
+	adapter, err := uc.registry.Lookup(input.ChainID)
+	if err != nil {
+		uc.logger.Error("failed to get chain adapter", err, map[string]interface{}{
+			"chain_id": input.ChainID,
+		})
+		return nil, fmt.Errorf("failed to get chain adapter: %w", err)
+	}
+
+	output := &GetChainInfoOutput{
+		ChainID:   input.ChainID,
+		ChainType: adapter.GetChainType(),
+		Active:    uc.registry.IsActive(input.ChainID),
+	}
+
+	if chain, err := uc.registry.Describe(input.ChainID); err == nil {
+		output.Chain = chain
+	}
+
+	if output.Network, err = adapter.GetNetworkInfo(ctx); err != nil {
+		uc.warn(input.ChainID, "failed to get network info", err)
+	}
+	if !output.Active {
+		if output.Network != nil {
+			output.Network.Deactivate()
+		}
+		return output, nil
+	}
+	if output.LatestBlock, err = adapter.GetLatestBlock(ctx); err != nil {
+		uc.warn(input.ChainID, "failed to get latest block", err)
+	}
+	if output.Peers, err = adapter.GetPeers(ctx); err != nil {
+		uc.warn(input.ChainID, "failed to get peers", err)
+	}
+	if output.GasPrice, err = adapter.GetGasPrice(ctx); err != nil {
+		uc.warn(input.ChainID, "failed to get gas price", err)
+	}
+
+	return output, nil
+}
+
+func (uc *GetChainInfoUseCase) warn(chainID, message string, err error) {
+	uc.logger.Warn(message, map[string]interface{}{
+		"chain_id": chainID,
+		"error":    err.Error(),
+	})
+}
@@ -0,0 +1,79 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/factory"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetChainInfo(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("described chain", func(t *testing.T) {
+		t.Parallel()
+		registry := mocks.NewMockChainRegistry()
+		chainID, adapter, err := factory.ChainFactory{}.NewChain(ports.ChainSpec{
+			Type:     "evm",
+			Network:  "localnet",
+			Settings: map[string]interface{}{"adapter": "harness", "chain_id": 31337},
+		})
+		require.NoError(t, err)
+		require.NoError(t, registry.Register(chainID, adapter))
+
+		uc := NewGetChainInfoUseCase(registry, mocks.NewMockLogger())
+		out, err := uc.Execute(ctx, GetChainInfoInput{ChainID: "localnet"})
+		require.NoError(t, err)
+		require.Equal(t, entities.ChainTypeEVM, out.ChainType)
+		require.NotNil(t, out.Chain)
+		require.Equal(t, "31337", out.Chain.NetworkID())
+		require.Equal(t, uint8(18), out.Chain.Decimals())
+		require.NotNil(t, out.Network)
+		require.NotNil(t, out.GasPrice)
+	})
+
+	t.Run("adapter without descriptor", func(t *testing.T) {
+		t.Parallel()
+		registry := mocks.NewMockChainRegistry()
+		_ = registry.Register("evm-mainnet", &mocks.MockChainAdapter{})
+
+		uc := NewGetChainInfoUseCase(registry, mocks.NewMockLogger())
+		out, err := uc.Execute(ctx, GetChainInfoInput{ChainID: "evm-mainnet"})
+		require.NoError(t, err)
+		require.Nil(t, out.Chain)
+		require.True(t, out.Active)
+		require.Equal(t, uint64(12345), out.LatestBlock)
+		require.Equal(t, 10, out.Peers)
+		require.Equal(t, "20000000000", out.GasPrice.String())
+		require.Equal(t, "Mock Chain", out.Network.Name())
+	})
+
+	t.Run("disabled chain", func(t *testing.T) {
+		t.Parallel()
+		registry := mocks.NewMockChainRegistry()
+		_ = registry.Register("evm-mainnet", &mocks.MockChainAdapter{})
+		require.NoError(t, registry.SetActive(ctx, "evm-mainnet", false))
+
+		uc := NewGetChainInfoUseCase(registry, mocks.NewMockLogger())
+		out, err := uc.Execute(ctx, GetChainInfoInput{ChainID: "evm-mainnet"})
+		require.NoError(t, err)
+		require.False(t, out.Active)
+		require.False(t, out.Network.IsActive())
+		require.Zero(t, out.LatestBlock)
+		require.Nil(t, out.GasPrice)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+		uc := NewGetChainInfoUseCase(mocks.NewMockChainRegistry(), mocks.NewMockLogger())
+		_, err := uc.Execute(ctx, GetChainInfoInput{})
+		require.ErrorContains(t, err, "chain ID cannot be empty")
+		_, err = uc.Execute(ctx, GetChainInfoInput{ChainID: "unknown"})
+		require.ErrorContains(t, err, "failed to get chain adapter")
+	})
+}
@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/events"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+)
+
+// ImportPSBTInput represents the input for ImportPSBT use case
+type ImportPSBTInput struct {
+	ChainID string
+	// PSBTs are combined before finalization, so each signer may return its own copy
+	PSBTs []string
+	// Broadcast sends the finalized transaction to the network
+	Broadcast bool
+}
+
+// ImportPSBTOutput represents the output for ImportPSBT use case
+type ImportPSBTOutput struct {
+	ChainID string
+	PSBT    string
+	// Complete reports whether every input is signed and the transaction could be finalized
+	Complete       bool
+	RawTransaction string
+	Hash           string
+	Status         string
+}
+
+// ImportPSBTUseCase combines signed PSBTs, finalizes them and optionally broadcasts the result
+type ImportPSBTUseCase struct {
+	registry ports.ChainRegistry
+	eventBus ports.EventPublisher
+	logger   ports.Logger
+}
+
+// NewImportPSBTUseCase creates a new ImportPSBTUseCase
+func NewImportPSBTUseCase(
+	registry ports.ChainRegistry,
+	eventBus ports.EventPublisher,
+	logger ports.Logger,
+) *ImportPSBTUseCase {
+	return &ImportPSBTUseCase{
+		registry: registry,
+		eventBus: eventBus,
+		logger:   logger,
+	}
+}
+
+// Execute executes the import PSBT use case
+func (uc *ImportPSBTUseCase) Execute(ctx context.Context, input ImportPSBTInput) (*ImportPSBTOutput, error) {
+	uc.logger.Info("executing ImportPSBT use case", map[string]interface{}{
+		"chain_id":  input.ChainID,
+		"psbts":     len(input.PSBTs),
+		"broadcast": input.Broadcast,
+	})
+
+	if input.ChainID == "" {
+		return nil, fmt.Errorf("chain ID cannot be empty")
+	}
+	if len(input.PSBTs) == 0 {
+		return nil, fmt.Errorf("at least one PSBT is required")
+	}
+
+	provider, err := psbtProvider(uc.registry, input.ChainID)
+	if err != nil {
+		uc.logger.Error("failed to get PSBT provider", err, map[string]interface{}{
+			"chain_id": input.ChainID,
+		})
+		return nil, err
+	}
+
+	psbt, err := provider.CombinePSBT(input.PSBTs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to combine PSBTs: %w", err)
+	}
+	output := &ImportPSBTOutput{ChainID: input.ChainID, PSBT: psbt}
+
+	rawTx, err := provider.FinalizePSBT(psbt)
+	if err != nil {
+		if input.Broadcast {
+			return nil, fmt.Errorf("failed to finalize PSBT: %w", err)
+		}
+		// Missing signatures are expected while signers are still returning their copies
+		uc.logger.Info("PSBT is not complete", map[string]interface{}{
+			"chain_id": input.ChainID,
+			"reason":   err.Error(),
+		})
+		return output, nil
+	}
+	output.Complete = true
+	output.RawTransaction = rawTx
+
+	if !input.Broadcast {
+		return output, nil
+	}
+
+	hash, err := provider.BroadcastPSBT(ctx, psbt)
+	if err != nil {
+		uc.logger.Error("failed to broadcast PSBT", err, map[string]interface{}{
+			"chain_id": input.ChainID,
+		})
+
+		event := events.NewTransactionFailedEvent(input.ChainID, "", "", err.Error(), "BROADCAST_ERROR")
+		if pubErr := uc.eventBus.Publish(ctx, event); pubErr != nil {
+			uc.logger.Warn("failed to publish broadcast error event", map[string]interface{}{
+				"error": pubErr.Error(),
+			})
+		}
+		return nil, fmt.Errorf("failed to broadcast transaction: %w", err)
+	}
+
+	event := events.NewTransactionBroadcastedEvent(input.ChainID, hash.HexWithoutPrefix(), hash)
+	if err := uc.eventBus.Publish(ctx, event); err != nil {
+		uc.logger.Warn("failed to publish transaction broadcasted event", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	uc.logger.Info("PSBT broadcasted successfully", map[string]interface{}{
+		"chain_id": input.ChainID,
+		"hash":     hash.Hex(),
+	})
+
+	output.Hash = hash.Hex()
+	output.Status = string(entities.TxStatusPending)
+	return output, nil
+}
@@ -0,0 +1,106 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/events"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportPSBT(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	newUseCase := func(t *testing.T) (*ImportPSBTUseCase, *mocks.MockPSBTAdapter, *mocks.MockEventPublisher) {
+		adapter := &mocks.MockPSBTAdapter{}
+		registry := mocks.NewMockChainRegistry()
+		publisher := mocks.NewMockEventPublisher()
+		require.NoError(t, registry.Register("bitcoin-mainnet", adapter))
+		return NewImportPSBTUseCase(registry, publisher, mocks.NewMockLogger()), adapter, publisher
+	}
+
+	t.Run("finalize without broadcast", func(t *testing.T) {
+		t.Parallel()
+		uc, adapter, publisher := newUseCase(t)
+		adapter.CombinePSBTFunc = func(psbts ...string) (string, error) {
+			require.Equal(t, []string{"first", "second"}, psbts)
+			return "combined", nil
+		}
+
+		out, err := uc.Execute(ctx, ImportPSBTInput{ChainID: "bitcoin-mainnet", PSBTs: []string{"first", "second"}})
+		require.NoError(t, err)
+		require.True(t, out.Complete)
+		require.Equal(t, "combined", out.PSBT)
+		require.Equal(t, "0200000000", out.RawTransaction)
+		require.Empty(t, out.Hash)
+		require.Empty(t, publisher.PublishedEvents)
+	})
+
+	t.Run("incomplete PSBT", func(t *testing.T) {
+		t.Parallel()
+		uc, adapter, _ := newUseCase(t)
+		adapter.FinalizePSBTFunc = func(psbt string) (string, error) {
+			return "", simpleError{"input 1 is not signed"}
+		}
+
+		out, err := uc.Execute(ctx, ImportPSBTInput{ChainID: "bitcoin-mainnet", PSBTs: []string{"first"}})
+		require.NoError(t, err)
+		require.False(t, out.Complete)
+		require.Equal(t, "first", out.PSBT)
+		require.Empty(t, out.RawTransaction)
+
+		_, err = uc.Execute(ctx, ImportPSBTInput{ChainID: "bitcoin-mainnet", PSBTs: []string{"first"}, Broadcast: true})
+		require.ErrorContains(t, err, "failed to finalize PSBT")
+	})
+
+	t.Run("broadcast", func(t *testing.T) {
+		t.Parallel()
+		uc, adapter, publisher := newUseCase(t)
+		adapter.BroadcastPSBTFunc = func(ctx context.Context, psbt string) (*valueobjects.Hash, error) {
+			return valueobjects.NewHash("aaaaaaaa")
+		}
+
+		out, err := uc.Execute(ctx, ImportPSBTInput{ChainID: "bitcoin-mainnet", PSBTs: []string{"signed"}, Broadcast: true})
+		require.NoError(t, err)
+		require.True(t, out.Complete)
+		require.Equal(t, "0xaaaaaaaa", out.Hash)
+		require.Equal(t, "pending", out.Status)
+		require.Len(t, publisher.PublishedEvents, 1)
+		require.IsType(t, &events.TransactionBroadcastedEvent{}, publisher.PublishedEvents[0])
+	})
+
+	t.Run("broadcast error", func(t *testing.T) {
+		t.Parallel()
+		uc, adapter, publisher := newUseCase(t)
+		adapter.BroadcastPSBTFunc = func(ctx context.Context, psbt string) (*valueobjects.Hash, error) {
+			return nil, simpleError{"rejected"}
+		}
+
+		_, err := uc.Execute(ctx, ImportPSBTInput{ChainID: "bitcoin-mainnet", PSBTs: []string{"signed"}, Broadcast: true})
+		require.ErrorContains(t, err, "failed to broadcast transaction")
+		require.Len(t, publisher.PublishedEvents, 1)
+		require.IsType(t, &events.TransactionFailedEvent{}, publisher.PublishedEvents[0])
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+		uc, adapter, _ := newUseCase(t)
+
+		_, err := uc.Execute(ctx, ImportPSBTInput{PSBTs: []string{"signed"}})
+		require.Error(t, err)
+		_, err = uc.Execute(ctx, ImportPSBTInput{ChainID: "bitcoin-mainnet"})
+		require.ErrorContains(t, err, "at least one PSBT")
+		_, err = uc.Execute(ctx, ImportPSBTInput{ChainID: "unknown", PSBTs: []string{"signed"}})
+		require.ErrorContains(t, err, "failed to get chain adapter")
+
+		adapter.CombinePSBTFunc = func(psbts ...string) (string, error) {
+			return "", simpleError{"different transactions"}
+		}
+		_, err = uc.Execute(ctx, ImportPSBTInput{ChainID: "bitcoin-mainnet", PSBTs: []string{"a", "b"}})
+		require.ErrorContains(t, err, "failed to combine PSBTs")
+	})
+}
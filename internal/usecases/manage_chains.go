@@ -0,0 +1,155 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/events"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+)
+
+// RegisterChainInput represents the input for registering a chain at runtime
+type RegisterChainInput struct {
+	// Type is the chain kind: evm, tron, bitcoin or solana
+	Type    string
+	Network string
+	// Settings holds the rest of the network entry under its config.yaml keys
+	Settings map[string]interface{}
+}
+
+// ChainDetailsOutput describes a registered chain
+type ChainDetailsOutput struct {
+	ChainID   string
+	ChainType entities.ChainType
+	// NetworkID, NativeToken, Decimals and Testnet are set when the adapter can describe its chain
+	NetworkID   string
+	NativeToken string
+	Decimals    uint8
+	Testnet     bool
+	Active      bool
+	Connected   bool
+	BlockHeight uint64
+}
+
+// ManageChainsUseCase registers, unregisters, enables and disables chains at runtime. Changes
+// are not written to config.yaml: they last until the server restarts.
+type ManageChainsUseCase struct {
+	registry ports.ChainRegistry
+	factory  ports.ChainFactory
+	eventBus ports.EventPublisher
+	logger   ports.Logger
+}
+
+// NewManageChainsUseCase creates a new ManageChainsUseCase
+func NewManageChainsUseCase(
+	registry ports.ChainRegistry,
+	factory ports.ChainFactory,
+	eventBus ports.EventPublisher,
+	logger ports.Logger,
+) *ManageChainsUseCase {
+	return &ManageChainsUseCase{
+		registry: registry,
+		factory:  factory,
+		eventBus: eventBus,
+		logger:   logger,
+	}
+}
+
+// Register creates the adapter of a network and registers it
+func (uc *ManageChainsUseCase) Register(ctx context.Context, input RegisterChainInput) (*ChainDetailsOutput, error) {
+	uc.logger.Info("executing RegisterChain use case", map[string]interface{}{
+		"type":    input.Type,
+		"network": input.Network,
+	})
+
+	if input.Type == "" {
+		return nil, fmt.Errorf("chain type cannot be empty")
+	}
+	if input.Network == "" {
+		return nil, fmt.Errorf("network cannot be empty")
+	}
+
+	chainID, adapter, err := uc.factory.NewChain(ports.ChainSpec{
+		Type:     input.Type,
+		Network:  input.Network,
+		Settings: input.Settings,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create chain: %w", err)
+	}
+	if err := uc.registry.Register(chainID, adapter); err != nil {
+		return nil, fmt.Errorf("failed to register chain: %w", err)
+	}
+
+	uc.publish(ctx, events.NewRegistryChangedEvent([]string{chainID}, nil, nil))
+	uc.logger.Info("chain registered", map[string]interface{}{
+		"chain_id": chainID,
+	})
+
+	return uc.Details(ctx, chainID)
+}
+
+// Unregister removes a chain; callers already holding its adapter finish with it
+func (uc *ManageChainsUseCase) Unregister(ctx context.Context, chainID string) error {
+	if err := uc.registry.Unregister(chainID); err != nil {
+		return fmt.Errorf("failed to unregister chain: %w", err)
+	}
+
+	uc.publish(ctx, events.NewRegistryChangedEvent(nil, []string{chainID}, nil))
+	uc.logger.Info("chain unregistered", map[string]interface{}{
+		"chain_id": chainID,
+	})
+
+	return nil
+}
+
+// SetActive enables or disables a chain; requests to a disabled chain are refused
+func (uc *ManageChainsUseCase) SetActive(ctx context.Context, chainID string, active bool) (*ChainDetailsOutput, error) {
+	if err := uc.registry.SetActive(ctx, chainID, active); err != nil {
+		return nil, fmt.Errorf("failed to set chain state: %w", err)
+	}
+	return uc.Details(ctx, chainID)
+}
+
+// Details describes a registered chain, enabled or not, along with its connectivity and block height
+func (uc *ManageChainsUseCase) Details(ctx context.Context, chainID string) (*ChainDetailsOutput, error) {
+	adapter, err := uc.registry.Lookup(chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain adapter: %w", err)
+	}
+
+	output := &ChainDetailsOutput{
+		ChainID:   chainID,
+		ChainType: adapter.GetChainType(),
+		Active:    uc.registry.IsActive(chainID),
+		Connected: adapter.IsConnected(ctx),
+	}
+
+	if chain, err := uc.registry.Describe(chainID); err == nil {
+		output.NetworkID = chain.NetworkID()
+		output.NativeToken = chain.NativeToken()
+		output.Decimals = chain.Decimals()
+		output.Testnet = chain.IsTestnet()
+	}
+
+	if output.Connected {
+		output.BlockHeight, err = adapter.GetBlockNumber(ctx)
+		if err != nil {
+			uc.logger.Warn("failed to get block height", map[string]interface{}{
+				"chain_id": chainID,
+				"error":    err.Error(),
+			})
+		}
+	}
+
+	return output, nil
+}
+
+func (uc *ManageChainsUseCase) publish(ctx context.Context, event interface{}) {
+	if err := uc.eventBus.Publish(ctx, event); err != nil {
+		uc.logger.Warn("failed to publish registry changed event", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+}
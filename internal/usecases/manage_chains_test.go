@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/adapters/factory"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/events"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManageChains(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	registry := mocks.NewMockChainRegistry()
+	publisher := mocks.NewMockEventPublisher()
+	uc := NewManageChainsUseCase(registry, factory.ChainFactory{}, publisher, mocks.NewMockLogger())
+
+	details, err := uc.Register(ctx, RegisterChainInput{
+		Type:     "evm",
+		Network:  "localnet",
+		Settings: map[string]interface{}{"adapter": "harness", "chain_id": 31337},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "localnet", details.ChainID)
+	require.Equal(t, entities.ChainTypeEVM, details.ChainType)
+	require.Equal(t, "31337", details.NetworkID)
+	require.Equal(t, "ETH", details.NativeToken)
+	require.Equal(t, uint8(18), details.Decimals)
+	require.True(t, details.Testnet)
+	require.True(t, details.Active)
+	require.True(t, details.Connected)
+	require.Equal(t, uint64(1), details.BlockHeight)
+	require.True(t, registry.Has("localnet"))
+
+	// Disabled chains are refused to other use cases but can still be inspected
+	details, err = uc.SetActive(ctx, "localnet", false)
+	require.NoError(t, err)
+	require.False(t, details.Active)
+	_, err = registry.Get("localnet")
+	require.Error(t, err)
+	details, err = uc.SetActive(ctx, "localnet", true)
+	require.NoError(t, err)
+	require.True(t, details.Active)
+
+	require.NoError(t, uc.Unregister(ctx, "localnet"))
+	require.False(t, registry.Has("localnet"))
+
+	require.Len(t, publisher.PublishedEvents, 2)
+	added, ok := publisher.PublishedEvents[0].(*events.RegistryChangedEvent)
+	require.True(t, ok)
+	require.Equal(t, []string{"localnet"}, added.Added)
+	removed, ok := publisher.PublishedEvents[1].(*events.RegistryChangedEvent)
+	require.True(t, ok)
+	require.Equal(t, []string{"localnet"}, removed.Removed)
+}
+
+func TestManageChains_Errors(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	uc := NewManageChainsUseCase(mocks.NewMockChainRegistry(), factory.ChainFactory{}, mocks.NewMockEventPublisher(), mocks.NewMockLogger())
+
+	_, err := uc.Register(ctx, RegisterChainInput{Network: "localnet"})
+	require.ErrorContains(t, err, "chain type cannot be empty")
+	_, err = uc.Register(ctx, RegisterChainInput{Type: "evm"})
+	require.ErrorContains(t, err, "network cannot be empty")
+	_, err = uc.Register(ctx, RegisterChainInput{Type: "evm", Network: "sepolia"})
+	require.ErrorContains(t, err, "failed to create chain")
+
+	_, err = uc.Details(ctx, "unknown")
+	require.ErrorContains(t, err, "chain adapter not found")
+	_, err = uc.SetActive(ctx, "unknown", false)
+	require.Error(t, err)
+}
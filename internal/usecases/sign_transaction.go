@@ -4,16 +4,17 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/events"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
 )
 
 // SignTransactionInput represents the input for SignTransaction use case
 type SignTransactionInput struct {
-	ChainID     string
-	Transaction *entities.Transaction
-	PrivateKey  []byte
+	ChainID string
+	// TransactionID is the ID of a transaction stored by CreateTransactionUseCase
+	TransactionID string
+	// KeyID references the signing key in the key manager
+	KeyID string
 }
 
 // SignTransactionOutput represents the output for SignTransaction use case
@@ -25,38 +26,46 @@ type SignTransactionOutput struct {
 
 // SignTransactionUseCase handles transaction signing
 type SignTransactionUseCase struct {
-	registry ports.ChainRegistry
-	eventBus ports.EventPublisher
-	logger   ports.Logger
+	registry     ports.ChainRegistry
+	transactions ports.TransactionRepository
+	keys         ports.KeyManager
+	eventBus     ports.EventPublisher
+	logger       ports.Logger
 }
 
 // NewSignTransactionUseCase creates a new SignTransactionUseCase
 func NewSignTransactionUseCase(
 	registry ports.ChainRegistry,
+	transactions ports.TransactionRepository,
+	keys ports.KeyManager,
 	eventBus ports.EventPublisher,
 	logger ports.Logger,
 ) *SignTransactionUseCase {
 	return &SignTransactionUseCase{
-		registry: registry,
-		eventBus: eventBus,
-		logger:   logger,
+		registry:     registry,
+		transactions: transactions,
+		keys:         keys,
+		eventBus:     eventBus,
+		logger:       logger,
 	}
 }
 
 // Execute executes the sign transaction use case
 func (uc *SignTransactionUseCase) Execute(ctx context.Context, input SignTransactionInput) (*SignTransactionOutput, error) {
 	uc.logger.Info("executing SignTransaction use case", map[string]interface{}{
-		"chain_id": input.ChainID,
+		"chain_id":       input.ChainID,
+		"transaction_id": input.TransactionID,
+		"key_id":         input.KeyID,
 	})
 
 	if input.ChainID == "" {
 		return nil, fmt.Errorf("chain ID cannot be empty")
 	}
-	if input.Transaction == nil {
-		return nil, fmt.Errorf("transaction cannot be nil")
+	if input.TransactionID == "" {
+		return nil, fmt.Errorf("transaction ID cannot be empty")
 	}
-	if len(input.PrivateKey) == 0 {
-		return nil, fmt.Errorf("private key cannot be empty")
+	if input.KeyID == "" {
+		return nil, fmt.Errorf("key ID cannot be empty")
 	}
 
 	adapter, err := uc.registry.Get(input.ChainID)
@@ -67,15 +76,32 @@ func (uc *SignTransactionUseCase) Execute(ctx context.Context, input SignTransac
 		return nil, fmt.Errorf("failed to get chain adapter: %w", err)
 	}
 
-	if err := adapter.SignTransaction(ctx, input.Transaction, input.PrivateKey); err != nil {
+	tx, err := loadTransaction(ctx, uc.transactions, input.ChainID, input.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := tx.Metadata()[MetadataBroadcastAt]; ok {
+		return nil, fmt.Errorf("%w: %s", ErrTransactionAlreadyBroadcast, tx.ID())
+	}
+
+	if err := adapter.SignTransaction(ctx, tx, uc.keys, input.KeyID); err != nil {
 		uc.logger.Error("failed to sign transaction", err, map[string]interface{}{
 			"chain_id":       input.ChainID,
-			"transaction_id": input.Transaction.ID(),
+			"transaction_id": tx.ID(),
+			"key_id":         input.KeyID,
 		})
 		return nil, fmt.Errorf("failed to sign transaction: %w", err)
 	}
 
-	event := events.NewTransactionSignedEvent(input.Transaction)
+	if err := uc.transactions.Save(ctx, tx); err != nil {
+		uc.logger.Error("failed to save transaction", err, map[string]interface{}{
+			"chain_id":       input.ChainID,
+			"transaction_id": tx.ID(),
+		})
+		return nil, fmt.Errorf("failed to save transaction: %w", err)
+	}
+
+	event := events.NewTransactionSignedEvent(tx)
 	if err := uc.eventBus.Publish(ctx, event); err != nil {
 		uc.logger.Warn("failed to publish transaction signed event", map[string]interface{}{
 			"error": err.Error(),
@@ -84,19 +110,19 @@ func (uc *SignTransactionUseCase) Execute(ctx context.Context, input SignTransac
 
 	uc.logger.Info("transaction signed successfully", map[string]interface{}{
 		"chain_id":       input.ChainID,
-		"transaction_id": input.Transaction.ID(),
+		"transaction_id": tx.ID(),
 	})
 
 	var hash, signature string
-	if input.Transaction.Hash() != nil {
-		hash = input.Transaction.Hash().Hex()
+	if tx.Hash() != nil {
+		hash = tx.Hash().Hex()
 	}
-	if input.Transaction.Signature() != nil {
-		signature = input.Transaction.Signature().Hex()
+	if tx.Signature() != nil {
+		signature = tx.Signature().Hex()
 	}
 
 	return &SignTransactionOutput{
-		TransactionID: input.Transaction.ID(),
+		TransactionID: tx.ID(),
 		Hash:          hash,
 		Signature:     signature,
 	}, nil
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/valueobjects"
 	"github.com/gabrielksneiva/ChainSystemPro/internal/mocks"
 	"github.com/stretchr/testify/require"
@@ -29,22 +30,58 @@ func TestSignTransaction(t *testing.T) {
 		registry := mocks.NewMockChainRegistry()
 		publisher := mocks.NewMockEventPublisher()
 		logger := mocks.NewMockLogger()
+		transactions := mocks.NewMockTransactionRepository()
+		_ = transactions.Save(ctx, tx)
 		_ = registry.Register("evm-mainnet", adapter)
-		adapter.SignTransactionFunc = func(ctx context.Context, inTx *entities.Transaction, pk []byte) error { return nil }
-		uc := NewSignTransactionUseCase(registry, publisher, logger)
-		out, err := uc.Execute(ctx, SignTransactionInput{ChainID: "evm-mainnet", Transaction: tx, PrivateKey: []byte("privkey")})
+		adapter.SignTransactionFunc = func(ctx context.Context, inTx *entities.Transaction, keys ports.KeyManager, keyID string) error {
+			return nil
+		}
+		uc := NewSignTransactionUseCase(registry, transactions, mocks.NewMockKeyManager(), publisher, logger)
+		out, err := uc.Execute(ctx, SignTransactionInput{ChainID: "evm-mainnet", TransactionID: tx.ID(), KeyID: "treasury"})
 		require.NoError(t, err)
 		require.NotNil(t, out)
 		require.Equal(t, tx.ID(), out.TransactionID)
 	})
 
+	t.Run("save error", func(t *testing.T) {
+		t.Parallel()
+		adapter := &mocks.MockChainAdapter{}
+		registry := mocks.NewMockChainRegistry()
+		transactions := mocks.NewMockTransactionRepository()
+		_ = transactions.Save(ctx, tx)
+		transactions.SaveErr = simpleError{"database down"}
+		_ = registry.Register("evm-mainnet", adapter)
+		adapter.SignTransactionFunc = func(ctx context.Context, inTx *entities.Transaction, keys ports.KeyManager, keyID string) error {
+			return nil
+		}
+		uc := NewSignTransactionUseCase(registry, transactions, mocks.NewMockKeyManager(), mocks.NewMockEventPublisher(), mocks.NewMockLogger())
+		_, err := uc.Execute(ctx, SignTransactionInput{ChainID: "evm-mainnet", TransactionID: tx.ID(), KeyID: "treasury"})
+		require.ErrorContains(t, err, "failed to save transaction")
+	})
+
+	t.Run("already broadcast", func(t *testing.T) {
+		t.Parallel()
+		adapter := &mocks.MockChainAdapter{}
+		registry := mocks.NewMockChainRegistry()
+		transactions := mocks.NewMockTransactionRepository()
+		broadcast, _ := entities.NewTransaction(entities.TransactionParams{ChainID: "evm-mainnet", From: fromAddr, To: toAddr})
+		broadcast.SetMetadata(MetadataBroadcastAt, "2024-01-01T00:00:00Z")
+		_ = transactions.Save(ctx, broadcast)
+		_ = registry.Register("evm-mainnet", adapter)
+		uc := NewSignTransactionUseCase(registry, transactions, mocks.NewMockKeyManager(), mocks.NewMockEventPublisher(), mocks.NewMockLogger())
+		_, err := uc.Execute(ctx, SignTransactionInput{ChainID: "evm-mainnet", TransactionID: broadcast.ID(), KeyID: "treasury"})
+		require.ErrorIs(t, err, ErrTransactionAlreadyBroadcast)
+	})
+
 	t.Run("registry error", func(t *testing.T) {
 		t.Parallel()
 		registry := mocks.NewMockChainRegistry()
 		publisher := mocks.NewMockEventPublisher()
 		logger := mocks.NewMockLogger()
-		uc := NewSignTransactionUseCase(registry, publisher, logger)
-		_, err := uc.Execute(ctx, SignTransactionInput{ChainID: "evm-mainnet", Transaction: tx, PrivateKey: []byte("privkey")})
+		transactions := mocks.NewMockTransactionRepository()
+		_ = transactions.Save(ctx, tx)
+		uc := NewSignTransactionUseCase(registry, transactions, mocks.NewMockKeyManager(), publisher, logger)
+		_, err := uc.Execute(ctx, SignTransactionInput{ChainID: "evm-mainnet", TransactionID: tx.ID(), KeyID: "treasury"})
 		require.Error(t, err)
 	})
 
@@ -54,36 +91,63 @@ func TestSignTransaction(t *testing.T) {
 		registry := mocks.NewMockChainRegistry()
 		publisher := mocks.NewMockEventPublisher()
 		logger := mocks.NewMockLogger()
+		transactions := mocks.NewMockTransactionRepository()
+		_ = transactions.Save(ctx, tx)
 		_ = registry.Register("evm-mainnet", adapter)
-		adapter.SignTransactionFunc = func(ctx context.Context, inTx *entities.Transaction, pk []byte) error {
+		adapter.SignTransactionFunc = func(ctx context.Context, inTx *entities.Transaction, keys ports.KeyManager, keyID string) error {
 			return simpleError{"sign failed"}
 		}
-		uc := NewSignTransactionUseCase(registry, publisher, logger)
-		_, err := uc.Execute(ctx, SignTransactionInput{ChainID: "evm-mainnet", Transaction: tx, PrivateKey: []byte("privkey")})
+		uc := NewSignTransactionUseCase(registry, transactions, mocks.NewMockKeyManager(), publisher, logger)
+		_, err := uc.Execute(ctx, SignTransactionInput{ChainID: "evm-mainnet", TransactionID: tx.ID(), KeyID: "treasury"})
 		require.Error(t, err)
 	})
 
-	t.Run("nil transaction", func(t *testing.T) {
+	t.Run("empty transaction ID", func(t *testing.T) {
 		t.Parallel()
 		adapter := &mocks.MockChainAdapter{}
 		registry := mocks.NewMockChainRegistry()
 		publisher := mocks.NewMockEventPublisher()
 		logger := mocks.NewMockLogger()
+		transactions := mocks.NewMockTransactionRepository()
+		_ = transactions.Save(ctx, tx)
 		_ = registry.Register("evm-mainnet", adapter)
-		uc := NewSignTransactionUseCase(registry, publisher, logger)
-		_, err := uc.Execute(ctx, SignTransactionInput{ChainID: "evm-mainnet", Transaction: nil, PrivateKey: []byte("privkey")})
+		uc := NewSignTransactionUseCase(registry, transactions, mocks.NewMockKeyManager(), publisher, logger)
+		_, err := uc.Execute(ctx, SignTransactionInput{ChainID: "evm-mainnet", TransactionID: "", KeyID: "treasury"})
 		require.Error(t, err)
 	})
 
-	t.Run("nil private key", func(t *testing.T) {
+	t.Run("empty key ID", func(t *testing.T) {
 		t.Parallel()
 		adapter := &mocks.MockChainAdapter{}
 		registry := mocks.NewMockChainRegistry()
 		publisher := mocks.NewMockEventPublisher()
 		logger := mocks.NewMockLogger()
+		transactions := mocks.NewMockTransactionRepository()
+		_ = transactions.Save(ctx, tx)
 		_ = registry.Register("evm-mainnet", adapter)
-		uc := NewSignTransactionUseCase(registry, publisher, logger)
-		_, err := uc.Execute(ctx, SignTransactionInput{ChainID: "evm-mainnet", Transaction: tx, PrivateKey: nil})
+		uc := NewSignTransactionUseCase(registry, transactions, mocks.NewMockKeyManager(), publisher, logger)
+		_, err := uc.Execute(ctx, SignTransactionInput{ChainID: "evm-mainnet", TransactionID: tx.ID()})
 		require.Error(t, err)
 	})
+	t.Run("key from key manager", func(t *testing.T) {
+		t.Parallel()
+		adapter := &mocks.MockChainAdapter{}
+		registry := mocks.NewMockChainRegistry()
+		transactions := mocks.NewMockTransactionRepository()
+		keys := mocks.NewMockKeyManager()
+		keys.Keys["treasury"] = ports.KeyInfo{ID: "treasury", Curve: ports.CurveSecp256k1}
+		_ = transactions.Save(ctx, tx)
+		_ = registry.Register("evm-mainnet", adapter)
+		adapter.SignTransactionFunc = func(ctx context.Context, inTx *entities.Transaction, keys ports.KeyManager, keyID string) error {
+			_, err := keys.Sign(ctx, keyID, []byte("digest"))
+			return err
+		}
+		uc := NewSignTransactionUseCase(registry, transactions, keys, mocks.NewMockEventPublisher(), mocks.NewMockLogger())
+		_, err := uc.Execute(ctx, SignTransactionInput{ChainID: "evm-mainnet", TransactionID: tx.ID(), KeyID: "treasury"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"treasury"}, keys.SignCalls)
+
+		_, err = uc.Execute(ctx, SignTransactionInput{ChainID: "evm-mainnet", TransactionID: tx.ID(), KeyID: "missing"})
+		require.ErrorIs(t, err, ports.ErrKeyNotFound)
+	})
 }
@@ -1,10 +1,48 @@
 package usecases
 
 import (
+	"context"
+	"fmt"
 	"math/big"
 	"strings"
+
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
 )
 
+// adapterCapability returns the optional capability T of an adapter from the outermost decorator
+// forwarding it, so that calls keep the decorators' timeouts and circuit breaker. Decorators may
+// forward a capability the adapter they wrap lacks, so T is only reported when that adapter has it.
+func adapterCapability[T any](adapter ports.ChainAdapter) (T, bool) {
+	capability, ok := adapter.(T)
+	wrapper, wraps := adapter.(ports.AdapterWrapper)
+	if !wraps {
+		return capability, ok
+	}
+	inner, supported := adapterCapability[T](wrapper.Unwrap())
+	switch {
+	case !supported:
+		var zero T
+		return zero, false
+	case ok:
+		return capability, true
+	default:
+		return inner, true
+	}
+}
+
+// loadTransaction loads a stored transaction, reporting transactions of another chain as not found
+func loadTransaction(ctx context.Context, transactions ports.TransactionRepository, chainID, id string) (*entities.Transaction, error) {
+	tx, err := transactions.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load transaction: %w", err)
+	}
+	if tx.ChainID() != chainID {
+		return nil, fmt.Errorf("failed to load transaction: %w: %s on chain %s", ports.ErrTransactionNotFound, id, chainID)
+	}
+	return tx, nil
+}
+
 // parseBigInt parses a string into a big.Int
 func parseBigInt(s string) (*big.Int, bool) {
 	if s == "" {
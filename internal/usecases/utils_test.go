@@ -4,9 +4,41 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/gabrielksneiva/ChainSystemPro/internal/domain/ports"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/infrastructure/resilience"
+	"github.com/gabrielksneiva/ChainSystemPro/internal/mocks"
 	"github.com/stretchr/testify/require"
 )
 
+// wrappedAdapter decorates an adapter the way the registry's resilience policies do
+type wrappedAdapter struct {
+	ports.ChainAdapter
+}
+
+func (w wrappedAdapter) Unwrap() ports.ChainAdapter { return w.ChainAdapter }
+
+func TestAdapterCapability(t *testing.T) {
+	t.Parallel()
+
+	bumper := &mocks.MockFeeBumpAdapter{}
+	found, ok := adapterCapability[ports.FeeBumper](wrappedAdapter{wrappedAdapter{bumper}})
+	require.True(t, ok)
+	require.Same(t, bumper, found)
+
+	_, ok = adapterCapability[ports.FeeBumper](wrappedAdapter{&mocks.MockChainAdapter{}})
+	require.False(t, ok)
+
+	// The resilience decorator forwards the capability under its policy, when the adapter has it
+	resilient := resilience.Wrap("bitcoin", bumper, resilience.DefaultPolicy(), mocks.NewMockLogger())
+	found, ok = adapterCapability[ports.FeeBumper](wrappedAdapter{resilient})
+	require.True(t, ok)
+	require.Same(t, resilient, found)
+
+	resilient = resilience.Wrap("ethereum", &mocks.MockChainAdapter{}, resilience.DefaultPolicy(), mocks.NewMockLogger())
+	_, ok = adapterCapability[ports.FeeBumper](resilient)
+	require.False(t, ok)
+}
+
 func TestParseBigInt(t *testing.T) {
 	t.Parallel()
 